@@ -0,0 +1,141 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+// hotplug-disk is the entrypoint of the pods which attach hotplugged volumes to the node of a
+// VirtualMachineInstance. It waits until the volume is available in the pod and announces the
+// pod to virt-handler through a socket. The pod keeps running until virt-handler removes the socket.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+func main() {
+	var socket string
+	var diskPath string
+	var blockDevicePath string
+
+	flag.StringVar(&socket, "socket", "", "Socket through which virt-handler detects the pod")
+	flag.StringVar(&diskPath, "disk-path", "", "Path of the disk image of a filesystem volume")
+	flag.StringVar(&blockDevicePath, "block-device-path", "", "Path of the device of a block volume")
+	flag.Parse()
+
+	logger := log.DefaultLogger()
+
+	if socket == "" {
+		logger.Error("No socket provided.")
+		os.Exit(1)
+	}
+	if (diskPath == "") == (blockDevicePath == "") {
+		logger.Error("Exactly one of disk-path and block-device-path must be provided.")
+		os.Exit(1)
+	}
+
+	if err := waitForDisk(diskPath, blockDevicePath); err != nil {
+		logger.Reason(err).Error("The disk of the hotplugged volume is not available.")
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(socket), os.ModePerm); err != nil {
+		logger.Reason(err).Errorf("Failed to create socket directory %s.", filepath.Dir(socket))
+		os.Exit(1)
+	}
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		logger.Reason(err).Error("Failed to create socket.")
+		os.Exit(1)
+	}
+	defer ln.Close()
+
+	go func() {
+		for {
+			if _, err := ln.Accept(); err != nil {
+				return
+			}
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-signals:
+			return
+		case <-ticker.C:
+			// virt-handler removes the socket once the volume is unplugged
+			if _, err := os.Stat(socket); os.IsNotExist(err) {
+				return
+			} else if err != nil {
+				logger.Reason(err).Error("Failed to check the socket.")
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// waitForDisk waits until the disk image or the block device of the volume shows up
+func waitForDisk(diskPath string, blockDevicePath string) error {
+	return waitFor(time.Minute, func() (bool, error) {
+		if blockDevicePath != "" {
+			fileInfo, err := os.Stat(blockDevicePath)
+			if os.IsNotExist(err) {
+				return false, nil
+			} else if err != nil {
+				return false, err
+			}
+			return fileInfo.Mode()&os.ModeDevice != 0, nil
+		}
+		fileInfo, err := os.Stat(diskPath)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		return fileInfo.Mode().IsRegular(), nil
+	})
+}
+
+func waitFor(timeout time.Duration, condition func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v", timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
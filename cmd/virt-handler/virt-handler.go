@@ -187,15 +187,18 @@ func (app *virtHandlerApp) Run() {
 	logger.V(1).Level(log.INFO).Log("hostname", app.HostOverride)
 	var err error
 
-	// Copy container-disk binary
-	targetFile := filepath.Join(app.VirtLibDir, "/init/usr/bin/container-disk")
-	err = os.MkdirAll(filepath.Dir(targetFile), os.ModePerm)
-	if err != nil {
-		panic(err)
-	}
-	err = copy("/usr/bin/container-disk", targetFile)
-	if err != nil {
-		panic(err)
+	// Copy the container-disk and hotplug-disk binaries, which are used as entrypoint of
+	// the container disk and the hotplug volume attachment pods
+	for _, binary := range []string{"container-disk", "hotplug-disk"} {
+		targetFile := filepath.Join(app.VirtLibDir, "/init/usr/bin", binary)
+		err = os.MkdirAll(filepath.Dir(targetFile), os.ModePerm)
+		if err != nil {
+			panic(err)
+		}
+		err = copy(filepath.Join("/usr/bin", binary), targetFile)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	se, exists, err := selinux.NewSELinux()
@@ -300,6 +303,7 @@ func (app *virtHandlerApp) Run() {
 		vmTargetSharedInformer,
 		domainSharedInformer,
 		gracefulShutdownInformer,
+		factory.MigrationPolicy(),
 		int(app.WatchdogTimeoutDuration.Seconds()),
 		app.MaxDevices,
 		virtconfig.NewClusterConfig(factory.ConfigMap(), factory.CRD(), app.namespace),
@@ -312,6 +316,11 @@ func (app *virtHandlerApp) Run() {
 		vmiInformer,
 	)
 
+	lifecycleHandler := rest.NewLifecycleHandler(
+		vmiInformer,
+		app.VirtShareDir,
+	)
+
 	certsDirectory, err := ioutil.TempDir("", "certsdir")
 	if err != nil {
 		panic(err)
@@ -335,7 +344,7 @@ func (app *virtHandlerApp) Run() {
 
 	errCh := make(chan error)
 	go app.runPrometheusServer(errCh, certStore)
-	go app.runConsoleServer(errCh, consoleHandler)
+	go app.runConsoleServer(errCh, consoleHandler, lifecycleHandler)
 
 	// wait for one of the servers to exit
 	<-errCh
@@ -347,10 +356,16 @@ func (app *virtHandlerApp) runPrometheusServer(errCh chan error, certStore certi
 	errCh <- http.ListenAndServeTLS(app.ServiceListen.Address(), certStore.CurrentPath(), certStore.CurrentPath(), nil)
 }
 
-func (app *virtHandlerApp) runConsoleServer(errCh chan error, consoleHandler *rest.ConsoleHandler) {
+func (app *virtHandlerApp) runConsoleServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler) {
 	ws := new(restful.WebService)
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/console").To(consoleHandler.SerialHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vnc").To(consoleHandler.VNCHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/pause").To(lifecycleHandler.PauseHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unpause").To(lifecycleHandler.UnpauseHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/freeze").To(lifecycleHandler.FreezeHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unfreeze").To(lifecycleHandler.UnfreezeHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/domainstats").To(lifecycleHandler.DomainStatsHandler).Produces(restful.MIME_JSON))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GuestInfoHandler).Produces(restful.MIME_JSON))
 	restful.DefaultContainer.Add(ws)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", app.ServiceListen.BindAddress, app.consoleServerPort),
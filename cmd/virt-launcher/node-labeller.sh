@@ -0,0 +1,59 @@
+#!/bin/bash
+#
+# Periodically stores the libvirt domain capabilities and the CPU model
+# definitions of the node, which the node labeller in virt-handler turns
+# into CPU model and feature labels. Failures are only logged, a later
+# refresh retries them.
+
+NODE_LABELLER_DIR=/var/lib/kubevirt-node-labeller
+REFRESH_INTERVAL=${NODE_LABELLER_REFRESH_INTERVAL:-300}
+
+virttype=kvm
+if [ ! -e /dev/kvm ]; then
+    virttype=qemu
+fi
+
+ensure_libvirtd() {
+    if virsh -c qemu:///system version >/dev/null 2>&1; then
+        return 0
+    fi
+
+    libvirtd -d
+
+    # Wait for libvirt to accept connections
+    for i in $(seq 1 10); do
+        if virsh -c qemu:///system version >/dev/null 2>&1; then
+            return 0
+        fi
+        sleep 1
+    done
+    return 1
+}
+
+refresh() {
+    if ! ensure_libvirtd; then
+        echo "libvirtd is not available, skipping the domain capabilities refresh" >&2
+        return
+    fi
+
+    if ! virsh -c qemu:///system domcapabilities --machine q35 --arch x86_64 --virttype $virttype >${NODE_LABELLER_DIR}/virsh_domcapabilities.xml.tmp; then
+        echo "failed to read the domain capabilities" >&2
+        rm -f ${NODE_LABELLER_DIR}/virsh_domcapabilities.xml.tmp
+        return
+    fi
+
+    if [ -d /usr/share/libvirt/cpu_map ]; then
+        rm -rf ${NODE_LABELLER_DIR}/cpu_map.tmp
+        cp -r /usr/share/libvirt/cpu_map ${NODE_LABELLER_DIR}/cpu_map.tmp &&
+            rm -rf ${NODE_LABELLER_DIR}/cpu_map &&
+            mv ${NODE_LABELLER_DIR}/cpu_map.tmp ${NODE_LABELLER_DIR}/cpu_map
+    fi
+
+    # Replace the file atomically, virt-handler may read it at any time
+    mv ${NODE_LABELLER_DIR}/virsh_domcapabilities.xml.tmp ${NODE_LABELLER_DIR}/virsh_domcapabilities.xml
+}
+
+while true; do
+    refresh
+    sleep ${REFRESH_INTERVAL}
+done
@@ -38,7 +38,7 @@ func GetConfigMapDiskPath(volumeName string) string {
 // CreateConfigMapDisks creates ConfigMap iso disks which are attached to vmis
 func CreateConfigMapDisks(vmi *v1.VirtualMachineInstance) error {
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.ConfigMap != nil {
+		if volume.ConfigMap != nil && !vmi.IsFilesystemVolume(volume.Name) {
 			var filesPath []string
 			filesPath, err := getFilesLayout(GetConfigMapSourcePath(volume.Name))
 			if err != nil {
@@ -70,4 +70,26 @@ var _ = Describe("ConfigMap", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("Should not create an iso disk for a config map shared as filesystem", func() {
+		vmi := v1.NewMinimalVMI("fake-vmi")
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "configmap-volume",
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: k8sv1.LocalObjectReference{
+						Name: "test-config",
+					},
+				},
+			},
+		})
+		vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+			{Name: "configmap-volume", Virtiofs: &v1.FilesystemVirtiofs{}},
+		}
+
+		err := CreateConfigMapDisks(vmi)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = os.Stat(filepath.Join(ConfigMapDisksDir, "configmap-volume.iso"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
 })
@@ -38,7 +38,7 @@ func GetSecretDiskPath(volumeName string) string {
 // CreateSecretDisks creates Secret iso disks which are attached to vmis
 func CreateSecretDisks(vmi *v1.VirtualMachineInstance) error {
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.Secret != nil {
+		if volume.Secret != nil && !vmi.IsFilesystemVolume(volume.Name) {
 
 			var filesPath []string
 			filesPath, err := getFilesLayout(GetSecretSourcePath(volume.Name))
@@ -33,7 +33,7 @@ func GetServiceAccountDiskPath() string {
 // CreateServiceAccountDisk creates the ServiceAccount iso disk which is attached to vmis
 func CreateServiceAccountDisk(vmi *v1.VirtualMachineInstance) error {
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.ServiceAccount != nil {
+		if volume.ServiceAccount != nil && !vmi.IsFilesystemVolume(volume.Name) {
 			var filesPath []string
 			filesPath, err := getFilesLayout(ServiceAccountSourceDir)
 			if err != nil {
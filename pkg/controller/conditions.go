@@ -66,6 +66,15 @@ func (d *VirtualMachineConditionManager) HasConditionWithStatus(vmi *v1.VirtualM
 	return false
 }
 
+func (d *VirtualMachineConditionManager) GetCondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceConditionType) *v1.VirtualMachineInstanceCondition {
+	for i, c := range vmi.Status.Conditions {
+		if c.Type == cond {
+			return &vmi.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func (d *VirtualMachineConditionManager) RemoveCondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceConditionType) {
 	var conds []v1.VirtualMachineInstanceCondition
 	for _, c := range vmi.Status.Conditions {
@@ -17,6 +17,11 @@ It has these top-level messages:
 	Response
 	DomainResponse
 	DomainStatsResponse
+	GuestInfoResponse
+	GuestPingRequest
+	ExecRequest
+	ExecResponse
+	ResizeDiskRequest
 */
 package v1
 
@@ -248,6 +253,158 @@ func (m *DomainStatsResponse) GetDomainStats() string {
 	return ""
 }
 
+type GuestInfoResponse struct {
+	Response  *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestInfo string    `protobuf:"bytes,2,opt,name=guestInfo" json:"guestInfo,omitempty"`
+}
+
+func (m *GuestInfoResponse) Reset()                    { *m = GuestInfoResponse{} }
+func (m *GuestInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestInfoResponse) ProtoMessage()               {}
+func (*GuestInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *GuestInfoResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestInfoResponse) GetGuestInfo() string {
+	if m != nil {
+		return m.GuestInfo
+	}
+	return ""
+}
+
+type GuestPingRequest struct {
+	DomainName     string `protobuf:"bytes,1,opt,name=domainName" json:"domainName,omitempty"`
+	TimeoutSeconds int32  `protobuf:"varint,2,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *GuestPingRequest) Reset()                    { *m = GuestPingRequest{} }
+func (m *GuestPingRequest) String() string            { return proto.CompactTextString(m) }
+func (*GuestPingRequest) ProtoMessage()               {}
+func (*GuestPingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *GuestPingRequest) GetDomainName() string {
+	if m != nil {
+		return m.DomainName
+	}
+	return ""
+}
+
+func (m *GuestPingRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type ExecRequest struct {
+	DomainName     string   `protobuf:"bytes,1,opt,name=domainName" json:"domainName,omitempty"`
+	Command        string   `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
+	Args           []string `protobuf:"bytes,3,rep,name=args" json:"args,omitempty"`
+	TimeoutSeconds int32    `protobuf:"varint,4,opt,name=timeoutSeconds" json:"timeoutSeconds,omitempty"`
+}
+
+func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
+func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()               {}
+func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ExecRequest) GetDomainName() string {
+	if m != nil {
+		return m.DomainName
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type ExecResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ExitCode int32     `protobuf:"varint,2,opt,name=exitCode" json:"exitCode,omitempty"`
+	StdOut   string    `protobuf:"bytes,3,opt,name=stdOut" json:"stdOut,omitempty"`
+}
+
+func (m *ExecResponse) Reset()                    { *m = ExecResponse{} }
+func (m *ExecResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExecResponse) ProtoMessage()               {}
+func (*ExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ExecResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ExecResponse) GetStdOut() string {
+	if m != nil {
+		return m.StdOut
+	}
+	return ""
+}
+
+type ResizeDiskRequest struct {
+	DomainName string `protobuf:"bytes,1,opt,name=domainName" json:"domainName,omitempty"`
+	DiskName   string `protobuf:"bytes,2,opt,name=diskName" json:"diskName,omitempty"`
+	Size       int64  `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *ResizeDiskRequest) Reset()                    { *m = ResizeDiskRequest{} }
+func (m *ResizeDiskRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResizeDiskRequest) ProtoMessage()               {}
+func (*ResizeDiskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *ResizeDiskRequest) GetDomainName() string {
+	if m != nil {
+		return m.DomainName
+	}
+	return ""
+}
+
+func (m *ResizeDiskRequest) GetDiskName() string {
+	if m != nil {
+		return m.DiskName
+	}
+	return ""
+}
+
+func (m *ResizeDiskRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*SMBios)(nil), "kubevirt.cmd.v1.SMBios")
@@ -258,6 +415,11 @@ func init() {
 	proto.RegisterType((*Response)(nil), "kubevirt.cmd.v1.Response")
 	proto.RegisterType((*DomainResponse)(nil), "kubevirt.cmd.v1.DomainResponse")
 	proto.RegisterType((*DomainStatsResponse)(nil), "kubevirt.cmd.v1.DomainStatsResponse")
+	proto.RegisterType((*GuestInfoResponse)(nil), "kubevirt.cmd.v1.GuestInfoResponse")
+	proto.RegisterType((*GuestPingRequest)(nil), "kubevirt.cmd.v1.GuestPingRequest")
+	proto.RegisterType((*ExecRequest)(nil), "kubevirt.cmd.v1.ExecRequest")
+	proto.RegisterType((*ExecResponse)(nil), "kubevirt.cmd.v1.ExecResponse")
+	proto.RegisterType((*ResizeDiskRequest)(nil), "kubevirt.cmd.v1.ResizeDiskRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -278,8 +440,20 @@ type CmdClient interface {
 	MigrateVirtualMachine(ctx context.Context, in *MigrationRequest, opts ...grpc.CallOption) (*Response, error)
 	SyncMigrationTarget(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	CancelVirtualMachineMigration(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	PauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	UnpauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	FreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	HotplugDisk(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	UnplugDisk(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	HotplugResources(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	ChangeMedia(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	ResizeDisk(ctx context.Context, in *ResizeDiskRequest, opts ...grpc.CallOption) (*Response, error)
 	GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error)
 	GetDomainStats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainStatsResponse, error)
+	GetGuestInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error)
+	GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*Response, error)
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
@@ -354,6 +528,87 @@ func (c *cmdClient) CancelVirtualMachineMigration(ctx context.Context, in *VMIRe
 	return out, nil
 }
 
+func (c *cmdClient) PauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/PauseVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnpauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnpauseVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) FreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) HotplugDisk(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/HotplugDisk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnplugDisk(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnplugDisk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) HotplugResources(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/HotplugResources", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) ChangeMedia(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ChangeMedia", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) ResizeDisk(ctx context.Context, in *ResizeDiskRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ResizeDisk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cmdClient) GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
 	out := new(DomainResponse)
 	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetDomain", in, out, c.cc, opts...)
@@ -372,6 +627,33 @@ func (c *cmdClient) GetDomainStats(ctx context.Context, in *EmptyRequest, opts .
 	return out, nil
 }
 
+func (c *cmdClient) GetGuestInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error) {
+	out := new(GuestInfoResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetGuestInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GuestPing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
+	out := new(ExecResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Exec", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cmdClient) Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Ping", in, out, c.cc, opts...)
@@ -391,8 +673,20 @@ type CmdServer interface {
 	MigrateVirtualMachine(context.Context, *MigrationRequest) (*Response, error)
 	SyncMigrationTarget(context.Context, *VMIRequest) (*Response, error)
 	CancelVirtualMachineMigration(context.Context, *VMIRequest) (*Response, error)
+	PauseVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	UnpauseVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	FreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	UnfreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	HotplugDisk(context.Context, *VMIRequest) (*Response, error)
+	UnplugDisk(context.Context, *VMIRequest) (*Response, error)
+	HotplugResources(context.Context, *VMIRequest) (*Response, error)
+	ChangeMedia(context.Context, *VMIRequest) (*Response, error)
+	ResizeDisk(context.Context, *ResizeDiskRequest) (*Response, error)
 	GetDomain(context.Context, *EmptyRequest) (*DomainResponse, error)
 	GetDomainStats(context.Context, *EmptyRequest) (*DomainStatsResponse, error)
+	GetGuestInfo(context.Context, *EmptyRequest) (*GuestInfoResponse, error)
+	GuestPing(context.Context, *GuestPingRequest) (*Response, error)
+	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	Ping(context.Context, *EmptyRequest) (*Response, error)
 }
 
@@ -526,6 +820,168 @@ func _Cmd_CancelVirtualMachineMigration_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_PauseVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).PauseVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/PauseVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).PauseVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnpauseVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnpauseVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnpauseVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnpauseVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_FreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnfreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_HotplugDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).HotplugDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/HotplugDisk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).HotplugDisk(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnplugDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnplugDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnplugDisk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnplugDisk(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_HotplugResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).HotplugResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/HotplugResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).HotplugResources(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_ChangeMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).ChangeMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/ChangeMedia",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).ChangeMedia(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_ResizeDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeDiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).ResizeDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/ResizeDisk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).ResizeDisk(ctx, req.(*ResizeDiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cmd_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
@@ -562,6 +1018,60 @@ func _Cmd_GetDomainStats_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GetGuestInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetGuestInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetGuestInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetGuestInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GuestPing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GuestPingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GuestPing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GuestPing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GuestPing(ctx, req.(*GuestPingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/Exec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).Exec(ctx, req.(*ExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cmd_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
@@ -612,6 +1122,42 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelVirtualMachineMigration",
 			Handler:    _Cmd_CancelVirtualMachineMigration_Handler,
 		},
+		{
+			MethodName: "PauseVirtualMachine",
+			Handler:    _Cmd_PauseVirtualMachine_Handler,
+		},
+		{
+			MethodName: "UnpauseVirtualMachine",
+			Handler:    _Cmd_UnpauseVirtualMachine_Handler,
+		},
+		{
+			MethodName: "FreezeVirtualMachine",
+			Handler:    _Cmd_FreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "UnfreezeVirtualMachine",
+			Handler:    _Cmd_UnfreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "HotplugDisk",
+			Handler:    _Cmd_HotplugDisk_Handler,
+		},
+		{
+			MethodName: "UnplugDisk",
+			Handler:    _Cmd_UnplugDisk_Handler,
+		},
+		{
+			MethodName: "HotplugResources",
+			Handler:    _Cmd_HotplugResources_Handler,
+		},
+		{
+			MethodName: "ChangeMedia",
+			Handler:    _Cmd_ChangeMedia_Handler,
+		},
+		{
+			MethodName: "ResizeDisk",
+			Handler:    _Cmd_ResizeDisk_Handler,
+		},
 		{
 			MethodName: "GetDomain",
 			Handler:    _Cmd_GetDomain_Handler,
@@ -620,6 +1166,18 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDomainStats",
 			Handler:    _Cmd_GetDomainStats_Handler,
 		},
+		{
+			MethodName: "GetGuestInfo",
+			Handler:    _Cmd_GetGuestInfo_Handler,
+		},
+		{
+			MethodName: "GuestPing",
+			Handler:    _Cmd_GuestPing_Handler,
+		},
+		{
+			MethodName: "Exec",
+			Handler:    _Cmd_Exec_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _Cmd_Ping_Handler,
@@ -632,41 +1190,59 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 562 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0xdd, 0x4e, 0xdb, 0x30,
-	0x14, 0xc7, 0x29, 0xed, 0x4a, 0x39, 0xad, 0x3a, 0x64, 0x0a, 0xcb, 0x98, 0x10, 0x2c, 0x9a, 0xd0,
-	0x76, 0x41, 0xab, 0x76, 0xda, 0xed, 0x34, 0x15, 0xa6, 0x89, 0xa1, 0x00, 0x4a, 0x51, 0xa7, 0xed,
-	0x66, 0x32, 0x89, 0x69, 0xad, 0x26, 0x76, 0x66, 0x3b, 0x99, 0xfa, 0x0a, 0x7b, 0xa6, 0x3d, 0xdc,
-	0x14, 0x27, 0x29, 0xa4, 0x29, 0xa0, 0xa9, 0xbd, 0x6a, 0xce, 0xd7, 0xef, 0xfc, 0x7d, 0x7c, 0xac,
-	0xc2, 0xbb, 0x60, 0x32, 0xea, 0x8c, 0x31, 0x73, 0x3d, 0x22, 0x8e, 0x3d, 0x1c, 0x32, 0x67, 0x4c,
-	0xc4, 0xb1, 0xc3, 0xfd, 0x8e, 0xe3, 0xbb, 0x9d, 0xa8, 0x1b, 0xff, 0xb4, 0x03, 0xc1, 0x15, 0x47,
-	0xcf, 0x27, 0xe1, 0x0d, 0x89, 0xa8, 0x50, 0xed, 0xd8, 0x17, 0x75, 0xcd, 0x03, 0x28, 0x0f, 0xad,
-	0x33, 0x64, 0xc0, 0x46, 0xe4, 0xd3, 0xaf, 0x92, 0x33, 0xa3, 0x74, 0x58, 0x7a, 0xdb, 0xb0, 0x33,
-	0xd3, 0xfc, 0x53, 0x82, 0xea, 0xc0, 0xea, 0x53, 0x2e, 0x91, 0x09, 0x0d, 0x1f, 0xb3, 0xf0, 0x16,
-	0x3b, 0x2a, 0x14, 0x44, 0xe8, 0xcc, 0x4d, 0x3b, 0xe7, 0x8b, 0x41, 0x81, 0xe0, 0x6e, 0xe8, 0x28,
-	0x63, 0x5d, 0x87, 0x33, 0x53, 0xb7, 0x20, 0x42, 0x52, 0xce, 0x8c, 0x72, 0x12, 0x49, 0x4d, 0xb4,
-	0x05, 0x65, 0x39, 0x09, 0x8d, 0x8a, 0xf6, 0xc6, 0x9f, 0x68, 0x17, 0xaa, 0xb7, 0xd8, 0xa7, 0xde,
-	0xd4, 0x78, 0xa6, 0x9d, 0xa9, 0x65, 0xba, 0xb0, 0x33, 0xa4, 0x42, 0x85, 0xd8, 0xb3, 0xb0, 0x33,
-	0xa6, 0x8c, 0x5c, 0x06, 0x8a, 0x72, 0x26, 0xd1, 0x39, 0xb4, 0xf2, 0x81, 0x44, 0xb2, 0x96, 0x58,
-	0xef, 0xbd, 0x68, 0xcf, 0x1d, 0xbb, 0x9d, 0x84, 0xed, 0x85, 0x45, 0x66, 0x04, 0x30, 0xb4, 0xce,
-	0x6c, 0xf2, 0x2b, 0x24, 0x52, 0xa1, 0x23, 0x28, 0x47, 0x3e, 0x4d, 0x49, 0xad, 0x02, 0x29, 0xce,
-	0x8c, 0x13, 0xd0, 0x27, 0xd8, 0xe0, 0x89, 0x1a, 0x7d, 0xf2, 0x7a, 0xef, 0xa8, 0x98, 0xbb, 0x48,
-	0xbb, 0x9d, 0x95, 0x99, 0xd7, 0xb0, 0x65, 0xd1, 0x91, 0xc0, 0xb1, 0xf5, 0xbf, 0xdd, 0x8d, 0x7c,
-	0xf7, 0xc6, 0x1d, 0xb5, 0x09, 0x8d, 0xcf, 0x7e, 0xa0, 0xa6, 0x29, 0xd1, 0xfc, 0x08, 0x35, 0x9b,
-	0xc8, 0x80, 0x33, 0x49, 0xe2, 0x2a, 0x19, 0x3a, 0x0e, 0x91, 0xc9, 0xa4, 0x6a, 0x76, 0x66, 0xc6,
-	0x11, 0x9f, 0x48, 0x89, 0x47, 0x24, 0xbb, 0xc7, 0xd4, 0x34, 0x7f, 0x42, 0xf3, 0x94, 0xfb, 0x98,
-	0xb2, 0x19, 0xe5, 0x03, 0xd4, 0x44, 0xfa, 0x9d, 0x0a, 0x7d, 0x59, 0x10, 0x9a, 0x25, 0xdb, 0xb3,
-	0xd4, 0xf8, 0x92, 0x5d, 0x0d, 0x4a, 0x3b, 0xa4, 0x96, 0xc9, 0x60, 0x3b, 0x69, 0x30, 0x50, 0x58,
-	0xc9, 0x65, 0xbb, 0x1c, 0x42, 0xdd, 0xbd, 0xa3, 0xa5, 0xad, 0xee, 0xbb, 0x7a, 0x7f, 0xab, 0x50,
-	0x3e, 0xf1, 0x5d, 0x74, 0x01, 0x68, 0x30, 0x65, 0x4e, 0xfe, 0x92, 0xd0, 0xab, 0x85, 0x33, 0x4f,
-	0x66, 0xb9, 0xf7, 0xb0, 0x02, 0x73, 0x0d, 0xd9, 0xb0, 0x3b, 0x18, 0x87, 0xca, 0xe5, 0xbf, 0xd9,
-	0xca, 0x98, 0x17, 0x80, 0xce, 0xa9, 0xe7, 0xad, 0x8c, 0x77, 0x05, 0xad, 0x53, 0xe2, 0x11, 0x45,
-	0x56, 0x46, 0xfc, 0x06, 0x3b, 0xc9, 0x12, 0xcf, 0x23, 0x5f, 0x17, 0xaa, 0xe6, 0x97, 0xfd, 0x71,
-	0xf0, 0x25, 0x6c, 0xc7, 0xd7, 0x33, 0x2b, 0xba, 0xc6, 0x62, 0x44, 0xd4, 0x12, 0x4a, 0xbf, 0xc3,
-	0xfe, 0x09, 0x66, 0x0e, 0x99, 0x9b, 0xe6, 0xac, 0xc1, 0x12, 0x68, 0x0b, 0x36, 0xbf, 0x10, 0x95,
-	0x6c, 0x31, 0xda, 0x2f, 0x64, 0xde, 0x7f, 0x8f, 0x7b, 0x07, 0x85, 0x70, 0xfe, 0x79, 0xe9, 0x99,
-	0x36, 0x67, 0x38, 0xbd, 0xb3, 0x4f, 0x31, 0xdf, 0x3c, 0xc0, 0xcc, 0xbd, 0x28, 0x73, 0x0d, 0xf5,
-	0xa1, 0x72, 0x45, 0xd9, 0xe8, 0x29, 0xdc, 0x63, 0x67, 0xed, 0x57, 0x7e, 0xac, 0x47, 0xdd, 0x9b,
-	0xaa, 0xfe, 0x7f, 0x79, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x6f, 0xca, 0x9c, 0xe5, 0x8c, 0x06,
-	0x00, 0x00,
+	// 863 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdf, 0x53, 0x1b, 0xb7,
+	0x13, 0x87, 0xd8, 0x01, 0x7b, 0xed, 0x2f, 0x5f, 0x22, 0x20, 0xbd, 0xba, 0xa1, 0xa1, 0x9a, 0x0e,
+	0x93, 0x3e, 0x04, 0x06, 0x3a, 0x7d, 0xed, 0x74, 0xf8, 0x11, 0x4a, 0xa9, 0x81, 0x9e, 0x03, 0x9d,
+	0xe6, 0xa5, 0xa3, 0xdc, 0x2d, 0x67, 0x8d, 0x7d, 0x92, 0x7b, 0xd2, 0xb9, 0x21, 0xaf, 0x7d, 0xeb,
+	0x1f, 0xda, 0xbf, 0xa3, 0x23, 0x9d, 0xee, 0x8c, 0x7d, 0x06, 0xd2, 0xb1, 0x9f, 0xac, 0xfd, 0xa1,
+	0xcf, 0x7e, 0x76, 0x75, 0xda, 0x95, 0xe1, 0x9b, 0x41, 0x2f, 0xda, 0xed, 0x32, 0x11, 0xf6, 0x31,
+	0x79, 0xdd, 0x67, 0xa9, 0x08, 0xba, 0x98, 0xbc, 0x0e, 0x64, 0xbc, 0x1b, 0xc4, 0xe1, 0xee, 0x70,
+	0xcf, 0xfc, 0xec, 0x0c, 0x12, 0xa9, 0x25, 0xf9, 0x7f, 0x2f, 0x7d, 0x8f, 0x43, 0x9e, 0xe8, 0x1d,
+	0xa3, 0x1b, 0xee, 0xd1, 0x97, 0x50, 0xb9, 0x6e, 0x9f, 0x12, 0x0f, 0x96, 0x87, 0x31, 0xff, 0x49,
+	0x49, 0xe1, 0x2d, 0x6e, 0x2d, 0xbe, 0x6a, 0xfa, 0xb9, 0x48, 0xff, 0x5e, 0x84, 0xa5, 0x4e, 0xfb,
+	0x80, 0x4b, 0x45, 0x28, 0x34, 0x63, 0x26, 0xd2, 0x1b, 0x16, 0xe8, 0x34, 0xc1, 0xc4, 0x7a, 0xd6,
+	0xfd, 0x31, 0x9d, 0x01, 0x1a, 0x24, 0x32, 0x4c, 0x03, 0xed, 0x3d, 0xb1, 0xe6, 0x5c, 0xb4, 0x21,
+	0x30, 0x51, 0x5c, 0x0a, 0xaf, 0x92, 0x59, 0x9c, 0x48, 0x56, 0xa1, 0xa2, 0x7a, 0xa9, 0x57, 0xb5,
+	0x5a, 0xb3, 0x24, 0xcf, 0x61, 0xe9, 0x86, 0xc5, 0xbc, 0x7f, 0xeb, 0x3d, 0xb5, 0x4a, 0x27, 0xd1,
+	0x10, 0x36, 0xae, 0x79, 0xa2, 0x53, 0xd6, 0x6f, 0xb3, 0xa0, 0xcb, 0x05, 0x5e, 0x0c, 0x34, 0x97,
+	0x42, 0x91, 0x33, 0x58, 0x1f, 0x37, 0x64, 0x94, 0x2d, 0xc5, 0xc6, 0xfe, 0x67, 0x3b, 0x13, 0x69,
+	0xef, 0x64, 0x66, 0x7f, 0xea, 0x26, 0x3a, 0x04, 0xb8, 0x6e, 0x9f, 0xfa, 0xf8, 0x47, 0x8a, 0x4a,
+	0x93, 0x6d, 0xa8, 0x0c, 0x63, 0xee, 0x90, 0xd6, 0x4b, 0x48, 0xc6, 0xd3, 0x38, 0x90, 0x1f, 0x60,
+	0x59, 0x66, 0x6c, 0x6c, 0xe6, 0x8d, 0xfd, 0xed, 0xb2, 0xef, 0x34, 0xee, 0x7e, 0xbe, 0x8d, 0xbe,
+	0x85, 0xd5, 0x36, 0x8f, 0x12, 0x66, 0xa4, 0xff, 0x1a, 0xdd, 0x1b, 0x8f, 0xde, 0x1c, 0xa1, 0xae,
+	0x40, 0xf3, 0x38, 0x1e, 0xe8, 0x5b, 0x87, 0x48, 0xbf, 0x87, 0x9a, 0x8f, 0x6a, 0x20, 0x85, 0x42,
+	0xb3, 0x4b, 0xa5, 0x41, 0x80, 0x2a, 0xab, 0x54, 0xcd, 0xcf, 0x45, 0x63, 0x89, 0x51, 0x29, 0x16,
+	0x61, 0x7e, 0x8e, 0x4e, 0xa4, 0xbf, 0xc3, 0xca, 0x91, 0x8c, 0x19, 0x17, 0x05, 0xca, 0x77, 0x50,
+	0x4b, 0xdc, 0xda, 0x11, 0xfd, 0xbc, 0x44, 0x34, 0x77, 0xf6, 0x0b, 0x57, 0x73, 0xc8, 0xa1, 0x05,
+	0x72, 0x11, 0x9c, 0x44, 0x05, 0xac, 0x65, 0x01, 0x3a, 0x9a, 0x69, 0x35, 0x6b, 0x94, 0x2d, 0x68,
+	0x84, 0x23, 0x34, 0x17, 0xea, 0xae, 0x8a, 0x76, 0xe1, 0xd9, 0x89, 0xa9, 0xcc, 0xa9, 0xb8, 0x91,
+	0xb3, 0x46, 0x7b, 0x01, 0xf5, 0x28, 0xc7, 0x72, 0xb1, 0x46, 0x0a, 0xfa, 0x0e, 0x56, 0x6d, 0xa4,
+	0x4b, 0x2e, 0xa2, 0xfc, 0x80, 0xbf, 0x04, 0xc8, 0xc8, 0x9c, 0xb3, 0x18, 0xdd, 0x95, 0xba, 0xa3,
+	0x21, 0xdb, 0xb0, 0xa2, 0x79, 0x8c, 0x32, 0xd5, 0x1d, 0x0c, 0xa4, 0x08, 0xb3, 0x14, 0x9e, 0xfa,
+	0x13, 0x5a, 0xfa, 0xd7, 0x22, 0x34, 0x8e, 0x3f, 0x60, 0xf0, 0xa9, 0xb8, 0x1e, 0x2c, 0x07, 0x32,
+	0x8e, 0x99, 0x08, 0xf3, 0x03, 0x76, 0x22, 0x21, 0x50, 0x65, 0x49, 0xa4, 0xbc, 0xca, 0x56, 0xe5,
+	0x55, 0xdd, 0xb7, 0xeb, 0x29, 0x2c, 0xaa, 0x53, 0x59, 0xdc, 0x42, 0x33, 0x23, 0x31, 0x5b, 0x19,
+	0x5b, 0x50, 0xc3, 0x0f, 0x5c, 0x1f, 0xca, 0x10, 0x5d, 0xba, 0x85, 0x6c, 0x3e, 0x1b, 0xa5, 0xc3,
+	0x8b, 0x54, 0xbb, 0x36, 0xe2, 0x24, 0x1a, 0xc0, 0x33, 0x1f, 0x15, 0xff, 0x88, 0x47, 0x5c, 0xf5,
+	0x3e, 0xb5, 0x0a, 0x2d, 0xa8, 0x85, 0x5c, 0xf5, 0xac, 0x35, 0x2b, 0x43, 0x21, 0x9b, 0x3a, 0x18,
+	0x38, 0x1b, 0xa6, 0xe2, 0xdb, 0xf5, 0xfe, 0x3f, 0xff, 0x83, 0xca, 0x61, 0x1c, 0x92, 0x73, 0x20,
+	0x9d, 0x5b, 0x11, 0x8c, 0x5f, 0x68, 0xf2, 0xc5, 0xd4, 0xfb, 0x99, 0x51, 0x69, 0xdd, 0x9f, 0x38,
+	0x5d, 0x20, 0x3e, 0x3c, 0xef, 0x74, 0x53, 0x1d, 0xca, 0x3f, 0xc5, 0xdc, 0x30, 0xcf, 0x81, 0x9c,
+	0xf1, 0x7e, 0x7f, 0x6e, 0x78, 0x97, 0xb0, 0x7e, 0x84, 0x7d, 0xd4, 0x38, 0x37, 0xc4, 0x5f, 0x61,
+	0x23, 0x6b, 0x78, 0x93, 0x90, 0x5f, 0x95, 0x76, 0x4d, 0x36, 0xc6, 0x87, 0x81, 0x2f, 0x60, 0xcd,
+	0x1c, 0x4f, 0xb1, 0xe9, 0x2d, 0x4b, 0x22, 0xd4, 0x33, 0x30, 0xfd, 0x0d, 0x36, 0x0f, 0x99, 0x08,
+	0x70, 0xa2, 0x9a, 0x45, 0x80, 0x19, 0xa0, 0x2f, 0x60, 0xed, 0x92, 0xa5, 0x6a, 0x7e, 0x55, 0xfd,
+	0x05, 0x36, 0xae, 0xc4, 0x60, 0xae, 0x90, 0x97, 0xb0, 0xfe, 0x26, 0x41, 0xfc, 0x88, 0xf3, 0xfc,
+	0xe0, 0xaf, 0xc4, 0xcd, 0x7c, 0x31, 0x4f, 0xa0, 0xf1, 0xa3, 0xd4, 0x83, 0x7e, 0x1a, 0x99, 0x16,
+	0x30, 0x03, 0xd0, 0x1b, 0x80, 0x2b, 0x31, 0x07, 0x9c, 0x9f, 0x61, 0xd5, 0x11, 0xf2, 0x51, 0xc9,
+	0x34, 0x09, 0x50, 0xcd, 0x96, 0xde, 0x61, 0x97, 0x89, 0x08, 0xdb, 0x18, 0x72, 0x36, 0x03, 0x50,
+	0x1b, 0x60, 0xd4, 0x29, 0x09, 0x9d, 0xe6, 0x3a, 0xde, 0x46, 0x1f, 0x83, 0xab, 0x9f, 0xa0, 0xce,
+	0x46, 0x36, 0xd9, 0x2c, 0x79, 0xde, 0x7d, 0x7c, 0xb4, 0x5e, 0x96, 0xcc, 0xe3, 0x6f, 0x09, 0xdb,
+	0x14, 0x56, 0x0a, 0x38, 0x3b, 0xa0, 0x1f, 0xc3, 0xfc, 0xfa, 0x1e, 0xcc, 0xb1, 0xe7, 0x03, 0x5d,
+	0x20, 0x1d, 0x68, 0x9e, 0xa0, 0x2e, 0x46, 0xfd, 0x63, 0xb0, 0xe5, 0xba, 0x94, 0x5e, 0x09, 0x74,
+	0x81, 0x9c, 0x41, 0xbd, 0x18, 0xe9, 0x53, 0xda, 0xd6, 0xe4, 0xb8, 0x7f, 0xb8, 0x92, 0xc7, 0x50,
+	0x35, 0xd3, 0x93, 0xbc, 0x28, 0x33, 0x1b, 0x4d, 0xf6, 0xd6, 0xe6, 0x3d, 0xd6, 0x02, 0xe6, 0x00,
+	0xaa, 0x96, 0xce, 0x23, 0x09, 0x3e, 0x44, 0xe5, 0xa0, 0xfa, 0xee, 0xc9, 0x70, 0xef, 0xfd, 0x92,
+	0xfd, 0xd7, 0xf0, 0xed, 0xbf, 0x03, 0x00, 0x17, 0xe7, 0xce, 0x76, 0x62, 0x0c, 0x00, 0x00,
 }
@@ -32,6 +32,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/util/types"
 )
 
@@ -40,6 +41,8 @@ var pvcBaseDir = "/var/run/kubevirt-private/vmi-disks"
 const (
 	EventReasonToleratedSmallPV = "ToleratedSmallPV"
 	EventTypeToleratedSmallPV   = k8sv1.EventTypeNormal
+
+	mib = 1024 * 1024
 )
 
 // Used by tests.
@@ -53,6 +56,14 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance, clientset kubecli.Kube
 	// Filesystem PersistenVolumeClaim is mounted into pod as directory from node filesystem
 	for i := range vmi.Spec.Volumes {
 		if volumeSource := &vmi.Spec.Volumes[i].VolumeSource; volumeSource.PersistentVolumeClaim != nil {
+			// hotplugged volumes are mounted into the pod by virt-handler
+			if hotplugdisk.IsHotplugVolume(vmi, vmi.Spec.Volumes[i].Name) {
+				continue
+			}
+			// filesystems share the mounted PVC directory with the guest
+			if vmi.IsFilesystemVolume(vmi.Spec.Volumes[i].Name) {
+				continue
+			}
 
 			pvc, exists, isBlockVolumePVC, err := types.IsPVCBlockFromClient(clientset, vmi.Namespace, volumeSource.PersistentVolumeClaim.ClaimName)
 			if err != nil {
@@ -86,6 +97,43 @@ func dirBytesAvailable(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
+// ExpandImage grows an existing disk image to the capacity of its expanded PVC, tolerating less
+// space in the same way as Create does. The new size is aligned down to MiB, since QEMU needs
+// whole sectors. It returns the size of the image after the expansion.
+func (hdc DiskImgCreator) ExpandImage(imagePath string, capacity int64) (int64, error) {
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	if capacity <= size {
+		return size, nil
+	}
+
+	availableSize, err := hdc.dirBytesAvailableFunc(filepath.Dir(imagePath))
+	if err != nil {
+		return size, err
+	}
+	newSize := capacity
+	if uint64(capacity-size) > availableSize {
+		toleratedSize := capacity * (100 - int64(hdc.lessPVCSpaceToleration)) / 100
+		if toleratedSize > size+int64(availableSize) {
+			return size, fmt.Errorf("unable to expand %s, not enough space, demanded size %d B is bigger than available space %d B, also after taking %v %% toleration into account",
+				imagePath, capacity, size+int64(availableSize), hdc.lessPVCSpaceToleration)
+		}
+		newSize = size + int64(availableSize)
+	}
+
+	newSize = newSize &^ (mib - 1)
+	if newSize <= size {
+		return size, nil
+	}
+	if err := os.Truncate(imagePath, newSize); err != nil {
+		return size, err
+	}
+	return newSize, nil
+}
+
 func createSparseRaw(fullPath string, size int64) error {
 	offset := size - 1
 	f, _ := os.Create(fullPath)
@@ -37,6 +37,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 )
 
 type MockNotifier struct {
@@ -298,6 +299,56 @@ var _ = Describe("HostDisk", func() {
 		})
 	})
 
+	Describe("Expanding a disk.img", func() {
+		var creator DiskImgCreator
+		var imgPath string
+
+		BeforeEach(func() {
+			creator = NewHostDiskCreator(notifier, 0)
+			imgPath = path.Join(tempDir, "volume1", "disk.img")
+			createTempDiskImg("volume1")
+		})
+
+		fakeDirBytesAvailable := func(available uint64) func(path string) (uint64, error) {
+			return func(path string) (uint64, error) {
+				return available, nil
+			}
+		}
+
+		It("Should grow the disk.img to the new capacity", func() {
+			creator.dirBytesAvailableFunc = fakeDirBytesAvailable(1024 * mib)
+
+			size, err := creator.ExpandImage(imgPath, 128*mib)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(128 * mib)))
+			file, err := os.Stat(imgPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Size()).To(Equal(int64(128 * mib)))
+		})
+
+		It("Should not shrink the disk.img", func() {
+			size, err := creator.ExpandImage(imgPath, 32*mib)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(64 * mib)))
+		})
+
+		It("Should take lessPVCSpaceToleration into account", func() {
+			creator.setlessPVCSpaceToleration(10)
+			creator.dirBytesAvailableFunc = fakeDirBytesAvailable(60*mib + 4096)
+
+			By("Using the available space within the toleration")
+			size, err := creator.ExpandImage(imgPath, 128*mib)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(124 * mib)))
+
+			By("Failing when the available space is beyond the toleration")
+			creator.dirBytesAvailableFunc = fakeDirBytesAvailable(mib)
+			_, err = creator.ExpandImage(imgPath, 256*mib)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not enough space"))
+		})
+	})
+
 	Describe("HostDisk with unkown type", func() {
 		It("Should not create a disk.img", func() {
 			By("Creating a new minimal vmi")
@@ -383,6 +434,45 @@ var _ = Describe("HostDisk", func() {
 			table.Entry("filemode", k8sv1.PersistentVolumeFilesystem),
 			table.Entry("blockmode", k8sv1.PersistentVolumeBlock),
 		)
+
+		It("should not replace hotplugged PVCs", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "hpvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hppvc"},
+					},
+				},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{Name: "hpvolume", HotplugVolume: &v1.HotplugVolumeStatus{}},
+			}
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+
+			Expect(ReplacePVCByHostDisk(vmi, virtClient)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk).To(BeNil())
+			Expect(vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("hppvc"))
+		})
+
+		It("should not replace PVCs shared as filesystem", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "fsvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "fspvc"},
+					},
+				},
+			}
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "fsvolume", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+
+			Expect(ReplacePVCByHostDisk(vmi, virtClient)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk).To(BeNil())
+			Expect(vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("fspvc"))
+		})
 	})
 
 })
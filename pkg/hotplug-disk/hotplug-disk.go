@@ -0,0 +1,130 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package hotplugdisk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+var mountBaseDir = filepath.Join(util.VirtShareDir, "/hotplug-disks")
+
+// GetMountBaseDir returns the directory below which the hotplugged disks of all VMIs are mounted
+func GetMountBaseDir() string {
+	return mountBaseDir
+}
+
+func SetLocalDirectory(dir string) error {
+	mountBaseDir = dir
+	return os.MkdirAll(dir, 0755)
+}
+
+func GenerateVolumeMountDir(vmi *v1.VirtualMachineInstance) string {
+	return filepath.Join(mountBaseDir, string(vmi.UID))
+}
+
+func GenerateDiskTargetPathFromHostView(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	return filepath.Join(GenerateVolumeMountDir(vmi), fmt.Sprintf("%s.img", volumeName))
+}
+
+func GenerateDiskTargetPathFromLauncherView(volumeName string) string {
+	return filepath.Join(mountBaseDir, fmt.Sprintf("%s.img", volumeName))
+}
+
+// GetVolumeNameFromLauncherView returns the name of the hotplugged volume whose disk image is at the
+// given path in the virt-launcher pod
+func GetVolumeNameFromLauncherView(path string) (string, bool) {
+	if filepath.Dir(path) != mountBaseDir || filepath.Ext(path) != ".img" {
+		return "", false
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".img"), true
+}
+
+// AttachmentPodBlockDevicePath is the path of the block device of a hotplugged block volume
+// in its attachment pod
+const AttachmentPodBlockDevicePath = "/dev/hotplug-disk"
+
+// GenerateSocketPathFromHostView returns the socket through which the attachment pod of a volume
+// announces itself to virt-handler
+func GenerateSocketPathFromHostView(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	return filepath.Join(GenerateVolumeMountDir(vmi), fmt.Sprintf("%s.sock", volumeName))
+}
+
+// GetHotplugVolumeNames returns the names of all volumes which were ever hotplugged into the VMI.
+// They are recorded by virt-api in an annotation which users can't change.
+func GetHotplugVolumeNames(vmi *v1.VirtualMachineInstance) map[string]bool {
+	names := map[string]bool{}
+	for _, name := range strings.Split(vmi.Annotations[v1.HotplugVolumesAnnotation], ",") {
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// AddHotplugVolumeName records that a volume was hotplugged into the VMI. Names are never removed,
+// since unplugged volumes are still detached and unmounted after they are gone from the spec.
+func AddHotplugVolumeName(vmi *v1.VirtualMachineInstance, volumeName string) {
+	names := GetHotplugVolumeNames(vmi)
+	if names[volumeName] {
+		return
+	}
+	if vmi.Annotations == nil {
+		vmi.Annotations = map[string]string{}
+	}
+	if value := vmi.Annotations[v1.HotplugVolumesAnnotation]; value != "" {
+		vmi.Annotations[v1.HotplugVolumesAnnotation] = value + "," + volumeName
+	} else {
+		vmi.Annotations[v1.HotplugVolumesAnnotation] = volumeName
+	}
+}
+
+// GetHotplugVolumes returns the status of all volumes which were hotplugged into the VMI, by volume name
+func GetHotplugVolumes(vmi *v1.VirtualMachineInstance) map[string]*v1.VolumeStatus {
+	names := GetHotplugVolumeNames(vmi)
+	volumes := map[string]*v1.VolumeStatus{}
+	for i, status := range vmi.Status.VolumeStatus {
+		if status.HotplugVolume != nil && names[status.Name] {
+			volumes[status.Name] = &vmi.Status.VolumeStatus[i]
+		}
+	}
+	return volumes
+}
+
+// IsHotplugVolume checks if a volume of the VMI was hotplugged
+func IsHotplugVolume(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	_, exists := GetHotplugVolumes(vmi)[volumeName]
+	return exists
+}
+
+// GetRequestedMedium returns the name of the volume which was requested to be in the CD-ROM or floppy,
+// which is the volume of the drive itself unless another medium was inserted. It is empty if the
+// medium was ejected.
+func GetRequestedMedium(disk *v1.Disk) string {
+	if disk.Medium != nil {
+		return *disk.Medium
+	}
+	return disk.Name
+}
@@ -0,0 +1,100 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package hotplugdisk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("HotplugDisk", func() {
+	var tmpDir string
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "hotplug-disks")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(SetLocalDirectory(tmpDir)).To(Succeed())
+
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should generate the host and launcher paths of a disk", func() {
+		Expect(GenerateDiskTargetPathFromHostView(vmi, "hpvolume")).To(Equal(filepath.Join(tmpDir, "1234", "hpvolume.img")))
+		Expect(GenerateSocketPathFromHostView(vmi, "hpvolume")).To(Equal(filepath.Join(tmpDir, "1234", "hpvolume.sock")))
+		Expect(GenerateDiskTargetPathFromLauncherView("hpvolume")).To(Equal(filepath.Join(tmpDir, "hpvolume.img")))
+	})
+
+	It("should find the volume behind a disk image in the launcher", func() {
+		name, isHotplugVolume := GetVolumeNameFromLauncherView(GenerateDiskTargetPathFromLauncherView("hpvolume"))
+		Expect(isHotplugVolume).To(BeTrue())
+		Expect(name).To(Equal("hpvolume"))
+
+		_, isHotplugVolume = GetVolumeNameFromLauncherView("/var/run/kubevirt-private/vmi-disks/disk0/disk.img")
+		Expect(isHotplugVolume).To(BeFalse())
+	})
+
+	It("should only report recorded volumes with a hotplug status as hotplugged", func() {
+		AddHotplugVolumeName(vmi, "hpvolume")
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{
+			{Name: "hpvolume", HotplugVolume: &v1.HotplugVolumeStatus{}},
+			{Name: "other"},
+			{Name: "unrecorded", HotplugVolume: &v1.HotplugVolumeStatus{}},
+		}
+		Expect(GetHotplugVolumes(vmi)).To(HaveLen(1))
+		Expect(IsHotplugVolume(vmi, "hpvolume")).To(BeTrue())
+		Expect(IsHotplugVolume(vmi, "other")).To(BeFalse())
+		Expect(IsHotplugVolume(vmi, "unrecorded")).To(BeFalse())
+		Expect(IsHotplugVolume(vmi, "unknown")).To(BeFalse())
+	})
+
+	It("should record each hotplugged volume once", func() {
+		AddHotplugVolumeName(vmi, "hpvolume1")
+		AddHotplugVolumeName(vmi, "hpvolume2")
+		AddHotplugVolumeName(vmi, "hpvolume1")
+		Expect(vmi.Annotations[v1.HotplugVolumesAnnotation]).To(Equal("hpvolume1,hpvolume2"))
+		Expect(GetHotplugVolumeNames(vmi)).To(Equal(map[string]bool{"hpvolume1": true, "hpvolume2": true}))
+	})
+
+	It("should default the requested medium of a drive to its own volume", func() {
+		disk := v1.Disk{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}}
+		Expect(GetRequestedMedium(&disk)).To(Equal("cdrom"))
+
+		iso := "iso"
+		disk.Medium = &iso
+		Expect(GetRequestedMedium(&disk)).To(Equal("iso"))
+
+		ejected := ""
+		disk.Medium = &ejected
+		Expect(GetRequestedMedium(&disk)).To(BeEmpty())
+	})
+})
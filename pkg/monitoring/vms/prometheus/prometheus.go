@@ -140,6 +140,42 @@ var (
 		},
 		nil,
 	)
+	memoryUnusedDesc = prometheus.NewDesc(
+		"kubevirt_vmi_memory_unused_bytes",
+		"amount of memory left completely unused by the guest.",
+		[]string{
+			"node", "namespace", "name",
+			"domain",
+		},
+		nil,
+	)
+	memoryUsableDesc = prometheus.NewDesc(
+		"kubevirt_vmi_memory_usable_bytes",
+		"amount of memory which the guest can reclaim without swapping, as reported by the balloon driver.",
+		[]string{
+			"node", "namespace", "name",
+			"domain",
+		},
+		nil,
+	)
+	memoryActualBalloonDesc = prometheus.NewDesc(
+		"kubevirt_vmi_memory_actual_balloon_bytes",
+		"current balloon size.",
+		[]string{
+			"node", "namespace", "name",
+			"domain",
+		},
+		nil,
+	)
+	memoryPageFaultsDesc = prometheus.NewDesc(
+		"kubevirt_vmi_memory_page_faults_total",
+		"number of page faults in the guest.",
+		[]string{
+			"node", "namespace", "name",
+			"domain", "type",
+		},
+		nil,
+	)
 
 	swapTrafficDesc = prometheus.NewDesc(
 		"kubevirt_vmi_memory_swap_traffic_bytes_total",
@@ -150,6 +186,48 @@ var (
 		},
 		nil,
 	)
+
+	// live migration progress, as reported by the source node
+	migrationDataProcessedDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_data_processed_bytes",
+		"amount of data transferred by the running migration.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
+	migrationDataRemainingDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_data_remaining_bytes",
+		"amount of data left to transfer by the running migration.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
+	migrationDirtyMemoryRateDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_dirty_memory_rate_bytes",
+		"rate at which the guest dirties its memory during the running migration, per second.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
+	migrationMemoryTransferRateDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_memory_transfer_rate_bytes",
+		"rate at which the memory is transferred by the running migration, per second.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
+	migrationMemoryIterationDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_memory_iteration",
+		"number of iterations over the guest memory of the running migration.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
 )
 
 func tryToPushMetric(desc *prometheus.Desc, mv prometheus.Metric, err error, ch chan<- prometheus.Metric) {
@@ -181,6 +259,54 @@ func updateMemory(vmi *k6tv1.VirtualMachineInstance, vmStats *stats.DomainStats,
 		)
 		tryToPushMetric(memoryResidentDesc, mv, err, ch)
 	}
+	if vmStats.Memory.UnusedSet {
+		mv, err := prometheus.NewConstMetric(
+			memoryUnusedDesc, prometheus.GaugeValue,
+			// the libvirt value is in KiB
+			float64(vmStats.Memory.Unused)*1024,
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			vmStats.Name,
+		)
+		tryToPushMetric(memoryUnusedDesc, mv, err, ch)
+	}
+	if vmStats.Memory.UsableSet {
+		mv, err := prometheus.NewConstMetric(
+			memoryUsableDesc, prometheus.GaugeValue,
+			// the libvirt value is in KiB
+			float64(vmStats.Memory.Usable)*1024,
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			vmStats.Name,
+		)
+		tryToPushMetric(memoryUsableDesc, mv, err, ch)
+	}
+	if vmStats.Memory.ActualBalloonSet {
+		mv, err := prometheus.NewConstMetric(
+			memoryActualBalloonDesc, prometheus.GaugeValue,
+			// the libvirt value is in KiB
+			float64(vmStats.Memory.ActualBalloon)*1024,
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			vmStats.Name,
+		)
+		tryToPushMetric(memoryActualBalloonDesc, mv, err, ch)
+	}
+	if vmStats.Memory.MajorFaultSet {
+		mv, err := prometheus.NewConstMetric(
+			memoryPageFaultsDesc, prometheus.CounterValue,
+			float64(vmStats.Memory.MajorFault),
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			vmStats.Name, "major",
+		)
+		tryToPushMetric(memoryPageFaultsDesc, mv, err, ch)
+	}
+	if vmStats.Memory.MinorFaultSet {
+		mv, err := prometheus.NewConstMetric(
+			memoryPageFaultsDesc, prometheus.CounterValue,
+			float64(vmStats.Memory.MinorFault),
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			vmStats.Name, "minor",
+		)
+		tryToPushMetric(memoryPageFaultsDesc, mv, err, ch)
+	}
 
 	if vmStats.Memory.SwapInSet {
 		mv, err := prometheus.NewConstMetric(
@@ -375,6 +501,32 @@ func updateVMIsPhase(nodeName string, vmis []*k6tv1.VirtualMachineInstance, ch c
 	}
 }
 
+func updateVMIsMigrationProgress(vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	for _, vmi := range vmis {
+		migrationState := vmi.Status.MigrationState
+		if migrationState == nil || migrationState.Progress == nil ||
+			migrationState.Completed || migrationState.Failed {
+			continue
+		}
+		progress := migrationState.Progress
+
+		for desc, value := range map[*prometheus.Desc]int64{
+			migrationDataProcessedDesc:      progress.DataProcessedBytes,
+			migrationDataRemainingDesc:      progress.DataRemainingBytes,
+			migrationDirtyMemoryRateDesc:    progress.MemoryDirtyRateBytes,
+			migrationMemoryTransferRateDesc: progress.MemoryTransferRateBytes,
+			migrationMemoryIterationDesc:    progress.MemoryIteration,
+		} {
+			mv, err := prometheus.NewConstMetric(
+				desc, prometheus.GaugeValue,
+				float64(value),
+				vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			)
+			tryToPushMetric(desc, mv, err, ch)
+		}
+	}
+}
+
 func updateVersion(ch chan<- prometheus.Metric) {
 	verinfo := version.Get()
 	ch <- prometheus.MustNewConstMetric(
@@ -415,6 +567,15 @@ func (co *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- networkErrorsDesc
 	ch <- memoryAvailableDesc
 	ch <- memoryResidentDesc
+	ch <- memoryUnusedDesc
+	ch <- memoryUsableDesc
+	ch <- memoryActualBalloonDesc
+	ch <- memoryPageFaultsDesc
+	ch <- migrationDataProcessedDesc
+	ch <- migrationDataRemainingDesc
+	ch <- migrationDirtyMemoryRateDesc
+	ch <- migrationMemoryTransferRateDesc
+	ch <- migrationMemoryIterationDesc
 }
 
 func newvmiSocketMapFromVMIs(baseDir string, vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap {
@@ -452,6 +613,7 @@ func (co *Collector) Collect(ch chan<- prometheus.Metric) {
 	co.concCollector.Collect(socketToVMIs, scraper, collectionTimeout)
 
 	updateVMIsPhase(co.nodeName, vmis, ch)
+	updateVMIsMigrationProgress(vmis, ch)
 	return
 }
 
@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -59,6 +60,57 @@ var _ = Describe("Prometheus", func() {
 			Expect(testReportPanic).ToNot(Panic())
 		})
 	})
+
+	Context("on handling memory stats", func() {
+		It("should expose the balloon driver statistics", func() {
+			ch := make(chan prometheus.Metric, 10)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu: &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{
+					UnusedSet:        true,
+					Unused:           1024,
+					UsableSet:        true,
+					Usable:           2048,
+					ActualBalloonSet: true,
+					ActualBalloon:    4096,
+					MajorFaultSet:    true,
+					MajorFault:       42,
+					MinorFaultSet:    true,
+					MinorFault:       4242,
+				},
+			}
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			expected := []struct {
+				desc  *prometheus.Desc
+				value float64
+			}{
+				{memoryUnusedDesc, 1024 * 1024},
+				{memoryUsableDesc, 2048 * 1024},
+				{memoryActualBalloonDesc, 4096 * 1024},
+				{memoryPageFaultsDesc, 42},
+				{memoryPageFaultsDesc, 4242},
+			}
+			Expect(ch).To(HaveLen(len(expected)))
+			for _, e := range expected {
+				metric := <-ch
+				Expect(metric.Desc()).To(Equal(e.desc))
+
+				dto := &io_prometheus_client.Metric{}
+				Expect(metric.Write(dto)).To(Succeed())
+				if dto.Gauge != nil {
+					Expect(dto.GetGauge().GetValue()).To(Equal(e.value))
+				} else {
+					Expect(dto.GetCounter().GetValue()).To(Equal(e.value))
+				}
+			}
+		})
+	})
 })
 
 var _ = Describe("Utility functions", func() {
@@ -121,4 +173,48 @@ var _ = Describe("Utility functions", func() {
 			Expect(phasesMap["bogus"]).To(Equal(uint64(0))) // intentionally bogus key
 		})
 	})
+
+	Context("VMI migration progress reporting", func() {
+		It("should only report the progress of running migrations", func() {
+			newVMI := func(name string, completed bool, progress *k6tv1.VirtualMachineInstanceMigrationProgress) *k6tv1.VirtualMachineInstance {
+				return &k6tv1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						MigrationState: &k6tv1.VirtualMachineInstanceMigrationState{
+							Completed: completed,
+							Progress:  progress,
+						},
+					},
+				}
+			}
+			progress := &k6tv1.VirtualMachineInstanceMigrationProgress{
+				DataProcessedBytes: 1024,
+				DataRemainingBytes: 2048,
+				MemoryIteration:    3,
+			}
+			vmis := []*k6tv1.VirtualMachineInstance{
+				newVMI("migrating", false, progress),
+				newVMI("migrated", true, progress),
+				newVMI("starting", false, nil),
+				&k6tv1.VirtualMachineInstance{},
+			}
+
+			ch := make(chan prometheus.Metric, 20)
+			updateVMIsMigrationProgress(vmis, ch)
+			close(ch)
+
+			values := map[*prometheus.Desc]float64{}
+			for metric := range ch {
+				dto := &io_prometheus_client.Metric{}
+				Expect(metric.Write(dto)).To(Succeed())
+				values[metric.Desc()] = dto.GetGauge().GetValue()
+			}
+			Expect(values).To(HaveLen(5))
+			Expect(values[migrationDataProcessedDesc]).To(Equal(float64(1024)))
+			Expect(values[migrationDataRemainingDesc]).To(Equal(float64(2048)))
+			Expect(values[migrationMemoryIterationDesc]).To(Equal(float64(3)))
+		})
+	})
 })
@@ -0,0 +1,207 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search for activations of schedules which never or only very rarely match, like "0 0 30 2 *"
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bounds struct {
+	name     string
+	min, max uint
+}
+
+var (
+	minuteBounds     = bounds{"minute", 0, 59}
+	hourBounds       = bounds{"hour", 0, 23}
+	dayOfMonthBounds = bounds{"day of month", 1, 31}
+	monthBounds      = bounds{"month", 1, 12}
+	// both 0 and 7 stand for Sunday
+	dayOfWeekBounds = bounds{"day of week", 0, 7}
+)
+
+// Schedule is a parsed cron expression with the five standard fields
+// minute, hour, day of month, month and day of week
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// as in cron, if both day fields are restricted, either of them has to match
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+// Parse parses a cron expression like "30 18 * * 1-5" or one of the macros like "@daily"
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, isMacro := macros[spec]; isMacro {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", spec, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+	s.dayOfMonthStar = strings.HasPrefix(fields[2], "*")
+	s.dayOfWeekStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps, like "*/15" or "1-5,7"
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+		start, end := b.min, b.max
+		step := uint(1)
+
+		switch {
+		case rangeAndStep[0] == "*":
+		case strings.Contains(rangeAndStep[0], "-"):
+			startAndEnd := strings.SplitN(rangeAndStep[0], "-", 2)
+			var err error
+			if start, err = parseValue(startAndEnd[0], b); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(startAndEnd[1], b); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid %s range %q", b.name, rangeAndStep[0])
+			}
+		default:
+			var err error
+			if start, err = parseValue(rangeAndStep[0], b); err != nil {
+				return 0, err
+			}
+			// a single value without step only matches itself, "5/10" is the same as "5-max/10"
+			if len(rangeAndStep) == 1 {
+				end = start
+			}
+		}
+
+		if len(rangeAndStep) == 2 {
+			value, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+			if err != nil || value == 0 {
+				return 0, fmt.Errorf("invalid %s step %q", b.name, rangeAndStep[1])
+			}
+			step = uint(value)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (uint, error) {
+	parsed, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || uint(parsed) < b.min || uint(parsed) > b.max {
+		return 0, fmt.Errorf("invalid %s %q, expected a value between %d and %d", b.name, value, b.min, b.max)
+	}
+	return uint(parsed), nil
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first activation of the schedule after the given time.
+// It returns the zero time if there is none within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	limit := t.Add(searchLimit)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last activation of the schedule at or before the given time.
+// It returns the zero time if there is none within the last five years.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	limit := t.Add(-searchLimit)
+	t = t.Truncate(time.Minute)
+	for t.After(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(-time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
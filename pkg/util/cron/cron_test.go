@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+
+	// a Wednesday
+	now := time.Date(2020, time.January, 15, 10, 30, 15, 0, time.UTC)
+
+	table.DescribeTable("should find the next activation", func(spec string, expected time.Time) {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(now)).To(Equal(expected))
+	},
+		table.Entry("every minute", "* * * * *", time.Date(2020, time.January, 15, 10, 31, 0, 0, time.UTC)),
+		table.Entry("every quarter hour", "*/15 * * * *", time.Date(2020, time.January, 15, 10, 45, 0, 0, time.UTC)),
+		table.Entry("in the evening of working days", "0 18 * * 1-5", time.Date(2020, time.January, 15, 18, 0, 0, 0, time.UTC)),
+		table.Entry("on weekends", "0 8 * * 6,7", time.Date(2020, time.January, 18, 8, 0, 0, 0, time.UTC)),
+		table.Entry("on the first of the month", "@monthly", time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("on either day field", "0 0 1 * 5", time.Date(2020, time.January, 17, 0, 0, 0, 0, time.UTC)),
+		table.Entry("on a leap day", "0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		table.Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	table.DescribeTable("should find the previous activation", func(spec string, expected time.Time) {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Prev(now)).To(Equal(expected))
+	},
+		table.Entry("every minute", "* * * * *", time.Date(2020, time.January, 15, 10, 30, 0, 0, time.UTC)),
+		table.Entry("every quarter hour", "*/15 * * * *", time.Date(2020, time.January, 15, 10, 30, 0, 0, time.UTC)),
+		table.Entry("in the evening of working days", "0 18 * * 1-5", time.Date(2020, time.January, 14, 18, 0, 0, 0, time.UTC)),
+		table.Entry("on weekends", "0 8 * * 6,7", time.Date(2020, time.January, 12, 8, 0, 0, 0, time.UTC)),
+		table.Entry("on the first of the month", "@monthly", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("on a leap day", "0 0 29 2 *", time.Date(2016, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		table.Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	table.DescribeTable("should reject", func(spec string) {
+		_, err := Parse(spec)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("too few fields", "* * * *"),
+		table.Entry("an unknown macro", "@never"),
+		table.Entry("a value out of bounds", "60 * * * *"),
+		table.Entry("an inverted range", "* 5-1 * * *"),
+		table.Entry("a zero step", "*/0 * * * *"),
+		table.Entry("names", "* * * JAN *"),
+	)
+})
@@ -20,6 +20,9 @@
 package hardware
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -28,6 +31,9 @@ import (
 
 const CPUSET_PATH = "/sys/fs/cgroup/cpuset/cpuset.cpus"
 
+// NUMA_NODES_PATH is where the kernel exposes the host NUMA nodes, one node<ID> directory per node
+const NUMA_NODES_PATH = "/sys/devices/system/node"
+
 // Parse linux cpuset into an array of ints
 // See: http://man7.org/linux/man-pages/man7/cpuset.7.html#FORMATS
 func ParseCPUSetLine(cpusetLine string) (cpusList []int, err error) {
@@ -79,3 +85,46 @@ func GetNumberOfVCPUs(cpuSpec *v1.CPU) int64 {
 	}
 	return int64(vCPUs)
 }
+
+// GetCPUNUMANodes returns the host NUMA node of each of the given CPUs.
+// The NUMA nodes are read from nodesPath, which is NUMA_NODES_PATH on a real host.
+func GetCPUNUMANodes(nodesPath string, cpus []int) (map[int]int, error) {
+	nodeDirs, err := filepath.Glob(filepath.Join(nodesPath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	cpuNodes := map[int]int{}
+	for _, nodeDir := range nodeDirs {
+		nodeID, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(nodeDir), "node"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the NUMA node id of %s: %v", nodeDir, err)
+		}
+		cpuList, err := ioutil.ReadFile(filepath.Join(nodeDir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		line := strings.TrimSpace(string(cpuList))
+		if line == "" {
+			// memory only NUMA node
+			continue
+		}
+		nodeCPUs, err := ParseCPUSetLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the cpulist of NUMA node %d: %v", nodeID, err)
+		}
+		for _, cpu := range nodeCPUs {
+			cpuNodes[cpu] = nodeID
+		}
+	}
+
+	result := map[int]int{}
+	for _, cpu := range cpus {
+		nodeID, exists := cpuNodes[cpu]
+		if !exists {
+			return nil, fmt.Errorf("could not find the NUMA node of CPU %d", cpu)
+		}
+		result[cpu] = nodeID
+	}
+	return result, nil
+}
@@ -20,6 +20,10 @@
 package hardware
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -82,4 +86,40 @@ var _ = Describe("Hardware utils test", func() {
 			Expect(vCPUs).To(Equal(int64(4)), "Expect vCPUs")
 		})
 	})
+
+	Context("NUMA nodes", func() {
+		var nodesPath string
+
+		writeNode := func(node string, cpuList string) {
+			nodeDir := filepath.Join(nodesPath, node)
+			Expect(os.MkdirAll(nodeDir, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(nodeDir, "cpulist"), []byte(cpuList+"\n"), 0644)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			var err error
+			nodesPath, err = ioutil.TempDir("", "numa-nodes")
+			Expect(err).ToNot(HaveOccurred())
+			writeNode("node0", "0-3,8-11")
+			writeNode("node1", "4-7,12-15")
+			writeNode("node2", "")
+			// not a NUMA node
+			Expect(os.MkdirAll(filepath.Join(nodesPath, "power"), 0755)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(nodesPath)
+		})
+
+		It("should detect the NUMA node of each CPU", func() {
+			nodes, err := GetCPUNUMANodes(nodesPath, []int{2, 4, 9, 15})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes).To(Equal(map[int]int{2: 0, 4: 1, 9: 0, 15: 1}))
+		})
+
+		It("should fail for CPUs without a NUMA node", func() {
+			_, err := GetCPUNUMANodes(nodesPath, []int{2, 16})
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
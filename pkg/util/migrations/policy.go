@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"sort"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+func ListMigrationPolicies(informer cache.SharedIndexInformer) []*v1.MigrationPolicy {
+	objs := informer.GetStore().List()
+	policies := []*v1.MigrationPolicy{}
+	for _, obj := range objs {
+		policies = append(policies, obj.(*v1.MigrationPolicy))
+	}
+	return policies
+}
+
+// MatchMigrationPolicy returns the policy which applies to the VirtualMachineInstance, or nil if no policy selects it.
+// If several policies select the VirtualMachineInstance, the one with the most label requirements wins,
+// ties are broken by the policy name.
+func MatchMigrationPolicy(policies []*v1.MigrationPolicy, vmi *v1.VirtualMachineInstance, namespace *k8sv1.Namespace) *v1.MigrationPolicy {
+	var namespaceLabels map[string]string
+	if namespace != nil {
+		namespaceLabels = namespace.Labels
+	}
+
+	sorted := make([]*v1.MigrationPolicy, len(policies))
+	copy(sorted, policies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var match *v1.MigrationPolicy
+	matchPriority := -1
+	for _, policy := range sorted {
+		selectors := policy.Spec.Selectors
+		if selectors == nil {
+			continue
+		}
+
+		namespaceMatches, err := selectorMatches(selectors.NamespaceSelector, namespaceLabels)
+		if err != nil {
+			log.Log.Object(policy).Reason(err).Warning("Ignoring migration policy with an invalid namespace selector")
+			continue
+		}
+		vmiMatches, err := selectorMatches(selectors.VirtualMachineInstanceSelector, vmi.Labels)
+		if err != nil {
+			log.Log.Object(policy).Reason(err).Warning("Ignoring migration policy with an invalid VirtualMachineInstance selector")
+			continue
+		}
+		if !namespaceMatches || !vmiMatches {
+			continue
+		}
+
+		priority := selectorPriority(selectors.NamespaceSelector) + selectorPriority(selectors.VirtualMachineInstanceSelector)
+		if priority > matchPriority {
+			match = policy
+			matchPriority = priority
+		}
+	}
+	return match
+}
+
+// ApplyMigrationPolicy returns a copy of the cluster wide migration configuration,
+// overridden by the settings of the policy
+func ApplyMigrationPolicy(config *virtconfig.MigrationConfig, policy *v1.MigrationPolicy) *virtconfig.MigrationConfig {
+	applied := *config
+	if policy == nil {
+		return &applied
+	}
+
+	spec := policy.Spec
+	if spec.BandwidthPerMigration != nil {
+		bandwidth := spec.BandwidthPerMigration.DeepCopy()
+		applied.BandwidthPerMigration = &bandwidth
+	}
+	if spec.CompletionTimeoutPerGiB != nil {
+		completionTimeoutPerGiB := *spec.CompletionTimeoutPerGiB
+		applied.CompletionTimeoutPerGiB = &completionTimeoutPerGiB
+	}
+	if spec.ProgressTimeout != nil {
+		progressTimeout := *spec.ProgressTimeout
+		applied.ProgressTimeout = &progressTimeout
+	}
+	if spec.AllowAutoConverge != nil {
+		applied.AllowAutoConverge = *spec.AllowAutoConverge
+	}
+	if spec.AllowPostCopy != nil {
+		applied.AllowPostCopy = *spec.AllowPostCopy
+	}
+	if spec.PostCopyAfterIterations != nil {
+		postCopyAfterIterations := *spec.PostCopyAfterIterations
+		applied.PostCopyAfterIterations = &postCopyAfterIterations
+	}
+	return &applied
+}
+
+// An unset selector matches everything
+func selectorMatches(selector *metav1.LabelSelector, objLabels map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(objLabels)), nil
+}
+
+func selectorPriority(selector *metav1.LabelSelector) int {
+	if selector == nil {
+		return 0
+	}
+	return len(selector.MatchLabels) + len(selector.MatchExpressions)
+}
@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Migration policies", func() {
+
+	newPolicy := func(name string, namespaceLabels map[string]string, vmiLabels map[string]string) *v1.MigrationPolicy {
+		policy := &v1.MigrationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.MigrationPolicySpec{
+				Selectors: &v1.MigrationPolicySelectors{},
+			},
+		}
+		if namespaceLabels != nil {
+			policy.Spec.Selectors.NamespaceSelector = &metav1.LabelSelector{MatchLabels: namespaceLabels}
+		}
+		if vmiLabels != nil {
+			policy.Spec.Selectors.VirtualMachineInstanceSelector = &metav1.LabelSelector{MatchLabels: vmiLabels}
+		}
+		return policy
+	}
+
+	var vmi *v1.VirtualMachineInstance
+	var namespace *k8sv1.Namespace
+
+	BeforeEach(func() {
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Labels = map[string]string{"workload": "database", "tier": "gold"}
+		namespace = &k8sv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "default",
+				Labels: map[string]string{"team": "storage"},
+			},
+		}
+	})
+
+	Context("matching", func() {
+		It("should not match anything without policies", func() {
+			Expect(MatchMigrationPolicy(nil, vmi, namespace)).To(BeNil())
+		})
+
+		It("should ignore policies without selectors", func() {
+			policy := newPolicy("policy", nil, nil)
+			policy.Spec.Selectors = nil
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{policy}, vmi, namespace)).To(BeNil())
+		})
+
+		It("should require all selectors to match", func() {
+			policy := newPolicy("policy", map[string]string{"team": "network"}, map[string]string{"workload": "database"})
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{policy}, vmi, namespace)).To(BeNil())
+		})
+
+		It("should match by namespace labels", func() {
+			policy := newPolicy("policy", map[string]string{"team": "storage"}, nil)
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{policy}, vmi, namespace)).To(Equal(policy))
+		})
+
+		It("should prefer the policy with the most label requirements", func() {
+			generic := newPolicy("a-generic", map[string]string{"team": "storage"}, nil)
+			specific := newPolicy("b-specific", map[string]string{"team": "storage"}, map[string]string{"workload": "database"})
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{generic, specific}, vmi, namespace)).To(Equal(specific))
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{specific, generic}, vmi, namespace)).To(Equal(specific))
+		})
+
+		It("should break ties by the policy name", func() {
+			first := newPolicy("a-policy", nil, map[string]string{"tier": "gold"})
+			second := newPolicy("b-policy", nil, map[string]string{"workload": "database"})
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{second, first}, vmi, namespace)).To(Equal(first))
+		})
+
+		It("should ignore policies with invalid selectors", func() {
+			policy := newPolicy("policy", nil, nil)
+			policy.Spec.Selectors.VirtualMachineInstanceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "workload", Operator: "invalid"},
+				},
+			}
+			Expect(MatchMigrationPolicy([]*v1.MigrationPolicy{policy}, vmi, namespace)).To(BeNil())
+		})
+	})
+
+	Context("applying", func() {
+		var config *virtconfig.MigrationConfig
+
+		BeforeEach(func() {
+			bandwidth := resource.MustParse("64Mi")
+			progressTimeout := int64(150)
+			completionTimeoutPerGiB := int64(800)
+			postCopyAfterIterations := int64(0)
+			config = &virtconfig.MigrationConfig{
+				BandwidthPerMigration:   &bandwidth,
+				ProgressTimeout:         &progressTimeout,
+				CompletionTimeoutPerGiB: &completionTimeoutPerGiB,
+				PostCopyAfterIterations: &postCopyAfterIterations,
+			}
+		})
+
+		It("should keep the cluster wide configuration without a policy", func() {
+			Expect(ApplyMigrationPolicy(config, nil)).To(Equal(config))
+		})
+
+		It("should only override the settings given in the policy", func() {
+			bandwidth := resource.MustParse("1Gi")
+			allowPostCopy := true
+			postCopyAfterIterations := int64(4)
+			policy := newPolicy("policy", nil, nil)
+			policy.Spec.BandwidthPerMigration = &bandwidth
+			policy.Spec.AllowPostCopy = &allowPostCopy
+			policy.Spec.PostCopyAfterIterations = &postCopyAfterIterations
+
+			applied := ApplyMigrationPolicy(config, policy)
+			Expect(applied.BandwidthPerMigration.String()).To(Equal("1Gi"))
+			Expect(applied.AllowPostCopy).To(BeTrue())
+			Expect(*applied.PostCopyAfterIterations).To(Equal(int64(4)))
+			Expect(*applied.ProgressTimeout).To(Equal(int64(150)))
+			Expect(*applied.CompletionTimeoutPerGiB).To(Equal(int64(800)))
+			Expect(applied.AllowAutoConverge).To(BeFalse())
+
+			By("leaving the cluster wide configuration untouched")
+			Expect(config.BandwidthPerMigration.String()).To(Equal("64Mi"))
+			Expect(config.AllowPostCopy).To(BeFalse())
+		})
+	})
+})
@@ -28,8 +28,9 @@ func ParseNameservers(content string) ([][]byte, error) {
 		line := scanner.Text()
 		if strings.HasPrefix(line, nameserverPrefix) {
 			nameserver := re.FindString(line)
-			if nameserver != "" {
-				nameservers = append(nameservers, net.ParseIP(nameserver).To4())
+			// IPv6 nameservers can partially match the expression, skip them
+			if ip := net.ParseIP(nameserver).To4(); ip != nil {
+				nameservers = append(nameservers, ip)
 			}
 		}
 	}
@@ -46,6 +47,30 @@ func ParseNameservers(content string) ([][]byte, error) {
 	return nameservers, nil
 }
 
+// ParseIpv6Nameservers returns the IPv6 nameservers in the given resolv.conf content.
+// Unlike ParseNameservers it does not fall back to a default nameserver.
+func ParseIpv6Nameservers(content string) ([][]byte, error) {
+	var nameservers [][]byte
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != nameserverPrefix {
+			continue
+		}
+		if ip := net.ParseIP(fields[1]); ip != nil && ip.To4() == nil {
+			nameservers = append(nameservers, []byte(ip.To16()))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nameservers, nil
+}
+
 func ParseSearchDomains(content string) ([]string, error) {
 	var searchDomains []string
 
@@ -34,6 +34,31 @@ var _ = Describe("Resolveconf", func() {
 		})
 	})
 
+	Context("Function ParseIpv6Nameservers()", func() {
+		It("should return only the IPv6 nameservers", func() {
+			resolvConf := "nameserver 8.8.8.8\nnameserver 2001:4860:4860::8888\nnameserver fd00::a\n"
+			nameservers, err := ParseIpv6Nameservers(resolvConf)
+			Expect(err).To(BeNil())
+			Expect(nameservers).To(Equal([][]uint8{
+				net.ParseIP("2001:4860:4860::8888").To16(),
+				net.ParseIP("fd00::a").To16(),
+			}))
+		})
+
+		It("should not be confused with IPv4 nameservers", func() {
+			resolvConf := "nameserver 2001:4860:4860::8888\nnameserver 8.8.8.8\n"
+			nameservers, err := ParseNameservers(resolvConf)
+			Expect(err).To(BeNil())
+			Expect(nameservers).To(Equal([][]uint8{{8, 8, 8, 8}}))
+		})
+
+		It("should not return a default nameserver", func() {
+			nameservers, err := ParseIpv6Nameservers("nameserver 8.8.8.8\n")
+			Expect(err).To(BeNil())
+			Expect(nameservers).To(BeEmpty())
+		})
+	})
+
 	Context("Function ParseSearchDomains()", func() {
 		It("should return a string of search domains", func() {
 			resolvConf := "search cluster.local svc.cluster.local example.com\nnameserver 8.8.8.8\n"
@@ -232,6 +232,17 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", nil).
 			Returns(http.StatusBadRequest, "Bad Request", nil))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("migrate")).
+			To(subresourceApp.MigrateVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("migrate").
+			Doc("Migrate a running VirtualMachine to another node.").
+			Writes(v1.VirtualMachineInstanceMigration{}).
+			Returns(http.StatusAccepted, "Accepted", v1.VirtualMachineInstanceMigration{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusConflict, "Conflict", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
 			To(subresourceApp.ConsoleRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -244,6 +255,101 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation("vnc").
 			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("pause")).
+			To(subresourceApp.PauseVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("pause").
+			Doc("Pause a VirtualMachineInstance object.").
+			Returns(http.StatusAccepted, "Accepted", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unpause")).
+			To(subresourceApp.UnpauseVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("unpause").
+			Doc("Unpause a VirtualMachineInstance object.").
+			Returns(http.StatusAccepted, "Accepted", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("freeze")).
+			To(subresourceApp.FreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("freeze").
+			Doc("Freeze the filesystems of a VirtualMachineInstance object.").
+			Returns(http.StatusAccepted, "Accepted", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unfreeze")).
+			To(subresourceApp.UnfreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("unfreeze").
+			Doc("Thaw the filesystems of a VirtualMachineInstance object.").
+			Returns(http.StatusAccepted, "Accepted", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addvolume")).
+			To(subresourceApp.AddVolumeRequestHandler).
+			Reads(v1.AddVolumeOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("addvolume").
+			Doc("Hotplug a volume and its disk into a running VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("removevolume")).
+			To(subresourceApp.RemoveVolumeRequestHandler).
+			Reads(v1.RemoveVolumeOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("removevolume").
+			Doc("Unplug a hotplugged volume and its disk from a running VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("insertmedia")).
+			To(subresourceApp.InsertMediaRequestHandler).
+			Reads(v1.InsertMediaOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("insertmedia").
+			Doc("Insert a medium into a CD-ROM or floppy of a running VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("ejectmedia")).
+			To(subresourceApp.EjectMediaRequestHandler).
+			Reads(v1.EjectMediaOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("ejectmedia").
+			Doc("Eject the medium from a CD-ROM or floppy of a running VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("guestosinfo")).
+			To(subresourceApp.GuestOSInfo).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation("guestosinfo").
+			Doc("Get guest agent os information").
+			Writes(v1.VirtualMachineInstanceGuestAgentInfo{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusConflict, "Conflict", nil))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("test")).
 			To(func(request *restful.Request, response *restful.Response) {
 				response.WriteHeader(http.StatusOK)
@@ -296,10 +402,50 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/stop",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/migrate",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/pause",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/unpause",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/freeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/unfreeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/guestosinfo",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/addvolume",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/removevolume",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/insertmedia",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/ejectmedia",
+						Namespaced: true,
+					},
 				}
 
 				response.WriteAsJson(list)
@@ -379,6 +525,80 @@ func (app *virtAPIApp) composeSubresources() {
 	restful.Add(ws)
 }
 
+func (app *virtAPIApp) composeMetrics() {
+
+	metricsApp := rest.NewMetricsAPIApp(app.virtCli, rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort))
+
+	metricsws := new(restful.WebService)
+	metricsws.Doc("KubeVirt guest metrics API.")
+	metricsws.Path(rest.GroupVersionBasePath(rest.CustomMetricsGroupVersion))
+
+	metricsws.Route(metricsws.GET("/namespaces/{namespace}/virtualmachineinstances."+v1.GroupVersion.Group+"/{name}/{metric}").
+		To(metricsApp.VMIMetricRequestHandler).
+		Param(rest.NamespaceParam(metricsws)).Param(rest.NameParam(metricsws)).
+		Param(metricsws.PathParameter("metric", "Name of the metric").Required(true)).
+		Param(metricsws.QueryParameter("labelSelector", "A selector to restrict the list of returned objects by their labels.")).
+		Produces(restful.MIME_JSON).Writes(rest.MetricValueList{}).
+		Operation("vmiMetric").
+		Doc("Get a guest metric of VirtualMachineInstances").
+		Returns(http.StatusOK, "OK", rest.MetricValueList{}).
+		Returns(http.StatusNotFound, "Not Found", nil))
+
+	metricsws.Route(metricsws.GET("/namespaces/{namespace}/virtualmachineinstancereplicasets."+v1.GroupVersion.Group+"/{name}/{metric}").
+		To(metricsApp.ReplicaSetMetricRequestHandler).
+		Param(rest.NamespaceParam(metricsws)).Param(rest.NameParam(metricsws)).
+		Param(metricsws.PathParameter("metric", "Name of the metric").Required(true)).
+		Produces(restful.MIME_JSON).Writes(rest.MetricValueList{}).
+		Operation("vmirsMetric").
+		Doc("Get a guest metric summed up over the VirtualMachineInstances of a VirtualMachineInstanceReplicaSet").
+		Returns(http.StatusOK, "OK", rest.MetricValueList{}).
+		Returns(http.StatusNotFound, "Not Found", nil))
+
+	// K8s needs the ability to query the metrics this endpoint provides
+	metricsws.Route(metricsws.GET("/").
+		To(metricsApp.APIResourceListRequestHandler).
+		Produces(restful.MIME_JSON).Writes(metav1.APIResourceList{}).
+		Operation("getGuestMetricsAPIResources").
+		Doc("Get the guest metrics API resources").
+		Returns(http.StatusOK, "OK", metav1.APIResourceList{}).
+		Returns(http.StatusNotFound, "Not Found", nil))
+
+	restful.Add(metricsws)
+
+	resourcemetricsws := new(restful.WebService)
+	resourcemetricsws.Doc("KubeVirt guest resource metrics API.")
+	resourcemetricsws.Path(rest.GroupVersionBasePath(rest.ResourceMetricsGroupVersion))
+
+	resourcemetricsws.Route(resourcemetricsws.GET("/namespaces/{namespace}/pods").
+		To(metricsApp.PodMetricsListRequestHandler).
+		Param(rest.NamespaceParam(resourcemetricsws)).
+		Param(resourcemetricsws.QueryParameter("labelSelector", "A selector to restrict the list of returned objects by their labels.")).
+		Produces(restful.MIME_JSON).Writes(rest.PodMetricsList{}).
+		Operation("listPodMetrics").
+		Doc("Get the guest usage of the virt-launcher pods").
+		Returns(http.StatusOK, "OK", rest.PodMetricsList{}))
+
+	resourcemetricsws.Route(resourcemetricsws.GET("/namespaces/{namespace}/pods/{name}").
+		To(metricsApp.PodMetricsRequestHandler).
+		Param(rest.NamespaceParam(resourcemetricsws)).Param(rest.NameParam(resourcemetricsws)).
+		Produces(restful.MIME_JSON).Writes(rest.PodMetrics{}).
+		Operation("readPodMetrics").
+		Doc("Get the guest usage of a virt-launcher pod").
+		Returns(http.StatusOK, "OK", rest.PodMetrics{}).
+		Returns(http.StatusNotFound, "Not Found", nil))
+
+	// K8s needs the ability to query the metrics this endpoint provides
+	resourcemetricsws.Route(resourcemetricsws.GET("/").
+		To(metricsApp.ResourceMetricsAPIResourceListRequestHandler).
+		Produces(restful.MIME_JSON).Writes(metav1.APIResourceList{}).
+		Operation("getGuestResourceMetricsAPIResources").
+		Doc("Get the guest resource metrics API resources").
+		Returns(http.StatusOK, "OK", metav1.APIResourceList{}).
+		Returns(http.StatusNotFound, "Not Found", nil))
+
+	restful.Add(resourcemetricsws)
+}
+
 func (app *virtAPIApp) Compose() {
 
 	app.composeSubresources()
@@ -744,7 +964,7 @@ func (app *virtAPIApp) createValidatingWebhook() error {
 		validating_webhook.ServeVMICreate(w, r, app.clusterConfig)
 	})
 	http.HandleFunc(vmiUpdateValidatePath, func(w http.ResponseWriter, r *http.Request) {
-		validating_webhook.ServeVMIUpdate(w, r)
+		validating_webhook.ServeVMIUpdate(w, r, app.clusterConfig)
 	})
 	http.HandleFunc(vmValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMs(w, r, app.clusterConfig, app.virtCli)
@@ -887,6 +1107,15 @@ func (app *virtAPIApp) subresourceApiservice(version schema.GroupVersion) *apire
 	}
 }
 
+// isKubeVirtApiservice checks whether an existing APIService was registered by virt-api
+func (app *virtAPIApp) isKubeVirtApiservice(apiService *apiregistrationv1beta1.APIService) bool {
+	if apiService.Labels[v1.AppLabel] == "virt-api-aggregator" {
+		return true
+	}
+	service := apiService.Spec.Service
+	return service != nil && service.Namespace == app.namespace && service.Name == virtApiServiceName
+}
+
 func (app *virtAPIApp) createSubresourceApiservice(version schema.GroupVersion) error {
 
 	subresourceApiservice := app.subresourceApiservice(version)
@@ -908,6 +1137,9 @@ func (app *virtAPIApp) createSubresourceApiservice(version schema.GroupVersion)
 			return err
 		}
 	} else {
+		if !app.isKubeVirtApiservice(apiService) {
+			return fmt.Errorf("APIService %s is registered by another provider", apiService.Name)
+		}
 
 		// Always update spec to latest.
 		apiService.Spec = app.subresourceApiservice(version).Spec
@@ -1041,6 +1273,18 @@ func (app *virtAPIApp) Run() {
 
 	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, app.namespace)
 
+	// Only one provider can serve each metrics API, so serving guest
+	// metrics is opt-in and APIServices of other providers are kept
+	if app.clusterConfig.GuestMetricsEnabled() {
+		app.composeMetrics()
+		for _, version := range []schema.GroupVersion{rest.CustomMetricsGroupVersion, rest.ResourceMetricsGroupVersion} {
+			err = app.createSubresourceApiservice(version)
+			if err != nil {
+				log.Log.Reason(err).Errorf("Not serving guest metrics through %s", version.String())
+			}
+		}
+	}
+
 	// Verify/create webhook endpoint.
 	err = app.createWebhook()
 	if err != nil {
@@ -301,6 +301,22 @@ var _ = Describe("Virt-api", func() {
 			Expect(err).ToNot(HaveOccurred())
 		}, 5)
 
+		It("should not take over an apiservice of another provider", func() {
+			foreignApiService := app.subresourceApiservice(v1.SubresourceGroupVersions[0])
+			foreignApiService.Labels = nil
+			foreignApiService.Spec.Service.Namespace = "monitoring"
+			foreignApiService.Spec.Service.Name = "prometheus-adapter"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/apiregistration.k8s.io/v1beta1/apiservices/"+subresourceAggregatedApiName),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, foreignApiService),
+				),
+			)
+			err := app.createSubresourceApiservice(v1.SubresourceGroupVersions[0])
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		}, 5)
+
 		It("should return internal error on authorizor error", func() {
 			app.authorizor = authorizorMock
 			authorizorMock.EXPECT().
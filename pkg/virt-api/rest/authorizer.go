@@ -140,7 +140,12 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	// URL example
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
 	pathSplit := strings.Split(url.Path, "/")
-	if len(pathSplit) != 9 {
+	if isResourceMetricsPath(pathSplit) {
+		// URL examples
+		// /apis/metrics.k8s.io/v1beta1/namespaces/default/pods
+		// /apis/metrics.k8s.io/v1beta1/namespaces/default/pods/testpod
+		pathSplit = append(pathSplit, make([]string, 9-len(pathSplit))...)
+	} else if len(pathSplit) != 9 {
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
@@ -152,7 +157,21 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	subresource := pathSplit[8]
 	userExtras := a.getUserExtras(headers)
 
-	if resource != "virtualmachineinstances" && resource != "virtualmachines" {
+	if group == ResourceMetricsGroupVersion.Group {
+		if resource != "pods" {
+			return nil, fmt.Errorf("unknown resource type %s", resource)
+		}
+	} else if group == CustomMetricsGroupVersion.Group {
+		// URL example
+		// /apis/custom.metrics.k8s.io/v1beta1/namespaces/default/virtualmachineinstances.kubevirt.io/*/cpu_usage
+		if resource != vmiMetricsResource && resource != vmirsMetricsResource {
+			return nil, fmt.Errorf("unknown resource type %s", resource)
+		}
+		// a wildcard name selects all objects, which is a list request
+		if resourceName == "*" {
+			resourceName = ""
+		}
+	} else if resource != "virtualmachineinstances" && resource != "virtualmachines" {
 		return nil, fmt.Errorf("unknown resource type %s", resource)
 	}
 
@@ -191,6 +210,12 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	return r, nil
 }
 
+func isResourceMetricsPath(pathSplit []string) bool {
+	return (len(pathSplit) == 7 || len(pathSplit) == 8) &&
+		pathSplit[2] == ResourceMetricsGroupVersion.Group &&
+		pathSplit[4] == "namespaces"
+}
+
 func mapHttpVerbToRbacVerb(httpVerb string, name string) (string, error) {
 	// see https://kubernetes.io/docs/reference/access-authn-authz/authorization/#determine-the-request-verb
 	// if name is empty, we assume plural verbs
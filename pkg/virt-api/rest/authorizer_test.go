@@ -173,6 +173,44 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("random2", "/1/2/3/4/5/6/7/8/9/0/1/2/3/4/5/6/7/8/9"),
 				table.Entry("no subresource provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 				table.Entry("invalid resource type", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madeupresource/testvmi/console"),
+				table.Entry("invalid metrics resource type", "/apis/custom.metrics.k8s.io/v1beta1/namespaces/default/pods/testpod/cpu_usage"),
+				table.Entry("invalid resource metrics resource type", "/apis/metrics.k8s.io/v1beta1/namespaces/default/nodes/testnode"),
+			)
+		})
+
+		Context("Custom metrics api", func() {
+			table.DescribeTable("should generate an access review for", func(path string, resource string, name string, verb string) {
+				req.Request.URL.Path = path
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Group).To(Equal("custom.metrics.k8s.io"))
+				Expect(result.Spec.ResourceAttributes.Resource).To(Equal(resource))
+				Expect(result.Spec.ResourceAttributes.Subresource).To(Equal("cpu_usage"))
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal(name))
+				Expect(result.Spec.ResourceAttributes.Verb).To(Equal(verb))
+			},
+				table.Entry("a single vmi", "/apis/custom.metrics.k8s.io/v1beta1/namespaces/default/virtualmachineinstances.kubevirt.io/testvmi/cpu_usage", "virtualmachineinstances.kubevirt.io", "testvmi", "get"),
+				table.Entry("all vmis", "/apis/custom.metrics.k8s.io/v1beta1/namespaces/default/virtualmachineinstances.kubevirt.io/*/cpu_usage", "virtualmachineinstances.kubevirt.io", "", "list"),
+				table.Entry("a replicaset", "/apis/custom.metrics.k8s.io/v1beta1/namespaces/default/virtualmachineinstancereplicasets.kubevirt.io/testrs/cpu_usage", "virtualmachineinstancereplicasets.kubevirt.io", "testrs", "get"),
+			)
+		})
+
+		Context("Resource metrics api", func() {
+			table.DescribeTable("should generate an access review for", func(path string, name string, verb string) {
+				req.Request.URL.Path = path
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Group).To(Equal("metrics.k8s.io"))
+				Expect(result.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+				Expect(result.Spec.ResourceAttributes.Resource).To(Equal("pods"))
+				Expect(result.Spec.ResourceAttributes.Subresource).To(BeEmpty())
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal(name))
+				Expect(result.Spec.ResourceAttributes.Verb).To(Equal(verb))
+			},
+				table.Entry("a single pod", "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/testpod", "testpod", "get"),
+				table.Entry("all pods", "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods", "", "list"),
 			)
 		})
 
@@ -41,6 +41,8 @@ func ComposeAPIDefinitions() []*restful.WebService {
 	vmipGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineinstancepresets"}
 	vmGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachines"}
 	migrationGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineinstancemigrations"}
+	snapshotGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachinesnapshots"}
+	restoreGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachinerestores"}
 
 	ws, err := GroupVersionProxyBase(v1.GroupVersion)
 	if err != nil {
@@ -72,6 +74,16 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws, err = GenericResourceProxy(ws, snapshotGVR, &v1.VirtualMachineSnapshot{}, v1.VirtualMachineSnapshotGroupVersionKind.Kind, &v1.VirtualMachineSnapshotList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = GenericResourceProxy(ws, restoreGVR, &v1.VirtualMachineRestore{}, v1.VirtualMachineRestoreGroupVersionKind.Kind, &v1.VirtualMachineRestoreList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws1, err := ResourceProxyAutodiscovery(vmiGVR)
 	if err != nil {
 		panic(err)
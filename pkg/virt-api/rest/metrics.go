@@ -0,0 +1,510 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+const (
+	// CPUUsageMetricName is the guest CPU usage in cores
+	CPUUsageMetricName = "cpu_usage"
+	// MemoryUsageMetricName is the guest memory usage in bytes
+	MemoryUsageMetricName = "memory_usage"
+
+	// the stored CPU sample is only replaced once it is at least that old,
+	// so that concurrent scrapes don't shrink the rate window to nothing
+	minCPUSampleWindow = 10 * time.Second
+	// CPU samples of VMIs which were not scraped for that long are dropped
+	cpuSampleTTL = 5 * time.Minute
+
+	// launcherComputeContainer is the container of the virt-launcher pod which runs the guest
+	launcherComputeContainer = "compute"
+)
+
+var CustomMetricsGroupVersion = schema.GroupVersion{Group: "custom.metrics.k8s.io", Version: "v1beta1"}
+
+// ResourceMetricsGroupVersion is used by HorizontalPodAutoscalers for cpu and memory utilization targets.
+// The guest usage is reported as the usage of the compute container of the virt-launcher pods.
+var ResourceMetricsGroupVersion = schema.GroupVersion{Group: "metrics.k8s.io", Version: "v1beta1"}
+
+var (
+	vmiMetricsResource   = "virtualmachineinstances." + v1.GroupVersion.Group
+	vmirsMetricsResource = "virtualmachineinstancereplicasets." + v1.GroupVersion.Group
+)
+
+// StatsSource provides the latest domain statistics of a running VirtualMachineInstance
+type StatsSource interface {
+	DomainStats(vmi *v1.VirtualMachineInstance) (*stats.DomainStats, error)
+}
+
+// MetricValueList mirrors the custom.metrics.k8s.io/v1beta1 MetricValueList type
+type MetricValueList struct {
+	k8smetav1.TypeMeta `json:",inline"`
+	k8smetav1.ListMeta `json:"metadata,omitempty"`
+	Items              []MetricValue `json:"items"`
+}
+
+// MetricValue mirrors the custom.metrics.k8s.io/v1beta1 MetricValue type
+type MetricValue struct {
+	k8smetav1.TypeMeta `json:",inline"`
+	DescribedObject    k8sv1.ObjectReference    `json:"describedObject"`
+	MetricName         string                   `json:"metricName"`
+	Timestamp          k8smetav1.Time           `json:"timestamp"`
+	WindowSeconds      *int64                   `json:"window,omitempty"`
+	Value              resource.Quantity        `json:"value"`
+	Selector           *k8smetav1.LabelSelector `json:"selector"`
+}
+
+// PodMetricsList mirrors the metrics.k8s.io/v1beta1 PodMetricsList type
+type PodMetricsList struct {
+	k8smetav1.TypeMeta `json:",inline"`
+	k8smetav1.ListMeta `json:"metadata,omitempty"`
+	Items              []PodMetrics `json:"items"`
+}
+
+// PodMetrics mirrors the metrics.k8s.io/v1beta1 PodMetrics type
+type PodMetrics struct {
+	k8smetav1.TypeMeta   `json:",inline"`
+	k8smetav1.ObjectMeta `json:"metadata,omitempty"`
+	Timestamp            k8smetav1.Time     `json:"timestamp"`
+	Window               k8smetav1.Duration `json:"window"`
+	Containers           []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics mirrors the metrics.k8s.io/v1beta1 ContainerMetrics type
+type ContainerMetrics struct {
+	Name  string             `json:"name"`
+	Usage k8sv1.ResourceList `json:"usage"`
+}
+
+type cpuSample struct {
+	time      uint64
+	timestamp time.Time
+}
+
+type MetricsAPIApp struct {
+	virtCli     kubecli.KubevirtClient
+	statsSource StatsSource
+	samplesLock *sync.Mutex
+	cpuSamples  map[types.UID]cpuSample
+	now         func() time.Time
+}
+
+func NewMetricsAPIApp(virtCli kubecli.KubevirtClient, statsSource StatsSource) *MetricsAPIApp {
+	return &MetricsAPIApp{
+		virtCli:     virtCli,
+		statsSource: statsSource,
+		samplesLock: &sync.Mutex{},
+		cpuSamples:  map[types.UID]cpuSample{},
+		now:         time.Now,
+	}
+}
+
+func (app *MetricsAPIApp) APIResourceListRequestHandler(_ *restful.Request, response *restful.Response) {
+	list := &k8smetav1.APIResourceList{}
+	list.Kind = "APIResourceList"
+	list.APIVersion = "v1"
+	list.GroupVersion = CustomMetricsGroupVersion.String()
+
+	for _, res := range []string{vmiMetricsResource, vmirsMetricsResource} {
+		for _, metric := range []string{CPUUsageMetricName, MemoryUsageMetricName} {
+			list.APIResources = append(list.APIResources, k8smetav1.APIResource{
+				Name:       res + "/" + metric,
+				Namespaced: true,
+				Kind:       "MetricValueList",
+				Verbs:      []string{"get"},
+			})
+		}
+	}
+	response.WriteAsJson(list)
+}
+
+func (app *MetricsAPIApp) ResourceMetricsAPIResourceListRequestHandler(_ *restful.Request, response *restful.Response) {
+	list := &k8smetav1.APIResourceList{}
+	list.Kind = "APIResourceList"
+	list.APIVersion = "v1"
+	list.GroupVersion = ResourceMetricsGroupVersion.String()
+	list.APIResources = []k8smetav1.APIResource{
+		{
+			Name:       "pods",
+			Namespaced: true,
+			Kind:       "PodMetrics",
+			Verbs:      []string{"get", "list"},
+		},
+	}
+	response.WriteAsJson(list)
+}
+
+// PodMetricsRequestHandler serves the guest usage of a single virt-launcher pod
+func (app *MetricsAPIApp) PodMetricsRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	pod, err := app.virtCli.CoreV1().Pods(namespace).Get(name, k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			response.WriteError(http.StatusNotFound, fmt.Errorf("pod %s in namespace %s not found", name, namespace))
+			return
+		}
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	podMetrics, err := app.podMetrics(pod)
+	if err != nil {
+		response.WriteError(http.StatusNotFound, err)
+		return
+	}
+	response.WriteAsJson(podMetrics)
+}
+
+// PodMetricsListRequestHandler serves the guest usage of all virt-launcher pods matching the label selector.
+// Other pods are skipped.
+func (app *MetricsAPIApp) PodMetricsListRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	pods, err := app.virtCli.CoreV1().Pods(namespace).List(k8smetav1.ListOptions{LabelSelector: request.QueryParameter("labelSelector")})
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to list pods in namespace %s.", namespace)
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	list := &PodMetricsList{}
+	list.Kind = "PodMetricsList"
+	list.APIVersion = ResourceMetricsGroupVersion.String()
+	list.Items = []PodMetrics{}
+	for i := range pods.Items {
+		podMetrics, err := app.podMetrics(&pods.Items[i])
+		if err != nil {
+			log.Log.Object(&pods.Items[i]).Reason(err).V(4).Info("Skipping pod metrics")
+			continue
+		}
+		list.Items = append(list.Items, *podMetrics)
+	}
+	response.WriteAsJson(list)
+}
+
+// podMetrics reports the cpu and memory usage of the guest as the usage of the compute container
+func (app *MetricsAPIApp) podMetrics(pod *k8sv1.Pod) (*PodMetrics, error) {
+	vmiName, exists := pod.Annotations[v1.DomainAnnotation]
+	if !exists || pod.Labels[v1.AppLabel] != "virt-launcher" {
+		return nil, fmt.Errorf("pod %s is not a virt-launcher pod", pod.Name)
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(pod.Namespace).Get(vmiName, &k8smetav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if string(vmi.UID) != pod.Labels[v1.CreatedByLabel] {
+		return nil, fmt.Errorf("pod %s does not belong to VirtualMachineInstance %s", pod.Name, vmi.Name)
+	}
+
+	domainStats, err := app.domainStats(vmi)
+	if err != nil {
+		return nil, err
+	}
+	cpu, window, err := app.cpuUsage(vmi, domainStats)
+	if err != nil {
+		return nil, err
+	}
+	memory, err := memoryUsage(domainStats)
+	if err != nil {
+		return nil, err
+	}
+
+	podMetrics := &PodMetrics{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Labels:    pod.Labels,
+		},
+		Timestamp: k8smetav1.NewTime(app.now()),
+		Window:    k8smetav1.Duration{Duration: time.Duration(*window) * time.Second},
+		Containers: []ContainerMetrics{
+			{
+				Name: launcherComputeContainer,
+				Usage: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    cpu,
+					k8sv1.ResourceMemory: memory,
+				},
+			},
+		},
+	}
+	podMetrics.Kind = "PodMetrics"
+	podMetrics.APIVersion = ResourceMetricsGroupVersion.String()
+	return podMetrics, nil
+}
+
+// VMIMetricRequestHandler serves a metric of a single VirtualMachineInstance, or of all
+// VirtualMachineInstances matching the label selector if the name is "*"
+func (app *MetricsAPIApp) VMIMetricRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	metric := request.PathParameter("metric")
+
+	if !isKnownMetric(metric) {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("unknown metric %s", metric))
+		return
+	}
+
+	var vmis []v1.VirtualMachineInstance
+	if name == "*" {
+		list, err := app.virtCli.VirtualMachineInstance(namespace).List(&k8smetav1.ListOptions{LabelSelector: request.QueryParameter("labelSelector")})
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to list vmis in namespace %s.", namespace)
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+		vmis = list.Items
+	} else {
+		vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				response.WriteError(http.StatusNotFound, fmt.Errorf("VirtualMachineInstance %s in namespace %s not found", name, namespace))
+				return
+			}
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+		vmis = append(vmis, *vmi)
+	}
+
+	list := newMetricValueList()
+	for i := range vmis {
+		vmi := &vmis[i]
+		value, window, err := app.metricValue(vmi, metric)
+		if err != nil {
+			if name != "*" {
+				response.WriteError(http.StatusNotFound, err)
+				return
+			}
+			log.Log.Object(vmi).Reason(err).V(4).Infof("Skipping metric %s", metric)
+			continue
+		}
+		list.Items = append(list.Items, MetricValue{
+			DescribedObject: k8sv1.ObjectReference{
+				APIVersion: v1.GroupVersion.String(),
+				Kind:       "VirtualMachineInstance",
+				Namespace:  vmi.Namespace,
+				Name:       vmi.Name,
+			},
+			MetricName:    metric,
+			Timestamp:     k8smetav1.NewTime(app.now()),
+			WindowSeconds: window,
+			Value:         value,
+		})
+	}
+	response.WriteAsJson(list)
+}
+
+// ReplicaSetMetricRequestHandler serves a metric summed up over all VirtualMachineInstances
+// which are matched by the selector of a VirtualMachineInstanceReplicaSet
+func (app *MetricsAPIApp) ReplicaSetMetricRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	metric := request.PathParameter("metric")
+
+	if !isKnownMetric(metric) {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("unknown metric %s", metric))
+		return
+	}
+
+	rs, err := app.virtCli.ReplicaSet(namespace).Get(name, k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			response.WriteError(http.StatusNotFound, fmt.Errorf("VirtualMachineInstanceReplicaSet %s in namespace %s not found", name, namespace))
+			return
+		}
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	selector, err := k8smetav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if selector.Empty() {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("VirtualMachineInstanceReplicaSet %s has no selector", name))
+		return
+	}
+
+	vmis, err := app.virtCli.VirtualMachineInstance(namespace).List(&k8smetav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		log.Log.Object(rs).Reason(err).Error("Failed to list matching vmis.")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	var sum resource.Quantity
+	var window *int64
+	found := false
+	for i := range vmis.Items {
+		vmi := &vmis.Items[i]
+		value, vmiWindow, err := app.metricValue(vmi, metric)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).V(4).Infof("Skipping metric %s", metric)
+			continue
+		}
+		if !found {
+			sum = value
+			found = true
+		} else {
+			sum.Add(value)
+		}
+		if vmiWindow != nil && (window == nil || *vmiWindow > *window) {
+			window = vmiWindow
+		}
+	}
+
+	if !found {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("metric %s is not available for any VirtualMachineInstance of VirtualMachineInstanceReplicaSet %s", metric, name))
+		return
+	}
+
+	list := newMetricValueList()
+	list.Items = append(list.Items, MetricValue{
+		DescribedObject: k8sv1.ObjectReference{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachineInstanceReplicaSet",
+			Namespace:  rs.Namespace,
+			Name:       rs.Name,
+		},
+		MetricName:    metric,
+		Timestamp:     k8smetav1.NewTime(app.now()),
+		WindowSeconds: window,
+		Value:         sum,
+		Selector:      rs.Spec.Selector,
+	})
+	response.WriteAsJson(list)
+}
+
+func (app *MetricsAPIApp) domainStats(vmi *v1.VirtualMachineInstance) (*stats.DomainStats, error) {
+	if !vmi.IsRunning() {
+		return nil, fmt.Errorf("VirtualMachineInstance is not running")
+	}
+	return app.statsSource.DomainStats(vmi)
+}
+
+func (app *MetricsAPIApp) metricValue(vmi *v1.VirtualMachineInstance, metric string) (resource.Quantity, *int64, error) {
+	domainStats, err := app.domainStats(vmi)
+	if err != nil {
+		return resource.Quantity{}, nil, err
+	}
+
+	switch metric {
+	case CPUUsageMetricName:
+		return app.cpuUsage(vmi, domainStats)
+	case MemoryUsageMetricName:
+		value, err := memoryUsage(domainStats)
+		return value, nil, err
+	}
+	return resource.Quantity{}, nil, fmt.Errorf("unknown metric %s", metric)
+}
+
+// cpuUsage calculates the average number of cores used since the stored sample of the VMI.
+// The first scrape of a VMI only records a sample and reports no usage.
+func (app *MetricsAPIApp) cpuUsage(vmi *v1.VirtualMachineInstance, domainStats *stats.DomainStats) (resource.Quantity, *int64, error) {
+	if domainStats.Cpu == nil || !domainStats.Cpu.TimeSet {
+		return resource.Quantity{}, nil, fmt.Errorf("no cpu time reported")
+	}
+
+	app.samplesLock.Lock()
+	defer app.samplesLock.Unlock()
+
+	now := app.now()
+	app.pruneCPUSamples(now)
+
+	current := cpuSample{time: domainStats.Cpu.Time, timestamp: now}
+	previous, exists := app.cpuSamples[vmi.UID]
+	noWindow := int64(0)
+	if !exists || current.time < previous.time {
+		app.cpuSamples[vmi.UID] = current
+		return *resource.NewMilliQuantity(0, resource.DecimalSI), &noWindow, nil
+	}
+
+	elapsed := current.timestamp.Sub(previous.timestamp)
+	if elapsed <= 0 {
+		return *resource.NewMilliQuantity(0, resource.DecimalSI), &noWindow, nil
+	}
+	if elapsed >= minCPUSampleWindow {
+		app.cpuSamples[vmi.UID] = current
+	}
+
+	// cpu time is reported in nanoseconds
+	millicores := int64(float64(current.time-previous.time) / float64(elapsed.Nanoseconds()) * 1000)
+	window := int64(elapsed.Seconds())
+	return *resource.NewMilliQuantity(millicores, resource.DecimalSI), &window, nil
+}
+
+func (app *MetricsAPIApp) pruneCPUSamples(now time.Time) {
+	for uid, sample := range app.cpuSamples {
+		if now.Sub(sample.timestamp) > cpuSampleTTL {
+			delete(app.cpuSamples, uid)
+		}
+	}
+}
+
+// memoryUsage returns the memory used by the guest, as reported by the balloon driver,
+// and falls back to the resident set size of the domain
+func memoryUsage(domainStats *stats.DomainStats) (resource.Quantity, error) {
+	if domainStats.Memory == nil {
+		return resource.Quantity{}, fmt.Errorf("no memory stats reported")
+	}
+	// the libvirt values are in KiB
+	memory := domainStats.Memory
+	if memory.AvailableSet && memory.UnusedSet && memory.Available >= memory.Unused {
+		return *resource.NewQuantity(int64(memory.Available-memory.Unused)*1024, resource.BinarySI), nil
+	}
+	if memory.RSSSet {
+		return *resource.NewQuantity(int64(memory.RSS)*1024, resource.BinarySI), nil
+	}
+	return resource.Quantity{}, fmt.Errorf("no memory usage reported")
+}
+
+func isKnownMetric(metric string) bool {
+	return metric == CPUUsageMetricName || metric == MemoryUsageMetricName
+}
+
+func newMetricValueList() *MetricValueList {
+	list := &MetricValueList{}
+	list.Kind = "MetricValueList"
+	list.APIVersion = CustomMetricsGroupVersion.String()
+	list.Items = []MetricValue{}
+	return list
+}
@@ -0,0 +1,440 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+type fakeStatsSource struct {
+	stats map[string]*stats.DomainStats
+}
+
+func (f *fakeStatsSource) DomainStats(vmi *v1.VirtualMachineInstance) (*stats.DomainStats, error) {
+	domainStats, exists := f.stats[vmi.Name]
+	if !exists {
+		return nil, fmt.Errorf("no stats for %s", vmi.Name)
+	}
+	return domainStats, nil
+}
+
+var _ = Describe("Guest metrics API", func() {
+	kubecli.Init()
+
+	var server *ghttp.Server
+	var recorder *httptest.ResponseRecorder
+	var request *restful.Request
+	var response *restful.Response
+	var statsSource *fakeStatsSource
+	var app *MetricsAPIApp
+	var now time.Time
+
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	newRunningVMI := func(name string) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI(name)
+		vmi.UID = types.UID(name + "-uid")
+		vmi.Labels = map[string]string{"app": "test"}
+		vmi.Status.Phase = v1.Running
+		return vmi
+	}
+
+	newMemoryStats := func(availableKiB uint64, unusedKiB uint64) *stats.DomainStats {
+		return &stats.DomainStats{
+			Memory: &stats.DomainStatsMemory{
+				AvailableSet: true,
+				Available:    availableKiB,
+				UnusedSet:    true,
+				Unused:       unusedKiB,
+			},
+		}
+	}
+
+	newCPUStats := func(cpuTime time.Duration) *stats.DomainStats {
+		return &stats.DomainStats{
+			Cpu: &stats.DomainStatsCPU{
+				TimeSet: true,
+				Time:    uint64(cpuTime.Nanoseconds()),
+			},
+		}
+	}
+
+	newRequest := func(resourceName string, metric string, url string) {
+		httpRequest, err := http.NewRequest(http.MethodGet, url, nil)
+		Expect(err).ToNot(HaveOccurred())
+		request = restful.NewRequest(httpRequest)
+		request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		request.PathParameters()["name"] = resourceName
+		request.PathParameters()["metric"] = metric
+	}
+
+	metricValues := func() *MetricValueList {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		list := &MetricValueList{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), list)).To(Succeed())
+		return list
+	}
+
+	expectVMIGet := func(vmi *v1.VirtualMachineInstance) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/"+vmi.Name),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+			),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		virtClient, err := kubecli.GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+
+		statsSource = &fakeStatsSource{stats: map[string]*stats.DomainStats{}}
+		app = NewMetricsAPIApp(virtClient, statsSource)
+		now = time.Now()
+		app.now = func() time.Time { return now }
+
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list the available metrics", func() {
+		newRequest("", "", "/")
+		app.APIResourceListRequestHandler(request, response)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		list := &k8smetav1.APIResourceList{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), list)).To(Succeed())
+		Expect(list.GroupVersion).To(Equal("custom.metrics.k8s.io/v1beta1"))
+
+		var names []string
+		for _, res := range list.APIResources {
+			names = append(names, res.Name)
+		}
+		Expect(names).To(ConsistOf(
+			"virtualmachineinstances.kubevirt.io/cpu_usage",
+			"virtualmachineinstances.kubevirt.io/memory_usage",
+			"virtualmachineinstancereplicasets.kubevirt.io/cpu_usage",
+			"virtualmachineinstancereplicasets.kubevirt.io/memory_usage",
+		))
+	})
+
+	Context("for a single VirtualMachineInstance", func() {
+		It("should report the memory used by the guest", func() {
+			vmi := newRunningVMI("testvmi")
+			statsSource.stats[vmi.Name] = newMemoryStats(1024*1024, 256*1024)
+			expectVMIGet(vmi)
+
+			newRequest(vmi.Name, MemoryUsageMetricName, "/")
+			app.VMIMetricRequestHandler(request, response)
+
+			list := metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].DescribedObject.Kind).To(Equal("VirtualMachineInstance"))
+			Expect(list.Items[0].DescribedObject.Name).To(Equal(vmi.Name))
+			Expect(list.Items[0].MetricName).To(Equal(MemoryUsageMetricName))
+			Expect(list.Items[0].Value.Value()).To(Equal(int64(768 * 1024 * 1024)))
+		})
+
+		It("should fall back to the resident set size without balloon stats", func() {
+			vmi := newRunningVMI("testvmi")
+			statsSource.stats[vmi.Name] = &stats.DomainStats{
+				Memory: &stats.DomainStatsMemory{RSSSet: true, RSS: 512 * 1024},
+			}
+			expectVMIGet(vmi)
+
+			newRequest(vmi.Name, MemoryUsageMetricName, "/")
+			app.VMIMetricRequestHandler(request, response)
+
+			list := metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Value.Value()).To(Equal(int64(512 * 1024 * 1024)))
+		})
+
+		It("should calculate the cpu usage between two samples", func() {
+			vmi := newRunningVMI("testvmi")
+			statsSource.stats[vmi.Name] = newCPUStats(10 * time.Second)
+			expectVMIGet(vmi)
+			expectVMIGet(vmi)
+
+			newRequest(vmi.Name, CPUUsageMetricName, "/")
+			app.VMIMetricRequestHandler(request, response)
+			list := metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Value.MilliValue()).To(BeZero())
+
+			now = now.Add(20 * time.Second)
+			statsSource.stats[vmi.Name] = newCPUStats(40 * time.Second)
+			recorder = httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+			app.VMIMetricRequestHandler(request, response)
+
+			list = metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Value.MilliValue()).To(Equal(int64(1500)))
+			Expect(*list.Items[0].WindowSeconds).To(Equal(int64(20)))
+		})
+
+		It("should reject unknown metrics", func() {
+			newRequest("testvmi", "madeup", "/")
+			app.VMIMetricRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fail if the VirtualMachineInstance is not running", func() {
+			vmi := newRunningVMI("testvmi")
+			vmi.Status.Phase = v1.Scheduled
+			statsSource.stats[vmi.Name] = newMemoryStats(1024, 256)
+			expectVMIGet(vmi)
+
+			newRequest(vmi.Name, MemoryUsageMetricName, "/")
+			app.VMIMetricRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("for all VirtualMachineInstances", func() {
+		It("should only report VirtualMachineInstances with available metrics", func() {
+			vmi1 := newRunningVMI("testvmi1")
+			vmi2 := newRunningVMI("testvmi2")
+			vmi3 := newRunningVMI("testvmi3")
+			vmi3.Status.Phase = v1.Scheduled
+			statsSource.stats[vmi1.Name] = newMemoryStats(1024, 256)
+			statsSource.stats[vmi3.Name] = newMemoryStats(1024, 256)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances", "labelSelector=app%3Dtest"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineInstanceList{
+						Items: []v1.VirtualMachineInstance{*vmi1, *vmi2, *vmi3},
+					}),
+				),
+			)
+
+			newRequest("*", MemoryUsageMetricName, "/?labelSelector=app%3Dtest")
+			app.VMIMetricRequestHandler(request, response)
+
+			list := metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].DescribedObject.Name).To(Equal(vmi1.Name))
+		})
+	})
+
+	Context("for virt-launcher pods", func() {
+		newLauncherPod := func(vmi *v1.VirtualMachineInstance) *k8sv1.Pod {
+			return &k8sv1.Pod{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:        "virt-launcher-" + vmi.Name,
+					Namespace:   k8smetav1.NamespaceDefault,
+					Labels:      map[string]string{v1.AppLabel: "virt-launcher", v1.CreatedByLabel: string(vmi.UID)},
+					Annotations: map[string]string{v1.DomainAnnotation: vmi.Name},
+				},
+			}
+		}
+
+		newUsageStats := func(cpuTime time.Duration) *stats.DomainStats {
+			domainStats := newMemoryStats(1024*1024, 256*1024)
+			domainStats.Cpu = newCPUStats(cpuTime).Cpu
+			return domainStats
+		}
+
+		It("should list the available resources", func() {
+			newRequest("", "", "/")
+			app.ResourceMetricsAPIResourceListRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			list := &k8smetav1.APIResourceList{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), list)).To(Succeed())
+			Expect(list.GroupVersion).To(Equal("metrics.k8s.io/v1beta1"))
+			Expect(list.APIResources).To(HaveLen(1))
+			Expect(list.APIResources[0].Name).To(Equal("pods"))
+		})
+
+		It("should report the guest usage as usage of the compute container", func() {
+			vmi := newRunningVMI("testvmi")
+			pod := newLauncherPod(vmi)
+			statsSource.stats[vmi.Name] = newUsageStats(10 * time.Second)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/pods/"+pod.Name),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, pod),
+				),
+			)
+			expectVMIGet(vmi)
+
+			newRequest(pod.Name, "", "/")
+			app.PodMetricsRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			podMetrics := &PodMetrics{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), podMetrics)).To(Succeed())
+			Expect(podMetrics.Kind).To(Equal("PodMetrics"))
+			Expect(podMetrics.Name).To(Equal(pod.Name))
+			Expect(podMetrics.Containers).To(HaveLen(1))
+			Expect(podMetrics.Containers[0].Name).To(Equal("compute"))
+			memory := podMetrics.Containers[0].Usage[k8sv1.ResourceMemory]
+			Expect(memory.Value()).To(Equal(int64(768 * 1024 * 1024)))
+			cpu := podMetrics.Containers[0].Usage[k8sv1.ResourceCPU]
+			Expect(cpu.MilliValue()).To(BeZero())
+		})
+
+		It("should not report pods which are not virt-launcher pods", func() {
+			vmi := newRunningVMI("testvmi")
+			statsSource.stats[vmi.Name] = newUsageStats(10 * time.Second)
+			otherPod := &k8sv1.Pod{ObjectMeta: k8smetav1.ObjectMeta{Name: "otherpod", Namespace: k8smetav1.NamespaceDefault}}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/pods", "labelSelector=app%3Dtest"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, k8sv1.PodList{
+						Items: []k8sv1.Pod{*newLauncherPod(vmi), *otherPod},
+					}),
+				),
+			)
+			expectVMIGet(vmi)
+
+			newRequest("", "", "/?labelSelector=app%3Dtest")
+			app.PodMetricsListRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			list := &PodMetricsList{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), list)).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal("virt-launcher-testvmi"))
+		})
+
+		It("should fail for pods of a former VirtualMachineInstance with the same name", func() {
+			vmi := newRunningVMI("testvmi")
+			pod := newLauncherPod(vmi)
+			pod.Labels[v1.CreatedByLabel] = "former-uid"
+			statsSource.stats[vmi.Name] = newUsageStats(10 * time.Second)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/pods/"+pod.Name),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, pod),
+				),
+			)
+			expectVMIGet(vmi)
+
+			newRequest(pod.Name, "", "/")
+			app.PodMetricsRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("for a VirtualMachineInstanceReplicaSet", func() {
+		var rs *v1.VirtualMachineInstanceReplicaSet
+
+		BeforeEach(func() {
+			rs = &v1.VirtualMachineInstanceReplicaSet{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "testrs", Namespace: k8smetav1.NamespaceDefault},
+				Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+					Selector: &k8smetav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				},
+			}
+		})
+
+		It("should sum up the metric of all matching VirtualMachineInstances", func() {
+			vmi1 := newRunningVMI("testvmi1")
+			vmi2 := newRunningVMI("testvmi2")
+			vmi3 := newRunningVMI("testvmi3")
+			statsSource.stats[vmi1.Name] = newMemoryStats(1024*1024, 256*1024)
+			statsSource.stats[vmi2.Name] = newMemoryStats(1024*1024, 512*1024)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancereplicasets/testrs"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, rs),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances", "labelSelector=app%3Dtest"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineInstanceList{
+						Items: []v1.VirtualMachineInstance{*vmi1, *vmi2, *vmi3},
+					}),
+				),
+			)
+
+			newRequest(rs.Name, MemoryUsageMetricName, "/")
+			app.ReplicaSetMetricRequestHandler(request, response)
+
+			list := metricValues()
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].DescribedObject.Kind).To(Equal("VirtualMachineInstanceReplicaSet"))
+			Expect(list.Items[0].DescribedObject.Name).To(Equal(rs.Name))
+			Expect(list.Items[0].Selector).To(Equal(rs.Spec.Selector))
+			Expect(list.Items[0].Value.Value()).To(Equal(int64(1280 * 1024 * 1024)))
+		})
+
+		It("should fail if no VirtualMachineInstance has the metric available", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancereplicasets/testrs"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, rs),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineInstanceList{}),
+				),
+			)
+
+			newRequest(rs.Name, MemoryUsageMetricName, "/")
+			app.ReplicaSetMetricRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fail if the VirtualMachineInstanceReplicaSet does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancereplicasets/testrs"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			newRequest(rs.Name, MemoryUsageMetricName, "/")
+			app.ReplicaSetMetricRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
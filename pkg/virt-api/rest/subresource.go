@@ -29,24 +29,31 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/util/cert"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
+	"kubevirt.io/kubevirt/pkg/controller"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 )
 
 type SubresourceAPIApp struct {
 	virtCli                 kubecli.KubevirtClient
 	consoleServerPort       int
 	consoleTLSConfiguration *tls.Config
+	handlerHTTPClient       *http.Client
 	credentialsLock         *sync.Mutex
 }
 
@@ -67,6 +74,9 @@ const (
 	clientKeyBytesValue       = "client-key-bytes"
 	signingCertBytesValue     = "signing-cert-bytes"
 	virtHandlerCertSecretName = "kubevirt-virt-handler-certs"
+
+	// virtHandlerRequestTimeout bounds requests to virt-handler, freezing the guest filesystems may take a while
+	virtHandlerRequestTimeout = 1 * time.Minute
 )
 
 type validation func(*v1.VirtualMachineInstance) error
@@ -101,24 +111,10 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 		return
 	}
 
-	if app.consoleTLSConfiguration == nil {
-		setTLSConfiguration := func() error {
-			app.credentialsLock.Lock()
-			defer app.credentialsLock.Unlock()
-			if app.consoleTLSConfiguration == nil {
-				tlsConfig, err := app.getConsoleTLSConfig()
-				if err != nil {
-					return err
-				}
-				app.consoleTLSConfiguration = tlsConfig
-			}
-			return nil
-		}
-		if err := setTLSConfiguration(); err != nil {
-			log.Log.Object(vmi).Reason(err).Error("Failed to set TLS configuration for console/vnc connection")
-			response.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	if err := app.setTLSConfiguration(); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to set TLS configuration for console/vnc connection")
+		response.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
 	upgrader := kubecli.NewUpgrader()
@@ -160,6 +156,104 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 	}
 }
 
+func (app *SubresourceAPIApp) setTLSConfiguration() error {
+	app.credentialsLock.Lock()
+	defer app.credentialsLock.Unlock()
+	if app.consoleTLSConfiguration == nil {
+		tlsConfig, err := app.getConsoleTLSConfig()
+		if err != nil {
+			return err
+		}
+		app.consoleTLSConfiguration = tlsConfig
+		app.handlerHTTPClient = &http.Client{
+			Timeout: virtHandlerRequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		}
+	}
+	return nil
+}
+
+func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver) {
+	vmiName := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vmi, code, err := app.fetchVirtualMachineInstance(vmiName, namespace)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to gather vmi %s in namespace %s.", vmiName, namespace)
+		response.WriteError(code, err)
+		return
+	}
+
+	if err := validate(vmi); err != nil {
+		response.WriteError(http.StatusConflict, err)
+		return
+	}
+
+	conn, err := app.getVirtHandlerConnForVMI(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Unable to establish connection to virt-handler")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	url, err := getVirtHandlerURL(vmi, conn)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Unable to retrieve target virt-handler URL")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := app.setTLSConfiguration(); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to set TLS configuration for virt-handler connection")
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := conn.Put(url, app.handlerHTTPClient); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Request to virt-handler failed")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// DomainStats fetches the latest domain statistics of the VirtualMachineInstance from its virt-handler
+func (app *SubresourceAPIApp) DomainStats(vmi *v1.VirtualMachineInstance) (*stats.DomainStats, error) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).DomainStatsURI(vmi)
+	}
+	domainStats := &stats.DomainStats{}
+	if err := app.getFromVirtHandler(vmi, getURL, domainStats); err != nil {
+		return nil, err
+	}
+	return domainStats, nil
+}
+
+// getFromVirtHandler fetches a resource of the VirtualMachineInstance from its virt-handler and
+// unmarshals it into obj
+func (app *SubresourceAPIApp) getFromVirtHandler(vmi *v1.VirtualMachineInstance, getVirtHandlerURL URLResolver, obj interface{}) error {
+	conn, err := app.getVirtHandlerConnForVMI(vmi)
+	if err != nil {
+		return err
+	}
+	url, err := getVirtHandlerURL(vmi, conn)
+	if err != nil {
+		return err
+	}
+
+	if err := app.setTLSConfiguration(); err != nil {
+		return err
+	}
+
+	body, err := conn.Get(url, app.handlerHTTPClient)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(body), obj)
+}
+
 func (app *SubresourceAPIApp) getConsoleTLSConfig() (*tls.Config, error) {
 	ns, err := clientutil.GetNamespace()
 	if err != nil {
@@ -269,6 +363,559 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 	app.streamRequestHandler(request, response, validate, getConsoleURL)
 }
 
+func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) error {
+		if vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VMI is not running")
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstancePaused, k8sv1.ConditionTrue) {
+			return fmt.Errorf("VMI is already paused")
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).PauseURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) error {
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstancePaused, k8sv1.ConditionTrue) {
+			return fmt.Errorf("VMI is not paused")
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).UnpauseURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) error {
+		if vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VMI is not running")
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).FreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) error {
+		if vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VMI is not running")
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).UnfreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) GuestOSInfo(request *restful.Request, response *restful.Response) {
+	vmiName := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vmi, code, err := app.fetchVirtualMachineInstance(vmiName, namespace)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to gather vmi %s in namespace %s.", vmiName, namespace)
+		response.WriteError(code, err)
+		return
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue) {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VMI does not have guest agent connected"))
+		return
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetPort(app.consoleServerPort).GuestInfoURI(vmi)
+	}
+	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+	if err := app.getFromVirtHandler(vmi, getURL, &guestInfo); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get the guest OS info from virt-handler")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(guestInfo)
+}
+
+func (app *SubresourceAPIApp) AddVolumeRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("no request body"))
+		return
+	}
+	opts := &v1.AddVolumeOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil && err != io.EOF {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the request body: %v", err))
+		return
+	}
+	if err := validateAddVolumeOptions(opts); err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VMI is not running"))
+		return
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == opts.Name {
+			response.WriteError(http.StatusConflict, fmt.Errorf("VMI already has a volume with the name %s", opts.Name))
+			return
+		}
+	}
+
+	disk := opts.Disk.DeepCopy()
+	disk.Name = opts.Name
+	// hotplugged disks are attached to the scsi bus, unless told otherwise
+	if disk.DiskDevice == (v1.DiskDevice{}) {
+		disk.Disk = &v1.DiskTarget{}
+	}
+	if disk.Disk != nil && disk.Disk.Bus == "" {
+		disk.Disk.Bus = "scsi"
+	}
+	volume := v1.Volume{Name: opts.Name}
+	if opts.VolumeSource.PersistentVolumeClaim != nil {
+		volume.PersistentVolumeClaim = opts.VolumeSource.PersistentVolumeClaim
+	} else {
+		volume.DataVolume = opts.VolumeSource.DataVolume
+	}
+
+	newVMI := vmi.DeepCopy()
+	newVMI.Spec.Volumes = append(newVMI.Spec.Volumes, volume)
+	newVMI.Spec.Domain.Devices.Disks = append(newVMI.Spec.Domain.Devices.Disks, *disk)
+	newVMI.Status.VolumeStatus = append(newVMI.Status.VolumeStatus, v1.VolumeStatus{
+		Name:          opts.Name,
+		Phase:         v1.VolumePending,
+		HotplugVolume: &v1.HotplugVolumeStatus{},
+	})
+	hotplugdisk.AddHotplugVolumeName(newVMI, opts.Name)
+	if !app.patchVMIVolumes(vmi, newVMI, response) {
+		return
+	}
+	app.patchVMTemplateVolumes(vmi, []v1.Volume{volume}, []v1.Disk{*disk}, nil, response)
+}
+
+func (app *SubresourceAPIApp) RemoveVolumeRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("no request body"))
+		return
+	}
+	opts := &v1.RemoveVolumeOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil && err != io.EOF {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the request body: %v", err))
+		return
+	}
+	if opts.Name == "" {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("volume name must be specified"))
+		return
+	}
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VMI is not running"))
+		return
+	}
+	if !hotplugdisk.IsHotplugVolume(vmi, opts.Name) {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("volume %s was not hotplugged and can not be removed", opts.Name))
+		return
+	}
+	if drive := getDriveWithMedium(vmi, opts.Name); drive != "" {
+		response.WriteError(http.StatusConflict, fmt.Errorf("volume %s is inserted into %s and has to be ejected first", opts.Name, drive))
+		return
+	}
+
+	newVMI := vmi.DeepCopy()
+	newVMI.Spec.Volumes = []v1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != opts.Name {
+			newVMI.Spec.Volumes = append(newVMI.Spec.Volumes, volume)
+		}
+	}
+	newVMI.Spec.Domain.Devices.Disks = []v1.Disk{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name != opts.Name {
+			newVMI.Spec.Domain.Devices.Disks = append(newVMI.Spec.Domain.Devices.Disks, disk)
+		}
+	}
+	if !app.patchVMIVolumes(vmi, newVMI, response) {
+		return
+	}
+	app.patchVMTemplateVolumes(vmi, nil, nil, []string{opts.Name}, response)
+}
+
+func (app *SubresourceAPIApp) InsertMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("no request body"))
+		return
+	}
+	opts := &v1.InsertMediaOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil && err != io.EOF {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the request body: %v", err))
+		return
+	}
+	if err := validateInsertMediaOptions(opts); err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VMI is not running"))
+		return
+	}
+	if !hasDrive(vmi, opts.Name) {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("VMI has no CD-ROM or floppy with the name %s", opts.Name))
+		return
+	}
+
+	volumeExists := false
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == opts.VolumeName {
+			volumeExists = true
+		}
+	}
+	newVMI := vmi.DeepCopy()
+	switch {
+	case opts.VolumeSource != nil:
+		if volumeExists {
+			response.WriteError(http.StatusConflict, fmt.Errorf("VMI already has a volume with the name %s", opts.VolumeName))
+			return
+		}
+		// the medium is hotplugged as a volume without a disk
+		volume := v1.Volume{Name: opts.VolumeName}
+		if opts.VolumeSource.PersistentVolumeClaim != nil {
+			volume.PersistentVolumeClaim = opts.VolumeSource.PersistentVolumeClaim
+		} else {
+			volume.DataVolume = opts.VolumeSource.DataVolume
+		}
+		newVMI.Spec.Volumes = append(newVMI.Spec.Volumes, volume)
+		newVMI.Status.VolumeStatus = append(newVMI.Status.VolumeStatus, v1.VolumeStatus{
+			Name:          opts.VolumeName,
+			Phase:         v1.VolumePending,
+			HotplugVolume: &v1.HotplugVolumeStatus{},
+		})
+		hotplugdisk.AddHotplugVolumeName(newVMI, opts.VolumeName)
+	case !volumeExists:
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("VMI has no volume with the name %s", opts.VolumeName))
+		return
+	case opts.VolumeName != opts.Name && !isMediumVolume(vmi, opts.VolumeName):
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("volume %s is in use by a disk and can not be inserted as medium", opts.VolumeName))
+		return
+	}
+	if drive := getDriveWithMedium(vmi, opts.VolumeName); drive != "" && drive != opts.Name {
+		response.WriteError(http.StatusConflict, fmt.Errorf("volume %s is already inserted into %s", opts.VolumeName, drive))
+		return
+	}
+
+	setRequestedMedium(newVMI, opts.Name, opts.VolumeName)
+	if app.patchVMIVolumes(vmi, newVMI, response) {
+		response.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (app *SubresourceAPIApp) EjectMediaRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("no request body"))
+		return
+	}
+	opts := &v1.EjectMediaOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil && err != io.EOF {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the request body: %v", err))
+		return
+	}
+	if opts.Name == "" {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("CD-ROM or floppy name must be specified"))
+		return
+	}
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VMI is not running"))
+		return
+	}
+	if !hasDrive(vmi, opts.Name) {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("VMI has no CD-ROM or floppy with the name %s", opts.Name))
+		return
+	}
+
+	newVMI := vmi.DeepCopy()
+	setRequestedMedium(newVMI, opts.Name, "")
+	if app.patchVMIVolumes(vmi, newVMI, response) {
+		response.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func validateInsertMediaOptions(opts *v1.InsertMediaOptions) error {
+	if opts.Name == "" {
+		return fmt.Errorf("CD-ROM or floppy name must be specified")
+	}
+	if opts.VolumeName == "" {
+		return fmt.Errorf("volume name must be specified")
+	}
+	if opts.VolumeSource != nil && (opts.VolumeSource.PersistentVolumeClaim == nil) == (opts.VolumeSource.DataVolume == nil) {
+		return fmt.Errorf("exactly one of persistentVolumeClaim or dataVolume must be specified as volume source")
+	}
+	return nil
+}
+
+// hasDrive checks if the VMI has a CD-ROM or floppy with the given name
+func hasDrive(vmi *v1.VirtualMachineInstance, name string) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == name {
+			return disk.CDRom != nil || disk.Floppy != nil
+		}
+	}
+	return false
+}
+
+// isMediumVolume checks if a volume was hotplugged as medium, which means that it has no disk of its own
+func isMediumVolume(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == volumeName {
+			return false
+		}
+	}
+	return hotplugdisk.IsHotplugVolume(vmi, volumeName)
+}
+
+// getDriveWithMedium returns the name of the drive which the volume was requested to be inserted into
+func getDriveWithMedium(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		if (disk.CDRom != nil || disk.Floppy != nil) && hotplugdisk.GetRequestedMedium(disk) == volumeName {
+			return disk.Name
+		}
+	}
+	return ""
+}
+
+// setRequestedMedium records in the spec of the drive which volume virt-handler should insert into it,
+// an empty volume name ejects the medium
+func setRequestedMedium(vmi *v1.VirtualMachineInstance, drive string, volumeName string) {
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		if disk.Name != drive {
+			continue
+		}
+		if volumeName == drive {
+			// the drive gets back the medium it was defined with
+			disk.Medium = nil
+		} else {
+			disk.Medium = &volumeName
+		}
+	}
+}
+
+func validateAddVolumeOptions(opts *v1.AddVolumeOptions) error {
+	if opts.Name == "" {
+		return fmt.Errorf("volume name must be specified")
+	}
+	if opts.Disk == nil {
+		return fmt.Errorf("disk must be specified")
+	}
+	if opts.VolumeSource == nil {
+		return fmt.Errorf("volume source must be specified")
+	}
+	if (opts.VolumeSource.PersistentVolumeClaim == nil) == (opts.VolumeSource.DataVolume == nil) {
+		return fmt.Errorf("exactly one of persistentVolumeClaim or dataVolume must be specified as volume source")
+	}
+	return nil
+}
+
+// jsonPatchField is a field which gets replaced by a JSON patch, after testing its old value
+type jsonPatchField struct {
+	path     string
+	oldValue interface{}
+	newValue interface{}
+	isEmpty  bool
+}
+
+// getReplaceJsonPatch renders a JSON patch which replaces all fields. The old values are tested,
+// so that concurrent changes lead to a conflict.
+func getReplaceJsonPatch(fields []jsonPatchField) (string, error) {
+	var ops []string
+	for _, field := range fields {
+		newJson, err := json.Marshal(field.newValue)
+		if err != nil {
+			return "", err
+		}
+		if field.isEmpty {
+			// nothing to add to a field which stays empty
+			if string(newJson) != "null" {
+				ops = append(ops, fmt.Sprintf(`{ "op": "add", "path": "%s", "value": %s }`, field.path, string(newJson)))
+			}
+			continue
+		}
+		oldJson, err := json.Marshal(field.oldValue)
+		if err != nil {
+			return "", err
+		}
+		ops = append(ops, fmt.Sprintf(`{ "op": "test", "path": "%s", "value": %s }`, field.path, string(oldJson)))
+		ops = append(ops, fmt.Sprintf(`{ "op": "replace", "path": "%s", "value": %s }`, field.path, string(newJson)))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(ops, ", ")), nil
+}
+
+// writePatchError responds with the status code matching the error of a failed patch
+func writePatchError(err error, response *restful.Response) {
+	errCode := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "jsonpatch test operation does not apply") {
+		errCode = http.StatusConflict
+	} else if status, ok := err.(errors.APIStatus); ok && status.Status().Code != 0 {
+		errCode = int(status.Status().Code)
+	}
+	response.WriteError(errCode, err)
+}
+
+// patchVMIVolumes replaces the volumes, disks and volume statuses of a VMI with the ones of newVMI,
+// together with the annotations and the media statuses if they changed. It reports if the patch
+// was applied, and writes the error to the response otherwise.
+func (app *SubresourceAPIApp) patchVMIVolumes(vmi *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance, response *restful.Response) bool {
+	fields := []jsonPatchField{
+		{"/spec/volumes", vmi.Spec.Volumes, newVMI.Spec.Volumes, len(vmi.Spec.Volumes) == 0},
+		{"/spec/domain/devices/disks", vmi.Spec.Domain.Devices.Disks, newVMI.Spec.Domain.Devices.Disks, len(vmi.Spec.Domain.Devices.Disks) == 0},
+		{"/status/volumeStatus", vmi.Status.VolumeStatus, newVMI.Status.VolumeStatus, len(vmi.Status.VolumeStatus) == 0},
+	}
+	if !reflect.DeepEqual(vmi.Annotations, newVMI.Annotations) {
+		fields = append(fields, jsonPatchField{"/metadata/annotations", vmi.Annotations, newVMI.Annotations, len(vmi.Annotations) == 0})
+	}
+
+	bodyString, err := getReplaceJsonPatch(fields)
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return false
+	}
+
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", bodyString)
+	_, err = app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(bodyString))
+	if err != nil {
+		writePatchError(err, response)
+		return false
+	}
+	return true
+}
+
+// patchVMTemplateVolumes applies a hotplug or an unplug to the template of the VirtualMachine
+// which controls the VMI, so that the volumes survive a restart. Volumes which are already in
+// the template are not added again, and missing ones are not removed.
+func (app *SubresourceAPIApp) patchVMTemplateVolumes(vmi *v1.VirtualMachineInstance, addVolumes []v1.Volume, addDisks []v1.Disk, removeNames []string, response *restful.Response) {
+	owner := k8smetav1.GetControllerOf(vmi)
+	if owner == nil || owner.Kind != v1.VirtualMachineGroupVersionKind.Kind {
+		response.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	vm, code, err := app.fetchVirtualMachine(owner.Name, vmi.Namespace)
+	if code == http.StatusNotFound {
+		// the VMI goes away together with its VirtualMachine
+		response.WriteHeader(http.StatusAccepted)
+		return
+	} else if err != nil {
+		response.WriteError(code, fmt.Errorf("the VMI was updated, but its VirtualMachine could not be fetched: %v", err))
+		return
+	}
+	if vm.UID != owner.UID || vm.Spec.Template == nil {
+		response.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	templateSpec := &vm.Spec.Template.Spec
+	removed := map[string]bool{}
+	for _, name := range removeNames {
+		removed[name] = true
+	}
+	volumes := []v1.Volume{}
+	existingVolumes := map[string]bool{}
+	for _, volume := range templateSpec.Volumes {
+		if !removed[volume.Name] {
+			volumes = append(volumes, volume)
+			existingVolumes[volume.Name] = true
+		}
+	}
+	for _, volume := range addVolumes {
+		if !existingVolumes[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	disks := []v1.Disk{}
+	existingDisks := map[string]bool{}
+	for _, disk := range templateSpec.Domain.Devices.Disks {
+		if !removed[disk.Name] {
+			disks = append(disks, disk)
+			existingDisks[disk.Name] = true
+		}
+	}
+	for _, disk := range addDisks {
+		if !existingDisks[disk.Name] {
+			disks = append(disks, disk)
+		}
+	}
+	if reflect.DeepEqual(volumes, templateSpec.Volumes) && reflect.DeepEqual(disks, templateSpec.Domain.Devices.Disks) {
+		response.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	bodyString, err := getReplaceJsonPatch([]jsonPatchField{
+		{"/spec/template/spec/volumes", templateSpec.Volumes, volumes, len(templateSpec.Volumes) == 0},
+		{"/spec/template/spec/domain/devices/disks", templateSpec.Domain.Devices.Disks, disks, len(templateSpec.Domain.Devices.Disks) == 0},
+	})
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
+	_, err = app.virtCli.VirtualMachine(vm.Namespace).Patch(vm.Name, types.JSONPatchType, []byte(bodyString))
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to apply the hotplugged volumes to the VirtualMachine")
+		writePatchError(fmt.Errorf("the VMI was updated, but its VirtualMachine could not be patched: %v", err), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func getChangeRequestJson(vm *v1.VirtualMachine, changes ...v1.VirtualMachineStateChangeRequest) (string, error) {
 	verb := "add"
 	// Special case: if there's no status field at all, add one.
@@ -337,6 +984,7 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 	// RunStrategyManual         -> send restart request
 	// RunStrategyAlways         -> send restart request
 	// RunStrategyRerunOnFailure -> send restart request
+	// RunStrategyOnce           -> doesn't make sense
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
@@ -351,8 +999,8 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 		response.WriteError(http.StatusInternalServerError, err)
 		return
 	}
-	if runStrategy == v1.RunStrategyHalted {
-		response.WriteError(http.StatusForbidden, fmt.Errorf("%v does not support manual restart requests", v1.RunStrategyHalted))
+	if runStrategy == v1.RunStrategyHalted || runStrategy == v1.RunStrategyOnce {
+		response.WriteError(http.StatusForbidden, fmt.Errorf("%v does not support manual restart requests", runStrategy))
 		return
 	}
 
@@ -422,6 +1070,7 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	// RunStrategyManual         -> send start request
 	// RunStrategyAlways         -> doesn't make sense
 	// RunStrategyRerunOnFailure -> doesn't make sense
+	// RunStrategyOnce           -> doesn't make sense
 	switch runStrategy {
 	case v1.RunStrategyHalted:
 		bodyString = getRunningJson(vm, true)
@@ -449,8 +1098,8 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 			response.WriteError(http.StatusInternalServerError, err)
 			return
 		}
-	case v1.RunStrategyAlways:
-		response.WriteError(http.StatusForbidden, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways))
+	case v1.RunStrategyAlways, v1.RunStrategyOnce:
+		response.WriteError(http.StatusForbidden, fmt.Errorf("%v does not support manual start requests", runStrategy))
 		return
 	}
 
@@ -473,6 +1122,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	// RunStrategyManual         -> send stop request
 	// RunStrategyAlways         -> spec.running = false
 	// RunStrategyRerunOnFailure -> spec.running = false
+	// RunStrategyOnce           -> spec.runStrategy = Halted
 
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
@@ -519,7 +1169,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 			response.WriteError(http.StatusInternalServerError, err)
 			return
 		}
-	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways:
+	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways, v1.RunStrategyOnce:
 		bodyString = getRunningJson(vm, false)
 	}
 
@@ -537,6 +1187,53 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, code, err := app.fetchVirtualMachine(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+		response.WriteError(http.StatusConflict, fmt.Errorf("VM is not running"))
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusConflict, fmt.Errorf("VM is not running"))
+		return
+	}
+
+	migration := &v1.VirtualMachineInstanceMigration{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			GenerateName: "kubevirt-migrate-vm-",
+		},
+		Spec: v1.VirtualMachineInstanceMigrationSpec{
+			VMIName: vm.Name,
+		},
+	}
+	migration, err = app.virtCli.VirtualMachineInstanceMigration(namespace).Create(migration)
+	if err != nil {
+		// Pass through rejections by the migration admission webhook, e.g. for non migratable VMIs
+		errCode := http.StatusInternalServerError
+		if statusErr, ok := err.(*errors.StatusError); ok {
+			errCode = int(statusErr.Status().Code)
+		}
+		response.WriteError(errCode, err)
+		return
+	}
+
+	// the created migration lets clients follow exactly this migration
+	response.WriteHeaderAndEntity(http.StatusAccepted, migration)
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, int, error) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
@@ -20,8 +20,11 @@
 package rest
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 
@@ -32,12 +35,14 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 )
 
 var _ = Describe("VirtualMachineInstance Subresources", func() {
@@ -465,6 +470,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Manual", v1.RunStrategyManual, "VM is not running"),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
 			table.Entry("Halted", v1.RunStrategyHalted, "Halted does not support manual restart requests"),
+			table.Entry("Once", v1.RunStrategyOnce, "Once does not support manual restart requests"),
 		)
 	})
 
@@ -505,6 +511,8 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			},
 			table.Entry("Always without VMI", v1.RunStrategyAlways, v1.VmPhaseUnset, http.StatusNotFound, "Always does not support manual start requests"),
 			table.Entry("Always with VMI in phase Running", v1.RunStrategyAlways, v1.Running, http.StatusOK, "VM is already running"),
+			table.Entry("Once without VMI", v1.RunStrategyOnce, v1.VmPhaseUnset, http.StatusNotFound, "Once does not support manual start requests"),
+			table.Entry("Once with VMI in phase Succeeded", v1.RunStrategyOnce, v1.Succeeded, http.StatusOK, "Once does not support manual start requests"),
 			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, http.StatusOK, "RerunOnFailure does not support starting VM from failed state"),
 		)
 
@@ -643,6 +651,652 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Always", v1.RunStrategyAlways),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
 			table.Entry("Manual", v1.RunStrategyManual),
+			table.Entry("Once", v1.RunStrategyOnce),
+		)
+	})
+
+	Context("Subresource api - error handling for MigrateVMRequestHandler", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if VM does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusNotFound))
+		})
+
+		table.DescribeTable("should fail if VMI is not running", func(vmiStatusCode int, phase v1.VirtualMachineInstancePhase) {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)
+			vmi := newVirtualMachineInstanceInPhase(phase)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(vmiStatusCode, vmi),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("VM is not running"))
+		},
+			table.Entry("with no VMI", http.StatusNotFound, v1.Running),
+			table.Entry("with a scheduling VMI", http.StatusOK, v1.Scheduling),
+			table.Entry("with a succeeded VMI", http.StatusOK, v1.Succeeded),
+		)
+
+		It("should create a migration for a running VMI", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			migration := &v1.VirtualMachineInstanceMigration{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(json.NewDecoder(r.Body).Decode(migration)).To(Succeed())
+						migration.Name = "kubevirt-migrate-vm-abcde"
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusCreated)
+						Expect(json.NewEncoder(w).Encode(migration)).To(Succeed())
+					},
+				),
+			)
+
+			recorder := httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+			response.SetRequestAccepts(restful.MIME_JSON)
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).NotTo(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			Expect(migration.Spec.VMIName).To(Equal("testvm"))
+			Expect(migration.GenerateName).To(Equal("kubevirt-migrate-vm-"))
+
+			created := &v1.VirtualMachineInstanceMigration{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), created)).To(Succeed())
+			Expect(created.Name).To(Equal("kubevirt-migrate-vm-abcde"))
+		})
+
+		It("should pass through a rejected migration", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					ghttp.RespondWithJSONEncoded(http.StatusBadRequest, errors.NewBadRequest("Cannot migrate VMI").Status()),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Subresource api - error handling for pause and unpause", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("should fail pausing a VMI", func(phase v1.VirtualMachineInstancePhase, paused bool, msg string) {
+			vmi := newVirtualMachineInstanceInPhase(phase)
+			if paused {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue}}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal(msg))
+		},
+			table.Entry("which is not running", v1.Scheduled, false, "VMI is not running"),
+			table.Entry("which is already paused", v1.Running, true, "VMI is already paused"),
+		)
+
+		It("should fail unpausing a VMI which is not paused", func() {
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.UnpauseVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("VMI is not paused"))
+		})
+
+		It("should fail if VMI does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("Subresource api - volume hotplug", func() {
+		const vmiPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"
+		const vmPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvmi"
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		setBody := func(opts interface{}) {
+			body, err := json.Marshal(opts)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		newRunningVMI := func() *v1.VirtualMachineInstance {
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			vmi.Name = "testvmi"
+			vmi.Namespace = "default"
+			return vmi
+		}
+
+		newAddVolumeOptions := func() *v1.AddVolumeOptions {
+			return &v1.AddVolumeOptions{
+				Name: "hpvolume",
+				Disk: &v1.Disk{},
+				VolumeSource: &v1.HotplugVolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+				},
+			}
+		}
+
+		It("should add a volume, its disk and a hotplug volume status", func() {
+			vmi := newRunningVMI()
+			setBody(newAddVolumeOptions())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmiPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						patch := []map[string]interface{}{}
+						Expect(json.Unmarshal(body, &patch)).To(Succeed())
+						Expect(patch).To(HaveLen(4))
+						for _, op := range patch {
+							Expect(op["op"]).To(Equal("add"))
+						}
+						Expect(patch[1]["value"]).To(Equal([]interface{}{
+							map[string]interface{}{"name": "hpvolume", "disk": map[string]interface{}{"bus": "scsi"}},
+						}))
+						Expect(patch[2]["value"]).To(Equal([]interface{}{
+							map[string]interface{}{"name": "hpvolume", "phase": "Pending", "hotplugVolume": map[string]interface{}{}},
+						}))
+						Expect(patch[3]).To(Equal(map[string]interface{}{
+							"op": "add", "path": "/metadata/annotations", "value": map[string]interface{}{v1.HotplugVolumesAnnotation: "hpvolume"},
+						}))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.AddVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should add a hotplugged volume to the template of the VirtualMachine", func() {
+			vm := newMinimalVM("testvmi")
+			vm.Namespace = "default"
+			vm.UID = "vm-uid"
+			vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+			vm.Spec.Template.Spec.Volumes = []v1.Volume{{Name: "rootdisk"}}
+			vmi := newRunningVMI()
+			vmi.OwnerReferences = []k8smetav1.OwnerReference{*k8smetav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+			setBody(newAddVolumeOptions())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						patch := []map[string]interface{}{}
+						Expect(json.Unmarshal(body, &patch)).To(Succeed())
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/template/spec/volumes", "value": []interface{}{
+							map[string]interface{}{"name": "rootdisk"},
+							map[string]interface{}{"name": "hpvolume", "persistentVolumeClaim": map[string]interface{}{"claimName": "testpvc"}},
+						}}))
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "add", "path": "/spec/template/spec/domain/devices/disks", "value": []interface{}{
+							map[string]interface{}{"name": "hpvolume", "disk": map[string]interface{}{"bus": "scsi"}},
+						}}))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.AddVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should remove an unplugged volume from the template of the VirtualMachine", func() {
+			vm := newMinimalVM("testvmi")
+			vm.Namespace = "default"
+			vm.UID = "vm-uid"
+			vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+			vm.Spec.Template.Spec.Volumes = []v1.Volume{{Name: "hpvolume"}}
+			vm.Spec.Template.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "hpvolume"}}
+			vmi := newRunningVMI()
+			vmi.OwnerReferences = []k8smetav1.OwnerReference{*k8smetav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "hpvolume"}}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "hpvolume"}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hpvolume", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+			setBody(&v1.RemoveVolumeOptions{Name: "hpvolume"})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						patch := []map[string]interface{}{}
+						Expect(json.Unmarshal(body, &patch)).To(Succeed())
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/template/spec/volumes", "value": []interface{}{}}))
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/template/spec/domain/devices/disks", "value": []interface{}{}}))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.RemoveVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject invalid add volume requests", func(modify func(opts *v1.AddVolumeOptions), msg string) {
+			opts := newAddVolumeOptions()
+			modify(opts)
+			setBody(opts)
+
+			app.AddVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(response.Error().Error()).To(Equal(msg))
+		},
+			table.Entry("without a name", func(opts *v1.AddVolumeOptions) { opts.Name = "" }, "volume name must be specified"),
+			table.Entry("without a disk", func(opts *v1.AddVolumeOptions) { opts.Disk = nil }, "disk must be specified"),
+			table.Entry("without a volume source", func(opts *v1.AddVolumeOptions) { opts.VolumeSource = nil }, "volume source must be specified"),
+			table.Entry("with two volume sources", func(opts *v1.AddVolumeOptions) {
+				opts.VolumeSource.DataVolume = &v1.DataVolumeSource{Name: "testdv"}
+			}, "exactly one of persistentVolumeClaim or dataVolume must be specified as volume source"),
+		)
+
+		It("should fail adding a volume to a VMI which is not running", func() {
+			vmi := newVirtualMachineInstanceInPhase(v1.Scheduled)
+			setBody(newAddVolumeOptions())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.AddVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("VMI is not running"))
+		})
+
+		It("should fail adding a volume with a name which is already in use", func() {
+			vmi := newRunningVMI()
+			vmi.Spec.Volumes = []v1.Volume{{Name: "hpvolume"}}
+			setBody(newAddVolumeOptions())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.AddVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("VMI already has a volume with the name hpvolume"))
+		})
+
+		It("should remove a hotplugged volume and its disk", func() {
+			vmi := newRunningVMI()
+			vmi.Spec.Volumes = []v1.Volume{{Name: "hpvolume"}}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "hpvolume"}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hpvolume", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+			setBody(&v1.RemoveVolumeOptions{Name: "hpvolume"})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmiPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						patch := []map[string]interface{}{}
+						Expect(json.Unmarshal(body, &patch)).To(Succeed())
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/volumes", "value": []interface{}{}}))
+						Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/domain/devices/disks", "value": []interface{}{}}))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.RemoveVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should fail removing a volume which was not hotplugged", func() {
+			vmi := newRunningVMI()
+			vmi.Spec.Volumes = []v1.Volume{{Name: "rootdisk"}}
+			setBody(&v1.RemoveVolumeOptions{Name: "rootdisk"})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.RemoveVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(response.Error().Error()).To(Equal("volume rootdisk was not hotplugged and can not be removed"))
+		})
+
+		newRunningVMIWithCDRom := func() *v1.VirtualMachineInstance {
+			vmi := newRunningVMI()
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "rootdisk"}, {Name: "cdrom"}}
+			return vmi
+		}
+
+		expectMediaPatch := func(vmi *v1.VirtualMachineInstance, expectPatch func(patch []map[string]interface{})) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", vmiPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						patch := []map[string]interface{}{}
+						Expect(json.Unmarshal(body, &patch)).To(Succeed())
+						expectPatch(patch)
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		It("should hotplug a new medium and request it to be inserted", func() {
+			vmi := newRunningVMIWithCDRom()
+			setBody(&v1.InsertMediaOptions{
+				Name:       "cdrom",
+				VolumeName: "iso",
+				VolumeSource: &v1.HotplugVolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "isopvc"},
+				},
+			})
+
+			expectMediaPatch(vmi, func(patch []map[string]interface{}) {
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/volumes", "value": []interface{}{
+					map[string]interface{}{"name": "rootdisk"},
+					map[string]interface{}{"name": "cdrom"},
+					map[string]interface{}{"name": "iso", "persistentVolumeClaim": map[string]interface{}{"claimName": "isopvc"}},
+				}}))
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "add", "path": "/status/volumeStatus", "value": []interface{}{
+					map[string]interface{}{"name": "iso", "phase": "Pending", "hotplugVolume": map[string]interface{}{}},
+				}}))
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/domain/devices/disks", "value": []interface{}{
+					map[string]interface{}{"name": "rootdisk", "disk": map[string]interface{}{}},
+					map[string]interface{}{"name": "cdrom", "cdrom": map[string]interface{}{}, "medium": "iso"},
+				}}))
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "add", "path": "/metadata/annotations", "value": map[string]interface{}{
+					v1.HotplugVolumesAnnotation: "iso",
+				}}))
+			})
+
+			app.InsertMediaRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should request the medium to be ejected in the spec", func() {
+			vmi := newRunningVMIWithCDRom()
+			vmi.Status.Media = []v1.MediaStatus{{Name: "cdrom", Source: "cdrom", Tray: v1.TrayStateClosed}}
+			setBody(&v1.EjectMediaOptions{Name: "cdrom"})
+
+			expectMediaPatch(vmi, func(patch []map[string]interface{}) {
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/domain/devices/disks", "value": []interface{}{
+					map[string]interface{}{"name": "rootdisk", "disk": map[string]interface{}{}},
+					map[string]interface{}{"name": "cdrom", "cdrom": map[string]interface{}{}, "medium": ""},
+				}}))
+				for _, op := range patch {
+					// virt-handler owns the status
+					Expect(op["path"]).ToNot(HavePrefix("/status/"))
+				}
+			})
+
+			app.EjectMediaRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should request the original medium of the drive to be inserted again", func() {
+			vmi := newRunningVMIWithCDRom()
+			ejected := ""
+			vmi.Spec.Domain.Devices.Disks[1].Medium = &ejected
+			setBody(&v1.InsertMediaOptions{Name: "cdrom", VolumeName: "cdrom"})
+
+			expectMediaPatch(vmi, func(patch []map[string]interface{}) {
+				Expect(patch).To(ContainElement(map[string]interface{}{"op": "replace", "path": "/spec/domain/devices/disks", "value": []interface{}{
+					map[string]interface{}{"name": "rootdisk", "disk": map[string]interface{}{}},
+					map[string]interface{}{"name": "cdrom", "cdrom": map[string]interface{}{}},
+				}}))
+			})
+
+			app.InsertMediaRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject inserting media", func(opts *v1.InsertMediaOptions, msg string) {
+			vmi := newRunningVMIWithCDRom()
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: "iso"})
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "iso", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "iso")
+			setBody(opts)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.InsertMediaRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(Equal(msg))
+		},
+			table.Entry("into a disk which is no CD-ROM or floppy",
+				&v1.InsertMediaOptions{Name: "rootdisk", VolumeName: "iso"}, "VMI has no CD-ROM or floppy with the name rootdisk"),
+			table.Entry("from a volume which does not exist",
+				&v1.InsertMediaOptions{Name: "cdrom", VolumeName: "other"}, "VMI has no volume with the name other"),
+			table.Entry("from the volume of another disk",
+				&v1.InsertMediaOptions{Name: "cdrom", VolumeName: "rootdisk"}, "volume rootdisk is in use by a disk and can not be inserted as medium"),
+			table.Entry("from a new volume with the name of an existing one",
+				&v1.InsertMediaOptions{
+					Name:       "cdrom",
+					VolumeName: "iso",
+					VolumeSource: &v1.HotplugVolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "isopvc"},
+					},
+				}, "VMI already has a volume with the name iso"),
+		)
+
+		It("should fail removing a volume which is inserted as medium", func() {
+			vmi := newRunningVMIWithCDRom()
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: "iso"})
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "iso", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "iso")
+			iso := "iso"
+			vmi.Spec.Domain.Devices.Disks[1].Medium = &iso
+			setBody(&v1.RemoveVolumeOptions{Name: "iso"})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmiPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.RemoveVolumeRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("volume iso is inserted into cdrom and has to be ejected first"))
+		})
+	})
+
+	Context("Subresource api - error handling for freeze and unfreeze", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		table.DescribeTable("should fail on a VMI which is not running", func(freeze bool) {
+			vmi := newVirtualMachineInstanceInPhase(v1.Scheduled)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			if freeze {
+				app.FreezeVMIRequestHandler(request, response)
+			} else {
+				app.UnfreezeVMIRequestHandler(request, response)
+			}
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			Expect(response.Error().Error()).To(Equal("VMI is not running"))
+		},
+			table.Entry("when freezing", true),
+			table.Entry("when unfreezing", false),
 		)
 	})
 
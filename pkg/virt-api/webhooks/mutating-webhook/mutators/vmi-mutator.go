@@ -29,10 +29,12 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -80,6 +82,7 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 	mutator.setDefaultMachineType(&vmi)
 	mutator.setDefaultResourceRequests(&vmi)
 	mutator.setDefaultPullPoliciesOnContainerDisks(&vmi)
+	mutator.setDefaultDiskIOTune(&vmi)
 	err = mutator.setDefaultNetworkInterface(&vmi)
 	if err != nil {
 		return webhooks.ToAdmissionResponseError(err)
@@ -190,6 +193,25 @@ func (mutator *VMIsMutator) setDefaultPullPoliciesOnContainerDisks(vmi *v1.Virtu
 	}
 }
 
+func (mutator *VMIsMutator) setDefaultDiskIOTune(vmi *v1.VirtualMachineInstance) {
+	defaultIOTune := mutator.ClusterConfig.GetDefaultDiskIOTune()
+	if defaultIOTune == nil {
+		return
+	}
+	// an invalid default would make every VMI creation fail
+	if causes := admitters.ValidateDiskIOTune(k8sfield.NewPath(virtconfig.DiskIOTuneKey), defaultIOTune); len(causes) > 0 {
+		log.Log.Object(vmi).Warningf("Ignoring the invalid %s config: %s", virtconfig.DiskIOTuneKey, causes[0].Message)
+		return
+	}
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		// removable media is not throttled
+		if disk.IOTune == nil && disk.CDRom == nil && disk.Floppy == nil {
+			disk.IOTune = defaultIOTune.DeepCopy()
+		}
+	}
+}
+
 func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineInstance) {
 
 	resources := &vmi.Spec.Domain.Resources
@@ -189,6 +189,41 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Cpu().String()).To(Equal(cpuRequestFromConfig))
 	})
 
+	It("should apply the default disk I/O limits to disks without their own", func() {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.DiskIOTuneKey: `{"totalIOPSSec": 500}`,
+			},
+		})
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "default"},
+			{Name: "own", IOTune: &v1.DiskIOTune{ReadIOPSSec: 100}},
+			{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+			{Name: "floppy", DiskDevice: v1.DiskDevice{Floppy: &v1.FloppyTarget{}}},
+		}
+
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].IOTune).To(Equal(&v1.DiskIOTune{TotalIOPSSec: 500}))
+		Expect(vmiSpec.Domain.Devices.Disks[1].IOTune).To(Equal(&v1.DiskIOTune{ReadIOPSSec: 100}))
+		Expect(vmiSpec.Domain.Devices.Disks[2].IOTune).To(BeNil())
+		Expect(vmiSpec.Domain.Devices.Disks[3].IOTune).To(BeNil())
+	})
+
+	table.DescribeTable("should ignore an invalid default disk I/O limit", func(ioTune string) {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.DiskIOTuneKey: ioTune},
+		})
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "default"}}
+
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].IOTune).To(BeNil())
+	},
+		table.Entry("with total and read limits combined", "totalIOPSSec: 500\nreadIOPSSec: 100\n"),
+		table.Entry("with a burst below the limit", "totalBytesSec: 1000\ntotalBytesSecMax: 500\n"),
+		table.Entry("with a burst without a limit", "writeIOPSSecMax: 500\n"),
+		table.Entry("with a negative limit", "readBytesSec: -1\n"),
+	)
+
 	table.DescribeTable("it should", func(given []v1.Volume, expected []v1.Volume) {
 		vmi.Spec.Volumes = given
 		vmiSpec, _ := getVMISpecMetaFromResponse()
@@ -28,6 +28,7 @@ import (
 
 	"github.com/golang/glog"
 	"k8s.io/api/admission/v1beta1"
+	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
@@ -131,6 +132,17 @@ func newInformers() *Informers {
 	}
 }
 
+// IsKubeVirtServiceAccount checks if a request was sent by the given service account
+// of the namespace KubeVirt is installed in
+func IsKubeVirtServiceAccount(userInfo authv1.UserInfo, serviceAccount string) bool {
+	namespace, err := clientutil.GetNamespace()
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to determine the KubeVirt namespace")
+		return false
+	}
+	return userInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+}
+
 // GetAdmissionReview
 func GetAdmissionReview(r *http.Request) (*v1beta1.AdmissionReview, error) {
 	var body []byte
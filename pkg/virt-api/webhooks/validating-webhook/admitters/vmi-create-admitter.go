@@ -426,6 +426,121 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
+	// Validate CPU hotplug
+	if spec.Domain.CPU != nil && spec.Domain.CPU.MaxSockets > 0 {
+		if !config.HotplugCPUMemoryEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "HotplugCPUMemory feature gate is not enabled",
+				Field:   field.Child("domain", "cpu", "maxSockets").String(),
+			})
+		}
+		if spec.Domain.CPU.Sockets == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be provided when %s is set",
+					field.Child("domain", "cpu", "sockets").String(),
+					field.Child("domain", "cpu", "maxSockets").String(),
+				),
+				Field: field.Child("domain", "cpu", "sockets").String(),
+			})
+		} else if spec.Domain.CPU.Sockets > spec.Domain.CPU.MaxSockets {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%d' must be equal to or less than %s '%d'",
+					field.Child("domain", "cpu", "sockets").String(),
+					spec.Domain.CPU.Sockets,
+					field.Child("domain", "cpu", "maxSockets").String(),
+					spec.Domain.CPU.MaxSockets,
+				),
+				Field: field.Child("domain", "cpu", "sockets").String(),
+			})
+		}
+		if spec.Domain.CPU.DedicatedCPUPlacement {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be set when DedicatedCPUPlacement is true",
+					field.Child("domain", "cpu", "maxSockets").String(),
+				),
+				Field: field.Child("domain", "cpu", "maxSockets").String(),
+			})
+		}
+	}
+
+	// Validate memory hotplug
+	if spec.Domain.Memory != nil && spec.Domain.Memory.MaxGuest != nil {
+		if !config.HotplugCPUMemoryEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "HotplugCPUMemory feature gate is not enabled",
+				Field:   field.Child("domain", "memory", "maxGuest").String(),
+			})
+		}
+		if spec.Domain.Memory.Guest == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be provided when %s is set",
+					field.Child("domain", "memory", "guest").String(),
+					field.Child("domain", "memory", "maxGuest").String(),
+				),
+				Field: field.Child("domain", "memory", "guest").String(),
+			})
+		} else if spec.Domain.Memory.Guest.Cmp(*spec.Domain.Memory.MaxGuest) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' must be equal to or less than %s '%s'",
+					field.Child("domain", "memory", "guest").String(),
+					spec.Domain.Memory.Guest,
+					field.Child("domain", "memory", "maxGuest").String(),
+					spec.Domain.Memory.MaxGuest,
+				),
+				Field: field.Child("domain", "memory", "guest").String(),
+			})
+		}
+	}
+
+	// Validate guest NUMA topology
+	if spec.Domain.CPU != nil && spec.Domain.CPU.NUMA != nil && spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+		numaField := field.Child("domain", "cpu", "numa", "guestMappingPassthrough")
+		if !config.NUMAEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "NUMA feature gate is not enabled",
+				Field:   numaField.String(),
+			})
+		}
+		if !spec.Domain.CPU.DedicatedCPUPlacement {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be true when %s is set",
+					field.Child("domain", "cpu", "dedicatedCpuPlacement").String(),
+					numaField.String(),
+				),
+				Field: field.Child("domain", "cpu", "dedicatedCpuPlacement").String(),
+			})
+		}
+		if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be provided when %s is set",
+					field.Child("domain", "memory", "hugepages").String(),
+					numaField.String(),
+				),
+				Field: field.Child("domain", "memory", "hugepages").String(),
+			})
+		}
+		if spec.Domain.Memory != nil && spec.Domain.Memory.MaxGuest != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be set when %s is set",
+					field.Child("domain", "memory", "maxGuest").String(),
+					numaField.String(),
+				),
+				Field: field.Child("domain", "memory", "maxGuest").String(),
+			})
+		}
+	}
+
 	// Validate CPU Feature Policies
 	if spec.Domain.CPU != nil && spec.Domain.CPU.Features != nil {
 		isValidPolicy := func(policy string) bool {
@@ -519,6 +634,9 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
+	causes = append(causes, validateFilesystems(field.Child("domain", "devices", "filesystems"), spec, config)...)
+	causes = append(causes, validateNoMedia(field.Child("domain", "devices", "disks"), spec)...)
+
 	if len(spec.Networks) > 0 && len(spec.Domain.Devices.Interfaces) > 0 {
 		multusDefaultCount := 0
 		multusExists := false
@@ -534,6 +652,23 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			if network.Pod != nil {
 				cniTypesCount++
 				podExists = true
+
+				if network.Pod.VMIPv6NetworkCIDR != "" {
+					if ip, ipNet, err := net.ParseCIDR(network.Pod.VMIPv6NetworkCIDR); err != nil || ip.To4() != nil {
+						causes = append(causes, metav1.StatusCause{
+							Type:    metav1.CauseTypeFieldValueInvalid,
+							Message: fmt.Sprintf("%s is not a valid IPv6 CIDR", network.Pod.VMIPv6NetworkCIDR),
+							Field:   field.Child("networks").Index(idx).Child("pod", "vmIPv6NetworkCIDR").String(),
+						})
+					} else if ones, _ := ipNet.Mask.Size(); ones > 126 {
+						// the network needs room for the gateway and the vm address
+						causes = append(causes, metav1.StatusCause{
+							Type:    metav1.CauseTypeFieldValueInvalid,
+							Message: fmt.Sprintf("IPv6 CIDR %s is too small, the prefix length must not exceed 126", network.Pod.VMIPv6NetworkCIDR),
+							Field:   field.Child("networks").Index(idx).Child("pod", "vmIPv6NetworkCIDR").String(),
+						})
+					}
+				}
 			}
 
 			if network.NetworkSource.Multus != nil {
@@ -850,6 +985,14 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			})
 		}
 	}
+	if ballooning := spec.Domain.Devices.Ballooning; ballooning != nil && ballooning.Model != "" && ballooning.Model != "virtio" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("Memory balloon device can have only virtio model."),
+			Field:   field.Child("domain", "devices", "ballooning", "model").String(),
+		})
+	}
+
 	_, requestOk := spec.Domain.Resources.Requests[k8sv1.ResourceCPU]
 	_, limitOK := spec.Domain.Resources.Limits[k8sv1.ResourceCPU]
 	isCPUResourcesSet := (requestOk == true) || (limitOK == true)
@@ -886,55 +1029,19 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
-	if spec.ReadinessProbe != nil {
-		if spec.ReadinessProbe.HTTPGet != nil && spec.ReadinessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("readinessProbe").String()),
-				Field:   field.Child("readinessProbe").String(),
-			})
-		} else if spec.ReadinessProbe.HTTPGet == nil && spec.ReadinessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("readinessProbe", "tcpSocket").String(),
-					field.Child("readinessProbe", "httpGet").String(),
-					field.Child("readinessProbe").String(),
-				),
-				Field: field.Child("readinessProbe").String(),
-			})
-		}
-	}
-
-	if spec.LivenessProbe != nil {
-		if spec.LivenessProbe.HTTPGet != nil && spec.LivenessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("livenessProbe").String()),
-				Field:   field.Child("livenessProbe").String(),
-			})
-		} else if spec.LivenessProbe.HTTPGet == nil && spec.LivenessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("livenessProbe", "tcpSocket").String(),
-					field.Child("livenessProbe", "httpGet").String(),
-					field.Child("livenessProbe").String(),
-				),
-				Field: field.Child("livenessProbe").String(),
-			})
-		}
-	}
+	causes = append(causes, validateProbe(field.Child("readinessProbe"), spec.ReadinessProbe)...)
+	causes = append(causes, validateProbe(field.Child("livenessProbe"), spec.LivenessProbe)...)
 
+	// Guest agent probes are run by virt-handler and don't need to reach the guest over the network
 	if !podNetworkInterfacePresent {
-		if spec.LivenessProbe != nil {
+		if spec.LivenessProbe != nil && !spec.LivenessProbe.IsGuestAgentProbe() {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("livenessProbe").String()),
 				Field:   field.Child("livenessProbe").String(),
 			})
 		}
-		if spec.ReadinessProbe != nil {
+		if spec.ReadinessProbe != nil && !spec.ReadinessProbe.IsGuestAgentProbe() {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("readinessProbe").String()),
@@ -970,6 +1077,47 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	return causes
 }
 
+func validateProbe(field *k8sfield.Path, probe *v1.Probe) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if probe == nil {
+		return causes
+	}
+
+	numActions := 0
+	for _, set := range []bool{probe.HTTPGet != nil, probe.TCPSocket != nil, probe.Exec != nil, probe.GuestAgentPing != nil} {
+		if set {
+			numActions++
+		}
+	}
+
+	if numActions > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have exactly one probe type set", field.String()),
+			Field:   field.String(),
+		})
+	} else if numActions == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("either %s, %s, %s or %s must be set if a %s is specified",
+				field.Child("tcpSocket").String(),
+				field.Child("httpGet").String(),
+				field.Child("exec").String(),
+				field.Child("guestAgentPing").String(),
+				field.String(),
+			),
+			Field: field.String(),
+		})
+	} else if probe.Exec != nil && len(probe.Exec.Command) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must not be empty", field.Child("exec", "command").String()),
+			Field:   field.Child("exec", "command").String(),
+		})
+	}
+	return causes
+}
+
 // ValidateVirtualMachineInstanceMandatoryFields should be invoked after all defaults and presets are applied.
 // It is only meant to be used for VMI reviews, not if they are templates on other objects
 func ValidateVirtualMachineInstanceMandatoryFields(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
@@ -1015,6 +1163,16 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 		})
 	}
 
+	// Hotplugged volumes are only recorded by virt-api on running VMIs
+	if _, exists := annotations[v1.HotplugVolumesAnnotation]; exists {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is reserved for hotplugged volumes and can not be set",
+				field.Child("annotations", v1.HotplugVolumesAnnotation).String()),
+			Field: field.Child("annotations").String(),
+		})
+	}
+
 	return causes
 }
 
@@ -1445,6 +1603,92 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	return causes
 }
 
+// validateFilesystems makes sure that every filesystem is backed by a volume which can be shared through virtio-fs
+func validateFilesystems(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	// virtiofsd needs CAP_SYS_ADMIN in the compute container
+	if len(spec.Domain.Devices.Filesystems) > 0 && !config.VirtIOFSEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VirtIOFSGate),
+			Field:   field.String(),
+		})
+	}
+
+	diskNames := map[string]bool{}
+	for _, disk := range spec.Domain.Devices.Disks {
+		diskNames[disk.Name] = true
+	}
+	filesystemNames := map[string]int{}
+
+	for idx, filesystem := range spec.Domain.Devices.Filesystems {
+		if otherIdx, exists := filesystemNames[filesystem.Name]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s and %s must not have the same Name.", field.Index(idx).String(), field.Index(otherIdx).String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		} else {
+			filesystemNames[filesystem.Name] = idx
+		}
+
+		if filesystem.Virtiofs == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be set.", field.Index(idx).Child("virtiofs").String()),
+				Field:   field.Index(idx).Child("virtiofs").String(),
+			})
+		}
+
+		if diskNames[filesystem.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' is already used by a disk.", field.Index(idx).Child("name").String(), filesystem.Name),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+
+		var volume *v1.Volume
+		for i := range spec.Volumes {
+			if spec.Volumes[i].Name == filesystem.Name {
+				volume = &spec.Volumes[i]
+			}
+		}
+		if volume == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' not found.", field.Index(idx).Child("name").String(), filesystem.Name),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		} else if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil && volume.ConfigMap == nil &&
+			volume.Secret == nil && volume.ServiceAccount == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only be mapped to a PersistentVolumeClaim, DataVolume, ConfigMap, Secret or ServiceAccount volume.", field.Index(idx).String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+	}
+	return causes
+}
+
+// validateNoMedia rejects requested media, they can only be set on running VirtualMachineInstances
+func validateNoMedia(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Medium != nil {
+			mediumField := field.Index(idx).Child("medium")
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s can only be set by the insertmedia and ejectmedia subresources.", mediumField.String()),
+				Field:   mediumField.String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateDevices(field *k8sfield.Path, devices *v1.Devices) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks)...)
@@ -1466,6 +1710,81 @@ func getNumberOfPodInterfaces(spec *v1.VirtualMachineInstanceSpec) int {
 	return nPodInterfaces
 }
 
+// ValidateDiskIOTune checks the I/O limits of a disk, which are either set on the disk itself or
+// come from the cluster default
+func ValidateDiskIOTune(field *k8sfield.Path, ioTune *v1.DiskIOTune) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	// libvirt rejects total limits combined with read or write limits, and bursts need a limit to burst from
+	limits := []struct {
+		name     string
+		total    int64
+		read     int64
+		write    int64
+		totalMax int64
+		readMax  int64
+		writeMax int64
+	}{
+		{name: "BytesSec", total: ioTune.TotalBytesSec, read: ioTune.ReadBytesSec, write: ioTune.WriteBytesSec,
+			totalMax: ioTune.TotalBytesSecMax, readMax: ioTune.ReadBytesSecMax, writeMax: ioTune.WriteBytesSecMax},
+		{name: "IOPSSec", total: ioTune.TotalIOPSSec, read: ioTune.ReadIOPSSec, write: ioTune.WriteIOPSSec,
+			totalMax: ioTune.TotalIOPSSecMax, readMax: ioTune.ReadIOPSSecMax, writeMax: ioTune.WriteIOPSSecMax},
+	}
+	for _, limit := range limits {
+		values := []struct {
+			kind  string
+			value int64
+			max   int64
+		}{
+			{"total", limit.total, limit.totalMax},
+			{"read", limit.read, limit.readMax},
+			{"write", limit.write, limit.writeMax},
+		}
+		for _, v := range values {
+			valueField := field.Child(v.kind + limit.name)
+			maxField := field.Child(v.kind + limit.name + "Max")
+			if v.value < 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must not be negative", valueField.String()),
+					Field:   valueField.String(),
+				})
+			}
+			if v.max < 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must not be negative", maxField.String()),
+					Field:   maxField.String(),
+				})
+			} else if v.max > 0 && v.max < v.value {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be greater than or equal to %s", maxField.String(), valueField.String()),
+					Field:   maxField.String(),
+				})
+			} else if v.max > 0 && v.value == 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: fmt.Sprintf("%s requires %s to be set", maxField.String(), valueField.String()),
+					Field:   maxField.String(),
+				})
+			}
+		}
+		if (limit.total != 0 || limit.totalMax != 0) && (limit.read != 0 || limit.write != 0 || limit.readMax != 0 || limit.writeMax != 0) {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can't be combined with %s or %s",
+					field.Child("total"+limit.name).String(),
+					field.Child("read"+limit.name).String(),
+					field.Child("write"+limit.name).String(),
+				),
+				Field: field.Child("total" + limit.name).String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	nameMap := make(map[string]int)
@@ -1617,6 +1936,64 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// Verify the I/O mode is valid and native I/O is not combined with the host cache
+		if disk.IO != "" && disk.IO != v1.IOThreads && disk.IO != v1.IONative {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("io").String(), disk.IO),
+				Field:   field.Index(idx).Child("io").String(),
+			})
+		} else if disk.IO == v1.IONative && disk.Cache == v1.CacheWriteThrough {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s %s requires %s to be %s", field.Index(idx).Child("io").String(), disk.IO, field.Index(idx).Child("cache").String(), v1.CacheNone),
+				Field:   field.Index(idx).Child("io").String(),
+			})
+		}
+
+		// Verify if the error policy is valid
+		switch disk.ErrorPolicy {
+		case "", v1.DiskErrorPolicyStop, v1.DiskErrorPolicyReport, v1.DiskErrorPolicyIgnore, v1.DiskErrorPolicyENOSPC:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("errorPolicy").String(), disk.ErrorPolicy),
+				Field:   field.Index(idx).Child("errorPolicy").String(),
+			})
+		}
+
+		// Verify if the discard mode is valid
+		if disk.Discard != "" && disk.Discard != v1.DiscardUnmap && disk.Discard != v1.DiscardIgnore {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("discard").String(), disk.Discard),
+				Field:   field.Index(idx).Child("discard").String(),
+			})
+		}
+
+		// Verify if zero detection is valid, turning zeroes into discards needs discards to be passed on
+		switch disk.DetectZeroes {
+		case "", v1.DetectZeroesOff, v1.DetectZeroesOn:
+		case v1.DetectZeroesUnmap:
+			if disk.Discard != v1.DiscardUnmap {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s %s requires %s to be %s", field.Index(idx).Child("detectZeroes").String(), disk.DetectZeroes, field.Index(idx).Child("discard").String(), v1.DiscardUnmap),
+					Field:   field.Index(idx).Child("detectZeroes").String(),
+				})
+			}
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("detectZeroes").String(), disk.DetectZeroes),
+				Field:   field.Index(idx).Child("detectZeroes").String(),
+			})
+		}
+
+		if disk.IOTune != nil {
+			causes = append(causes, ValidateDiskIOTune(field.Index(idx).Child("ioTune"), disk.IOTune)...)
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
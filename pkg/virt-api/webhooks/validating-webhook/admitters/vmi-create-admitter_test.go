@@ -166,7 +166,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			}
 			resp := vmiCreateAdmitter.Admit(ar)
 			Expect(resp.Allowed).To(BeFalse())
-			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket or spec.readinessProbe.httpGet must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket or spec.livenessProbe.httpGet must be set if a spec.livenessProbe is specified`))
+			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket, spec.readinessProbe.httpGet, spec.readinessProbe.exec or spec.readinessProbe.guestAgentPing must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket, spec.livenessProbe.httpGet, spec.livenessProbe.exec or spec.livenessProbe.guestAgentPing must be set if a spec.livenessProbe is specified`))
 		})
 		It("should reject probes with more than one action per probe configured", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -260,6 +260,47 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(Equal(`spec.livenessProbe is only allowed if the Pod Network is attached, spec.readinessProbe is only allowed if the Pod Network is attached`))
 		})
+		It("should accept guest agent probes if no Pod Network is present", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.ReadinessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				},
+			}
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{Command: []string{"cat", "/tmp/healthy"}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject exec probes without a command", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.livenessProbe.exec.command"))
+		})
+		It("should reject a guest agent probe combined with another probe type", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.ReadinessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+					Exec:           &k8sv1.ExecAction{Command: []string{"true"}},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("spec.readinessProbe must have exactly one probe type set"))
+		})
 	})
 
 	It("should accept valid vmi spec on create", func() {
@@ -366,6 +407,18 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				virtconfig.SidecarGate,
 			),
 		)
+
+		It("should reject the hotplug volumes annotation", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.ObjectMeta = metav1.ObjectMeta{
+				Annotations: map[string]string{v1.HotplugVolumesAnnotation: "rootdisk"},
+			}
+			causes := ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+			Expect(causes[0].Message).To(ContainSubstring("is reserved for hotplugged volumes"))
+		})
 	})
 
 	Context("with VirtualMachineInstance spec", func() {
@@ -524,6 +577,21 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[1].Field).To(Equal("fake.domain.devices.disks[0]"))
 		})
 
+		table.DescribeTable("should verify the memory balloon model",
+			func(model string, expectedErrors int) {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Ballooning = &v1.Ballooning{Model: model}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(len(causes)).To(Equal(expectedErrors))
+				if expectedErrors > 0 {
+					Expect(causes[0].Field).To(Equal("fake.domain.devices.ballooning.model"))
+				}
+			},
+			table.Entry("and accept the default model", "", 0),
+			table.Entry("and accept the virtio model", "virtio", 0),
+			table.Entry("and reject other models", "xen", 1),
+		)
+
 		table.DescribeTable("should verify input device",
 			func(input v1.Input, expectedErrors int, expectedErrorTypes []string, expectMessage string) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -1396,6 +1464,31 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1), "unexpected number of errors")
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].ports[0].name"))
 		})
+		table.DescribeTable("should validate the IPv6 vm network CIDR", func(cidr string, expectedCauses int) {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Masquerade: &v1.InterfaceMasquerade{},
+				},
+			}}
+			vm.Spec.Networks = []v1.Network{{
+				Name:          "default",
+				NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMIPv6NetworkCIDR: cidr}},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.networks[0].pod.vmIPv6NetworkCIDR"))
+			}
+		},
+			table.Entry("and accept an empty CIDR", "", 0),
+			table.Entry("and accept an IPv6 CIDR", "fd10:1234::/64", 0),
+			table.Entry("and reject an IPv4 CIDR", "10.0.2.0/24", 1),
+			table.Entry("and reject an address without prefix length", "fd10:1234::1", 1),
+			table.Entry("and reject a network without room for the gateway and the vm", "fd10:1234::/127", 1),
+		)
 		It("should reject networks with a pod network source and slirp interface with bad protocol type", func() {
 			enableSlirpInterface()
 			vm := v1.NewMinimalVMI("testvm")
@@ -1896,6 +1989,98 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with CPU and memory hotplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			guest := resource.MustParse("1Gi")
+			maxGuest := resource.MustParse("4Gi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+		})
+		It("should accept maximums if the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject maximums if the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(2))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+			Expect(causes[1].Field).To(Equal("fake.domain.memory.maxGuest"))
+		})
+		It("should reject more sockets than the maximum", func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			vmi.Spec.Domain.CPU.Sockets = 6
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.sockets"))
+		})
+		It("should reject maximum sockets with dedicated CPUs", func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+			vmi.Spec.Domain.Memory = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.maxSockets"))
+		})
+		It("should reject a maximum guest memory without guest memory", func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			vmi.Spec.Domain.Memory.Guest = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.guest"))
+		})
+		It("should reject more guest memory than the maximum", func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			guest := resource.MustParse("8Gi")
+			vmi.Spec.Domain.Memory.Guest = &guest
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.guest"))
+		})
+	})
+
+	Context("with guest NUMA passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores:                 2,
+				DedicatedCPUPlacement: true,
+				NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+			}
+		})
+		It("should accept it with dedicated CPUs and hugepages if the feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject it if the feature gate is disabled", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+		})
+		It("should reject it without dedicated CPUs", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.dedicatedCpuPlacement"))
+		})
+		It("should reject it without hugepages", func() {
+			enableFeatureGate(virtconfig.NUMAGate)
+			vmi.Spec.Domain.Memory = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.hugepages"))
+		})
+	})
+
 	Context("with CPU features", func() {
 		It("should accept valid CPU feature policies", func() {
 			vmi := v1.NewMinimalVMI("testvm")
@@ -2167,6 +2352,69 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("fake[0].cache has invalid value unspported"))
 		})
 
+		It("should accept valid disk driver settings", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				Cache: v1.CacheNone, IO: v1.IONative, ErrorPolicy: v1.DiskErrorPolicyStop, Discard: v1.DiscardUnmap, DetectZeroes: v1.DetectZeroesUnmap})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
+		table.DescribeTable("should reject invalid disk driver settings", func(disk v1.Disk, field string, message string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			disk.Name = "testdisk"
+			disk.DiskDevice = v1.DiskDevice{Disk: &v1.DiskTarget{}}
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, disk)
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+			Expect(causes[0].Message).To(Equal(message))
+		},
+			table.Entry("with an unknown I/O mode", v1.Disk{IO: "fast"},
+				"fake[0].io", "fake[0].io has invalid value fast"),
+			table.Entry("with native I/O and the host cache", v1.Disk{IO: v1.IONative, Cache: v1.CacheWriteThrough},
+				"fake[0].io", "fake[0].io native requires fake[0].cache to be none"),
+			table.Entry("with an unknown error policy", v1.Disk{ErrorPolicy: "retry"},
+				"fake[0].errorPolicy", "fake[0].errorPolicy has invalid value retry"),
+			table.Entry("with an unknown discard mode", v1.Disk{Discard: "trim"},
+				"fake[0].discard", "fake[0].discard has invalid value trim"),
+			table.Entry("with zeroes turned into discards which are ignored", v1.Disk{DetectZeroes: v1.DetectZeroesUnmap},
+				"fake[0].detectZeroes", "fake[0].detectZeroes unmap requires fake[0].discard to be unmap"),
+		)
+
+		It("should accept disk I/O limits with bursts", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				IOTune: &v1.DiskIOTune{TotalBytesSec: 1000, TotalBytesSecMax: 2000, ReadIOPSSec: 100, WriteIOPSSec: 50, WriteIOPSSecMax: 50}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
+		table.DescribeTable("should reject invalid disk I/O limits", func(ioTune v1.DiskIOTune, field string, message string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, IOTune: &ioTune})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+			Expect(causes[0].Message).To(Equal(message))
+		},
+			table.Entry("with a negative limit", v1.DiskIOTune{ReadBytesSec: -1},
+				"fake[0].ioTune.readBytesSec", "fake[0].ioTune.readBytesSec must not be negative"),
+			table.Entry("with a burst below the limit", v1.DiskIOTune{TotalIOPSSec: 100, TotalIOPSSecMax: 50},
+				"fake[0].ioTune.totalIOPSSecMax", "fake[0].ioTune.totalIOPSSecMax must be greater than or equal to fake[0].ioTune.totalIOPSSec"),
+			table.Entry("with a burst without a limit", v1.DiskIOTune{WriteBytesSecMax: 50},
+				"fake[0].ioTune.writeBytesSecMax", "fake[0].ioTune.writeBytesSecMax requires fake[0].ioTune.writeBytesSec to be set"),
+			table.Entry("with a total limit combined with a read limit", v1.DiskIOTune{TotalIOPSSec: 100, ReadIOPSSec: 50},
+				"fake[0].ioTune.totalIOPSSec", "fake[0].ioTune.totalIOPSSec can't be combined with fake[0].ioTune.readIOPSSec or fake[0].ioTune.writeIOPSSec"),
+		)
+
 		It("should reject disk count > arrayLenMax", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			for i := 0; i <= arrayLenMax; i++ {
@@ -2240,6 +2488,88 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 	})
 
+	Context("with filesystems", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "config",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: k8sv1.LocalObjectReference{Name: "test-config"},
+						},
+					},
+				},
+			}
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "config", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			enableFeatureGate(virtconfig.VirtIOFSGate)
+		})
+
+		It("should reject filesystems if the feature gate is disabled", func() {
+			disableFeatureGates()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystems"))
+			Expect(causes[0].Message).To(Equal("ExperimentalVirtiofsSupport feature gate is not enabled"))
+		})
+
+		It("should accept a filesystem backed by a ConfigMap", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject a filesystem without a matching volume", func() {
+			vmi.Spec.Domain.Devices.Filesystems[0].Name = "missing"
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystems[0].name"))
+			Expect(causes[0].Message).To(Equal("fake.domain.devices.filesystems[0].name 'missing' not found."))
+		})
+
+		It("should reject a filesystem backed by an unsupported volume", func() {
+			vmi.Spec.Volumes[0].VolumeSource = v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("can only be mapped to a PersistentVolumeClaim, DataVolume, ConfigMap, Secret or ServiceAccount volume"))
+		})
+
+		It("should reject a volume used as disk and filesystem", func() {
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "config"}}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("fake.domain.devices.filesystems[0].name 'config' is already used by a disk."))
+		})
+
+		It("should reject duplicate filesystems and filesystems without a type", func() {
+			vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{Name: "config"})
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[1].Field).To(Equal("fake.domain.devices.filesystems[1].virtiofs"))
+		})
+	})
+
+	It("should reject requested media", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}}}
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: "cdrom",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "cdrom"},
+			},
+		}}
+		medium := ""
+		vmi.Spec.Domain.Devices.Disks[0].Medium = &medium
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].medium"))
+		Expect(causes[0].Message).To(Equal("fake.domain.devices.disks[0].medium can only be set by the insertmedia and ejectmedia subresources."))
+	})
+
 	Context("with bootloader", func() {
 		It("should accept empty bootloader setting", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -20,16 +20,24 @@
 package admitters
 
 import (
+	"fmt"
 	"reflect"
 
 	"k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
+// apiServerServiceAccount is the service account of virt-api, the only one which may record hotplugged volumes
+const apiServerServiceAccount = "kubevirt-apiserver"
+
 type VMIUpdateAdmitter struct {
+	ClusterConfig *virtconfig.ClusterConfig
 }
 
 func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -43,17 +51,313 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 		return webhooks.ToAdmissionResponseError(err)
 	}
 
-	// Reject VMI update if VMI spec changed
-	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
+	// Only virt-api may record hotplugged volumes, otherwise users could mark any volume as hotplugged
+	if newVMI.Annotations[v1.HotplugVolumesAnnotation] != oldVMI.Annotations[v1.HotplugVolumesAnnotation] &&
+		!webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo, apiServerServiceAccount) {
 		return webhooks.ToAdmissionResponse([]metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueNotSupported,
-				Message: "update of VMI object is restricted",
+				Message: fmt.Sprintf("annotation %s can only be changed by the addvolume and removevolume subresources", v1.HotplugVolumesAnnotation),
+				Field:   k8sfield.NewPath("metadata", "annotations").String(),
 			},
 		})
 	}
 
+	// Only virt-api may change media, otherwise users could bypass the insertmedia and ejectmedia subresources
+	if !reflect.DeepEqual(getMedia(&newVMI.Spec), getMedia(&oldVMI.Spec)) &&
+		!webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo, apiServerServiceAccount) {
+		return webhooks.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "media can only be changed by the insertmedia and ejectmedia subresources",
+				Field:   k8sfield.NewPath("spec", "domain", "devices", "disks").String(),
+			},
+		})
+	}
+
+	// Reject VMI update if VMI spec changed, except for hotplugging and unplugging volumes,
+	// for changing media and for scaling up the sockets and the guest memory
+	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
+		var causes []metav1.StatusCause
+		newSpec := newVMI.Spec.DeepCopy()
+		if admitter.ClusterConfig.HotplugCPUMemoryEnabled() {
+			causes = append(causes, admitHotplugCPUMemory(&newVMI.Spec, &oldVMI.Spec)...)
+			revertCPUMemory(newSpec, &oldVMI.Spec)
+		}
+		revertMedia(newSpec, &oldVMI.Spec)
+
+		if !reflect.DeepEqual(newSpec, &oldVMI.Spec) {
+			if !admitter.ClusterConfig.HotplugVolumesEnabled() || !onlyVolumesChanged(newSpec, &oldVMI.Spec) {
+				return webhooks.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueNotSupported,
+						Message: "update of VMI object is restricted",
+					},
+				})
+			}
+			causes = append(causes, admitHotplugVolumes(newVMI, oldVMI)...)
+		}
+
+		if len(causes) == 0 {
+			// media are only accepted on updates from the insertmedia and ejectmedia subresources
+			specWithoutMedia := newVMI.Spec.DeepCopy()
+			for i := range specWithoutMedia.Domain.Devices.Disks {
+				specWithoutMedia.Domain.Devices.Disks[i].Medium = nil
+			}
+			causes = ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), specWithoutMedia, admitter.ClusterConfig)
+			causes = append(causes, validateMedia(k8sfield.NewPath("spec", "domain", "devices", "disks"), &newVMI.Spec)...)
+		}
+		if len(causes) > 0 {
+			return webhooks.ToAdmissionResponse(causes)
+		}
+	}
+
 	reviewResponse := v1beta1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 	return &reviewResponse
 }
+
+// onlyVolumesChanged checks if the specs only differ in their volumes and disks
+func onlyVolumesChanged(newSpec *v1.VirtualMachineInstanceSpec, oldSpec *v1.VirtualMachineInstanceSpec) bool {
+	newSpecCopy := newSpec.DeepCopy()
+	oldSpecCopy := oldSpec.DeepCopy()
+	newSpecCopy.Volumes = nil
+	newSpecCopy.Domain.Devices.Disks = nil
+	oldSpecCopy.Volumes = nil
+	oldSpecCopy.Domain.Devices.Disks = nil
+	return reflect.DeepEqual(newSpecCopy, oldSpecCopy)
+}
+
+// admitHotplugVolumes makes sure that only hotplugged volumes and their disks are added or removed,
+// and that all others stay untouched
+func admitHotplugVolumes(newVMI *v1.VirtualMachineInstance, oldVMI *v1.VirtualMachineInstance) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	oldVolumes := map[string]v1.Volume{}
+	for _, volume := range oldVMI.Spec.Volumes {
+		oldVolumes[volume.Name] = volume
+	}
+	newVolumes := map[string]v1.Volume{}
+	for _, volume := range newVMI.Spec.Volumes {
+		newVolumes[volume.Name] = volume
+	}
+	// media are admitted on their own
+	oldDisks := map[string]v1.Disk{}
+	for _, disk := range oldVMI.Spec.Domain.Devices.Disks {
+		disk.Medium = nil
+		oldDisks[disk.Name] = disk
+	}
+	newDisks := map[string]v1.Disk{}
+	newMedia := getMedia(&newVMI.Spec)
+	for _, disk := range newVMI.Spec.Domain.Devices.Disks {
+		disk.Medium = nil
+		newDisks[disk.Name] = disk
+	}
+
+	for name, volume := range oldVolumes {
+		newVolume, exists := newVolumes[name]
+		if exists && reflect.DeepEqual(volume, newVolume) && reflect.DeepEqual(oldDisks[name], newDisks[name]) {
+			continue
+		}
+		if !hotplugdisk.IsHotplugVolume(oldVMI, name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s was not hotplugged and can not be modified or removed", name),
+				Field:   k8sfield.NewPath("spec", "volumes").String(),
+			})
+		} else if exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hotplugged volume %s can not be modified", name),
+				Field:   k8sfield.NewPath("spec", "volumes").String(),
+			})
+		}
+	}
+
+	for i, volume := range newVMI.Spec.Volumes {
+		if _, exists := oldVolumes[volume.Name]; exists {
+			continue
+		}
+		field := k8sfield.NewPath("spec", "volumes").Index(i)
+		if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hotplugged volume %s must be a persistentVolumeClaim or a dataVolume", volume.Name),
+				Field:   field.String(),
+			})
+		}
+		if !hotplugdisk.IsHotplugVolume(newVMI, volume.Name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s must be added through the addvolume subresource", volume.Name),
+				Field:   field.String(),
+			})
+		}
+		disk, exists := newDisks[volume.Name]
+		if exists && (disk.Disk == nil || disk.Disk.Bus != "scsi") {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hotplugged disk %s must be a disk on the scsi bus", volume.Name),
+				Field:   k8sfield.NewPath("spec", "domain", "devices", "disks").String(),
+			})
+		} else if !exists && !isMedium(newMedia, volume.Name) {
+			// a volume without a disk is only hotplugged to be inserted as medium
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hotplugged volume %s needs a disk or has to be inserted as medium", volume.Name),
+				Field:   field.String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// validateMedia makes sure that only CD-ROMs and floppies have a medium, and that a medium is either
+// the volume of the drive itself or a volume without a disk or filesystem which is in no other drive
+func validateMedia(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	volumeNames := map[string]bool{}
+	for _, volume := range spec.Volumes {
+		volumeNames[volume.Name] = true
+	}
+	usedVolumes := map[string]bool{}
+	for _, disk := range spec.Domain.Devices.Disks {
+		usedVolumes[disk.Name] = true
+	}
+	for _, filesystem := range spec.Domain.Devices.Filesystems {
+		usedVolumes[filesystem.Name] = true
+	}
+	media := map[string]int{}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Medium == nil {
+			continue
+		}
+		mediumField := field.Index(idx).Child("medium")
+		medium := *disk.Medium
+		switch {
+		case disk.CDRom == nil && disk.Floppy == nil:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only be set on a CD-ROM or floppy.", mediumField.String()),
+				Field:   mediumField.String(),
+			})
+		case medium == "" || medium == disk.Name:
+			// the drive is empty or has its own medium
+		case !volumeNames[medium]:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' not found.", mediumField.String(), medium),
+				Field:   mediumField.String(),
+			})
+		case usedVolumes[medium]:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' is already used by a disk or filesystem.", mediumField.String(), medium),
+				Field:   mediumField.String(),
+			})
+		default:
+			if otherIdx, exists := media[medium]; exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueDuplicate,
+					Message: fmt.Sprintf("%s and %s must not have the same medium.", field.Index(idx).String(), field.Index(otherIdx).String()),
+					Field:   mediumField.String(),
+				})
+			} else {
+				media[medium] = idx
+			}
+		}
+	}
+	return causes
+}
+
+// getMedia returns the medium requested for each CD-ROM and floppy, by drive name
+func getMedia(spec *v1.VirtualMachineInstanceSpec) map[string]string {
+	media := map[string]string{}
+	for i := range spec.Domain.Devices.Disks {
+		disk := &spec.Domain.Devices.Disks[i]
+		if disk.CDRom != nil || disk.Floppy != nil {
+			media[disk.Name] = hotplugdisk.GetRequestedMedium(disk)
+		}
+	}
+	return media
+}
+
+func isMedium(media map[string]string, volumeName string) bool {
+	for _, medium := range media {
+		if medium == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
+// revertMedia resets the media of the new spec to the ones of the old spec, for the drives
+// which exist in both
+func revertMedia(newSpec *v1.VirtualMachineInstanceSpec, oldSpec *v1.VirtualMachineInstanceSpec) {
+	oldMedia := map[string]*string{}
+	for _, disk := range oldSpec.Domain.Devices.Disks {
+		oldMedia[disk.Name] = disk.Medium
+	}
+	for i := range newSpec.Domain.Devices.Disks {
+		disk := &newSpec.Domain.Devices.Disks[i]
+		if medium, exists := oldMedia[disk.Name]; exists {
+			disk.Medium = medium
+		}
+	}
+}
+
+// admitHotplugCPUMemory makes sure that sockets and guest memory are only increased,
+// and only up to the maximum set on the VirtualMachineInstance
+func admitHotplugCPUMemory(newSpec *v1.VirtualMachineInstanceSpec, oldSpec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if newSpec.Domain.CPU != nil && oldSpec.Domain.CPU != nil && newSpec.Domain.CPU.Sockets != oldSpec.Domain.CPU.Sockets {
+		field := k8sfield.NewPath("spec", "domain", "cpu", "sockets")
+		if oldSpec.Domain.CPU.MaxSockets == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s can only be changed when maxSockets is set", field.String()),
+				Field:   field.String(),
+			})
+		} else if newSpec.Domain.CPU.Sockets < oldSpec.Domain.CPU.Sockets {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can not be decreased on a running VMI", field.String()),
+				Field:   field.String(),
+			})
+		}
+	}
+
+	if newSpec.Domain.Memory != nil && oldSpec.Domain.Memory != nil && !reflect.DeepEqual(newSpec.Domain.Memory.Guest, oldSpec.Domain.Memory.Guest) {
+		field := k8sfield.NewPath("spec", "domain", "memory", "guest")
+		if oldSpec.Domain.Memory.MaxGuest == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s can only be changed when maxGuest is set", field.String()),
+				Field:   field.String(),
+			})
+		} else if newSpec.Domain.Memory.Guest == nil || newSpec.Domain.Memory.Guest.Cmp(*oldSpec.Domain.Memory.Guest) < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can not be decreased on a running VMI", field.String()),
+				Field:   field.String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// revertCPUMemory resets the sockets and the guest memory of the new spec to the old values,
+// so that the remaining changes can be checked separately
+func revertCPUMemory(newSpec *v1.VirtualMachineInstanceSpec, oldSpec *v1.VirtualMachineInstanceSpec) {
+	if newSpec.Domain.CPU != nil && oldSpec.Domain.CPU != nil {
+		newSpec.Domain.CPU.Sockets = oldSpec.Domain.CPU.Sockets
+	}
+	if newSpec.Domain.Memory != nil && oldSpec.Domain.Memory != nil {
+		newSpec.Domain.Memory.Guest = oldSpec.Domain.Memory.Guest
+	}
+}
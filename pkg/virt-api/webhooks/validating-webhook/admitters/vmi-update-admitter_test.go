@@ -21,20 +21,91 @@ package admitters
 
 import (
 	"encoding/json"
+	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"k8s.io/api/admission/v1beta1"
+	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Validating VMIUpdate Admitter", func() {
-	vmiUpdateAdmitter := &VMIUpdateAdmitter{}
+	config, configMapInformer, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+	vmiUpdateAdmitter := &VMIUpdateAdmitter{ClusterConfig: config}
+
+	enableFeatureGate := func(featureGate string) {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: featureGate},
+		})
+	}
+	disableFeatureGates := func() {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{})
+	}
+
+	AfterEach(func() {
+		disableFeatureGates()
+	})
+
+	apiServerUser := authv1.UserInfo{Username: "system:serviceaccount:kubevirt:kubevirt-apiserver"}
+
+	admitUpdateAs := func(userInfo authv1.UserInfo, oldVMI *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance) *v1beta1.AdmissionResponse {
+		newVMIBytes, _ := json.Marshal(newVMI)
+		oldVMIBytes, _ := json.Marshal(oldVMI)
+
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+				UserInfo:  userInfo,
+			},
+		}
+		return vmiUpdateAdmitter.Admit(ar)
+	}
+
+	admitUpdate := func(oldVMI *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance) *v1beta1.AdmissionResponse {
+		return admitUpdateAs(apiServerUser, oldVMI, newVMI)
+	}
+
+	addHotplugVolume := func(vmi *v1.VirtualMachineInstance, name string, bus string) *v1.VirtualMachineInstance {
+		newVMI := vmi.DeepCopy()
+		newVMI.Spec.Domain.Devices.Disks = append(newVMI.Spec.Domain.Devices.Disks, v1.Disk{
+			Name: name,
+			DiskDevice: v1.DiskDevice{
+				Disk: &v1.DiskTarget{Bus: bus},
+			},
+		})
+		newVMI.Spec.Volumes = append(newVMI.Spec.Volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+			},
+		})
+		newVMI.Status.VolumeStatus = append(newVMI.Status.VolumeStatus, v1.VolumeStatus{
+			Name:          name,
+			Phase:         v1.VolumePending,
+			HotplugVolume: &v1.HotplugVolumeStatus{},
+		})
+		hotplugdisk.AddHotplugVolumeName(newVMI, name)
+		return newVMI
+	}
 
 	table.DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse) {
 		input := map[string]interface{}{}
@@ -94,4 +165,296 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		Expect(len(resp.Result.Details.Causes)).To(Equal(1))
 		Expect(resp.Result.Details.Causes[0].Message).To(Equal("update of VMI object is restricted"))
 	})
+
+	Context("with the HotplugVolumes feature gate enabled", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "rootdisk"}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "test"},
+				},
+			}}
+		})
+
+		It("should allow hotplugging a volume", func() {
+			resp := admitUpdate(vmi, addHotplugVolume(vmi, "hpvolume", "scsi"))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should allow unplugging a hotplugged volume", func() {
+			hotpluggedVMI := addHotplugVolume(vmi, "hpvolume", "scsi")
+			unpluggedVMI := hotpluggedVMI.DeepCopy()
+			unpluggedVMI.Spec.Volumes = vmi.Spec.Volumes
+			unpluggedVMI.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks
+			resp := admitUpdate(hotpluggedVMI, unpluggedVMI)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject hotplugging a disk which is not on the scsi bus", func() {
+			resp := admitUpdate(vmi, addHotplugVolume(vmi, "hpvolume", "virtio"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("hotplugged disk hpvolume must be a disk on the scsi bus"))
+		})
+
+		It("should reject hotplugging a volume without a hotplug volume status", func() {
+			newVMI := addHotplugVolume(vmi, "hpvolume", "scsi")
+			newVMI.Status.VolumeStatus = nil
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("volume hpvolume must be added through the addvolume subresource"))
+		})
+
+		It("should reject hotplugging a volume which virt-api did not record", func() {
+			newVMI := addHotplugVolume(vmi, "hpvolume", "scsi")
+			delete(newVMI.Annotations, v1.HotplugVolumesAnnotation)
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("volume hpvolume must be added through the addvolume subresource"))
+		})
+
+		It("should reject users recording hotplugged volumes", func() {
+			user := authv1.UserInfo{Username: "someuser"}
+			resp := admitUpdateAs(user, vmi, addHotplugVolume(vmi, "hpvolume", "scsi"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("can only be changed by the addvolume and removevolume subresources"))
+
+			newVMI := vmi.DeepCopy()
+			hotplugdisk.AddHotplugVolumeName(newVMI, "rootdisk")
+			resp = admitUpdateAs(user, vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+		})
+
+		It("should reject users marking a volume as hotplugged through its status", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Volumes = nil
+			newVMI.Spec.Domain.Devices.Disks = nil
+			newVMI.Status.VolumeStatus = []v1.VolumeStatus{{Name: "rootdisk", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+			oldVMI := vmi.DeepCopy()
+			oldVMI.Status.VolumeStatus = newVMI.Status.VolumeStatus
+			resp := admitUpdateAs(authv1.UserInfo{Username: "someuser"}, oldVMI, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("volume rootdisk was not hotplugged and can not be modified or removed"))
+		})
+
+		It("should allow users to unplug a hotplugged volume", func() {
+			hotpluggedVMI := addHotplugVolume(vmi, "hpvolume", "scsi")
+			unpluggedVMI := hotpluggedVMI.DeepCopy()
+			unpluggedVMI.Spec.Volumes = vmi.Spec.Volumes
+			unpluggedVMI.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks
+			resp := admitUpdateAs(authv1.UserInfo{Username: "someuser"}, hotpluggedVMI, unpluggedVMI)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject removing a volume which was not hotplugged", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Volumes = nil
+			newVMI.Spec.Domain.Devices.Disks = nil
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("volume rootdisk was not hotplugged and can not be modified or removed"))
+		})
+
+		Context("with a CD-ROM", func() {
+			var iso string
+
+			BeforeEach(func() {
+				iso = "iso"
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name:       "cdrom",
+					DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}},
+				})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "cdrom",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "cdrom"},
+					},
+				})
+			})
+
+			insertHotplugVolume := func(vmi *v1.VirtualMachineInstance, name string) *v1.VirtualMachineInstance {
+				newVMI := addHotplugVolume(vmi, name, "scsi")
+				newVMI.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks
+				newVMI.Spec.Domain.Devices.Disks[1].Medium = &name
+				return newVMI
+			}
+
+			It("should allow virt-api to insert a hotplugged volume as medium", func() {
+				resp := admitUpdate(vmi, insertHotplugVolume(vmi.DeepCopy(), iso))
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			It("should allow virt-api to eject a medium", func() {
+				newVMI := vmi.DeepCopy()
+				empty := ""
+				newVMI.Spec.Domain.Devices.Disks[1].Medium = &empty
+				resp := admitUpdate(vmi, newVMI)
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			It("should reject users changing media", func() {
+				user := authv1.UserInfo{Username: "someuser"}
+				insertedVMI := insertHotplugVolume(vmi.DeepCopy(), iso)
+				oldVMI := insertedVMI.DeepCopy()
+				oldVMI.Spec.Domain.Devices.Disks[1].Medium = nil
+				resp := admitUpdateAs(user, oldVMI, insertedVMI)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes[0].Message).To(Equal("media can only be changed by the insertmedia and ejectmedia subresources"))
+
+				newVMI := vmi.DeepCopy()
+				empty := ""
+				newVMI.Spec.Domain.Devices.Disks[1].Medium = &empty
+				resp = admitUpdateAs(user, vmi, newVMI)
+				Expect(resp.Allowed).To(BeFalse())
+			})
+
+			It("should reject a medium which is not a volume", func() {
+				newVMI := vmi.DeepCopy()
+				newVMI.Spec.Domain.Devices.Disks[1].Medium = &iso
+				resp := admitUpdate(vmi, newVMI)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes[0].Message).To(Equal("spec.domain.devices.disks[1].medium 'iso' not found."))
+			})
+
+			It("should reject hotplugging a volume which has no disk and is not inserted", func() {
+				newVMI := addHotplugVolume(vmi, iso, "scsi")
+				newVMI.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks
+				resp := admitUpdate(vmi, newVMI)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes[0].Message).To(Equal("hotplugged volume iso needs a disk or has to be inserted as medium"))
+			})
+		})
+
+		It("should reject other spec changes", func() {
+			newVMI := addHotplugVolume(vmi, "hpvolume", "scsi")
+			newVMI.Spec.Hostname = "changed"
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("update of VMI object is restricted"))
+		})
+	})
+
+	Context("with media", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		newPVCVolume := func(name string) v1.Volume {
+			return v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+				{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{newPVCVolume("cdrom"), newPVCVolume("rootdisk"), newPVCVolume("iso")}
+		})
+
+		table.DescribeTable("should accept", func(medium string) {
+			vmi.Spec.Domain.Devices.Disks[0].Medium = &medium
+			causes := validateMedia(k8sfield.NewPath("fake", "domain", "devices", "disks"), &vmi.Spec)
+			Expect(causes).To(BeEmpty())
+		},
+			table.Entry("an ejected medium", ""),
+			table.Entry("the medium of the drive", "cdrom"),
+			table.Entry("a volume without a disk", "iso"),
+		)
+
+		table.DescribeTable("should reject", func(diskIndex int, medium string, message string) {
+			vmi.Spec.Domain.Devices.Disks[diskIndex].Medium = &medium
+			causes := validateMedia(k8sfield.NewPath("fake", "domain", "devices", "disks"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(fmt.Sprintf("fake.domain.devices.disks[%d].medium", diskIndex)))
+			Expect(causes[0].Message).To(Equal(message))
+		},
+			table.Entry("a medium on a disk", 1, "iso", "fake.domain.devices.disks[1].medium can only be set on a CD-ROM or floppy."),
+			table.Entry("a missing volume", 0, "missing", "fake.domain.devices.disks[0].medium 'missing' not found."),
+			table.Entry("a volume used by a disk", 0, "rootdisk", "fake.domain.devices.disks[0].medium 'rootdisk' is already used by a disk or filesystem."),
+		)
+
+		It("should reject the same medium in two drives", func() {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "cdrom2", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, newPVCVolume("cdrom2"))
+			medium := "iso"
+			vmi.Spec.Domain.Devices.Disks[0].Medium = &medium
+			vmi.Spec.Domain.Devices.Disks[2].Medium = &medium
+			causes := validateMedia(k8sfield.NewPath("fake", "domain", "devices", "disks"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[0].Message).To(Equal("fake.domain.devices.disks[2] and fake.domain.devices.disks[0] must not have the same medium."))
+		})
+	})
+
+	Context("with the HotplugCPUMemory feature gate enabled", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.HotplugCPUMemoryGate)
+			vmi = v1.NewMinimalVMI("testvmi")
+			guest := resource.MustParse("1Gi")
+			maxGuest := resource.MustParse("4Gi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+		})
+
+		It("should allow increasing the sockets and the guest memory", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Domain.CPU.Sockets = 4
+			guest := resource.MustParse("2Gi")
+			newVMI.Spec.Domain.Memory.Guest = &guest
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject decreasing the sockets", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Domain.CPU.Sockets = 1
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("spec.domain.cpu.sockets can not be decreased on a running VMI"))
+		})
+
+		It("should reject decreasing the guest memory", func() {
+			newVMI := vmi.DeepCopy()
+			guest := resource.MustParse("512Mi")
+			newVMI.Spec.Domain.Memory.Guest = &guest
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("spec.domain.memory.guest can not be decreased on a running VMI"))
+		})
+
+		It("should reject increasing the sockets above the maximum", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Domain.CPU.Sockets = 5
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("spec.domain.cpu.sockets '5' must be equal to or less than spec.domain.cpu.maxSockets '4'"))
+		})
+
+		It("should reject changing the sockets if no maximum is set", func() {
+			vmi.Spec.Domain.CPU.MaxSockets = 0
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Domain.CPU.Sockets = 3
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("spec.domain.cpu.sockets can only be changed when maxSockets is set"))
+		})
+
+		It("should reject changing the maximum sockets", func() {
+			newVMI := vmi.DeepCopy()
+			newVMI.Spec.Domain.CPU.MaxSockets = 8
+			resp := admitUpdate(vmi, newVMI)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("update of VMI object is restricted"))
+		})
+	})
 })
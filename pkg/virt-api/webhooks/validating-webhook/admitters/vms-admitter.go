@@ -30,11 +30,12 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
+	"kubevirt.io/kubevirt/pkg/util/cron"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure}
+var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure, v1.RunStrategyOnce}
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
@@ -221,5 +222,51 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.RunSchedule != nil {
+		causes = append(causes, validateRunSchedule(field.Child("runSchedule"), spec)...)
+	}
+
+	return causes
+}
+
+func validateRunSchedule(field *k8sfield.Path, spec *v1.VirtualMachineSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.RunStrategy == nil || *spec.RunStrategy != v1.RunStrategyManual {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires the %s RunStrategy", field.String(), v1.RunStrategyManual),
+			Field:   field.String(),
+		})
+	}
+
+	if spec.RunSchedule.Start == "" && spec.RunSchedule.Stop == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must have a start or a stop schedule", field.String()),
+			Field:   field.String(),
+		})
+	}
+
+	schedules := []struct {
+		name string
+		spec string
+	}{
+		{"start", spec.RunSchedule.Start},
+		{"stop", spec.RunSchedule.Stop},
+	}
+	for _, schedule := range schedules {
+		if schedule.spec == "" {
+			continue
+		}
+		if _, err := cron.Parse(schedule.spec); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not a valid cron expression: %v", field.Child(schedule.name).String(), err),
+				Field:   field.Child(schedule.name).String(),
+			})
+		}
+	}
+
 	return causes
 }
@@ -121,6 +121,59 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	It("should reject requested media in the template", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		medium := "iso"
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}, Medium: &medium}}
+		vmi.Spec.Volumes = []v1.Volume{
+			{Name: "cdrom", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "cdrom"}}},
+			{Name: "iso", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "iso"}}},
+		}
+		running := false
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running: &running,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+
+		causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("spec.template.spec.domain.devices.disks[0].medium"))
+	})
+
+	table.DescribeTable("should validate the run strategy and schedule", func(runStrategy v1.VirtualMachineRunStrategy, runSchedule *v1.VirtualMachineRunSchedule, expectedFields []string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				RunStrategy: &runStrategy,
+				RunSchedule: runSchedule,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+
+		causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config)
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(Equal(expectedFields))
+	},
+		table.Entry("accept the Once run strategy", v1.RunStrategyOnce, nil, []string{}),
+		table.Entry("accept a run schedule with the Manual run strategy", v1.RunStrategyManual,
+			&v1.VirtualMachineRunSchedule{Start: "0 8 * * 1-5", Stop: "0 18 * * 1-5"}, []string{}),
+		table.Entry("reject a run schedule with another run strategy", v1.RunStrategyAlways,
+			&v1.VirtualMachineRunSchedule{Stop: "@daily"}, []string{"spec.runSchedule"}),
+		table.Entry("reject an empty run schedule", v1.RunStrategyManual,
+			&v1.VirtualMachineRunSchedule{}, []string{"spec.runSchedule"}),
+		table.Entry("reject an invalid cron expression", v1.RunStrategyManual,
+			&v1.VirtualMachineRunSchedule{Start: "0 8 * *", Stop: "0 25 * * *"}, []string{"spec.runSchedule.start", "spec.runSchedule.stop"}),
+	)
+
 	It("should accept valid DataVolumeTemplate", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
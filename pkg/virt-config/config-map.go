@@ -59,6 +59,8 @@ const (
 	PermitSlirpInterface      = "permitSlirpInterface"
 	NodeDrainTaintDefaultKey  = "kubevirt.io/drain"
 	SmbiosConfigKey           = "smbios"
+	ObsoleteCPUModelsKey      = "obsolete-cpu-models"
+	DiskIOTuneKey             = "default-disk-iotune"
 )
 
 type ConfigModifiedFn func()
@@ -177,10 +179,13 @@ func defaultClusterConfig() *Config {
 	bandwithPerMigrationDefault := resource.MustParse(BandwithPerMigrationDefault)
 	nodeDrainTaintDefaultKey := NodeDrainTaintDefaultKey
 	allowAutoConverge := MigrationAllowAutoConverge
+	allowPostCopy := MigrationAllowPostCopy
+	postCopyAfterIterations := MigrationPostCopyAfterIterations
 	progressTimeout := MigrationProgressTimeout
 	completionTimeoutPerGiB := MigrationCompletionTimeoutPerGiB
 	cpuRequestDefault := resource.MustParse(DefaultCPURequest)
 	emulatedMachinesDefault := strings.Split(DefaultEmulatedMachines, ",")
+	obsoleteCPUModelsDefault := strings.Split(DefaultObsoleteCPUModels, ",")
 	nodeSelectorsDefault, _ := parseNodeSelectors(DefaultNodeSelectors)
 	defaultNetworkInterface := DefaultNetworkInterface
 	SmbiosDefaultConfig := &cmdv1.SMBios{
@@ -201,6 +206,8 @@ func defaultClusterConfig() *Config {
 			CompletionTimeoutPerGiB:           &completionTimeoutPerGiB,
 			UnsafeMigrationOverride:           DefaultUnsafeMigrationOverride,
 			AllowAutoConverge:                 allowAutoConverge,
+			AllowPostCopy:                     allowPostCopy,
+			PostCopyAfterIterations:           &postCopyAfterIterations,
 		},
 		MachineType:            DefaultMachineType,
 		CPURequest:             cpuRequestDefault,
@@ -211,6 +218,7 @@ func defaultClusterConfig() *Config {
 		NetworkInterface:       defaultNetworkInterface,
 		PermitSlirpInterface:   DefaultPermitSlirpInterface,
 		SmbiosConfig:           SmbiosDefaultConfig,
+		ObsoleteCPUModels:      obsoleteCPUModelsDefault,
 	}
 }
 
@@ -230,6 +238,8 @@ type Config struct {
 	NetworkInterface       string
 	PermitSlirpInterface   bool
 	SmbiosConfig           *cmdv1.SMBios
+	ObsoleteCPUModels      []string
+	DiskIOTune             *v1.DiskIOTune
 }
 
 type MigrationConfig struct {
@@ -241,6 +251,12 @@ type MigrationConfig struct {
 	CompletionTimeoutPerGiB           *int64             `json:"completionTimeoutPerGiB,omitempty"`
 	UnsafeMigrationOverride           bool               `json:"unsafeMigrationOverride"`
 	AllowAutoConverge                 bool               `json:"allowAutoConverge"`
+	// AllowPostCopy lets a migration which does not converge switch to post-copy,
+	// where the guest runs on the target while its remaining memory is still transferred
+	AllowPostCopy bool `json:"allowPostCopy"`
+	// PostCopyAfterIterations switches to post-copy after the given number of iterations over
+	// the guest memory, even if the migration still progresses. Zero only switches a stuck migration.
+	PostCopyAfterIterations *int64 `json:"postCopyAfterIterations,omitempty"`
 }
 
 type ClusterConfig struct {
@@ -288,6 +304,16 @@ func setConfig(config *Config, configMap *k8sv1.ConfigMap) error {
 		}
 	}
 
+	// set the I/O limits of disks which don't have their own
+	if diskIOTune := strings.TrimSpace(configMap.Data[DiskIOTuneKey]); diskIOTune != "" {
+		ioTune := &v1.DiskIOTune{}
+		err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(diskIOTune), 1024).Decode(ioTune)
+		if err != nil {
+			return fmt.Errorf("failed to parse default-disk-iotune config: %v", err)
+		}
+		config.DiskIOTune = ioTune
+	}
+
 	// set image pull policy
 	policy := strings.TrimSpace(configMap.Data[ImagePullPolicyKey])
 	switch policy {
@@ -345,6 +371,14 @@ func setConfig(config *Config, configMap *k8sv1.ConfigMap) error {
 		config.EmulatedMachines = vals
 	}
 
+	if obsoleteCPUModels := strings.TrimSpace(configMap.Data[ObsoleteCPUModelsKey]); obsoleteCPUModels != "" {
+		vals := strings.Split(obsoleteCPUModels, ",")
+		for i := range vals {
+			vals[i] = strings.TrimSpace(vals[i])
+		}
+		config.ObsoleteCPUModels = vals
+	}
+
 	if featureGates := strings.TrimSpace(configMap.Data[FeatureGatesKey]); featureGates != "" {
 		config.FeatureGates = featureGates
 	}
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	kubev1 "k8s.io/api/core/v1"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	testutils "kubevirt.io/kubevirt/pkg/testutils"
@@ -158,9 +159,19 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, it should return the defaults", "", strings.Split(virtconfig.DefaultEmulatedMachines, ",")),
 	)
 
+	table.DescribeTable(" when obsoleteCPUModels", func(value string, result []string) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.ObsoleteCPUModelsKey: value},
+		})
+		Expect(clusterConfig.GetObsoleteCPUModels()).To(ConsistOf(result))
+	},
+		table.Entry("when set, it should return the value", "486, pentium", []string{"486", "pentium"}),
+		table.Entry("when unset, it should return the defaults", "", strings.Split(virtconfig.DefaultObsoleteCPUModels, ",")),
+	)
+
 	It("Should return migration config values if specified as json", func() {
 		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
-			Data: map[string]string{virtconfig.MigrationsConfigKey: `{"parallelOutboundMigrationsPerNode" : 10, "parallelMigrationsPerCluster": 20, "bandwidthPerMigration": "110Mi", "progressTimeout" : 5, "completionTimeoutPerGiB": 5, "unsafeMigrationOverride": true, "allowAutoConverge": true}`},
+			Data: map[string]string{virtconfig.MigrationsConfigKey: `{"parallelOutboundMigrationsPerNode" : 10, "parallelMigrationsPerCluster": 20, "bandwidthPerMigration": "110Mi", "progressTimeout" : 5, "completionTimeoutPerGiB": 5, "unsafeMigrationOverride": true, "allowAutoConverge": true, "allowPostCopy": true, "postCopyAfterIterations": 3}`},
 		})
 		result := clusterConfig.GetMigrationConfig()
 		Expect(*result.ParallelOutboundMigrationsPerNode).To(BeNumerically("==", 10))
@@ -170,6 +181,8 @@ var _ = Describe("ConfigMap", func() {
 		Expect(*result.CompletionTimeoutPerGiB).To(BeNumerically("==", 5))
 		Expect(result.UnsafeMigrationOverride).To(BeTrue())
 		Expect(result.AllowAutoConverge).To(BeTrue())
+		Expect(result.AllowPostCopy).To(BeTrue())
+		Expect(*result.PostCopyAfterIterations).To(BeNumerically("==", 3))
 	})
 
 	It("Should return migration config values if specified as yaml", func() {
@@ -192,6 +205,8 @@ var _ = Describe("ConfigMap", func() {
 		Expect(*result.ParallelOutboundMigrationsPerNode).To(BeNumerically("==", 10))
 		Expect(*result.ParallelMigrationsPerCluster).To(BeNumerically("==", 5))
 		Expect(result.BandwidthPerMigration.String()).To(Equal("64Mi"))
+		Expect(result.AllowPostCopy).To(BeFalse())
+		Expect(*result.PostCopyAfterIterations).To(BeNumerically("==", 0))
 	})
 
 	It("Should update the config if a newer version is available", func() {
@@ -276,4 +291,16 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when values set, should equal to result", `{"Family":"test","Product":"test", "Manufacturer":"None"}`, cmdv1.SMBios{Family: "test", Product: "test", Manufacturer: "None"}),
 		table.Entry("When an invalid smbios value is set, should return default values", `{"invalid":"invalid"}`, cmdv1.SMBios{Family: "KubeVirt", Product: "None", Manufacturer: "KubeVirt"}),
 	)
+
+	It("should not set a default disk I/O limit if none is configured", func() {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{})
+		Expect(clusterConfig.GetDefaultDiskIOTune()).To(BeNil())
+	})
+
+	It("should parse the default disk I/O limits", func() {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.DiskIOTuneKey: "totalIOPSSec: 500\ntotalIOPSSecMax: 1000\n"},
+		})
+		Expect(clusterConfig.GetDefaultDiskIOTune()).To(Equal(&v1.DiskIOTune{TotalIOPSSec: 500, TotalIOPSSecMax: 1000}))
+	})
 })
@@ -34,6 +34,12 @@ const (
 	CPUNodeDiscoveryGate  = "CPUNodeDiscovery"
 	HypervStrictCheckGate = "HypervStrictCheck"
 	SidecarGate           = "Sidecar"
+	GuestMetricsGate      = "GuestMetrics"
+	HotplugVolumesGate    = "HotplugVolumes"
+	HotplugCPUMemoryGate  = "HotplugCPUMemory"
+	NUMAGate              = "NUMA"
+	ExpandDisksGate       = "ExpandDisks"
+	VirtIOFSGate          = "ExperimentalVirtiofsSupport"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
@@ -63,3 +69,27 @@ func (config *ClusterConfig) CPUNodeDiscoveryEnabled() bool {
 func (config *ClusterConfig) SidecarEnabled() bool {
 	return config.isFeatureGateEnabled(SidecarGate)
 }
+
+func (config *ClusterConfig) GuestMetricsEnabled() bool {
+	return config.isFeatureGateEnabled(GuestMetricsGate)
+}
+
+func (config *ClusterConfig) HotplugVolumesEnabled() bool {
+	return config.isFeatureGateEnabled(HotplugVolumesGate)
+}
+
+func (config *ClusterConfig) HotplugCPUMemoryEnabled() bool {
+	return config.isFeatureGateEnabled(HotplugCPUMemoryGate)
+}
+
+func (config *ClusterConfig) NUMAEnabled() bool {
+	return config.isFeatureGateEnabled(NUMAGate)
+}
+
+func (config *ClusterConfig) ExpandDisksEnabled() bool {
+	return config.isFeatureGateEnabled(ExpandDisksGate)
+}
+
+func (config *ClusterConfig) VirtIOFSEnabled() bool {
+	return config.isFeatureGateEnabled(VirtIOFSGate)
+}
@@ -27,6 +27,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 )
 
@@ -35,12 +36,15 @@ const (
 	ParallelMigrationsPerClusterDefault      uint32 = 5
 	BandwithPerMigrationDefault                     = "64Mi"
 	MigrationAllowAutoConverge               bool   = false
+	MigrationAllowPostCopy                   bool   = false
+	MigrationPostCopyAfterIterations         int64  = 0
 	MigrationProgressTimeout                 int64  = 150
 	MigrationCompletionTimeoutPerGiB         int64  = 800
 	DefaultMachineType                              = "q35"
 	DefaultCPURequest                               = "100m"
 	DefaultMemoryOvercommit                         = 100
 	DefaultEmulatedMachines                         = "q35*,pc-q35*"
+	DefaultObsoleteCPUModels                        = "486,pentium,pentium2,pentium3,pentiumpro,coreduo,n270,core2duo,Conroe,athlon,phenom,qemu64,qemu32,kvm64,kvm32"
 	DefaultLessPVCSpaceToleration                   = 10
 	DefaultNodeSelectors                            = ""
 	DefaultNetworkInterface                         = "bridge"
@@ -85,6 +89,10 @@ func (c *ClusterConfig) GetEmulatedMachines() []string {
 	return c.getConfig().EmulatedMachines
 }
 
+func (c *ClusterConfig) GetObsoleteCPUModels() []string {
+	return c.getConfig().ObsoleteCPUModels
+}
+
 func (c *ClusterConfig) GetLessPVCSpaceToleration() int {
 	return c.getConfig().LessPVCSpaceToleration
 }
@@ -104,3 +112,8 @@ func (c *ClusterConfig) IsSlirpInterfaceEnabled() bool {
 func (c *ClusterConfig) GetSMBIOS() *cmdv1.SMBios {
 	return c.getConfig().SmbiosConfig
 }
+
+// GetDefaultDiskIOTune returns the I/O limits for disks which don't specify their own, nil if there are none
+func (c *ClusterConfig) GetDefaultDiskIOTune() *v1.DiskIOTune {
+	return c.getConfig().DiskIOTune
+}
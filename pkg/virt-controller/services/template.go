@@ -32,6 +32,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
@@ -61,6 +62,7 @@ const GenieNetworksAnnotation = "cni"
 const CAP_NET_ADMIN = "NET_ADMIN"
 const CAP_SYS_NICE = "SYS_NICE"
 const CAP_SYS_RESOURCE = "SYS_RESOURCE"
+const CAP_SYS_ADMIN = "SYS_ADMIN"
 
 // LibvirtStartupDelay is added to custom liveness and readiness probes initial delay value.
 // Libvirt needs roughly 10 seconds to start.
@@ -82,6 +84,7 @@ const ENV_VAR_LIBVIRT_DEBUG_LOGS = "LIBVIRT_DEBUG_LOGS"
 
 type TemplateService interface {
 	RenderLaunchManifest(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
+	RenderHotplugAttachmentPodTemplate(*v1.Volume, *v1.VirtualMachineInstance) (*k8sv1.Pod, error)
 }
 
 type templateService struct {
@@ -224,39 +227,65 @@ func SetNodeAffinityForForbiddenFeaturePolicy(vmi *v1.VirtualMachineInstance, po
 				Key:      NFD_CPU_FEATURE_PREFIX + feature.Name,
 				Operator: k8sv1.NodeSelectorOpDoesNotExist,
 			}
-			term := k8sv1.NodeSelectorTerm{
-				MatchExpressions: []k8sv1.NodeSelectorRequirement{requirement}}
+			addRequiredNodeAffinity(pod, requirement)
+		}
+	}
+}
 
-			nodeAffinity := &k8sv1.NodeAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
-					NodeSelectorTerms: []k8sv1.NodeSelectorTerm{term},
-				},
-			}
+// SetNodeAffinityForHostModelCPU restricts the pod to nodes which support the CPU model and features
+// which the host-model CPU of the VirtualMachineInstance was expanded to
+func SetNodeAffinityForHostModelCPU(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	hostModelCPU := vmi.Status.HostModelCPU
+	if hostModelCPU == nil {
+		return
+	}
 
-			if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil {
-				if pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-					terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
-					// Since NodeSelectorTerms are ORed , the anti affinity requirement will be added to each term.
-					for i, selectorTerm := range terms {
-						pod.Spec.Affinity.NodeAffinity.
-							RequiredDuringSchedulingIgnoredDuringExecution.
-							NodeSelectorTerms[i].MatchExpressions = append(selectorTerm.MatchExpressions, requirement)
-					}
-				} else {
-					pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &k8sv1.NodeSelector{
-						NodeSelectorTerms: []k8sv1.NodeSelectorTerm{term},
-					}
-				}
+	addRequiredNodeAffinity(pod, k8sv1.NodeSelectorRequirement{
+		Key:      NFD_CPU_MODEL_PREFIX + hostModelCPU.Model,
+		Operator: k8sv1.NodeSelectorOpIn,
+		Values:   []string{"true"},
+	})
+	for _, feature := range hostModelCPU.Features {
+		addRequiredNodeAffinity(pod, k8sv1.NodeSelectorRequirement{
+			Key:      NFD_CPU_FEATURE_PREFIX + feature,
+			Operator: k8sv1.NodeSelectorOpIn,
+			Values:   []string{"true"},
+		})
+	}
+}
 
-			} else if pod.Spec.Affinity != nil {
-				pod.Spec.Affinity.NodeAffinity = nodeAffinity
-			} else {
-				pod.Spec.Affinity = &k8sv1.Affinity{
-					NodeAffinity: nodeAffinity,
-				}
+func addRequiredNodeAffinity(pod *k8sv1.Pod, requirement k8sv1.NodeSelectorRequirement) {
+	term := k8sv1.NodeSelectorTerm{
+		MatchExpressions: []k8sv1.NodeSelectorRequirement{requirement}}
+
+	nodeAffinity := &k8sv1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+			NodeSelectorTerms: []k8sv1.NodeSelectorTerm{term},
+		},
+	}
 
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil {
+		if pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+			terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			// Since NodeSelectorTerms are ORed , the requirement will be added to each term.
+			for i, selectorTerm := range terms {
+				pod.Spec.Affinity.NodeAffinity.
+					RequiredDuringSchedulingIgnoredDuringExecution.
+					NodeSelectorTerms[i].MatchExpressions = append(selectorTerm.MatchExpressions, requirement)
+			}
+		} else {
+			pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &k8sv1.NodeSelector{
+				NodeSelectorTerms: []k8sv1.NodeSelectorTerm{term},
 			}
 		}
+
+	} else if pod.Spec.Affinity != nil {
+		pod.Spec.Affinity.NodeAffinity = nodeAffinity
+	} else {
+		pod.Spec.Affinity = &k8sv1.Affinity{
+			NodeAffinity: nodeAffinity,
+		}
+
 	}
 }
 
@@ -376,6 +405,10 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 	serviceAccountName := ""
 
 	for _, volume := range vmi.Spec.Volumes {
+		if hotplugdisk.IsHotplugVolume(vmi, volume.Name) {
+			// hotplugged volumes are provided by their attachment pods
+			continue
+		}
 		volumeMount := k8sv1.VolumeMount{
 			Name:      volume.Name,
 			MountPath: hostdisk.GetMountedHostDiskDir(volume.Name),
@@ -530,6 +563,18 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		resources.Limits[key] = value
 	}
 
+	// Pod resources can not be changed once the pod is running, so the memory
+	// the VMI can be scaled up to has to be reserved from the start
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+		maxGuest := *vmi.Spec.Domain.Memory.MaxGuest
+		if memoryRequest, ok := resources.Requests[k8sv1.ResourceMemory]; !ok || memoryRequest.Cmp(maxGuest) < 0 {
+			resources.Requests[k8sv1.ResourceMemory] = maxGuest
+		}
+		if memoryLimit, ok := resources.Limits[k8sv1.ResourceMemory]; ok && memoryLimit.Cmp(maxGuest) < 0 {
+			resources.Limits[k8sv1.ResourceMemory] = maxGuest
+		}
+	}
+
 	// Consider hugepages resource for pod scheduling
 	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
 		hugepageType := k8sv1.ResourceName(k8sv1.ResourceHugePagesPrefix + vmi.Spec.Domain.Memory.Hugepages.PageSize)
@@ -646,6 +691,12 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 
 	capabilities := getRequiredCapabilities(vmi)
 
+	volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+		Name:             "hotplug-disks",
+		MountPath:        hotplugdisk.GetMountBaseDir(),
+		MountPropagation: &prop,
+	})
+
 	volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
 		Name:      "infra-ready-mount",
 		MountPath: "/var/run/kubevirt-infra",
@@ -704,12 +755,13 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		ReadinessProbe: defaultReadinessProbe,
 	}
 
-	if vmi.Spec.ReadinessProbe != nil {
+	// Probes which need the guest agent are run by virt-handler
+	if vmi.Spec.ReadinessProbe != nil && !vmi.Spec.ReadinessProbe.IsGuestAgentProbe() {
 		container.ReadinessProbe = copyProbe(vmi.Spec.ReadinessProbe)
 		container.ReadinessProbe.InitialDelaySeconds = container.ReadinessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
 
-	if vmi.Spec.LivenessProbe != nil {
+	if vmi.Spec.LivenessProbe != nil && !vmi.Spec.LivenessProbe.IsGuestAgentProbe() {
 		container.LivenessProbe = copyProbe(vmi.Spec.LivenessProbe)
 		container.LivenessProbe.InitialDelaySeconds = container.LivenessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
@@ -763,6 +815,16 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 			},
 		},
 	})
+	hotplugDiskDirType := k8sv1.HostPathDirectoryOrCreate
+	volumes = append(volumes, k8sv1.Volume{
+		Name: "hotplug-disks",
+		VolumeSource: k8sv1.VolumeSource{
+			HostPath: &k8sv1.HostPathVolumeSource{
+				Path: hotplugdisk.GenerateVolumeMountDir(vmi),
+				Type: &hotplugDiskDirType,
+			},
+		},
+	})
 
 	for k, v := range vmi.Spec.NodeSelector {
 		nodeSelector[k] = v
@@ -936,6 +998,156 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 	return &pod, nil
 }
 
+// RenderHotplugAttachmentPodTemplate renders the pod which attaches a hotplugged volume to the node
+// of a running VirtualMachineInstance. Once the pod runs, virt-handler picks up the mounted volume
+// and makes it available to the virt-launcher pod.
+func (t *templateService) RenderHotplugAttachmentPodTemplate(volume *v1.Volume, vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
+	precond.MustNotBeNil(vmi)
+	precond.MustNotBeNil(volume)
+	namespace := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetNamespace())
+
+	var claimName string
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		claimName = volume.PersistentVolumeClaim.ClaimName
+	case volume.DataVolume != nil:
+		claimName = volume.DataVolume.Name
+	default:
+		return nil, fmt.Errorf("volume %s can not be hotplugged, only persistentVolumeClaim and dataVolume volumes are supported", volume.Name)
+	}
+
+	_, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, PvcNotFoundError(fmt.Errorf("didn't find PVC %v", claimName))
+	}
+
+	var userId int64 = 0
+	volumeMountDir := hotplugdisk.GenerateVolumeMountDir(vmi)
+	hotplugDiskDirType := k8sv1.HostPathDirectoryOrCreate
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "hp-volume-",
+			Labels: map[string]string{
+				v1.AppLabel:           "hotplug-disk",
+				v1.CreatedByLabel:     string(vmi.UID),
+				v1.HotplugVolumeLabel: volume.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vmi, v1.VirtualMachineInstanceGroupVersionKind),
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{
+				{
+					Name:            "hotplug-disk",
+					Image:           t.launcherImage,
+					ImagePullPolicy: t.clusterConfig.GetImagePullPolicy(),
+					Command:         []string{"/usr/bin/hotplug-disk"},
+					Args:            []string{"--socket", hotplugdisk.GenerateSocketPathFromHostView(vmi, volume.Name)},
+					SecurityContext: &k8sv1.SecurityContext{
+						RunAsUser: &userId,
+					},
+					Resources: k8sv1.ResourceRequirements{
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("100m"),
+							k8sv1.ResourceMemory: resource.MustParse("20M"),
+						},
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("10m"),
+							k8sv1.ResourceMemory: resource.MustParse("1M"),
+						},
+					},
+					VolumeMounts: []k8sv1.VolumeMount{
+						{
+							Name:      "hotplug-disks",
+							MountPath: volumeMountDir,
+						},
+						{
+							Name:      "virt-bin-share-dir",
+							MountPath: "/usr/bin",
+						},
+					},
+				},
+			},
+			Affinity: &k8sv1.Affinity{
+				NodeAffinity: &k8sv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+						NodeSelectorTerms: []k8sv1.NodeSelectorTerm{
+							{
+								MatchFields: []k8sv1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: k8sv1.NodeSelectorOpIn,
+										Values:   []string{vmi.Status.NodeName},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tolerations:   vmi.Spec.Tolerations,
+			RestartPolicy: k8sv1.RestartPolicyNever,
+			Volumes: []k8sv1.Volume{
+				{
+					Name: "hotplug-disks",
+					VolumeSource: k8sv1.VolumeSource{
+						HostPath: &k8sv1.HostPathVolumeSource{
+							Path: volumeMountDir,
+							Type: &hotplugDiskDirType,
+						},
+					},
+				},
+				{
+					Name: "virt-bin-share-dir",
+					VolumeSource: k8sv1.VolumeSource{
+						HostPath: &k8sv1.HostPathVolumeSource{
+							Path: filepath.Join(t.virtLibDir, "/init/usr/bin"),
+						},
+					},
+				},
+				{
+					Name: volume.Name,
+					VolumeSource: k8sv1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	container := &pod.Spec.Containers[0]
+	if isBlock {
+		container.Args = append(container.Args, "--block-device-path", hotplugdisk.AttachmentPodBlockDevicePath)
+		container.VolumeDevices = []k8sv1.VolumeDevice{
+			{
+				Name:       volume.Name,
+				DevicePath: hotplugdisk.AttachmentPodBlockDevicePath,
+			},
+		}
+	} else {
+		diskDir := hostdisk.GetMountedHostDiskDir(volume.Name)
+		container.Args = append(container.Args, "--disk-path", filepath.Join(diskDir, "disk.img"))
+		container.VolumeMounts = append(container.VolumeMounts, k8sv1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: diskDir,
+		})
+	}
+
+	if t.imagePullSecret != "" {
+		pod.Spec.ImagePullSecrets = []k8sv1.LocalObjectReference{{Name: t.imagePullSecret}}
+	}
+	automount := false
+	pod.Spec.AutomountServiceAccountToken = &automount
+
+	return pod, nil
+}
+
 func getRequiredCapabilities(vmi *v1.VirtualMachineInstance) []k8sv1.Capability {
 	res := []k8sv1.Capability{}
 	if (len(vmi.Spec.Domain.Devices.Interfaces) > 0) ||
@@ -951,6 +1163,11 @@ func getRequiredCapabilities(vmi *v1.VirtualMachineInstance) []k8sv1.Capability
 		// "error : cannot limit locked memory to 2098200576: Operation not permitted"
 		res = append(res, CAP_SYS_RESOURCE)
 	}
+
+	if len(vmi.Spec.Domain.Devices.Filesystems) > 0 {
+		// virtiofsd sandboxes itself in a mount namespace around the shared directory
+		res = append(res, CAP_SYS_ADMIN)
+	}
 	return res
 }
 
@@ -996,6 +1213,12 @@ func getMemoryOverhead(domain v1.DomainSpec) *resource.Quantity {
 
 	overhead := resource.NewScaledQuantity(0, resource.Kilo)
 
+	// Pod resources can not be changed once the pod is running, so the overhead
+	// has to cover the memory and the sockets the VMI can be scaled up to
+	if domain.Memory != nil && domain.Memory.MaxGuest != nil {
+		vmiMemoryReq = domain.Memory.MaxGuest
+	}
+
 	// Add the memory needed for pagetables (one bit for every 512b of RAM size)
 	pagetableMemory := resource.NewScaledQuantity(vmiMemoryReq.ScaledValue(resource.Kilo), resource.Kilo)
 	pagetableMemory.Set(pagetableMemory.Value() / 512)
@@ -1010,6 +1233,9 @@ func getMemoryOverhead(domain v1.DomainSpec) *resource.Quantity {
 	coresMemory := resource.MustParse("8Mi")
 	if domain.CPU != nil {
 		value := coresMemory.Value() * int64(domain.CPU.Cores)
+		if domain.CPU.MaxSockets > 0 {
+			value = value * int64(domain.CPU.MaxSockets)
+		}
 		coresMemory = *resource.NewQuantity(value, coresMemory.Format)
 	}
 	overhead.Add(coresMemory)
@@ -809,6 +809,37 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("1163507557"))
 				Expect(pod.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("2163507557"))
 			})
+			It("should reserve the maximum guest memory and size the overhead for it", func() {
+				maxGuest := resource.MustParse("4G")
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Memory: &v1.Memory{
+								MaxGuest: &maxGuest,
+							},
+							Resources: v1.ResourceRequirements{
+								Requests: kubev1.ResourceList{
+									kubev1.ResourceMemory: resource.MustParse("1G"),
+								},
+								Limits: kubev1.ResourceList{
+									kubev1.ResourceMemory: resource.MustParse("2G"),
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("4169366932"))
+				Expect(pod.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("4169366932"))
+			})
 			It("should overcommit guest overhead if selected, by only adding the overhead to memory limits", func() {
 
 				vmi := v1.VirtualMachineInstance{
@@ -944,11 +975,11 @@ var _ = Describe("Template", func() {
 				Expect(hugepagesRequest.ToDec().ScaledValue(resource.Mega)).To(Equal(int64(64)))
 				Expect(hugepagesLimit.ToDec().ScaledValue(resource.Mega)).To(Equal(int64(64)))
 
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].EmptyDir).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].EmptyDir.Medium).To(Equal(kubev1.StorageMediumHugePages))
 
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6))
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(7))
 				Expect(pod.Spec.Containers[0].VolumeMounts[4].MountPath).To(Equal("/dev/hugepages"))
 			},
 				table.Entry("hugepages-2Mi", "2Mi"),
@@ -989,16 +1020,131 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].VolumeDevices).To(BeEmpty(), "No devices in manifest for 1st container")
 
 				Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty(), "Some mounts in manifest for 1st container")
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6), "4 mounts in manifest for 1st container")
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(7), "4 mounts in manifest for 1st container")
 				Expect(pod.Spec.Containers[0].VolumeMounts[4].Name).To(Equal(volumeName), "1st mount in manifest for 1st container has correct name")
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty(), "Found some volumes in manifest")
-				Expect(len(pod.Spec.Volumes)).To(Equal(7), "Found 4 volumes in manifest")
+				Expect(len(pod.Spec.Volumes)).To(Equal(8), "Found 4 volumes in manifest")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil(), "Found PVC volume")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvcName), "Found PVC volume with correct name")
 			})
 		})
 
+		Context("with hotplugged volumes", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				pvc := kubev1.PersistentVolumeClaim{
+					TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "hotplug-pvc"},
+				}
+				Expect(pvcCache.Add(&pvc)).To(Succeed())
+
+				vmi = &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "testns", UID: "1234",
+						Annotations: map[string]string{v1.HotplugVolumesAnnotation: "hpvolume"},
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "hpvolume",
+								VolumeSource: v1.VolumeSource{
+									PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "hotplug-pvc"},
+								},
+							},
+						},
+					},
+					Status: v1.VirtualMachineInstanceStatus{
+						NodeName: "node01",
+						VolumeStatus: []v1.VolumeStatus{
+							{Name: "hpvolume", HotplugVolume: &v1.HotplugVolumeStatus{}},
+						},
+					},
+				}
+			})
+
+			It("should not add hotplugged volumes to the launcher pod", func() {
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal("hpvolume"))
+				}
+				prop := kubev1.MountPropagationHostToContainer
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:             "hotplug-disks",
+					MountPath:        "/var/run/kubevirt/hotplug-disks",
+					MountPropagation: &prop,
+				}))
+			})
+
+			It("should render an attachment pod on the node of the VMI", func() {
+				pod, err := svc.RenderHotplugAttachmentPodTemplate(&vmi.Spec.Volumes[0], vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Labels).To(Equal(map[string]string{
+					v1.AppLabel:           "hotplug-disk",
+					v1.CreatedByLabel:     "1234",
+					v1.HotplugVolumeLabel: "hpvolume",
+				}))
+				Expect(pod.OwnerReferences[0].UID).To(Equal(vmi.UID))
+				Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/usr/bin/hotplug-disk"}))
+				Expect(pod.Spec.Containers[0].Args).To(Equal([]string{
+					"--socket", "/var/run/kubevirt/hotplug-disks/1234/hpvolume.sock",
+					"--disk-path", "/var/run/kubevirt-private/vmi-disks/hpvolume/disk.img",
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts[2].MountPath).To(Equal("/var/run/kubevirt-private/vmi-disks/hpvolume"))
+				Expect(pod.Spec.Containers[0].VolumeDevices).To(BeEmpty())
+				Expect(pod.Spec.Volumes[2].PersistentVolumeClaim.ClaimName).To(Equal("hotplug-pvc"))
+				Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(Equal([]string{"node01"}))
+			})
+
+			It("should expose a hotplugged block volume as a device of the attachment pod", func() {
+				mode := kubev1.PersistentVolumeBlock
+				pvc := kubev1.PersistentVolumeClaim{
+					TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "hotplug-block-pvc"},
+					Spec:       kubev1.PersistentVolumeClaimSpec{VolumeMode: &mode},
+				}
+				Expect(pvcCache.Add(&pvc)).To(Succeed())
+				vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "hotplug-block-pvc"
+
+				pod, err := svc.RenderHotplugAttachmentPodTemplate(&vmi.Spec.Volumes[0], vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Args).To(Equal([]string{
+					"--socket", "/var/run/kubevirt/hotplug-disks/1234/hpvolume.sock",
+					"--block-device-path", "/dev/hotplug-disk",
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(HaveLen(2))
+				Expect(pod.Spec.Containers[0].VolumeDevices).To(Equal([]kubev1.VolumeDevice{
+					{Name: "hpvolume", DevicePath: "/dev/hotplug-disk"},
+				}))
+				Expect(pod.Spec.Volumes[2].PersistentVolumeClaim.ClaimName).To(Equal("hotplug-block-pvc"))
+			})
+
+			It("should use the claim of a hotplugged DataVolume", func() {
+				volume := v1.Volume{
+					Name: "hpvolume",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "hotplug-pvc"},
+					},
+				}
+				pod, err := svc.RenderHotplugAttachmentPodTemplate(&volume, vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Volumes[2].PersistentVolumeClaim.ClaimName).To(Equal("hotplug-pvc"))
+			})
+
+			It("should fail to render an attachment pod for a missing PVC", func() {
+				vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "missing"
+				_, err := svc.RenderHotplugAttachmentPodTemplate(&vmi.Spec.Volumes[0], vmi)
+				Expect(err).To(HaveOccurred())
+				_, isPvcNotFound := err.(PvcNotFoundError)
+				Expect(isPvcNotFound).To(BeTrue())
+			})
+		})
+
 		Context("with blockdevice mode pvc source", func() {
 			It("should add device to template", func() {
 				namespace := "testns"
@@ -1037,10 +1183,10 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].VolumeDevices[0].Name).To(Equal(volumeName), "Found device for 1st container with correct name")
 
 				Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty(), "Found some mounts in manifest for 1st container")
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(5), "Found 5 mounts in manifest for 1st container")
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6), "Found 6 mounts in manifest for 1st container")
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty(), "Found some volumes in manifest")
-				Expect(len(pod.Spec.Volumes)).To(Equal(7), "Found 4 volumes in manifest")
+				Expect(len(pod.Spec.Volumes)).To(Equal(8), "Found 4 volumes in manifest")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil(), "Found PVC volume")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvcName), "Found PVC volume with correct name")
 			})
@@ -1344,6 +1490,34 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with virtio-fs filesystems", func() {
+			It("Should grant SYS_ADMIN to the compute container for virtiofsd", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+					{Name: "configmap-volume", Virtiofs: &v1.FilesystemVirtiofs{}},
+				}
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "configmap-volume",
+						VolumeSource: v1.VolumeSource{
+							ConfigMap: &v1.ConfigMapVolumeSource{
+								LocalObjectReference: kubev1.LocalObjectReference{Name: "test-configmap"},
+							},
+						},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Add).To(ContainElement(kubev1.Capability(CAP_SYS_ADMIN)))
+			})
+
+			It("Should not grant SYS_ADMIN without filesystems", func() {
+				pod, err := svc.RenderLaunchManifest(v1.NewMinimalVMI("testvmi"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Add).ToNot(ContainElement(kubev1.Capability(CAP_SYS_ADMIN)))
+			})
+		})
+
 		Context("with a configMap volume source", func() {
 			It("Should add the ConfigMap to template", func() {
 				volumes := []v1.Volume{
@@ -1369,7 +1543,7 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty())
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].ConfigMap).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].ConfigMap.LocalObjectReference.Name).To(Equal("test-configmap"))
 			})
@@ -1398,7 +1572,7 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty())
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].Secret).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].Secret.SecretName).To(Equal("test-secret"))
 			})
@@ -1476,6 +1650,15 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pod.Spec.Containers[0].ReadinessProbe).To(Not(BeNil()))
 			})
+
+			It("should not copy guest agent probes, they are run by virt-handler", func() {
+				vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+				vmi.Spec.LivenessProbe = &v1.Probe{Handler: v1.Handler{Exec: &kubev1.ExecAction{Command: []string{"true"}}}}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].LivenessProbe).To(BeNil())
+				Expect(pod.Spec.Containers[0].ReadinessProbe.Exec.Command).To(Equal([]string{"cat", "/var/run/kubevirt-infra/healthy"}))
+			})
 		})
 
 		It("should add the lessPVCSpaceToleration argument to the template", func() {
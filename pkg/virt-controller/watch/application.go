@@ -106,8 +106,16 @@ type VirtControllerApp struct {
 
 	dataVolumeInformer cache.SharedIndexInformer
 
-	migrationController *MigrationController
-	migrationInformer   cache.SharedIndexInformer
+	migrationController     *MigrationController
+	migrationInformer       cache.SharedIndexInformer
+	migrationPolicyInformer cache.SharedIndexInformer
+	namespaceInformer       cache.SharedIndexInformer
+
+	snapshotController *SnapshotController
+	snapshotInformer   cache.SharedIndexInformer
+
+	restoreController *RestoreController
+	restoreInformer   cache.SharedIndexInformer
 
 	LeaderElection leaderelectionconfig.Configuration
 
@@ -196,6 +204,11 @@ func Execute() {
 	app.vmInformer = app.informerFactory.VirtualMachine()
 
 	app.migrationInformer = app.informerFactory.VirtualMachineInstanceMigration()
+	app.migrationPolicyInformer = app.informerFactory.MigrationPolicy()
+	app.namespaceInformer = app.informerFactory.Namespace()
+
+	app.snapshotInformer = app.informerFactory.VirtualMachineSnapshot()
+	app.restoreInformer = app.informerFactory.VirtualMachineRestore()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
@@ -213,6 +226,7 @@ func Execute() {
 	app.initVirtualMachines()
 	app.initDisruptionBudgetController()
 	app.initEvacuationController()
+	app.initSnapshotController()
 	go app.Run()
 
 	select {
@@ -294,6 +308,8 @@ func (vca *VirtControllerApp) Run() {
 					go vca.rsController.Run(controllerThreads, stop)
 					go vca.vmController.Run(controllerThreads, stop)
 					go vca.migrationController.Run(controllerThreads, stop)
+					go vca.snapshotController.Run(controllerThreads, stop)
+					go vca.restoreController.Run(controllerThreads, stop)
 					cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
 					close(vca.readyChan)
 				},
@@ -339,7 +355,7 @@ func (vca *VirtControllerApp) initCommon() {
 	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer)
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
 	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, recorder)
-	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.migrationInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
+	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.migrationInformer, vca.migrationPolicyInformer, vca.namespaceInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
 }
 
 func (vca *VirtControllerApp) initReplicaSet() {
@@ -381,6 +397,26 @@ func (vca *VirtControllerApp) initEvacuationController() {
 	)
 }
 
+func (vca *VirtControllerApp) initSnapshotController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "snapshot-controller")
+	vca.snapshotController = NewSnapshotController(
+		vca.clientSet,
+		vca.snapshotInformer,
+		vca.vmInformer,
+		vca.vmiInformer,
+		vca.persistentVolumeClaimInformer,
+		recorder,
+	)
+	vca.restoreController = NewRestoreController(
+		vca.clientSet,
+		vca.restoreInformer,
+		vca.snapshotInformer,
+		vca.vmInformer,
+		vca.vmiInformer,
+		recorder,
+	)
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -47,38 +47,44 @@ import (
 )
 
 type MigrationController struct {
-	templateService    services.TemplateService
-	clientset          kubecli.KubevirtClient
-	Queue              workqueue.RateLimitingInterface
-	vmiInformer        cache.SharedIndexInformer
-	podInformer        cache.SharedIndexInformer
-	migrationInformer  cache.SharedIndexInformer
-	recorder           record.EventRecorder
-	podExpectations    *controller.UIDTrackingControllerExpectations
-	migrationStartLock *sync.Mutex
-	clusterConfig      *virtconfig.ClusterConfig
+	templateService         services.TemplateService
+	clientset               kubecli.KubevirtClient
+	Queue                   workqueue.RateLimitingInterface
+	vmiInformer             cache.SharedIndexInformer
+	podInformer             cache.SharedIndexInformer
+	migrationInformer       cache.SharedIndexInformer
+	migrationPolicyInformer cache.SharedIndexInformer
+	namespaceInformer       cache.SharedIndexInformer
+	recorder                record.EventRecorder
+	podExpectations         *controller.UIDTrackingControllerExpectations
+	migrationStartLock      *sync.Mutex
+	clusterConfig           *virtconfig.ClusterConfig
 }
 
 func NewMigrationController(templateService services.TemplateService,
 	vmiInformer cache.SharedIndexInformer,
 	podInformer cache.SharedIndexInformer,
 	migrationInformer cache.SharedIndexInformer,
+	migrationPolicyInformer cache.SharedIndexInformer,
+	namespaceInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
 ) *MigrationController {
 
 	c := &MigrationController{
-		templateService:    templateService,
-		Queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		vmiInformer:        vmiInformer,
-		podInformer:        podInformer,
-		migrationInformer:  migrationInformer,
-		recorder:           recorder,
-		clientset:          clientset,
-		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		migrationStartLock: &sync.Mutex{},
-		clusterConfig:      clusterConfig,
+		templateService:         templateService,
+		Queue:                   workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		vmiInformer:             vmiInformer,
+		podInformer:             podInformer,
+		migrationInformer:       migrationInformer,
+		migrationPolicyInformer: migrationPolicyInformer,
+		namespaceInformer:       namespaceInformer,
+		recorder:                recorder,
+		clientset:               clientset,
+		podExpectations:         controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		migrationStartLock:      &sync.Mutex{},
+		clusterConfig:           clusterConfig,
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -108,7 +114,8 @@ func (c *MigrationController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting migration controller.")
 
 	// Wait for cache sync before we start the pod controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.migrationInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.migrationInformer.HasSynced,
+		c.migrationPolicyInformer.HasSynced, c.namespaceInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -355,6 +362,15 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 				log.Log.Object(migration).Infof("VMI reported migration succeeded.")
 			}
 		}
+
+		if vmi.Status.MigrationState != nil &&
+			vmi.Status.MigrationState.MigrationUID == migration.UID {
+			migrationCopy.Status.MigrationPolicyName = vmi.Status.MigrationState.MigrationPolicyName
+			// Mirror the data transfer progress reported by the source node
+			if vmi.Status.MigrationState.Progress != nil {
+				migrationCopy.Status.Progress = vmi.Status.MigrationState.Progress.DeepCopy()
+			}
+		}
 	}
 
 	if !reflect.DeepEqual(migration.Status, migrationCopy.Status) ||
@@ -397,6 +413,11 @@ func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineIn
 		templatePod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(templatePod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, antiAffinityTerm)
 	}
 
+	// The target has to provide the CPU which the host-model CPU was expanded to on the source
+	if c.clusterConfig.CPUNodeDiscoveryEnabled() {
+		services.SetNodeAffinityForHostModelCPU(vmi, templatePod)
+	}
+
 	templatePod.ObjectMeta.Labels[virtv1.MigrationJobLabel] = string(migration.UID)
 	templatePod.ObjectMeta.Annotations[virtv1.MigrationJobNameAnnotation] = string(migration.Name)
 
@@ -524,6 +545,11 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		// once target pod is scheduled, alert the VMI of the migration by
 		// setting the target and source nodes. This kicks off the preparation stage.
 		if podExists && !podIsDown(pod) {
+			policy, err := c.matchMigrationPolicy(vmi)
+			if err != nil {
+				return err
+			}
+
 			vmiCopy := vmi.DeepCopy()
 			vmiCopy.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
 				MigrationUID: migration.UID,
@@ -531,6 +557,10 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 				SourceNode:   vmi.Status.NodeName,
 				TargetPod:    pod.Name,
 			}
+			// virt-handler on the source node applies the policy when it starts the migration
+			if policy != nil {
+				vmiCopy.Status.MigrationState.MigrationPolicyName = policy.Name
+			}
 
 			// By setting this label, virt-handler on the target node will receive
 			// the vmi and prepare the local environment for the migration
@@ -550,6 +580,21 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 	return nil
 }
 
+// matchMigrationPolicy finds the MigrationPolicy which overrides the cluster wide migration configuration for the VMI
+func (c *MigrationController) matchMigrationPolicy(vmi *virtv1.VirtualMachineInstance) (*virtv1.MigrationPolicy, error) {
+	var namespace *k8sv1.Namespace
+	obj, exists, err := c.namespaceInformer.GetStore().GetByKey(vmi.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		namespace = obj.(*k8sv1.Namespace)
+	}
+
+	policies := migrations.ListMigrationPolicies(c.migrationPolicyInformer)
+	return migrations.MatchMigrationPolicy(policies, vmi, namespace), nil
+}
+
 func (c *MigrationController) listMatchingTargetPods(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) ([]*k8sv1.Pod, error) {
 
 	selector, err := v1.LabelSelectorAsSelector(&v1.LabelSelector{
@@ -42,6 +42,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -57,6 +58,8 @@ var _ = Describe("Migration watcher", func() {
 	var vmiInformer cache.SharedIndexInformer
 	var podInformer cache.SharedIndexInformer
 	var migrationInformer cache.SharedIndexInformer
+	var migrationPolicyInformer cache.SharedIndexInformer
+	var namespaceInformer cache.SharedIndexInformer
 	var stop chan struct{}
 	var controller *MigrationController
 	var recorder *record.FakeRecorder
@@ -66,6 +69,7 @@ var _ = Describe("Migration watcher", func() {
 	var kubeClient *fake.Clientset
 	var networkClient *fakenetworkclient.Clientset
 	var pvcInformer cache.SharedIndexInformer
+	var configMapInformer cache.SharedIndexInformer
 
 	shouldExpectPodCreation := func(uid types.UID, migrationUid types.UID, expectedAntiAffinityCount int, expectedAffinityCount int, expectedNodeAffinityCount int) {
 		// Expect pod creation
@@ -142,11 +146,15 @@ var _ = Describe("Migration watcher", func() {
 		go vmiInformer.Run(stop)
 		go podInformer.Run(stop)
 		go migrationInformer.Run(stop)
+		go migrationPolicyInformer.Run(stop)
+		go namespaceInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			podInformer.HasSynced,
-			migrationInformer.HasSynced)).To(BeTrue())
+			migrationInformer.HasSynced,
+			migrationPolicyInformer.HasSynced,
+			namespaceInformer.HasSynced)).To(BeTrue())
 	}
 
 	BeforeEach(func() {
@@ -159,16 +167,21 @@ var _ = Describe("Migration watcher", func() {
 		vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		migrationInformer, migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		podInformer, podSource = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		migrationPolicyInformer, _ = testutils.NewFakeInformerFor(&v1.MigrationPolicy{})
+		namespaceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Namespace{})
 		recorder = record.NewFakeRecorder(100)
 
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
-		config, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		config, cmInformer, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		configMapInformer = cmInformer
 
 		controller = NewMigrationController(
 			services.NewTemplateService("a", "b", "c", "d", "e", "f", pvcInformer.GetStore(), virtClient, config),
 			vmiInformer,
 			podInformer,
 			migrationInformer,
+			migrationPolicyInformer,
+			namespaceInformer,
 			recorder,
 			virtClient,
 			config,
@@ -414,6 +427,44 @@ var _ = Describe("Migration watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
 
+		It("should restrict the target pod to nodes which support the expanded host-model CPU", func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.CPUNodeDiscoveryGate},
+			})
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.HostModelCPU = &v1.HostModelCPU{
+				Model:    "Skylake-Client-IBRS",
+				Features: []string{"vmx"},
+			}
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				Expect(pod.Spec.Affinity.NodeAffinity).ToNot(BeNil())
+				terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				Expect(terms).To(HaveLen(1))
+				Expect(terms[0].MatchExpressions).To(ConsistOf(
+					k8sv1.NodeSelectorRequirement{
+						Key:      services.NFD_CPU_MODEL_PREFIX + "Skylake-Client-IBRS",
+						Operator: k8sv1.NodeSelectorOpIn,
+						Values:   []string{"true"},
+					},
+					k8sv1.NodeSelectorRequirement{
+						Key:      services.NFD_CPU_FEATURE_PREFIX + "vmx",
+						Operator: k8sv1.NodeSelectorOpIn,
+						Values:   []string{"true"},
+					},
+				))
+				return true, pod, nil
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
 		It("should place migration in scheduling state if pod exists", func() {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
@@ -548,6 +599,41 @@ var _ = Describe("Migration watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
 		})
 
+		It("should hand pod over to target virt-handler with the matching migration policy", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			vmi.Labels["workload"] = "database"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+
+			namespaceInformer.GetStore().Add(&k8sv1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   k8sv1.NamespaceDefault,
+					Labels: map[string]string{"team": "storage"},
+				},
+			})
+			for _, policy := range []*v1.MigrationPolicy{
+				newMigrationPolicy("namespace-policy", map[string]string{"team": "storage"}, nil),
+				newMigrationPolicy("database-policy", map[string]string{"team": "storage"}, map[string]string{"workload": "database"}),
+				newMigrationPolicy("other-policy", map[string]string{"team": "network"}, nil),
+			} {
+				migrationPolicyInformer.GetStore().Add(policy)
+			}
+
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.MigrationState.MigrationUID).To(Equal(migration.UID))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.MigrationState.MigrationPolicyName).To(Equal("database-policy"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
+
 		It("should hand pod over to target virt-handler overriding previous state", func() {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			vmi.Status.NodeName = "node02"
@@ -659,6 +745,64 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulMigrationReason)
 		})
+		It("should mirror the migration progress of the VMI", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationRunning)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:      migration.UID,
+				TargetNode:        "node01",
+				SourceNode:        "node02",
+				TargetNodeAddress: "10.10.10.10:1234",
+				StartTimestamp:    now(),
+				Progress: &v1.VirtualMachineInstanceMigrationProgress{
+					Timestamp:          now(),
+					DataRemainingBytes: 1024,
+					MemoryIteration:    3,
+				},
+			}
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			migrationInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				updated := arg.(*v1.VirtualMachineInstanceMigration)
+				Expect(updated.Status.Phase).To(Equal(v1.MigrationRunning))
+				Expect(updated.Status.Progress).To(Equal(vmi.Status.MigrationState.Progress))
+			}).Return(migration, nil)
+
+			controller.Execute()
+		})
+		It("should record the applied migration policy", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationTargetReady)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:        migration.UID,
+				TargetNode:          "node01",
+				SourceNode:          "node02",
+				TargetNodeAddress:   "10.10.10.10:1234",
+				StartTimestamp:      now(),
+				MigrationPolicyName: "database-policy",
+			}
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			migrationInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				updated := arg.(*v1.VirtualMachineInstanceMigration)
+				Expect(updated.Status.Phase).To(Equal(v1.MigrationRunning))
+				Expect(updated.Status.MigrationPolicyName).To(Equal("database-policy"))
+			}).Return(migration, nil)
+
+			controller.Execute()
+		})
 		It("should delete itself if VMI no longer exists", func() {
 			migration := newMigration("testmigration", "somevmi", v1.MigrationRunning)
 			addMigration(migration)
@@ -722,6 +866,24 @@ func newMigration(name string, vmiName string, phase v1.VirtualMachineInstanceMi
 	return migration
 }
 
+func newMigrationPolicy(name string, namespaceLabels map[string]string, vmiLabels map[string]string) *v1.MigrationPolicy {
+	policy := &v1.MigrationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.MigrationPolicySpec{
+			Selectors: &v1.MigrationPolicySelectors{},
+		},
+	}
+	if namespaceLabels != nil {
+		policy.Spec.Selectors.NamespaceSelector = &metav1.LabelSelector{MatchLabels: namespaceLabels}
+	}
+	if vmiLabels != nil {
+		policy.Spec.Selectors.VirtualMachineInstanceSelector = &metav1.LabelSelector{MatchLabels: vmiLabels}
+	}
+	return policy
+}
+
 func newVirtualMachine(name string, phase v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
 	vmi := v1.NewMinimalVMI(name)
 	vmi.UID = types.UID(name)
@@ -0,0 +1,428 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	// SuccessfulRestorePVCCreateReason is added in an event when a PersistentVolumeClaim is recreated from a VolumeSnapshot
+	SuccessfulRestorePVCCreateReason = "SuccessfulRestorePVCCreate"
+	// FailedRestorePVCCreateReason is added in an event when recreating a PersistentVolumeClaim failed
+	FailedRestorePVCCreateReason = "FailedRestorePVCCreate"
+	// SuccessfulVirtualMachineRestoreReason is added in an event when the VirtualMachine was rewritten
+	SuccessfulVirtualMachineRestoreReason = "SuccessfulVirtualMachineRestore"
+	// FailedVirtualMachineRestoreReason is added in an event when a restore could not be performed
+	FailedVirtualMachineRestoreReason = "FailedVirtualMachineRestore"
+)
+
+// restoreSourceVMLabel marks the PersistentVolumeClaims which were recreated by a restore, with the name
+// of the VirtualMachine they were restored for.
+const restoreSourceVMLabel = "restore.kubevirt.io/source-vm-name"
+
+// restoreVMIPollInterval is the interval in which a pending restore rechecks whether
+// the VirtualMachineInstance of its target is gone.
+const restoreVMIPollInterval = 5 * time.Second
+
+type RestoreController struct {
+	clientset        kubecli.KubevirtClient
+	Queue            workqueue.RateLimitingInterface
+	restoreInformer  cache.SharedIndexInformer
+	snapshotInformer cache.SharedIndexInformer
+	vmInformer       cache.SharedIndexInformer
+	vmiInformer      cache.SharedIndexInformer
+	recorder         record.EventRecorder
+}
+
+func NewRestoreController(clientset kubecli.KubevirtClient,
+	restoreInformer cache.SharedIndexInformer,
+	snapshotInformer cache.SharedIndexInformer,
+	vmInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder) *RestoreController {
+
+	c := &RestoreController{
+		clientset:        clientset,
+		Queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		restoreInformer:  restoreInformer,
+		snapshotInformer: snapshotInformer,
+		vmInformer:       vmInformer,
+		vmiInformer:      vmiInformer,
+		recorder:         recorder,
+	}
+
+	c.restoreInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addRestore,
+		DeleteFunc: c.deleteRestore,
+		UpdateFunc: c.updateRestore,
+	})
+
+	return c
+}
+
+func (c *RestoreController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting restore controller.")
+
+	cache.WaitForCacheSync(stopCh, c.restoreInformer.HasSynced, c.snapshotInformer.HasSynced, c.vmInformer.HasSynced, c.vmiInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping restore controller.")
+}
+
+func (c *RestoreController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *RestoreController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachineRestore %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachineRestore %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *RestoreController) execute(key string) error {
+	obj, exists, err := c.restoreInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	restore := obj.(*virtv1.VirtualMachineRestore)
+	if restore.IsFinal() || restore.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if restore.Spec.Target.Kind != "VirtualMachine" {
+		return c.failRestore(restore, fmt.Sprintf("Restoring %s is not supported", restore.Spec.Target.Kind))
+	}
+
+	snapshotObj, snapshotExists, err := c.snapshotInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", restore.Namespace, restore.Spec.VirtualMachineSnapshotName))
+	if err != nil {
+		return err
+	}
+	if !snapshotExists {
+		return c.failRestore(restore, fmt.Sprintf("VirtualMachineSnapshot %s does not exist", restore.Spec.VirtualMachineSnapshotName))
+	}
+	snapshot := snapshotObj.(*virtv1.VirtualMachineSnapshot)
+
+	if snapshot.Status.Phase != virtv1.VirtualMachineSnapshotSucceeded || snapshot.Status.VirtualMachineSpec == nil {
+		return c.failRestore(restore, fmt.Sprintf("VirtualMachineSnapshot %s is not ready to use", snapshot.Name))
+	}
+	if snapshot.Spec.Source.Name != restore.Spec.Target.Name {
+		return c.failRestore(restore, fmt.Sprintf("VirtualMachineSnapshot %s was not taken from VirtualMachine %s", snapshot.Name, restore.Spec.Target.Name))
+	}
+
+	vmKey := fmt.Sprintf("%s/%s", restore.Namespace, restore.Spec.Target.Name)
+	vmObj, vmExists, err := c.vmInformer.GetStore().GetByKey(vmKey)
+	if err != nil {
+		return err
+	}
+	if !vmExists {
+		return c.failRestore(restore, fmt.Sprintf("VirtualMachine %s does not exist", restore.Spec.Target.Name))
+	}
+	vm := vmObj.(*virtv1.VirtualMachine)
+
+	_, vmiExists, err := c.vmiInformer.GetStore().GetByKey(vmKey)
+	if err != nil {
+		return err
+	}
+	if vmiExists {
+		log.Log.Object(restore).Infof("Waiting for VirtualMachine %s to be stopped before it is restored", vm.Name)
+		c.Queue.AddAfter(key, restoreVMIPollInterval)
+		return nil
+	}
+
+	if restore.Status.Phase == virtv1.VirtualMachineRestorePhaseUnset {
+		restoreCopy := restore.DeepCopy()
+		restoreCopy.Status.Phase = virtv1.VirtualMachineRestoreInProgress
+		for _, backup := range snapshot.Status.VolumeBackups {
+			restoreCopy.Status.Restores = append(restoreCopy.Status.Restores, virtv1.VolumeRestore{
+				VolumeName:                backup.VolumeName,
+				PersistentVolumeClaimName: restorePVCName(restore, backup.VolumeName),
+				VolumeSnapshotName:        backup.VolumeSnapshotName,
+			})
+		}
+		_, err := c.clientset.VirtualMachineRestore(restore.Namespace).Update(restoreCopy)
+		return err
+	}
+
+	for _, volumeRestore := range restore.Status.Restores {
+		backup := findVolumeBackup(snapshot, volumeRestore.VolumeName)
+		if backup == nil {
+			return c.failRestore(restore, fmt.Sprintf("VirtualMachineSnapshot %s has no backup of volume %s", snapshot.Name, volumeRestore.VolumeName))
+		}
+		if err := c.createRestorePVC(restore, vm, backup, volumeRestore); err != nil {
+			return err
+		}
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Spec = restoredVirtualMachineSpec(vm, snapshot.Status.VirtualMachineSpec, restore.Status.Restores)
+	if !reflect.DeepEqual(vm.Spec, vmCopy.Spec) {
+		if err := c.deleteReplacedPVCs(vm, &vmCopy.Spec); err != nil {
+			return err
+		}
+		if _, err := c.clientset.VirtualMachine(vm.Namespace).Update(vmCopy); err != nil {
+			return err
+		}
+	}
+
+	restoreCopy := restore.DeepCopy()
+	restoreCopy.Status.Phase = virtv1.VirtualMachineRestoreSucceeded
+	now := v1.Now()
+	restoreCopy.Status.RestoreTime = &now
+	c.recorder.Eventf(restore, k8sv1.EventTypeNormal, SuccessfulVirtualMachineRestoreReason, "Restored VirtualMachine %s from VirtualMachineSnapshot %s", vm.Name, snapshot.Name)
+
+	_, err = c.clientset.VirtualMachineRestore(restore.Namespace).Update(restoreCopy)
+	return err
+}
+
+// createRestorePVC recreates the PersistentVolumeClaim of a volume backup, populated from its VolumeSnapshot
+func (c *RestoreController) createRestorePVC(restore *virtv1.VirtualMachineRestore, vm *virtv1.VirtualMachine, backup *virtv1.VolumeBackup, volumeRestore virtv1.VolumeRestore) error {
+	_, err := c.clientset.CoreV1().PersistentVolumeClaims(restore.Namespace).Get(volumeRestore.PersistentVolumeClaimName, v1.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	apiGroup := snapshotv1.GroupName
+	spec := backup.PersistentVolumeClaimSpec.DeepCopy()
+	// the claim is provisioned from the snapshot and must not bind to the original volume
+	spec.VolumeName = ""
+	spec.DataSource = &k8sv1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     "VolumeSnapshot",
+		Name:     volumeRestore.VolumeSnapshotName,
+	}
+
+	pvc := &k8sv1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      volumeRestore.PersistentVolumeClaimName,
+			Namespace: restore.Namespace,
+			Labels: map[string]string{
+				restoreSourceVMLabel: vm.Name,
+			},
+			OwnerReferences: []v1.OwnerReference{
+				*v1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: *spec,
+	}
+
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(restore.Namespace).Create(pvc)
+	if err != nil {
+		c.recorder.Eventf(restore, k8sv1.EventTypeWarning, FailedRestorePVCCreateReason, "Error creating PersistentVolumeClaim %s: %v", pvc.Name, err)
+		return err
+	}
+	c.recorder.Eventf(restore, k8sv1.EventTypeNormal, SuccessfulRestorePVCCreateReason, "Created PersistentVolumeClaim %s", pvc.Name)
+	return nil
+}
+
+// deleteReplacedPVCs deletes the PersistentVolumeClaims of former restores which are no longer referenced
+// by the restored VirtualMachine. Claims which were not created by a restore belong to the user and are left alone.
+func (c *RestoreController) deleteReplacedPVCs(vm *virtv1.VirtualMachine, restoredSpec *virtv1.VirtualMachineSpec) error {
+	if vm.Spec.Template == nil {
+		return nil
+	}
+
+	referenced := map[string]bool{}
+	if restoredSpec.Template != nil {
+		for _, volume := range restoredSpec.Template.Spec.Volumes {
+			if claimName := volumeClaimName(volume); claimName != "" {
+				referenced[claimName] = true
+			}
+		}
+	}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		claimName := volumeClaimName(volume)
+		if claimName == "" || referenced[claimName] {
+			continue
+		}
+
+		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Get(claimName, v1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !v1.IsControlledBy(pvc, vm) || pvc.Labels[restoreSourceVMLabel] != vm.Name {
+			continue
+		}
+
+		err = c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Delete(claimName, &v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Log.Object(vm).Infof("Deleted replaced PersistentVolumeClaim %s", claimName)
+	}
+	return nil
+}
+
+func (c *RestoreController) failRestore(restore *virtv1.VirtualMachineRestore, message string) error {
+	restoreCopy := restore.DeepCopy()
+	restoreCopy.Status.Phase = virtv1.VirtualMachineRestoreFailed
+	restoreCopy.Status.Message = message
+	c.recorder.Event(restore, k8sv1.EventTypeWarning, FailedVirtualMachineRestoreReason, message)
+	log.Log.Object(restore).Error(message)
+
+	_, err := c.clientset.VirtualMachineRestore(restore.Namespace).Update(restoreCopy)
+	return err
+}
+
+func (c *RestoreController) addRestore(obj interface{}) {
+	c.enqueueRestore(obj)
+}
+
+func (c *RestoreController) deleteRestore(obj interface{}) {
+	c.enqueueRestore(obj)
+}
+
+func (c *RestoreController) updateRestore(old, curr interface{}) {
+	c.enqueueRestore(curr)
+}
+
+func (c *RestoreController) enqueueRestore(obj interface{}) {
+	logger := log.Log
+	restore, ok := obj.(*virtv1.VirtualMachineRestore)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			logger.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		restore, ok = tombstone.Obj.(*virtv1.VirtualMachineRestore)
+		if !ok {
+			logger.Reason(fmt.Errorf("tombstone contained object that is not a restore %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	key, err := controller.KeyFunc(restore)
+	if err != nil {
+		logger.Object(restore).Reason(err).Error("Failed to extract key from restore.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+// volumeClaimName returns the name of the PersistentVolumeClaim backing a volume.
+// A DataVolume is backed by a PersistentVolumeClaim with the same name.
+func volumeClaimName(volume virtv1.Volume) string {
+	if volume.PersistentVolumeClaim != nil {
+		return volume.PersistentVolumeClaim.ClaimName
+	} else if volume.DataVolume != nil {
+		return volume.DataVolume.Name
+	}
+	return ""
+}
+
+// restoredVirtualMachineSpec returns the recorded VirtualMachine spec with all restored volumes pointing
+// to the recreated PersistentVolumeClaims. DataVolumes of restored volumes are replaced by their claims,
+// so their templates are dropped. The current run state of the VirtualMachine is kept.
+func restoredVirtualMachineSpec(vm *virtv1.VirtualMachine, recorded *virtv1.VirtualMachineSpec, restores []virtv1.VolumeRestore) virtv1.VirtualMachineSpec {
+	spec := recorded.DeepCopy()
+	spec.Running = vm.Spec.Running
+	spec.RunStrategy = vm.Spec.RunStrategy
+
+	if spec.Template == nil {
+		return *spec
+	}
+
+	replacedDataVolumes := map[string]bool{}
+	for i, volume := range spec.Template.Spec.Volumes {
+		for _, volumeRestore := range restores {
+			if volumeRestore.VolumeName != volume.Name {
+				continue
+			}
+			if volume.DataVolume != nil {
+				replacedDataVolumes[volume.DataVolume.Name] = true
+			}
+			spec.Template.Spec.Volumes[i].VolumeSource = virtv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volumeRestore.PersistentVolumeClaimName,
+				},
+			}
+		}
+	}
+
+	dataVolumeTemplates := spec.DataVolumeTemplates[:0]
+	for _, template := range spec.DataVolumeTemplates {
+		if !replacedDataVolumes[template.Name] {
+			dataVolumeTemplates = append(dataVolumeTemplates, template)
+		}
+	}
+	if len(dataVolumeTemplates) == 0 {
+		dataVolumeTemplates = nil
+	}
+	spec.DataVolumeTemplates = dataVolumeTemplates
+
+	return *spec
+}
+
+func findVolumeBackup(snapshot *virtv1.VirtualMachineSnapshot, volumeName string) *virtv1.VolumeBackup {
+	for i := range snapshot.Status.VolumeBackups {
+		if snapshot.Status.VolumeBackups[i].VolumeName == volumeName {
+			return &snapshot.Status.VolumeBackups[i]
+		}
+	}
+	return nil
+}
+
+func restorePVCName(restore *virtv1.VirtualMachineRestore, volumeName string) string {
+	return fmt.Sprintf("restore-%s-%s", restore.UID, volumeName)
+}
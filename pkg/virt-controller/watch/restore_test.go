@@ -0,0 +1,333 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Restore controller", func() {
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	var ctrl *gomock.Controller
+	var virtClient *kubecli.MockKubevirtClient
+	var restoreInterface *kubecli.MockVirtualMachineRestoreInterface
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var kubeClient *fake.Clientset
+	var restoreSource *framework.FakeControllerSource
+	var restoreInformer cache.SharedIndexInformer
+	var snapshotInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var stop chan struct{}
+	var controller *RestoreController
+	var recorder *record.FakeRecorder
+	var mockQueue *testutils.MockWorkQueue
+
+	syncCaches := func(stop chan struct{}) {
+		go restoreInformer.Run(stop)
+		go snapshotInformer.Run(stop)
+		go vmInformer.Run(stop)
+		go vmiInformer.Run(stop)
+
+		Expect(cache.WaitForCacheSync(stop,
+			restoreInformer.HasSynced,
+			snapshotInformer.HasSynced,
+			vmInformer.HasSynced,
+			vmiInformer.HasSynced)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		restoreInterface = kubecli.NewMockVirtualMachineRestoreInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+
+		restoreInformer, restoreSource = testutils.NewFakeInformerFor(&v1.VirtualMachineRestore{})
+		snapshotInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineSnapshot{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = NewRestoreController(virtClient, restoreInformer, snapshotInformer, vmInformer, vmiInformer, recorder)
+		// Wrap our workqueue to have a way to detect when we are done processing updates
+		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
+		controller.Queue = mockQueue
+
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineRestore(k8sv1.NamespaceDefault).Return(restoreInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(k8sv1.NamespaceDefault).Return(vmInterface).AnyTimes()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		syncCaches(stop)
+	})
+
+	AfterEach(func() {
+		close(stop)
+		// Ensure that we add checks for expected events to every test
+		Expect(recorder.Events).To(BeEmpty())
+		ctrl.Finish()
+	})
+
+	addRestore := func(restore *v1.VirtualMachineRestore) {
+		mockQueue.ExpectAdds(1)
+		restoreSource.Add(restore)
+		mockQueue.Wait()
+	}
+
+	shouldExpectRestoreUpdate := func(check func(*v1.VirtualMachineRestore)) {
+		restoreInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+			check(arg.(*v1.VirtualMachineRestore))
+		}).Return(nil, nil)
+	}
+
+	Context("with a finished snapshot", func() {
+		var vm *v1.VirtualMachine
+		var snapshot *v1.VirtualMachineSnapshot
+
+		BeforeEach(func() {
+			vm = newSnapshotSourceVM("testvm")
+			vm.Spec.DataVolumeTemplates = []cdiv1.DataVolume{
+				{ObjectMeta: metav1.ObjectMeta{Name: "testdv"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "otherdv"}},
+			}
+			snapshot = newVMSnapshot("testsnapshot", vm.Name, v1.VirtualMachineSnapshotSucceeded)
+			snapshot.Status.VirtualMachineSpec = vm.Spec.DeepCopy()
+			snapshot.Status.VolumeBackups = []v1.VolumeBackup{
+				{
+					VolumeName:                "disk0",
+					PersistentVolumeClaimName: "testpvc",
+					PersistentVolumeClaimSpec: newSnapshotSourcePVC("testpvc").Spec,
+					VolumeSnapshotName:        "vs0",
+				},
+				{
+					VolumeName:                "disk1",
+					PersistentVolumeClaimName: "testdv",
+					PersistentVolumeClaimSpec: newSnapshotSourcePVC("testdv").Spec,
+					VolumeSnapshotName:        "vs1",
+				},
+			}
+			snapshotInformer.GetStore().Add(snapshot)
+		})
+
+		It("should determine the PersistentVolumeClaims to restore", func() {
+			vmInformer.GetStore().Add(vm)
+			addRestore(newVMRestore("testrestore", vm.Name, snapshot.Name, v1.VirtualMachineRestorePhaseUnset))
+
+			shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+				Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreInProgress))
+				Expect(r.Status.Restores).To(Equal([]v1.VolumeRestore{
+					{VolumeName: "disk0", PersistentVolumeClaimName: "restore-restore-uid-disk0", VolumeSnapshotName: "vs0"},
+					{VolumeName: "disk1", PersistentVolumeClaimName: "restore-restore-uid-disk1", VolumeSnapshotName: "vs1"},
+				}))
+			})
+
+			controller.Execute()
+		})
+
+		It("should recreate the PersistentVolumeClaims and rewrite the VirtualMachine", func() {
+			// the VirtualMachine was modified after the snapshot was taken
+			vm.Spec.Template.Spec.Volumes = vm.Spec.Template.Spec.Volumes[:1]
+			running := true
+			vm.Spec.Running = &running
+			vmInformer.GetStore().Add(vm)
+
+			restore := newVMRestore("testrestore", vm.Name, snapshot.Name, v1.VirtualMachineRestoreInProgress)
+			restore.Status.Restores = []v1.VolumeRestore{
+				{VolumeName: "disk0", PersistentVolumeClaimName: "restore-restore-uid-disk0", VolumeSnapshotName: "vs0"},
+				{VolumeName: "disk1", PersistentVolumeClaimName: "restore-restore-uid-disk1", VolumeSnapshotName: "vs1"},
+			}
+			addRestore(restore)
+
+			var createdPVCs []*k8sv1.PersistentVolumeClaim
+			kubeClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				createdPVCs = append(createdPVCs, create.GetObject().(*k8sv1.PersistentVolumeClaim))
+				return true, create.GetObject(), nil
+			})
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				spec := arg.(*v1.VirtualMachine).Spec
+				Expect(*spec.Running).To(BeTrue())
+				Expect(spec.Template.Spec.Volumes).To(HaveLen(3))
+				Expect(spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("restore-restore-uid-disk0"))
+				Expect(spec.Template.Spec.Volumes[1].DataVolume).To(BeNil())
+				Expect(spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("restore-restore-uid-disk1"))
+				Expect(spec.Template.Spec.Volumes[2].CloudInitNoCloud).ToNot(BeNil())
+				Expect(spec.DataVolumeTemplates).To(HaveLen(1))
+				Expect(spec.DataVolumeTemplates[0].Name).To(Equal("otherdv"))
+			}).Return(vm, nil)
+			shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+				Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreSucceeded))
+				Expect(r.Status.RestoreTime).ToNot(BeNil())
+			})
+
+			controller.Execute()
+
+			Expect(createdPVCs).To(HaveLen(2))
+			Expect(createdPVCs[0].Name).To(Equal("restore-restore-uid-disk0"))
+			Expect(createdPVCs[0].Spec.VolumeName).To(BeEmpty())
+			Expect(*createdPVCs[0].Spec.DataSource.APIGroup).To(Equal(snapshotv1.GroupName))
+			Expect(createdPVCs[0].Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+			Expect(createdPVCs[0].Spec.DataSource.Name).To(Equal("vs0"))
+			Expect(createdPVCs[0].OwnerReferences[0].UID).To(Equal(vm.UID))
+			Expect(createdPVCs[0].Labels).To(HaveKeyWithValue(restoreSourceVMLabel, vm.Name))
+			testutils.ExpectEvents(recorder,
+				SuccessfulRestorePVCCreateReason,
+				SuccessfulRestorePVCCreateReason,
+				SuccessfulVirtualMachineRestoreReason)
+		})
+
+		It("should wait until the VirtualMachine is stopped", func() {
+			vmInformer.GetStore().Add(vm)
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addRestore(newVMRestore("testrestore", vm.Name, snapshot.Name, v1.VirtualMachineRestorePhaseUnset))
+
+			controller.Execute()
+
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(0))
+		})
+
+		It("should delete replaced claims of former restores and leave other replaced claims alone", func() {
+			vm.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "restore-former-disk0"
+			vmInformer.GetStore().Add(vm)
+
+			formerRestorePVC := newSnapshotSourcePVC("restore-former-disk0")
+			formerRestorePVC.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+			formerRestorePVC.Labels = map[string]string{restoreSourceVMLabel: vm.Name}
+			kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Create(formerRestorePVC)
+			kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Create(newSnapshotSourcePVC("testdv"))
+
+			restore := newVMRestore("testrestore", vm.Name, snapshot.Name, v1.VirtualMachineRestoreInProgress)
+			restore.Status.Restores = []v1.VolumeRestore{
+				{VolumeName: "disk0", PersistentVolumeClaimName: "restore-restore-uid-disk0", VolumeSnapshotName: "vs0"},
+				{VolumeName: "disk1", PersistentVolumeClaimName: "restore-restore-uid-disk1", VolumeSnapshotName: "vs1"},
+			}
+			addRestore(restore)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Return(vm, nil)
+			shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+				Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreSucceeded))
+			})
+
+			controller.Execute()
+
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Get("restore-former-disk0", metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Get("testdv", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+			testutils.ExpectEvents(recorder,
+				SuccessfulRestorePVCCreateReason,
+				SuccessfulRestorePVCCreateReason,
+				SuccessfulVirtualMachineRestoreReason)
+		})
+
+		It("should enqueue restores from delete tombstones", func() {
+			restore := newVMRestore("testrestore", vm.Name, snapshot.Name, v1.VirtualMachineRestoreInProgress)
+			mockQueue.ExpectAdds(1)
+			controller.deleteRestore(cache.DeletedFinalStateUnknown{Key: "default/testrestore", Obj: restore})
+			mockQueue.Wait()
+			Expect(controller.Queue.Len()).To(Equal(1))
+		})
+
+		It("should fail if the snapshot was taken from another VirtualMachine", func() {
+			otherVM := newSnapshotSourceVM("othervm")
+			vmInformer.GetStore().Add(otherVM)
+			addRestore(newVMRestore("testrestore", otherVM.Name, snapshot.Name, v1.VirtualMachineRestorePhaseUnset))
+
+			shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+				Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreFailed))
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineRestoreReason)
+		})
+	})
+
+	It("should fail if the snapshot is not ready to use", func() {
+		vm := newSnapshotSourceVM("testvm")
+		vmInformer.GetStore().Add(vm)
+		snapshotInformer.GetStore().Add(newVMSnapshot("testsnapshot", vm.Name, v1.VirtualMachineSnapshotInProgress))
+		addRestore(newVMRestore("testrestore", vm.Name, "testsnapshot", v1.VirtualMachineRestorePhaseUnset))
+
+		shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+			Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreFailed))
+			Expect(r.Status.Message).To(ContainSubstring("not ready"))
+		})
+
+		controller.Execute()
+
+		testutils.ExpectEvent(recorder, FailedVirtualMachineRestoreReason)
+	})
+
+	It("should fail if the snapshot does not exist", func() {
+		addRestore(newVMRestore("testrestore", "testvm", "testsnapshot", v1.VirtualMachineRestorePhaseUnset))
+
+		shouldExpectRestoreUpdate(func(r *v1.VirtualMachineRestore) {
+			Expect(r.Status.Phase).To(Equal(v1.VirtualMachineRestoreFailed))
+			Expect(r.Status.Message).To(ContainSubstring("does not exist"))
+		})
+
+		controller.Execute()
+
+		testutils.ExpectEvent(recorder, FailedVirtualMachineRestoreReason)
+	})
+})
+
+func newVMRestore(name string, vmName string, snapshotName string, phase v1.VirtualMachineRestorePhase) *v1.VirtualMachineRestore {
+	return &v1.VirtualMachineRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: k8sv1.NamespaceDefault,
+			UID:       "restore-uid",
+		},
+		Spec: v1.VirtualMachineRestoreSpec{
+			Target: k8sv1.TypedLocalObjectReference{
+				Kind: "VirtualMachine",
+				Name: vmName,
+			},
+			VirtualMachineSnapshotName: snapshotName,
+		},
+		Status: v1.VirtualMachineRestoreStatus{
+			Phase: phase,
+		},
+	}
+}
@@ -0,0 +1,503 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	// SuccessfulVolumeSnapshotCreateReason is added in an event when a VolumeSnapshot is created
+	SuccessfulVolumeSnapshotCreateReason = "SuccessfulVolumeSnapshotCreate"
+	// FailedVolumeSnapshotCreateReason is added in an event when creating a VolumeSnapshot failed
+	FailedVolumeSnapshotCreateReason = "FailedVolumeSnapshotCreate"
+	// FailedFreezeReason is added in an event when the guest filesystems could not be frozen
+	FailedFreezeReason = "FailedFreeze"
+	// FailedUnfreezeReason is added in an event when the guest filesystems could not be thawed
+	FailedUnfreezeReason = "FailedUnfreeze"
+	// SuccessfulVirtualMachineSnapshotReason is added in an event when all volume snapshots are ready
+	SuccessfulVirtualMachineSnapshotReason = "SuccessfulVirtualMachineSnapshot"
+	// FailedVirtualMachineSnapshotReason is added in an event when a snapshot could not be taken
+	FailedVirtualMachineSnapshotReason = "FailedVirtualMachineSnapshot"
+)
+
+// snapshotPollInterval is the interval in which pending volume snapshots are rechecked.
+// The VolumeSnapshots are not watched, since the snapshot api group is optional.
+const snapshotPollInterval = 5 * time.Second
+
+// snapshotFreezeTimeout is the maximum time a guest stays frozen while waiting for the
+// storage to take the volume snapshots. Afterwards the guest is thawed and the snapshot fails.
+const snapshotFreezeTimeout = 5 * time.Minute
+
+type SnapshotController struct {
+	clientset        kubecli.KubevirtClient
+	Queue            workqueue.RateLimitingInterface
+	snapshotInformer cache.SharedIndexInformer
+	vmInformer       cache.SharedIndexInformer
+	vmiInformer      cache.SharedIndexInformer
+	pvcInformer      cache.SharedIndexInformer
+	recorder         record.EventRecorder
+}
+
+func NewSnapshotController(clientset kubecli.KubevirtClient,
+	snapshotInformer cache.SharedIndexInformer,
+	vmInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder) *SnapshotController {
+
+	c := &SnapshotController{
+		clientset:        clientset,
+		Queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		snapshotInformer: snapshotInformer,
+		vmInformer:       vmInformer,
+		vmiInformer:      vmiInformer,
+		pvcInformer:      pvcInformer,
+		recorder:         recorder,
+	}
+
+	c.snapshotInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addSnapshot,
+		DeleteFunc: c.deleteSnapshot,
+		UpdateFunc: c.updateSnapshot,
+	})
+
+	return c
+}
+
+func (c *SnapshotController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting snapshot controller.")
+
+	cache.WaitForCacheSync(stopCh, c.snapshotInformer.HasSynced, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.pvcInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping snapshot controller.")
+}
+
+func (c *SnapshotController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *SnapshotController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachineSnapshot %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachineSnapshot %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *SnapshotController) execute(key string) error {
+	obj, exists, err := c.snapshotInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	snapshot := obj.(*virtv1.VirtualMachineSnapshot)
+	if snapshot.IsFinal() || snapshot.DeletionTimestamp != nil {
+		return c.releaseSnapshot(snapshot)
+	}
+
+	if snapshot.Spec.Source.Kind != "VirtualMachine" {
+		return c.failSnapshot(snapshot, fmt.Sprintf("Snapshots of %s are not supported", snapshot.Spec.Source.Kind))
+	}
+
+	vmObj, vmExists, err := c.vmInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", snapshot.Namespace, snapshot.Spec.Source.Name))
+	if err != nil {
+		return err
+	}
+	if !vmExists {
+		return c.failSnapshot(snapshot, fmt.Sprintf("VirtualMachine %s does not exist", snapshot.Spec.Source.Name))
+	}
+	vm := vmObj.(*virtv1.VirtualMachine)
+
+	if snapshot.Status.Phase == virtv1.VirtualMachineSnapshotPhaseUnset {
+		return c.recordVirtualMachine(snapshot, vm)
+	}
+
+	if snapshot.Status.SourceUID == nil || *snapshot.Status.SourceUID != vm.UID {
+		return c.failSnapshot(snapshot, fmt.Sprintf("VirtualMachine %s was recreated during the snapshot", vm.Name))
+	}
+
+	return c.syncVolumeSnapshots(key, snapshot, vm)
+}
+
+// recordVirtualMachine stores the VirtualMachine spec and determines the volumes which need to be backed up
+func (c *SnapshotController) recordVirtualMachine(snapshot *virtv1.VirtualMachineSnapshot, vm *virtv1.VirtualMachine) error {
+	var backups []virtv1.VolumeBackup
+
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			claimName := ""
+			if volume.PersistentVolumeClaim != nil {
+				claimName = volume.PersistentVolumeClaim.ClaimName
+			} else if volume.DataVolume != nil {
+				// a DataVolume is backed by a PersistentVolumeClaim with the same name
+				claimName = volume.DataVolume.Name
+			} else {
+				continue
+			}
+
+			pvcObj, pvcExists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", snapshot.Namespace, claimName))
+			if err != nil {
+				return err
+			}
+			if !pvcExists {
+				return c.failSnapshot(snapshot, fmt.Sprintf("PersistentVolumeClaim %s of volume %s does not exist", claimName, volume.Name))
+			}
+			pvc := pvcObj.(*k8sv1.PersistentVolumeClaim)
+
+			backups = append(backups, virtv1.VolumeBackup{
+				VolumeName:                volume.Name,
+				PersistentVolumeClaimName: claimName,
+				PersistentVolumeClaimSpec: *pvc.Spec.DeepCopy(),
+				VolumeSnapshotName:        volumeSnapshotName(snapshot, volume.Name),
+			})
+		}
+	}
+
+	snapshotCopy := snapshot.DeepCopy()
+	// the finalizer makes sure that the guest is thawed if the snapshot is deleted while it is in progress
+	if !controller.HasFinalizer(snapshotCopy, virtv1.VirtualMachineSnapshotFinalizer) {
+		snapshotCopy.Finalizers = append(snapshotCopy.Finalizers, virtv1.VirtualMachineSnapshotFinalizer)
+	}
+	snapshotCopy.Status.Phase = virtv1.VirtualMachineSnapshotInProgress
+	sourceUID := vm.UID
+	snapshotCopy.Status.SourceUID = &sourceUID
+	snapshotCopy.Status.VirtualMachineSpec = vm.Spec.DeepCopy()
+	snapshotCopy.Status.VolumeBackups = backups
+	readyToUse := false
+	snapshotCopy.Status.ReadyToUse = &readyToUse
+
+	_, err := c.clientset.VirtualMachineSnapshot(snapshot.Namespace).Update(snapshotCopy)
+	return err
+}
+
+// syncVolumeSnapshots creates the missing VolumeSnapshots while the guest is frozen and
+// tracks them until all of them are ready to use
+func (c *SnapshotController) syncVolumeSnapshots(key string, snapshot *virtv1.VirtualMachineSnapshot, vm *virtv1.VirtualMachine) error {
+	var missing []virtv1.VolumeBackup
+	var volumeSnapshots []*snapshotv1.VolumeSnapshot
+
+	for _, backup := range snapshot.Status.VolumeBackups {
+		volumeSnapshot, err := c.clientset.VolumeSnapshot(snapshot.Namespace).Get(backup.VolumeSnapshotName, &v1.GetOptions{})
+		if errors.IsNotFound(err) {
+			missing = append(missing, backup)
+			continue
+		} else if err != nil {
+			return err
+		}
+		volumeSnapshots = append(volumeSnapshots, volumeSnapshot)
+	}
+
+	vmi, err := c.agentConnectedVMI(vm.Namespace, vm.Name)
+	if err != nil {
+		return err
+	}
+
+	snapshotCopy := snapshot.DeepCopy()
+
+	if len(missing) > 0 {
+		if vmi != nil {
+			if err := c.clientset.VirtualMachineInstance(vmi.Namespace).Freeze(vmi.Name); err != nil {
+				c.recorder.Eventf(snapshot, k8sv1.EventTypeWarning, FailedFreezeReason, "Failed to freeze VirtualMachineInstance %s: %v", vmi.Name, err)
+				// some of the filesystems may have been frozen before the failure
+				c.unfreeze(snapshot, vmi)
+				return err
+			}
+		}
+
+		for _, backup := range missing {
+			if err := c.createVolumeSnapshot(snapshot, backup); err != nil {
+				c.unfreeze(snapshot, vmi)
+				return err
+			}
+		}
+
+		if snapshotCopy.Status.CreationTime == nil {
+			now := v1.Now()
+			snapshotCopy.Status.CreationTime = &now
+		}
+		if vmi != nil {
+			snapshotCopy.Status.Frozen = true
+		}
+		if err := c.updateSnapshotStatus(snapshot, snapshotCopy); err != nil {
+			// without the recorded freeze the guest would never be thawed
+			c.unfreeze(snapshot, vmi)
+			return err
+		}
+		c.Queue.AddAfter(key, snapshotPollInterval)
+		return nil
+	}
+
+	created := true
+	ready := true
+	for _, volumeSnapshot := range volumeSnapshots {
+		if volumeSnapshot.Status.Error != nil {
+			if err := c.thaw(snapshotCopy, vmi); err != nil {
+				return err
+			}
+			return c.failSnapshot(snapshotCopy, fmt.Sprintf("VolumeSnapshot %s failed: %s", volumeSnapshot.Name, volumeSnapshot.Status.Error.Message))
+		}
+		if volumeSnapshot.Status.CreationTime == nil {
+			created = false
+		}
+		if !volumeSnapshot.Status.ReadyToUse {
+			ready = false
+		}
+	}
+
+	if !created {
+		if freezeTimedOut(snapshot, volumeSnapshots) {
+			if err := c.thaw(snapshotCopy, vmi); err != nil {
+				return err
+			}
+			return c.failSnapshot(snapshotCopy, fmt.Sprintf("VolumeSnapshots were not taken within %v", snapshotFreezeTimeout))
+		}
+		// the guest stays frozen until the storage has taken the point in time snapshots
+		c.Queue.AddAfter(key, snapshotPollInterval)
+		return nil
+	}
+
+	if err := c.thaw(snapshotCopy, vmi); err != nil {
+		return err
+	}
+
+	if !ready {
+		c.Queue.AddAfter(key, snapshotPollInterval)
+		return c.updateSnapshotStatus(snapshot, snapshotCopy)
+	}
+
+	snapshotCopy.Status.Phase = virtv1.VirtualMachineSnapshotSucceeded
+	readyToUse := true
+	snapshotCopy.Status.ReadyToUse = &readyToUse
+	c.recorder.Eventf(snapshot, k8sv1.EventTypeNormal, SuccessfulVirtualMachineSnapshotReason, "Snapshot of VirtualMachine %s is ready to use", vm.Name)
+
+	return c.updateSnapshotStatus(snapshot, snapshotCopy)
+}
+
+// freezeTimedOut checks whether the guest was frozen for longer than snapshotFreezeTimeout.
+// The VolumeSnapshots are created right after the freeze, so the oldest of them
+// marks the freeze if the snapshot creation time was not recorded yet.
+func freezeTimedOut(snapshot *virtv1.VirtualMachineSnapshot, volumeSnapshots []*snapshotv1.VolumeSnapshot) bool {
+	var frozenSince *v1.Time
+	if snapshot.Status.CreationTime != nil {
+		frozenSince = snapshot.Status.CreationTime
+	} else {
+		for _, volumeSnapshot := range volumeSnapshots {
+			if frozenSince == nil || volumeSnapshot.CreationTimestamp.Before(frozenSince) {
+				frozenSince = volumeSnapshot.CreationTimestamp.DeepCopy()
+			}
+		}
+	}
+	if frozenSince == nil || frozenSince.IsZero() {
+		return false
+	}
+	return time.Now().After(frozenSince.Add(snapshotFreezeTimeout))
+}
+
+// releaseSnapshot thaws the guest of a snapshot which is deleted while the guest is frozen
+// and removes the finalizer once the snapshot is finished or deleted.
+func (c *SnapshotController) releaseSnapshot(snapshot *virtv1.VirtualMachineSnapshot) error {
+	if !controller.HasFinalizer(snapshot, virtv1.VirtualMachineSnapshotFinalizer) {
+		return nil
+	}
+
+	if snapshot.Status.Frozen {
+		vmi, err := c.agentConnectedVMI(snapshot.Namespace, snapshot.Spec.Source.Name)
+		if err != nil {
+			return err
+		}
+		if err := c.unfreeze(snapshot, vmi); err != nil {
+			return err
+		}
+	}
+
+	snapshotCopy := snapshot.DeepCopy()
+	controller.RemoveFinalizer(snapshotCopy, virtv1.VirtualMachineSnapshotFinalizer)
+	_, err := c.clientset.VirtualMachineSnapshot(snapshot.Namespace).Update(snapshotCopy)
+	return err
+}
+
+// agentConnectedVMI returns the running VirtualMachineInstance of the VirtualMachine, if its guest agent is connected.
+// Without a connected agent the volumes are snapshotted without freezing the guest.
+func (c *SnapshotController) agentConnectedVMI(namespace string, name string) (*virtv1.VirtualMachineInstance, error) {
+	obj, exists, err := c.vmiInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	vmi := obj.(*virtv1.VirtualMachineInstance)
+	if vmi.Status.Phase != virtv1.Running {
+		return nil, nil
+	}
+
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !conditionManager.HasCondition(vmi, virtv1.VirtualMachineInstanceAgentConnected) {
+		return nil, nil
+	}
+	return vmi, nil
+}
+
+// thaw unfreezes the guest if it was frozen for the snapshot and records it in the snapshot copy,
+// so that the guest is thawed only once.
+func (c *SnapshotController) thaw(snapshotCopy *virtv1.VirtualMachineSnapshot, vmi *virtv1.VirtualMachineInstance) error {
+	if !snapshotCopy.Status.Frozen {
+		return nil
+	}
+	if err := c.unfreeze(snapshotCopy, vmi); err != nil {
+		return err
+	}
+	snapshotCopy.Status.Frozen = false
+	return nil
+}
+
+func (c *SnapshotController) unfreeze(snapshot *virtv1.VirtualMachineSnapshot, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil {
+		return nil
+	}
+	if err := c.clientset.VirtualMachineInstance(vmi.Namespace).Unfreeze(vmi.Name); err != nil {
+		c.recorder.Eventf(snapshot, k8sv1.EventTypeWarning, FailedUnfreezeReason, "Failed to unfreeze VirtualMachineInstance %s: %v", vmi.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *SnapshotController) createVolumeSnapshot(snapshot *virtv1.VirtualMachineSnapshot, backup virtv1.VolumeBackup) error {
+	apiGroup := ""
+	volumeSnapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      backup.VolumeSnapshotName,
+			Namespace: snapshot.Namespace,
+			OwnerReferences: []v1.OwnerReference{
+				*v1.NewControllerRef(snapshot, virtv1.VirtualMachineSnapshotGroupVersionKind),
+			},
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: &k8sv1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "PersistentVolumeClaim",
+				Name:     backup.PersistentVolumeClaimName,
+			},
+		},
+	}
+
+	_, err := c.clientset.VolumeSnapshot(snapshot.Namespace).Create(volumeSnapshot)
+	if err != nil {
+		c.recorder.Eventf(snapshot, k8sv1.EventTypeWarning, FailedVolumeSnapshotCreateReason, "Error creating VolumeSnapshot %s: %v", backup.VolumeSnapshotName, err)
+		return err
+	}
+	c.recorder.Eventf(snapshot, k8sv1.EventTypeNormal, SuccessfulVolumeSnapshotCreateReason, "Created VolumeSnapshot %s", backup.VolumeSnapshotName)
+	return nil
+}
+
+func (c *SnapshotController) failSnapshot(snapshot *virtv1.VirtualMachineSnapshot, message string) error {
+	snapshotCopy := snapshot.DeepCopy()
+	snapshotCopy.Status.Phase = virtv1.VirtualMachineSnapshotFailed
+	snapshotCopy.Status.Message = message
+	readyToUse := false
+	snapshotCopy.Status.ReadyToUse = &readyToUse
+	c.recorder.Event(snapshot, k8sv1.EventTypeWarning, FailedVirtualMachineSnapshotReason, message)
+	log.Log.Object(snapshot).Error(message)
+
+	return c.updateSnapshotStatus(snapshot, snapshotCopy)
+}
+
+func (c *SnapshotController) updateSnapshotStatus(snapshot *virtv1.VirtualMachineSnapshot, snapshotCopy *virtv1.VirtualMachineSnapshot) error {
+	if reflect.DeepEqual(snapshot.Status, snapshotCopy.Status) {
+		return nil
+	}
+	_, err := c.clientset.VirtualMachineSnapshot(snapshot.Namespace).Update(snapshotCopy)
+	return err
+}
+
+func (c *SnapshotController) addSnapshot(obj interface{}) {
+	c.enqueueSnapshot(obj)
+}
+
+func (c *SnapshotController) deleteSnapshot(obj interface{}) {
+	c.enqueueSnapshot(obj)
+}
+
+func (c *SnapshotController) updateSnapshot(old, curr interface{}) {
+	c.enqueueSnapshot(curr)
+}
+
+func (c *SnapshotController) enqueueSnapshot(obj interface{}) {
+	logger := log.Log
+	snapshot, ok := obj.(*virtv1.VirtualMachineSnapshot)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			logger.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		snapshot, ok = tombstone.Obj.(*virtv1.VirtualMachineSnapshot)
+		if !ok {
+			logger.Reason(fmt.Errorf("tombstone contained object that is not a snapshot %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	key, err := controller.KeyFunc(snapshot)
+	if err != nil {
+		logger.Object(snapshot).Reason(err).Error("Failed to extract key from snapshot.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func volumeSnapshotName(snapshot *virtv1.VirtualMachineSnapshot, volumeName string) string {
+	return fmt.Sprintf("vmsnapshot-%s-volume-%s", snapshot.UID, volumeName)
+}
@@ -0,0 +1,540 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot controller", func() {
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	var ctrl *gomock.Controller
+	var virtClient *kubecli.MockKubevirtClient
+	var snapshotInterface *kubecli.MockVirtualMachineSnapshotInterface
+	var volumeSnapshotInterface *kubecli.MockVolumeSnapshotInterface
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var snapshotSource *framework.FakeControllerSource
+	var snapshotInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var pvcInformer cache.SharedIndexInformer
+	var stop chan struct{}
+	var controller *SnapshotController
+	var recorder *record.FakeRecorder
+	var mockQueue *testutils.MockWorkQueue
+
+	notFound := errors.NewNotFound(schema.GroupResource{Group: snapshotv1.GroupName, Resource: "volumesnapshots"}, "")
+
+	syncCaches := func(stop chan struct{}) {
+		go snapshotInformer.Run(stop)
+		go vmInformer.Run(stop)
+		go vmiInformer.Run(stop)
+		go pvcInformer.Run(stop)
+
+		Expect(cache.WaitForCacheSync(stop,
+			snapshotInformer.HasSynced,
+			vmInformer.HasSynced,
+			vmiInformer.HasSynced,
+			pvcInformer.HasSynced)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		snapshotInterface = kubecli.NewMockVirtualMachineSnapshotInterface(ctrl)
+		volumeSnapshotInterface = kubecli.NewMockVolumeSnapshotInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		snapshotInformer, snapshotSource = testutils.NewFakeInformerFor(&v1.VirtualMachineSnapshot{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = NewSnapshotController(virtClient, snapshotInformer, vmInformer, vmiInformer, pvcInformer, recorder)
+		// Wrap our workqueue to have a way to detect when we are done processing updates
+		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
+		controller.Queue = mockQueue
+
+		virtClient.EXPECT().VirtualMachineSnapshot(k8sv1.NamespaceDefault).Return(snapshotInterface).AnyTimes()
+		virtClient.EXPECT().VolumeSnapshot(k8sv1.NamespaceDefault).Return(volumeSnapshotInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(k8sv1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+
+		syncCaches(stop)
+	})
+
+	AfterEach(func() {
+		close(stop)
+		// Ensure that we add checks for expected events to every test
+		Expect(recorder.Events).To(BeEmpty())
+		ctrl.Finish()
+	})
+
+	addSnapshot := func(snapshot *v1.VirtualMachineSnapshot) {
+		mockQueue.ExpectAdds(1)
+		snapshotSource.Add(snapshot)
+		mockQueue.Wait()
+	}
+
+	shouldExpectSnapshotUpdate := func(check func(*v1.VirtualMachineSnapshot)) {
+		snapshotInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+			check(arg.(*v1.VirtualMachineSnapshot))
+		}).Return(nil, nil)
+	}
+
+	Context("VirtualMachineSnapshot without a phase", func() {
+		It("should record the VirtualMachine and its persistent volumes", func() {
+			vm := newSnapshotSourceVM("testvm")
+			vmInformer.GetStore().Add(vm)
+			pvcInformer.GetStore().Add(newSnapshotSourcePVC("testpvc"))
+			pvcInformer.GetStore().Add(newSnapshotSourcePVC("testdv"))
+			snapshot := newVMSnapshot("testsnapshot", vm.Name, v1.VirtualMachineSnapshotPhaseUnset)
+			addSnapshot(snapshot)
+
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Finalizers).To(ContainElement(v1.VirtualMachineSnapshotFinalizer))
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotInProgress))
+				Expect(*s.Status.SourceUID).To(Equal(vm.UID))
+				Expect(*s.Status.ReadyToUse).To(BeFalse())
+				Expect(s.Status.VirtualMachineSpec).To(Equal(&vm.Spec))
+				Expect(s.Status.VolumeBackups).To(HaveLen(2))
+				Expect(s.Status.VolumeBackups[0].VolumeName).To(Equal("disk0"))
+				Expect(s.Status.VolumeBackups[0].PersistentVolumeClaimName).To(Equal("testpvc"))
+				Expect(s.Status.VolumeBackups[0].PersistentVolumeClaimSpec.Resources.Requests).To(HaveKey(k8sv1.ResourceStorage))
+				Expect(s.Status.VolumeBackups[0].VolumeSnapshotName).To(Equal("vmsnapshot-snapshot-uid-volume-disk0"))
+				Expect(s.Status.VolumeBackups[1].VolumeName).To(Equal("disk1"))
+				Expect(s.Status.VolumeBackups[1].PersistentVolumeClaimName).To(Equal("testdv"))
+			})
+
+			controller.Execute()
+		})
+
+		It("should fail if the VirtualMachine does not exist", func() {
+			snapshot := newVMSnapshot("testsnapshot", "testvm", v1.VirtualMachineSnapshotPhaseUnset)
+			addSnapshot(snapshot)
+
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotFailed))
+				Expect(s.Status.Message).To(ContainSubstring("does not exist"))
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineSnapshotReason)
+		})
+
+		It("should fail if a PersistentVolumeClaim does not exist", func() {
+			vm := newSnapshotSourceVM("testvm")
+			vmInformer.GetStore().Add(vm)
+			pvcInformer.GetStore().Add(newSnapshotSourcePVC("testpvc"))
+			snapshot := newVMSnapshot("testsnapshot", vm.Name, v1.VirtualMachineSnapshotPhaseUnset)
+			addSnapshot(snapshot)
+
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotFailed))
+				Expect(s.Status.Message).To(ContainSubstring("testdv"))
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineSnapshotReason)
+		})
+	})
+
+	Context("VirtualMachineSnapshot in progress", func() {
+		var vm *v1.VirtualMachine
+		var snapshot *v1.VirtualMachineSnapshot
+
+		BeforeEach(func() {
+			vm = newSnapshotSourceVM("testvm")
+			vmInformer.GetStore().Add(vm)
+			snapshot = newVMSnapshot("testsnapshot", vm.Name, v1.VirtualMachineSnapshotInProgress)
+			snapshot.Status.SourceUID = &vm.UID
+			snapshot.Status.VirtualMachineSpec = vm.Spec.DeepCopy()
+			snapshot.Status.VolumeBackups = []v1.VolumeBackup{
+				{VolumeName: "disk0", PersistentVolumeClaimName: "testpvc", VolumeSnapshotName: "vs0"},
+			}
+		})
+
+		It("should freeze the guest and create the volume snapshots", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(nil, notFound)
+			vmiInterface.EXPECT().Freeze(vm.Name).Return(nil)
+			volumeSnapshotInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				volumeSnapshot := arg.(*snapshotv1.VolumeSnapshot)
+				Expect(volumeSnapshot.Name).To(Equal("vs0"))
+				Expect(volumeSnapshot.Spec.Source.Kind).To(Equal("PersistentVolumeClaim"))
+				Expect(volumeSnapshot.Spec.Source.Name).To(Equal("testpvc"))
+				Expect(volumeSnapshot.OwnerReferences[0].UID).To(Equal(snapshot.UID))
+			}).Return(nil, nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotInProgress))
+				Expect(s.Status.CreationTime).ToNot(BeNil())
+				Expect(s.Status.Frozen).To(BeTrue())
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulVolumeSnapshotCreateReason)
+		})
+
+		It("should unfreeze the guest if the freeze can not be recorded", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(nil, notFound)
+			vmiInterface.EXPECT().Freeze(vm.Name).Return(nil)
+			volumeSnapshotInterface.EXPECT().Create(gomock.Any()).Return(nil, nil)
+			snapshotInterface.EXPECT().Update(gomock.Any()).Return(nil, fmt.Errorf("failure"))
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulVolumeSnapshotCreateReason)
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should unfreeze the guest if it can not be frozen", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(nil, notFound)
+			vmiInterface.EXPECT().Freeze(vm.Name).Return(fmt.Errorf("failure"))
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedFreezeReason)
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should create the volume snapshots without freezing if the guest agent is not connected", func() {
+			vmi := newAgentConnectedVMI(vm.Name)
+			vmi.Status.Conditions = nil
+			vmiInformer.GetStore().Add(vmi)
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(nil, notFound)
+			volumeSnapshotInterface.EXPECT().Create(gomock.Any()).Return(nil, nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.CreationTime).ToNot(BeNil())
+				Expect(s.Status.Frozen).To(BeFalse())
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulVolumeSnapshotCreateReason)
+		})
+
+		It("should unfreeze the guest if a volume snapshot can not be created", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(nil, notFound)
+			vmiInterface.EXPECT().Freeze(vm.Name).Return(nil)
+			volumeSnapshotInterface.EXPECT().Create(gomock.Any()).Return(nil, fmt.Errorf("failure"))
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVolumeSnapshotCreateReason)
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should keep the guest frozen until the volume snapshots are taken", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(newVolumeSnapshot("vs0", false, false), nil)
+
+			controller.Execute()
+		})
+
+		It("should fail and unfreeze the guest if the volume snapshots are not taken in time", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			frozenSince := metav1.NewTime(time.Now().Add(-snapshotFreezeTimeout - time.Minute))
+			snapshot.Status.CreationTime = &frozenSince
+			snapshot.Status.Frozen = true
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(newVolumeSnapshot("vs0", false, false), nil)
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotFailed))
+				Expect(s.Status.Message).To(ContainSubstring("not taken within"))
+				Expect(s.Status.Frozen).To(BeFalse())
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineSnapshotReason)
+		})
+
+		It("should unfreeze the guest and release the snapshot if it is deleted", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			now := metav1.Now()
+			snapshot.DeletionTimestamp = &now
+			snapshot.Finalizers = []string{v1.VirtualMachineSnapshotFinalizer}
+			snapshot.Status.Frozen = true
+			addSnapshot(snapshot)
+
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Finalizers).ToNot(ContainElement(v1.VirtualMachineSnapshotFinalizer))
+			})
+
+			controller.Execute()
+		})
+
+		It("should keep the finalizer if the guest can not be unfrozen on deletion", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			now := metav1.Now()
+			snapshot.DeletionTimestamp = &now
+			snapshot.Finalizers = []string{v1.VirtualMachineSnapshotFinalizer}
+			snapshot.Status.Frozen = true
+			addSnapshot(snapshot)
+
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(fmt.Errorf("failure"))
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedUnfreezeReason)
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should unfreeze the guest once the volume snapshots are taken", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			snapshot.Status.Frozen = true
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(newVolumeSnapshot("vs0", true, false), nil)
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotInProgress))
+				Expect(s.Status.Frozen).To(BeFalse())
+			})
+
+			controller.Execute()
+		})
+
+		It("should not unfreeze the guest again while the volume snapshots become ready", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(newVolumeSnapshot("vs0", true, false), nil)
+
+			controller.Execute()
+		})
+
+		It("should succeed once all volume snapshots are ready to use", func() {
+			addSnapshot(snapshot)
+
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(newVolumeSnapshot("vs0", true, true), nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotSucceeded))
+				Expect(*s.Status.ReadyToUse).To(BeTrue())
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulVirtualMachineSnapshotReason)
+		})
+
+		It("should fail and unfreeze the guest if a volume snapshot failed", func() {
+			vmiInformer.GetStore().Add(newAgentConnectedVMI(vm.Name))
+			snapshot.Status.Frozen = true
+			addSnapshot(snapshot)
+
+			volumeSnapshot := newVolumeSnapshot("vs0", false, false)
+			volumeSnapshot.Status.Error = &storagev1beta1.VolumeError{Message: "no space left"}
+			volumeSnapshotInterface.EXPECT().Get("vs0", gomock.Any()).Return(volumeSnapshot, nil)
+			vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotFailed))
+				Expect(s.Status.Message).To(ContainSubstring("no space left"))
+				Expect(s.Status.Frozen).To(BeFalse())
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineSnapshotReason)
+		})
+
+		It("should fail if the VirtualMachine was recreated", func() {
+			otherUID := types.UID("other-uid")
+			snapshot.Status.SourceUID = &otherUID
+			addSnapshot(snapshot)
+
+			shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+				Expect(s.Status.Phase).To(Equal(v1.VirtualMachineSnapshotFailed))
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedVirtualMachineSnapshotReason)
+		})
+	})
+
+	It("should ignore finished snapshots", func() {
+		addSnapshot(newVMSnapshot("testsnapshot", "testvm", v1.VirtualMachineSnapshotSucceeded))
+
+		controller.Execute()
+	})
+
+	It("should remove the finalizer from finished snapshots without unfreezing the guest", func() {
+		vmiInformer.GetStore().Add(newAgentConnectedVMI("testvm"))
+		snapshot := newVMSnapshot("testsnapshot", "testvm", v1.VirtualMachineSnapshotSucceeded)
+		snapshot.Finalizers = []string{v1.VirtualMachineSnapshotFinalizer}
+		addSnapshot(snapshot)
+
+		shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+			Expect(s.Finalizers).To(BeEmpty())
+		})
+
+		controller.Execute()
+	})
+
+	It("should unfreeze the guest of failed snapshots before removing the finalizer", func() {
+		vmiInformer.GetStore().Add(newAgentConnectedVMI("testvm"))
+		snapshot := newVMSnapshot("testsnapshot", "testvm", v1.VirtualMachineSnapshotFailed)
+		snapshot.Finalizers = []string{v1.VirtualMachineSnapshotFinalizer}
+		snapshot.Status.Frozen = true
+		addSnapshot(snapshot)
+
+		vmiInterface.EXPECT().Unfreeze("testvm").Return(nil)
+		shouldExpectSnapshotUpdate(func(s *v1.VirtualMachineSnapshot) {
+			Expect(s.Finalizers).To(BeEmpty())
+		})
+
+		controller.Execute()
+	})
+
+	It("should enqueue snapshots from delete tombstones", func() {
+		snapshot := newVMSnapshot("testsnapshot", "testvm", v1.VirtualMachineSnapshotInProgress)
+		mockQueue.ExpectAdds(1)
+		controller.deleteSnapshot(cache.DeletedFinalStateUnknown{Key: "default/testsnapshot", Obj: snapshot})
+		mockQueue.Wait()
+		Expect(controller.Queue.Len()).To(Equal(1))
+	})
+})
+
+func newSnapshotSourceVM(name string) *v1.VirtualMachine {
+	vm, _ := DefaultVirtualMachineWithNames(false, name, name)
+	vm.UID = "vm-uid"
+	vm.Spec.Template.Spec.Volumes = []v1.Volume{
+		{
+			Name: "disk0",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+			},
+		},
+		{
+			Name: "disk1",
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{Name: "testdv"},
+			},
+		},
+		{
+			Name: "cloudinit",
+			VolumeSource: v1.VolumeSource{
+				CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+			},
+		},
+	}
+	return vm
+}
+
+func newSnapshotSourcePVC(name string) *k8sv1.PersistentVolumeClaim {
+	return &k8sv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k8sv1.NamespaceDefault},
+		Spec: k8sv1.PersistentVolumeClaimSpec{
+			AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+			Resources: k8sv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			VolumeName: "pv-" + name,
+		},
+	}
+}
+
+func newVMSnapshot(name string, vmName string, phase v1.VirtualMachineSnapshotPhase) *v1.VirtualMachineSnapshot {
+	return &v1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: k8sv1.NamespaceDefault,
+			UID:       "snapshot-uid",
+		},
+		Spec: v1.VirtualMachineSnapshotSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				Kind: "VirtualMachine",
+				Name: vmName,
+			},
+		},
+		Status: v1.VirtualMachineSnapshotStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func newAgentConnectedVMI(name string) *v1.VirtualMachineInstance {
+	vmi := v1.NewMinimalVMI(name)
+	vmi.Status.Phase = v1.Running
+	vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+		{Type: v1.VirtualMachineInstanceAgentConnected, Status: k8sv1.ConditionTrue},
+	}
+	return vmi
+}
+
+func newVolumeSnapshot(name string, created bool, ready bool) *snapshotv1.VolumeSnapshot {
+	volumeSnapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k8sv1.NamespaceDefault},
+	}
+	if created {
+		now := metav1.Now()
+		volumeSnapshot.Status.CreationTime = &now
+	}
+	volumeSnapshot.Status.ReadyToUse = ready
+	return volumeSnapshot
+}
@@ -20,8 +20,10 @@
 package watch
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -45,6 +48,8 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/util/cron"
 )
 
 // TODO remove the dataVolume deletion retry logic once CDI fixes this issue.
@@ -237,6 +242,7 @@ func (c *VMController) execute(key string) error {
 	}
 
 	var createErr error
+	var hotplugErr error
 
 	// Scale up or down, if all expected creates and deletes were report by the listener
 	if needsSync && VM.ObjectMeta.DeletionTimestamp == nil {
@@ -249,6 +255,10 @@ func (c *VMController) execute(key string) error {
 		} else {
 			log.Log.Object(VM).V(3).Infof("Waiting on DataVolumes to be ready. %d datavolumes found", len(dataVolumes))
 		}
+
+		if createErr == nil && vmi != nil && vmi.IsRunning() && vmi.DeletionTimestamp == nil {
+			hotplugErr = c.hotplugResources(VM, vmi)
+		}
 	}
 
 	// If the controller is going to be deleted and the orphan finalizer is the next one, release the VMIs. Don't update the status
@@ -271,7 +281,268 @@ func (c *VMController) execute(key string) error {
 		return err
 	}
 
-	return createErr
+	if VM.Spec.RunSchedule != nil && VM.ObjectMeta.DeletionTimestamp == nil {
+		if next := nextScheduleTime(VM, time.Now().UTC()); !next.IsZero() {
+			c.Queue.AddAfter(vmKey, time.Until(next))
+		}
+	}
+
+	if createErr != nil {
+		return createErr
+	}
+	return hotplugErr
+}
+
+// hotplugResources scales the sockets and the guest memory of the running VMI up to
+// the values of the VM template, as far as this is possible without a restart
+func (c *VMController) hotplugResources(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	template := vm.Spec.Template.Spec.Domain
+	vmiCopy := vmi.DeepCopy()
+	if canHotplugSockets(template.CPU, vmi.Spec.Domain.CPU) {
+		vmiCopy.Spec.Domain.CPU.Sockets = template.CPU.Sockets
+	}
+	if canHotplugGuestMemory(template.Memory, vmi.Spec.Domain.Memory) {
+		guest := template.Memory.Guest.DeepCopy()
+		vmiCopy.Spec.Domain.Memory.Guest = &guest
+	}
+	if reflect.DeepEqual(vmiCopy.Spec, vmi.Spec) {
+		return nil
+	}
+
+	log.Log.Object(vm).Info("Scaling up the CPUs and the memory of the VirtualMachineInstance")
+	_, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Update(vmiCopy)
+	return err
+}
+
+// canHotplugSockets checks if the sockets of the template can be applied to the running VMI
+func canHotplugSockets(template *virtv1.CPU, running *virtv1.CPU) bool {
+	return template != nil && running != nil && running.MaxSockets > 0 &&
+		template.MaxSockets == running.MaxSockets &&
+		template.Sockets > running.Sockets && template.Sockets <= running.MaxSockets
+}
+
+// canHotplugGuestMemory checks if the guest memory of the template can be applied to the running VMI
+func canHotplugGuestMemory(template *virtv1.Memory, running *virtv1.Memory) bool {
+	return template != nil && running != nil && running.MaxGuest != nil &&
+		template.Guest != nil && running.Guest != nil &&
+		quantitiesEqual(template.MaxGuest, running.MaxGuest) &&
+		template.Guest.Cmp(*running.Guest) > 0 && template.Guest.Cmp(*running.MaxGuest) <= 0
+}
+
+// restartRequiredMessage describes the fields of the template which differ from the template the
+// running VMI was started from and can not be applied to it without a restart. It is empty if there
+// are none. The template is not compared to the spec of the VMI itself, since the mutating webhook
+// and the presets fill in fields of the VMI when it gets created.
+func restartRequiredMessage(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) string {
+	if vmi == nil || vm.Spec.Template == nil {
+		return ""
+	}
+	started, err := startedTemplateSpec(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to read the template the VirtualMachineInstance was started from")
+		return ""
+	}
+	if started == nil {
+		// the VMI was started before the template got recorded
+		return ""
+	}
+	template := vm.Spec.Template.Spec.DeepCopy()
+	withoutHotpluggedVolumes(template, vmi)
+	withoutHotpluggedVolumes(started, vmi)
+
+	// the sockets and the guest memory get hotplugged to the running VMI
+	running := vmi.Spec.Domain
+	if started.Domain.CPU != nil && (canHotplugSockets(template.Domain.CPU, running.CPU) || socketsHotplugged(template.Domain.CPU, running.CPU)) {
+		template.Domain.CPU.Sockets = started.Domain.CPU.Sockets
+	}
+	if started.Domain.Memory != nil && (canHotplugGuestMemory(template.Domain.Memory, running.Memory) || guestMemoryHotplugged(template.Domain.Memory, running.Memory)) {
+		template.Domain.Memory.Guest = started.Domain.Memory.Guest
+	}
+
+	changes := changedFields("spec.template.spec", reflect.ValueOf(*template), reflect.ValueOf(*started))
+	if len(changes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("changes to %s require a restart of the VirtualMachine", strings.Join(changes, ", "))
+}
+
+// socketsHotplugged checks if the sockets of the template were already hotplugged to the running VMI
+func socketsHotplugged(template *virtv1.CPU, running *virtv1.CPU) bool {
+	return template != nil && running != nil && running.MaxSockets > 0 &&
+		template.MaxSockets == running.MaxSockets && template.Sockets == running.Sockets
+}
+
+// guestMemoryHotplugged checks if the guest memory of the template was already hotplugged to the running VMI
+func guestMemoryHotplugged(template *virtv1.Memory, running *virtv1.Memory) bool {
+	return template != nil && running != nil && running.MaxGuest != nil &&
+		quantitiesEqual(template.MaxGuest, running.MaxGuest) && quantitiesEqual(template.Guest, running.Guest)
+}
+
+// setStartedTemplateSpec records the template spec the VMI gets started from
+func setStartedTemplateSpec(vmi *virtv1.VirtualMachineInstance, spec *virtv1.VirtualMachineInstanceSpec) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	if vmi.Annotations == nil {
+		vmi.Annotations = map[string]string{}
+	}
+	vmi.Annotations[virtv1.VirtualMachineTemplateSpecAnnotation] = string(data)
+	return nil
+}
+
+// startedTemplateSpec returns the template spec the VMI was started from, or nil if it was not recorded
+func startedTemplateSpec(vmi *virtv1.VirtualMachineInstance) (*virtv1.VirtualMachineInstanceSpec, error) {
+	data, exists := vmi.Annotations[virtv1.VirtualMachineTemplateSpecAnnotation]
+	if !exists {
+		return nil, nil
+	}
+	spec := &virtv1.VirtualMachineInstanceSpec{}
+	if err := json.Unmarshal([]byte(data), spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// withoutHotpluggedVolumes removes the volumes and disks which were hotplugged to the VMI from
+// the spec. They are added to and removed from the template by the hotplug subresources together
+// with the running VMI, so they never require a restart.
+func withoutHotpluggedVolumes(spec *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance) {
+	hotplugged := hotplugdisk.GetHotplugVolumeNames(vmi)
+	if len(hotplugged) == 0 {
+		return
+	}
+
+	volumes := []virtv1.Volume{}
+	for _, volume := range spec.Volumes {
+		if !hotplugged[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	spec.Volumes = volumes
+	disks := []virtv1.Disk{}
+	for _, disk := range spec.Domain.Devices.Disks {
+		if !hotplugged[disk.Name] {
+			disks = append(disks, disk)
+		}
+	}
+	spec.Domain.Devices.Disks = disks
+}
+
+var quantityType = reflect.TypeOf(resource.Quantity{})
+
+// changedFields returns the paths of the fields which differ between the template and the template
+// the VMI was started from. Fields which were set, changed, reset to their zero value or removed are
+// all reported. The elements of lists of named structs, like disks or interfaces, are matched by
+// their name.
+func changedFields(path string, template reflect.Value, started reflect.Value) []string {
+	switch template.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if template.IsNil() && started.IsNil() {
+			return nil
+		}
+		if template.IsNil() || started.IsNil() {
+			return []string{path}
+		}
+		return changedFields(path, template.Elem(), started.Elem())
+	case reflect.Struct:
+		if template.Type() == quantityType {
+			templateQuantity, startedQuantity := template.Interface().(resource.Quantity), started.Interface().(resource.Quantity)
+			if templateQuantity.Cmp(startedQuantity) != 0 {
+				return []string{path}
+			}
+			return nil
+		}
+		var changes []string
+		for i := 0; i < template.NumField(); i++ {
+			field := template.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			fieldPath := path
+			if name == "" {
+				// inlined structs don't add to the path
+				if !field.Anonymous {
+					fieldPath = path + "." + field.Name
+				}
+			} else if name != "-" {
+				fieldPath = path + "." + name
+			}
+			changes = append(changes, changedFields(fieldPath, template.Field(i), started.Field(i))...)
+		}
+		return changes
+	case reflect.Map:
+		var changes []string
+		keys := template.MapKeys()
+		for _, key := range started.MapKeys() {
+			if !template.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			templateValue, startedValue := template.MapIndex(key), started.MapIndex(key)
+			if !templateValue.IsValid() || !startedValue.IsValid() {
+				changes = append(changes, keyPath)
+				continue
+			}
+			changes = append(changes, changedFields(keyPath, templateValue, startedValue)...)
+		}
+		return changes
+	case reflect.Slice:
+		if template.Type().Elem().Kind() == reflect.Struct {
+			if _, named := template.Type().Elem().FieldByName("Name"); named {
+				return changedNamedElements(path, template, started)
+			}
+		}
+		if template.Len() != started.Len() {
+			return []string{path}
+		}
+		var changes []string
+		for i := 0; i < template.Len(); i++ {
+			changes = append(changes, changedFields(fmt.Sprintf("%s[%d]", path, i), template.Index(i), started.Index(i))...)
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(template.Interface(), started.Interface()) {
+		return []string{path}
+	}
+	return nil
+}
+
+// changedNamedElements compares the elements of two lists of named structs by their name. Elements
+// which were added to or removed from the template are reported as changes of the whole list.
+func changedNamedElements(path string, template reflect.Value, started reflect.Value) []string {
+	if template.Len() != started.Len() {
+		return []string{path}
+	}
+	startedByName := map[string]reflect.Value{}
+	for i := 0; i < started.Len(); i++ {
+		startedByName[started.Index(i).FieldByName("Name").String()] = started.Index(i)
+	}
+
+	var changes []string
+	for i := 0; i < template.Len(); i++ {
+		name := template.Index(i).FieldByName("Name").String()
+		startedElement, exists := startedByName[name]
+		if !exists {
+			return []string{path}
+		}
+		changes = append(changes, changedFields(fmt.Sprintf("%s[%s]", path, name), template.Index(i), startedElement)...)
+	}
+	return changes
+}
+
+func quantitiesEqual(a *resource.Quantity, b *resource.Quantity) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(*b) == 0
 }
 
 func (c *VMController) listDataVolumesForVM(vm *virtv1.VirtualMachine) ([]*cdiv1.DataVolume, error) {
@@ -611,6 +882,22 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 		}
 		return nil
 
+	case virtv1.RunStrategyOnce:
+		// For this RunStrategy, the VMI is started a single time. Once it is final,
+		// the RunStrategy is switched to Halted, so that the VMI is neither restarted
+		// after a success nor after a failure, even if the final VMI gets deleted.
+		if vmi == nil {
+			log.Log.Object(vm).V(4).Info("Starting VMI")
+			return c.startVMI(vm)
+		}
+		if vmi.IsFinal() {
+			log.Log.Object(vm).V(4).Infof("VMI is final, switching to RunStrategy %s", virtv1.RunStrategyHalted)
+			patch := fmt.Sprintf(`{"spec":{"runStrategy":"%s"}}`, virtv1.RunStrategyHalted)
+			_, err := c.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.MergePatchType, []byte(patch))
+			return err
+		}
+		return nil
+
 	case virtv1.RunStrategyHalted:
 		// For this runStrategy, no VMI should be running under any circumstances.
 		log.Log.Object(vm).V(4).Info("VMI should be deleted")
@@ -687,7 +974,8 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	vmi.ObjectMeta.Name = basename
 	vmi.ObjectMeta.GenerateName = basename
 	vmi.ObjectMeta.Namespace = vm.ObjectMeta.Namespace
-	vmi.Spec = vm.Spec.Template.Spec
+	// don't modify the template in the cache
+	vmi.Spec = *vm.Spec.Template.Spec.DeepCopy()
 
 	setupStableFirmwareUUID(vm, vmi)
 
@@ -699,6 +987,9 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 		}
 		vmi.ObjectMeta.Annotations[k] = v
 	}
+	if err := setStartedTemplateSpec(vmi, &vm.Spec.Template.Spec); err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to record the template the VirtualMachineInstance is started from")
+	}
 
 	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
@@ -1153,28 +1444,199 @@ func (c *VMController) updateStatus(vm *virtv1.VirtualMachine, vmi *virtv1.Virtu
 		}
 	}
 
-	if errMatch && createdMatch && readyMatch && !clearChangeRequest {
+	scheduledRequests, scheduleTime := scheduledStateChangeRequests(vm, vmi, time.Now().UTC())
+
+	restartRequired := restartRequiredMessage(vm, vmi)
+	restartRequiredMatch := restartRequired == ""
+	for _, cond := range vm.Status.Conditions {
+		if cond.Type == virtv1.VirtualMachineRestartRequired {
+			restartRequiredMatch = restartRequired == cond.Message
+		}
+	}
+
+	printableStatus := c.getPrintableStatus(vm, vmi)
+	printableStatusMatch := printableStatus == vm.Status.PrintableStatus
+
+	if errMatch && createdMatch && readyMatch && restartRequiredMatch && printableStatusMatch && !clearChangeRequest && scheduleTime == nil {
 		return nil
 	}
 
 	// Set created and ready flags
 	vm.Status.Created = created
 	vm.Status.Ready = ready
+	vm.Status.PrintableStatus = printableStatus
 
 	if clearChangeRequest {
 		vm.Status.StateChangeRequests = vm.Status.StateChangeRequests[1:]
 	}
 
+	if scheduleTime != nil {
+		log.Log.Object(vm).V(4).Infof("Run schedule requests %d state changes", len(scheduledRequests))
+		vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, scheduledRequests...)
+		vm.Status.LastScheduleTime = scheduleTime
+	}
+
 	// Add/Remove Failure condition if necessary
 	if !(errMatch) {
 		c.processFailure(vm, vmi, createErr)
 	}
 
+	// Add/Remove RestartRequired condition if necessary
+	if !restartRequiredMatch {
+		c.removeCondition(vm, virtv1.VirtualMachineRestartRequired)
+		if restartRequired != "" {
+			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+				Type:               virtv1.VirtualMachineRestartRequired,
+				Reason:             "TemplateChanged",
+				Message:            restartRequired,
+				LastTransitionTime: v1.Now(),
+				Status:             k8score.ConditionTrue,
+			})
+		}
+	}
+
 	_, err = c.clientset.VirtualMachine(vm.ObjectMeta.Namespace).Update(vm)
 
 	return err
 }
 
+// getPrintableStatus derives a single human readable status for the VM from the phase and the
+// conditions of its VMI and from the phases of its DataVolumes. The checks are ordered by
+// precedence, since several of them can apply at the same time, e.g. a migrating VMI is also running.
+func (c *VMController) getPrintableStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) virtv1.VirtualMachinePrintableStatus {
+	if vm.DeletionTimestamp != nil {
+		return virtv1.VirtualMachineStatusTerminating
+	}
+
+	if vmi != nil {
+		conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+		switch {
+		case vmi.DeletionTimestamp != nil && !vmi.IsFinal():
+			return virtv1.VirtualMachineStatusStopping
+		case vmi.IsRunning() && isMigrating(vmi):
+			return virtv1.VirtualMachineStatusMigrating
+		case vmi.IsRunning() && conditionManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstancePaused, k8score.ConditionTrue):
+			return virtv1.VirtualMachineStatusPaused
+		case vmi.IsRunning():
+			return virtv1.VirtualMachineStatusRunning
+		case vmi.IsUnknown():
+			return virtv1.VirtualMachineStatusUnknown
+		case isUnschedulable(vmi):
+			return virtv1.VirtualMachineStatusUnschedulable
+		}
+	}
+
+	dataVolumes, err := c.listDataVolumesForVM(vm)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to fetch dataVolumes for printable status.")
+	}
+	// DataVolumes which are not in the cache yet are about to be created
+	provisioning := len(dataVolumes) < len(vm.Spec.DataVolumeTemplates)
+	for _, dataVolume := range dataVolumes {
+		switch dataVolume.Status.Phase {
+		case cdiv1.Failed:
+			return virtv1.VirtualMachineStatusDataVolumeError
+		case cdiv1.Succeeded:
+		default:
+			provisioning = true
+		}
+	}
+	if provisioning {
+		return virtv1.VirtualMachineStatusProvisioning
+	}
+	if vmi == nil || vmi.IsFinal() {
+		return virtv1.VirtualMachineStatusStopped
+	}
+	return virtv1.VirtualMachineStatusStarting
+}
+
+func isMigrating(vmi *virtv1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationState != nil &&
+		vmi.Status.MigrationState.StartTimestamp != nil &&
+		!vmi.Status.MigrationState.Completed
+}
+
+func isUnschedulable(vmi *virtv1.VirtualMachineInstance) bool {
+	cond := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceConditionType(k8score.PodScheduled))
+	return cond != nil && cond.Status == k8score.ConditionFalse && cond.Reason == k8score.PodReasonUnschedulable
+}
+
+// scheduledStateChangeRequests returns the state change requests for the latest activation of the
+// run schedule which was not handled yet, together with the time of that activation. Like the start
+// and stop subresources, the requests are only added if the VMI is not in the requested state already.
+func scheduledStateChangeRequests(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, now time.Time) ([]virtv1.VirtualMachineStateChangeRequest, *v1.Time) {
+	if vm.Spec.RunSchedule == nil || vm.ObjectMeta.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	// activations before the VM was created or which were already handled are skipped
+	since := vm.ObjectMeta.CreationTimestamp.Time
+	if vm.Status.LastScheduleTime != nil && vm.Status.LastScheduleTime.After(since) {
+		since = vm.Status.LastScheduleTime.Time
+	}
+
+	start := previousActivation(vm, vm.Spec.RunSchedule.Start, now)
+	stop := previousActivation(vm, vm.Spec.RunSchedule.Stop, now)
+	running := vmi != nil && !vmi.IsFinal()
+
+	var requests []virtv1.VirtualMachineStateChangeRequest
+	var latest time.Time
+	if !stop.IsZero() && !stop.Before(start) {
+		latest = stop
+		if running {
+			requests = append(requests, virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &vmi.UID})
+		}
+	} else if !start.IsZero() {
+		latest = start
+		if vmi != nil && vmi.IsFinal() {
+			requests = append(requests, virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &vmi.UID})
+		}
+		if !running {
+			requests = append(requests, virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StartRequest})
+		}
+	}
+
+	if !latest.After(since) {
+		return nil, nil
+	}
+	scheduleTime := v1.NewTime(latest)
+	return requests, &scheduleTime
+}
+
+// nextScheduleTime returns the time of the next start or stop of the run schedule
+func nextScheduleTime(vm *virtv1.VirtualMachine, now time.Time) time.Time {
+	var next time.Time
+	for _, spec := range []string{vm.Spec.RunSchedule.Start, vm.Spec.RunSchedule.Stop} {
+		schedule := parseRunSchedule(vm, spec)
+		if schedule == nil {
+			continue
+		}
+		if activation := schedule.Next(now); !activation.IsZero() && (next.IsZero() || activation.Before(next)) {
+			next = activation
+		}
+	}
+	return next
+}
+
+func previousActivation(vm *virtv1.VirtualMachine, spec string, now time.Time) time.Time {
+	schedule := parseRunSchedule(vm, spec)
+	if schedule == nil {
+		return time.Time{}
+	}
+	return schedule.Prev(now)
+}
+
+func parseRunSchedule(vm *virtv1.VirtualMachine, spec string) *cron.Schedule {
+	if spec == "" {
+		return nil
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Errorf("Invalid run schedule %q", spec)
+		return nil
+	}
+	return schedule
+}
+
 func (c *VMController) getVirtualMachineBaseName(vm *virtv1.VirtualMachine) string {
 
 	// TODO defaulting should make sure that the right field is set, instead of doing this
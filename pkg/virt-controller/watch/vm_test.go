@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pborman/uuid"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,6 +26,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	cdifake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/testutils"
 )
 
@@ -177,6 +179,9 @@ var _ = Describe("VirtualMachine", func() {
 			dataVolumeFeeder.Add(existingDataVolume)
 			createCount := 0
 			shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": "", "my": "label"}, map[string]string{"my": "annotation"}, &createCount)
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusProvisioning))
+			}).Return(nil, nil)
 			controller.Execute()
 			Expect(createCount).To(Equal(1))
 			testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
@@ -496,6 +501,9 @@ var _ = Describe("VirtualMachine", func() {
 
 			createCount := 0
 			shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": ""}, map[string]string{}, &createCount)
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusProvisioning))
+			}).Return(nil, nil)
 			controller.Execute()
 			Expect(createCount).To(Equal(2))
 			testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
@@ -562,9 +570,9 @@ var _ = Describe("VirtualMachine", func() {
 				createCount := 0
 				shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": ""}, map[string]string{}, &createCount)
 
-				if fail {
-					vmInterface.EXPECT().Update(gomock.Any()).Times(1).Return(vm, nil)
-				}
+				vmInterface.EXPECT().Update(gomock.Any()).Times(1).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusProvisioning))
+				}).Return(vm, nil)
 
 				controller.cloneAuthFunc = func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 					if dv.Spec.Source.PVC.Namespace != "" {
@@ -641,6 +649,212 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		table.DescribeTable("should update the printable status", func(running bool, updateVMI func(*v1.VirtualMachineInstance), expected v1.VirtualMachinePrintableStatus) {
+			vm, vmi := DefaultVirtualMachine(running)
+
+			addVirtualMachine(vm)
+			if updateVMI != nil {
+				updateVMI(vmi)
+				vmiFeeder.Add(vmi)
+			}
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(expected))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		},
+			table.Entry("to stopped when there is no vmi", false, nil, v1.VirtualMachineStatusStopped),
+			table.Entry("to starting when the vmi is scheduling", true, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Scheduling
+			}, v1.VirtualMachineStatusStarting),
+			table.Entry("to unschedulable when the launcher pod can't be scheduled", true, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Scheduling
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Status: k8sv1.ConditionFalse,
+					Reason: k8sv1.PodReasonUnschedulable,
+				})
+			}, v1.VirtualMachineStatusUnschedulable),
+			table.Entry("to running when the vmi is running", true, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Running
+			}, v1.VirtualMachineStatusRunning),
+			table.Entry("to paused when the vmi is paused", true, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Running
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstancePaused,
+					Status: k8sv1.ConditionTrue,
+				})
+			}, v1.VirtualMachineStatusPaused),
+			table.Entry("to migrating when the vmi is migrating", true, func(vmi *v1.VirtualMachineInstance) {
+				now := metav1.Now()
+				vmi.Status.Phase = v1.Running
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{StartTimestamp: &now}
+			}, v1.VirtualMachineStatusMigrating),
+			table.Entry("to unknown when the vmi state is unknown", true, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Unknown
+			}, v1.VirtualMachineStatusUnknown),
+		)
+
+		It("should update the printable status to stopped when the vmi of a manual VM is final", func() {
+			vm, vmi := DefaultVirtualMachine(false)
+			runStrategy := v1.RunStrategyManual
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			vmi.Status.Phase = v1.Succeeded
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusStopped))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should start the VMI of a VM with RunStrategy Once", func() {
+			vm, vmi := DefaultVirtualMachine(false)
+			runStrategy := v1.RunStrategyOnce
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+
+			addVirtualMachine(vm)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+			vmInterface.EXPECT().Update(gomock.Any()).Return(vm, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		table.DescribeTable("should switch to RunStrategy Halted once the VMI of a VM with RunStrategy Once is final", func(phase v1.VirtualMachineInstancePhase) {
+			vm, vmi := DefaultVirtualMachine(false)
+			runStrategy := v1.RunStrategyOnce
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			vmi.Status.Phase = phase
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Patch(vm.Name, types.MergePatchType, []byte(`{"spec":{"runStrategy":"Halted"}}`)).Return(vm, nil)
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusStopped))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		},
+			table.Entry("after a success", v1.Succeeded),
+			table.Entry("after a failure", v1.Failed),
+		)
+
+		It("should not switch the RunStrategy Once of a VM while its VMI is running", func() {
+			vm, vmi := DefaultVirtualMachine(false)
+			runStrategy := v1.RunStrategyOnce
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.PrintableStatus = v1.VirtualMachineStatusRunning
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue}}
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			controller.Execute()
+		})
+
+		It("should request to start the VMI at the scheduled time", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			runStrategy := v1.RunStrategyManual
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			vm.Spec.RunSchedule = &v1.VirtualMachineRunSchedule{Start: "* * * * *"}
+			vm.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				status := arg.(*v1.VirtualMachine).Status
+				Expect(status.StateChangeRequests).To(Equal([]v1.VirtualMachineStateChangeRequest{{Action: v1.StartRequest}}))
+				Expect(status.LastScheduleTime).ToNot(BeNil())
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		Context("with a run schedule", func() {
+			now := time.Date(2020, time.January, 15, 10, 30, 15, 0, time.UTC)
+			vmiUID := types.UID("vmi-uid")
+			startRequest := v1.VirtualMachineStateChangeRequest{Action: v1.StartRequest}
+			stopRequest := v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmiUID}
+
+			morning := time.Date(2020, time.January, 15, 8, 0, 0, 0, time.UTC)
+			tenOClock := time.Date(2020, time.January, 15, 10, 0, 0, 0, time.UTC)
+			officeHours := &v1.VirtualMachineRunSchedule{Start: "0 8 * * *", Stop: "0 18 * * *"}
+
+			table.DescribeTable("should request the scheduled state changes", func(schedule *v1.VirtualMachineRunSchedule, lastScheduleTime *time.Time, phase v1.VirtualMachineInstancePhase, expectedRequests []v1.VirtualMachineStateChangeRequest, expectedTime *time.Time) {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.CreationTimestamp = metav1.NewTime(now.Add(-7 * 24 * time.Hour))
+				vm.Spec.RunSchedule = schedule
+				if lastScheduleTime != nil {
+					t := metav1.NewTime(*lastScheduleTime)
+					vm.Status.LastScheduleTime = &t
+				}
+				vmi.UID = vmiUID
+				vmi.Status.Phase = phase
+				if phase == v1.VmPhaseUnset {
+					vmi = nil
+				}
+
+				requests, scheduleTime := scheduledStateChangeRequests(vm, vmi, now)
+				Expect(requests).To(Equal(expectedRequests))
+				if expectedTime == nil {
+					Expect(scheduleTime).To(BeNil())
+				} else {
+					Expect(scheduleTime.Time).To(Equal(*expectedTime))
+				}
+			},
+				table.Entry("to start a stopped VMI", officeHours, nil, v1.VmPhaseUnset,
+					[]v1.VirtualMachineStateChangeRequest{startRequest}, &morning),
+				table.Entry("to restart a final VMI", officeHours, nil, v1.Succeeded,
+					[]v1.VirtualMachineStateChangeRequest{stopRequest, startRequest}, &morning),
+				table.Entry("to only record the start of a running VMI", officeHours, nil, v1.Running,
+					nil, &morning),
+				table.Entry("to stop a running VMI", &v1.VirtualMachineRunSchedule{Start: "0 8 * * *", Stop: "0 10 * * *"}, &morning, v1.Running,
+					[]v1.VirtualMachineStateChangeRequest{stopRequest}, &tenOClock),
+				table.Entry("to do nothing if the activation was handled", officeHours, &morning, v1.VmPhaseUnset,
+					nil, nil),
+				table.Entry("to do nothing before the first activation after the creation", &v1.VirtualMachineRunSchedule{Start: "0 8 1 1 *"}, nil, v1.VmPhaseUnset,
+					nil, nil),
+			)
+		})
+
+		It("should update the printable status to data volume error when a data volume failed", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, cdiv1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "dv1",
+				},
+			})
+			addVirtualMachine(vm)
+
+			existingDataVolume := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
+			existingDataVolume.Namespace = "default"
+			existingDataVolume.Status.Phase = cdiv1.Failed
+			// set the delete after timestamp way into the future
+			existingDataVolume.Annotations[dataVolumeDeleteAfterTimestampAnno] = strconv.FormatInt(time.Now().UTC().Unix()+60, 10)
+			dataVolumeFeeder.Add(existingDataVolume)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusDataVolumeError))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
 		It("should update status to created and ready when vmi is running and running", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 			markAsReady(vmi)
@@ -681,6 +895,19 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(string(vmi1.Spec.Domain.Firmware.UUID)).To(Equal(uid))
 		})
 
+		It("should record the template the VirtualMachineInstance is started from", func() {
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{}
+
+			vmi := controller.setupVMIFromVM(vm)
+			Expect(vmi.Spec.Domain.Firmware.UUID).ToNot(BeEmpty())
+			Expect(vm.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+
+			started, err := startedTemplateSpec(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(Equal(&vm.Spec.Template.Spec))
+		})
+
 		It("should delete VirtualMachineInstance when stopped", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -769,6 +996,172 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		It("should hotplug the sockets and the guest memory of the template into the running VMI", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			guest := resource.MustParse("1Gi")
+			maxGuest := resource.MustParse("4Gi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, MaxSockets: 4}
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+			vm.Spec.Template.Spec = *vmi.Spec.DeepCopy()
+			vm.Spec.Template.Spec.Domain.CPU.Sockets = 4
+			templateGuest := resource.MustParse("2Gi")
+			vm.Spec.Template.Spec.Domain.Memory.Guest = &templateGuest
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+				objVMI := obj.(*v1.VirtualMachineInstance)
+				Expect(objVMI.Spec.Domain.CPU.Sockets).To(Equal(uint32(4)))
+				Expect(objVMI.Spec.Domain.Memory.Guest.String()).To(Equal("2Gi"))
+			}).Return(vmi, nil)
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+				Expect(obj.(*v1.VirtualMachine).Status.Conditions).To(BeEmpty())
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should add a RestartRequired condition if changes of the template can not be hotplugged", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2}
+			Expect(setStartedTemplateSpec(vmi, &vmi.Spec)).To(Succeed())
+			vm.Spec.Template.Spec = *vmi.Spec.DeepCopy()
+			vm.Spec.Template.Spec.Domain.CPU.Sockets = 4
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+				objVM := obj.(*v1.VirtualMachine)
+				Expect(objVM.Status.Conditions).To(HaveLen(1))
+				cond := objVM.Status.Conditions[0]
+				Expect(cond.Type).To(Equal(v1.VirtualMachineRestartRequired))
+				Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(cond.Message).To(Equal("changes to spec.template.spec.domain.cpu.sockets require a restart of the VirtualMachine"))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should remove the RestartRequired condition once the VMI matches the template", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Created = true
+			vm.Status.Conditions = []v1.VirtualMachineCondition{
+				{
+					Type:    v1.VirtualMachineRestartRequired,
+					Status:  k8sv1.ConditionTrue,
+					Message: "changes to spec.template.spec.domain.cpu.sockets require a restart of the VirtualMachine",
+				},
+			}
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+				Expect(obj.(*v1.VirtualMachine).Status.Conditions).To(BeEmpty())
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		table.DescribeTable("should describe the template changes which require a restart", func(updateStarted func(*v1.VirtualMachineInstanceSpec), updateTemplate func(*v1.VirtualMachineInstanceSpec), updateVMI func(*v1.VirtualMachineInstance), expected string) {
+			vm, vmi := DefaultVirtualMachine(true)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "disk0",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+			}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name:         "disk0",
+				VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fedora"}},
+			}}
+			if updateStarted != nil {
+				updateStarted(&vmi.Spec)
+			}
+			Expect(setStartedTemplateSpec(vmi, &vmi.Spec)).To(Succeed())
+			vm.Spec.Template.Spec = *vmi.Spec.DeepCopy()
+
+			if updateTemplate != nil {
+				updateTemplate(&vm.Spec.Template.Spec)
+			}
+			if updateVMI != nil {
+				updateVMI(vmi)
+			}
+			Expect(restartRequiredMessage(vm, vmi)).To(Equal(expected))
+		},
+			table.Entry("with no changes", nil, nil, nil, ""),
+			table.Entry("with fields defaulted on the VMI", nil, nil, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Machine.Type = "q35"
+				vmi.Spec.Domain.CPU = &v1.CPU{Model: "host-model"}
+				vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceCPU] = resource.MustParse("100m")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			}, ""),
+			table.Entry("with a hotplugged volume on the VMI", nil, nil, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "hotplug"})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: "hotplug"})
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hotplug", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+				hotplugdisk.AddHotplugVolumeName(vmi, "hotplug")
+			}, ""),
+			table.Entry("with a hotplugged volume on the VMI and in the template", nil, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, v1.Disk{Name: "hotplug", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}})
+				spec.Volumes = append(spec.Volumes, v1.Volume{Name: "hotplug"})
+			}, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "hotplug"})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: "hotplug"})
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{{Name: "hotplug", HotplugVolume: &v1.HotplugVolumeStatus{}}}
+				hotplugdisk.AddHotplugVolumeName(vmi, "hotplug")
+			}, ""),
+			table.Entry("with sockets which can be hotplugged", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{Sockets: 1, MaxSockets: 4}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU.Sockets = 2
+			}, nil, ""),
+			table.Entry("with sockets which were hotplugged", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{Sockets: 1, MaxSockets: 4}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU.Sockets = 2
+			}, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.CPU.Sockets = 2
+			}, ""),
+			table.Entry("with a changed disk", nil, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Devices.Disks[0].Disk.Bus = "sata"
+			}, nil, "changes to spec.template.spec.domain.devices.disks[disk0].disk.bus require a restart of the VirtualMachine"),
+			table.Entry("with an added volume", nil, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, v1.Disk{Name: "disk1"})
+				spec.Volumes = append(spec.Volumes, v1.Volume{Name: "disk1"})
+			}, nil, "changes to spec.template.spec.domain.devices.disks, spec.template.spec.volumes require a restart of the VirtualMachine"),
+			table.Entry("with a changed memory request and node selector", nil, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("128Mi")}
+				spec.NodeSelector = map[string]string{"zone": "a"}
+			}, nil, "changes to spec.template.spec.domain.resources.requests[memory], spec.template.spec.nodeSelector[zone] require a restart of the VirtualMachine"),
+			table.Entry("with a field reset to its zero value", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{DedicatedCPUPlacement: true}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU.DedicatedCPUPlacement = false
+			}, nil, "changes to spec.template.spec.domain.cpu.dedicatedCpuPlacement require a restart of the VirtualMachine"),
+			table.Entry("with a removed struct", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = &v1.CPU{DedicatedCPUPlacement: true}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.CPU = nil
+			}, nil, "changes to spec.template.spec.domain.cpu require a restart of the VirtualMachine"),
+			table.Entry("with a removed map key", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.NodeSelector = map[string]string{"zone": "a", "rack": "b"}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				delete(spec.NodeSelector, "zone")
+			}, nil, "changes to spec.template.spec.nodeSelector[zone] require a restart of the VirtualMachine"),
+			table.Entry("with an emptied map", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.NodeSelector = map[string]string{"zone": "a"}
+			}, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.NodeSelector = nil
+			}, nil, "changes to spec.template.spec.nodeSelector[zone] require a restart of the VirtualMachine"),
+			table.Entry("without a recorded template", nil, func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.Domain.Devices.Disks[0].Disk.Bus = "sata"
+			}, func(vmi *v1.VirtualMachineInstance) {
+				delete(vmi.Annotations, v1.VirtualMachineTemplateSpecAnnotation)
+			}, ""),
+		)
+
 		It("should add a fail condition if start up fails", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
@@ -38,6 +39,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -368,19 +370,27 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			controller.RemoveFinalizer(vmiCopy, virtv1.VirtualMachineInstanceFinalizer)
 		}
 	case vmi.IsRunning():
-		// Keep PodReady condition in sync with the VMI
-		if !podExists {
-			// Remove PodScheduling condition from the VM
-			conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
-		} else if cond := conditionManager.GetPodCondition(pod, k8sv1.PodReady); cond != nil {
-			conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
-			conditionManager.AddPodCondition(vmiCopy, cond)
-		} else if conditionManager.HasCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady)) {
-			// Remove PodScheduling condition from the VM
-			conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+		// Keep PodReady condition in sync with the VMI, unless virt-handler owns it
+		// because it runs the readiness probe in the guest
+		if !vmi.Spec.ReadinessProbe.IsGuestAgentProbe() {
+			if !podExists {
+				// Remove PodScheduling condition from the VM
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+			} else if cond := conditionManager.GetPodCondition(pod, k8sv1.PodReady); cond != nil {
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+				conditionManager.AddPodCondition(vmiCopy, cond)
+			} else if conditionManager.HasCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady)) {
+				// Remove PodScheduling condition from the VM
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+			}
+		}
+
+		if err := c.updateHotplugVolumeStatus(vmiCopy); err != nil {
+			return err
 		}
 
 		// We don't own the object anymore, so patch instead of update
+		var ops []string
 		if !reflect.DeepEqual(vmiCopy.Status.Conditions, vmi.Status.Conditions) {
 			newConditions, err := json.Marshal(vmiCopy.Status.Conditions)
 			if err != nil {
@@ -390,14 +400,28 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			if err != nil {
 				return err
 			}
-			test := fmt.Sprintf(`{ "op": "test", "path": "/status/conditions", "value": %s }`, string(oldConditions))
-			patch := fmt.Sprintf(`{ "op": "replace", "path": "/status/conditions", "value": %s }`, string(newConditions))
-			log.Log.V(3).Object(vmi).Infof("Patching VMI conditions")
-			_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s, %s ]", test, patch)))
+			ops = append(ops, fmt.Sprintf(`{ "op": "test", "path": "/status/conditions", "value": %s }`, string(oldConditions)))
+			ops = append(ops, fmt.Sprintf(`{ "op": "replace", "path": "/status/conditions", "value": %s }`, string(newConditions)))
+		}
+		if !reflect.DeepEqual(vmiCopy.Status.VolumeStatus, vmi.Status.VolumeStatus) {
+			newVolumeStatus, err := json.Marshal(vmiCopy.Status.VolumeStatus)
+			if err != nil {
+				return err
+			}
+			oldVolumeStatus, err := json.Marshal(vmi.Status.VolumeStatus)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, fmt.Sprintf(`{ "op": "test", "path": "/status/volumeStatus", "value": %s }`, string(oldVolumeStatus)))
+			ops = append(ops, fmt.Sprintf(`{ "op": "replace", "path": "/status/volumeStatus", "value": %s }`, string(newVolumeStatus)))
+		}
+		if len(ops) > 0 {
+			log.Log.V(3).Object(vmi).Infof("Patching VMI status")
+			_, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s ]", strings.Join(ops, ", "))))
 			// We could not retry if the "test" fails but we have no sane way to detect that right now: https://github.com/kubernetes/kubernetes/issues/68202 for details
 			// So just retry like with any other errors
 			if err != nil {
-				return fmt.Errorf("patching vmi conditions failed: %v", err)
+				return fmt.Errorf("patching vmi status failed: %v", err)
 			}
 		}
 		return nil
@@ -531,9 +555,114 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 		c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, SuccessfulCreatePodReason, "Created virtual machine pod %s", pod.Name)
 		return nil
 	}
+
+	if vmi.IsRunning() {
+		return c.handleHotplugVolumes(vmi)
+	}
+	return nil
+}
+
+// handleHotplugVolumes creates an attachment pod for every hotplugged volume which does not have one yet,
+// and deletes the attachment pods of volumes which are completely unplugged
+func (c *VMIController) handleHotplugVolumes(vmi *virtv1.VirtualMachineInstance) syncError {
+	attachmentPods, err := c.listHotplugAttachmentPods(vmi)
+	if err != nil {
+		return &syncErrorImpl{fmt.Errorf("failed to list attachment pods: %v", err), FailedCreatePodReason}
+	}
+	hotplugVolumes := hotplugdisk.GetHotplugVolumes(vmi)
+	vmiKey := controller.VirtualMachineKey(vmi)
+
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		if _, isHotplugVolume := hotplugVolumes[volume.Name]; !isHotplugVolume {
+			continue
+		}
+		if _, exists := attachmentPods[volume.Name]; exists {
+			continue
+		}
+
+		templatePod, err := c.templateService.RenderHotplugAttachmentPodTemplate(volume, vmi)
+		if _, ok := err.(services.PvcNotFoundError); ok {
+			return &syncErrorImpl{fmt.Errorf("failed to render attachment pod for volume %s: %v", volume.Name, err), FailedPvcNotFoundReason}
+		} else if err != nil {
+			return &syncErrorImpl{fmt.Errorf("failed to render attachment pod for volume %s: %v", volume.Name, err), FailedCreatePodReason}
+		}
+
+		c.podExpectations.ExpectCreations(vmiKey, 1)
+		pod, err := c.clientset.CoreV1().Pods(vmi.GetNamespace()).Create(templatePod)
+		if err != nil {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedCreatePodReason, "Error creating attachment pod for volume %s: %v", volume.Name, err)
+			c.podExpectations.CreationObserved(vmiKey)
+			return &syncErrorImpl{fmt.Errorf("failed to create attachment pod: %v", err), FailedCreatePodReason}
+		}
+		c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, SuccessfulCreatePodReason, "Created attachment pod %s for volume %s", pod.Name, volume.Name)
+	}
+
+	// virt-handler removes the volume status once the disk is detached and unmounted,
+	// only then it is safe to remove the attachment pod
+	for volumeName, pod := range attachmentPods {
+		if _, isHotplugVolume := hotplugVolumes[volumeName]; isHotplugVolume || pod.DeletionTimestamp != nil {
+			continue
+		}
+		c.podExpectations.ExpectDeletions(vmiKey, []string{controller.PodKey(pod)})
+		err := c.clientset.CoreV1().Pods(vmi.Namespace).Delete(pod.Name, &v1.DeleteOptions{})
+		if err != nil {
+			c.podExpectations.DeletionObserved(vmiKey, controller.PodKey(pod))
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedDeletePodReason, "Failed to delete attachment pod %s", pod.Name)
+			return &syncErrorImpl{fmt.Errorf("failed to delete attachment pod: %v", err), FailedDeletePodReason}
+		}
+		c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, SuccessfulDeletePodReason, "Deleted attachment pod %s for volume %s", pod.Name, volumeName)
+	}
+	return nil
+}
+
+// updateHotplugVolumeStatus records the attachment pods in the hotplug volume status and marks
+// volumes as attached to the node once their pod runs. All later phases are set by virt-handler.
+func (c *VMIController) updateHotplugVolumeStatus(vmi *virtv1.VirtualMachineInstance) error {
+	hotplugVolumes := hotplugdisk.GetHotplugVolumes(vmi)
+	if len(hotplugVolumes) == 0 {
+		return nil
+	}
+	attachmentPods, err := c.listHotplugAttachmentPods(vmi)
+	if err != nil {
+		return err
+	}
+	for volumeName, status := range hotplugVolumes {
+		pod, exists := attachmentPods[volumeName]
+		if !exists {
+			continue
+		}
+		status.HotplugVolume.AttachPodName = pod.Name
+		status.HotplugVolume.AttachPodUID = pod.UID
+		if status.Phase == virtv1.VolumePending && pod.Status.Phase == k8sv1.PodRunning {
+			status.Phase = virtv1.HotplugVolumeAttachedToNode
+			status.Reason = ""
+			status.Message = fmt.Sprintf("Created attachment pod %s", pod.Name)
+		}
+	}
 	return nil
 }
 
+// listHotplugAttachmentPods returns the attachment pods of a VMI by volume name
+func (c *VMIController) listHotplugAttachmentPods(vmi *virtv1.VirtualMachineInstance) (map[string]*k8sv1.Pod, error) {
+	pods, err := c.listPodsFromNamespace(vmi.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	attachmentPods := map[string]*k8sv1.Pod{}
+	for _, pod := range pods {
+		if !controller.IsControlledBy(pod, vmi) || !isHotplugAttachmentPod(pod) {
+			continue
+		}
+		attachmentPods[pod.Labels[virtv1.HotplugVolumeLabel]] = pod
+	}
+	return attachmentPods, nil
+}
+
+func isHotplugAttachmentPod(pod *k8sv1.Pod) bool {
+	return pod.Labels[virtv1.AppLabel] == "hotplug-disk"
+}
+
 func (c *VMIController) handleSyncDataVolumes(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, syncError) {
 
 	ready := true
@@ -907,7 +1036,7 @@ func (c *VMIController) currentPod(vmi *virtv1.VirtualMachineInstance) (*k8sv1.P
 
 	var curPod *k8sv1.Pod = nil
 	for _, pod := range pods {
-		if !controller.IsControlledBy(pod, vmi) {
+		if !controller.IsControlledBy(pod, vmi) || isHotplugAttachmentPod(pod) {
 			continue
 		}
 
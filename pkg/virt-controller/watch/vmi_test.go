@@ -20,6 +20,7 @@
 package watch
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/golang/mock/gomock"
@@ -45,6 +46,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -885,6 +887,19 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		It("should not sync the ready condition of the pod if the readiness probe is run in the guest", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			controller.Execute()
+		})
+
 		table.DescribeTable("should not add a ready condition if the vmi is", func(phase v1.VirtualMachineInstancePhase) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = phase
@@ -930,6 +945,107 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		)
 	})
 
+	Context("with hotplugged volumes", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		newAttachmentPod := func(volumeName string, phase k8sv1.PodPhase) *k8sv1.Pod {
+			pod := NewPodForVirtualMachine(vmi, phase)
+			pod.Name = "hp-volume-" + volumeName
+			pod.Labels[v1.AppLabel] = "hotplug-disk"
+			pod.Labels[v1.HotplugVolumeLabel] = volumeName
+			pod.UID = "5678"
+			return pod
+		}
+
+		newLauncherPod := func() *k8sv1.Pod {
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Spec.NodeName = vmi.Status.NodeName
+			return pod
+		}
+
+		BeforeEach(func() {
+			vmi = NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = "node01"
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "hpvolume",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hotplug-pvc"},
+				},
+			}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+				Name:          "hpvolume",
+				Phase:         v1.VolumePending,
+				HotplugVolume: &v1.HotplugVolumeStatus{},
+			}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+			pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: vmi.Namespace, Name: "hotplug-pvc"},
+			})
+		})
+
+		It("should create an attachment pod for a hotplugged volume", func() {
+			addVirtualMachine(vmi)
+			podFeeder.Add(newLauncherPod())
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				Expect(pod.Labels[v1.AppLabel]).To(Equal("hotplug-disk"))
+				Expect(pod.Labels[v1.HotplugVolumeLabel]).To(Equal("hpvolume"))
+				return true, pod, nil
+			})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should mark the volume as attached to the node once the attachment pod runs", func() {
+			addVirtualMachine(vmi)
+			podFeeder.Add(newLauncherPod())
+			podFeeder.Add(newAttachmentPod("hpvolume", k8sv1.PodRunning))
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Do(func(name string, patchType types.PatchType, body []byte) {
+				patch := []map[string]interface{}{}
+				Expect(json.Unmarshal(body, &patch)).To(Succeed())
+				Expect(patch).To(HaveLen(2))
+				Expect(patch[1]["path"]).To(Equal("/status/volumeStatus"))
+				status := patch[1]["value"].([]interface{})[0].(map[string]interface{})
+				Expect(status["phase"]).To(Equal(string(v1.HotplugVolumeAttachedToNode)))
+				Expect(status["hotplugVolume"]).To(Equal(map[string]interface{}{
+					"attachPodName": "hp-volume-hpvolume",
+					"attachPodUID":  "5678",
+				}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
+		It("should delete the attachment pod once the volume is unplugged", func() {
+			vmi.Spec.Volumes = nil
+			vmi.Status.VolumeStatus = nil
+			attachmentPod := newAttachmentPod("hpvolume", k8sv1.PodRunning)
+			addVirtualMachine(vmi)
+			podFeeder.Add(newLauncherPod())
+			podFeeder.Add(attachmentPod)
+
+			shouldExpectPodDeletion(attachmentPod)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
+		})
+
+		It("should keep the attachment pod while the volume is still being unplugged", func() {
+			vmi.Spec.Volumes = nil
+			vmi.Status.VolumeStatus[0].Phase = v1.VolumeReady
+			vmi.Status.VolumeStatus[0].HotplugVolume = &v1.HotplugVolumeStatus{AttachPodName: "hp-volume-hpvolume", AttachPodUID: "5678"}
+			addVirtualMachine(vmi)
+			podFeeder.Add(newLauncherPod())
+			podFeeder.Add(newAttachmentPod("hpvolume", k8sv1.PodRunning))
+
+			controller.Execute()
+		})
+	})
+
 	Context("When VirtualMachineInstance is connected to a network", func() {
 		It("should report the status of this network", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
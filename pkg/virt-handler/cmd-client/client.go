@@ -67,6 +67,8 @@ type MigrationOptions struct {
 	CompletionTimeoutPerGiB int64
 	UnsafeMigration         bool
 	AllowAutoConverge       bool
+	AllowPostCopy           bool
+	PostCopyAfterIterations int64
 }
 
 type LauncherClient interface {
@@ -76,9 +78,21 @@ type LauncherClient interface {
 	KillVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	MigrateVirtualMachine(vmi *v1.VirtualMachineInstance, options *MigrationOptions) error
 	CancelVirtualMachineMigration(vmi *v1.VirtualMachineInstance) error
+	PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	HotplugDisk(vmi *v1.VirtualMachineInstance) error
+	UnplugDisk(vmi *v1.VirtualMachineInstance) error
+	HotplugResources(vmi *v1.VirtualMachineInstance) error
+	ChangeMedia(vmi *v1.VirtualMachineInstance) error
+	ResizeDisk(domainName string, diskName string, size int64) error
 	DeleteDomain(vmi *v1.VirtualMachineInstance) error
 	GetDomain() (*api.Domain, bool, error)
 	GetDomainStats() (*stats.DomainStats, bool, error)
+	GetGuestInfo() (*v1.VirtualMachineInstanceGuestAgentInfo, bool, error)
+	GuestPing(domainName string, timeoutSeconds int32) error
+	Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error)
 	Ping() error
 	Close()
 }
@@ -251,6 +265,38 @@ func (c *VirtLauncherClient) KillVirtualMachine(vmi *v1.VirtualMachineInstance)
 	return c.genericSendVMICmd("Kill", c.v1client.KillVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
+func (c *VirtLauncherClient) PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Pause", c.v1client.PauseVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Unpause", c.v1client.UnpauseVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Freeze", c.v1client.FreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) HotplugDisk(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("HotplugDisk", c.v1client.HotplugDisk, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) UnplugDisk(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("UnplugDisk", c.v1client.UnplugDisk, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) HotplugResources(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("HotplugResources", c.v1client.HotplugResources, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) ChangeMedia(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("ChangeMedia", c.v1client.ChangeMedia, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
 func (c *VirtLauncherClient) DeleteDomain(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("Delete", c.v1client.DeleteVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
@@ -339,6 +385,74 @@ func (c *VirtLauncherClient) GetDomainStats() (*stats.DomainStats, bool, error)
 	return stats, exists, nil
 }
 
+func (c *VirtLauncherClient) GetGuestInfo() (*v1.VirtualMachineInstanceGuestAgentInfo, bool, error) {
+	guestInfo := &v1.VirtualMachineInstanceGuestAgentInfo{}
+	exists := false
+
+	request := &cmdv1.EmptyRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.GetGuestInfo(ctx, request)
+
+	if err = handleError(err, "GetGuestInfo", response.Response); err != nil {
+		return guestInfo, exists, err
+	}
+
+	if response.GuestInfo != "" {
+		if err := json.Unmarshal([]byte(response.GuestInfo), guestInfo); err != nil {
+			log.Log.Reason(err).Error("error unmarshalling guest info")
+			return guestInfo, exists, err
+		}
+		exists = true
+	}
+	return guestInfo, exists, nil
+}
+
+func (c *VirtLauncherClient) GuestPing(domainName string, timeoutSeconds int32) error {
+	request := &cmdv1.GuestPingRequest{
+		DomainName:     domainName,
+		TimeoutSeconds: timeoutSeconds,
+	}
+	// the guest agent gets the whole timeout, the launcher needs a bit on top
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second+shortTimeout)
+	defer cancel()
+	response, err := c.v1client.GuestPing(ctx, request)
+
+	err = handleError(err, "GuestPing", response)
+	return err
+}
+
+func (c *VirtLauncherClient) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	request := &cmdv1.ExecRequest{
+		DomainName:     domainName,
+		Command:        command,
+		Args:           args,
+		TimeoutSeconds: timeoutSeconds,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second+shortTimeout)
+	defer cancel()
+	response, err := c.v1client.Exec(ctx, request)
+
+	if err = handleError(err, "Exec", response.GetResponse()); err != nil {
+		return -1, "", err
+	}
+	return int(response.ExitCode), response.StdOut, nil
+}
+
+func (c *VirtLauncherClient) ResizeDisk(domainName string, diskName string, size int64) error {
+	request := &cmdv1.ResizeDiskRequest{
+		DomainName: domainName,
+		DiskName:   diskName,
+		Size:       size,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+	response, err := c.v1client.ResizeDisk(ctx, request)
+
+	err = handleError(err, "ResizeDisk", response)
+	return err
+}
+
 func (c *VirtLauncherClient) Ping() error {
 	request := &cmdv1.EmptyRequest{}
 	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package hotplug_disk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+type Mounter struct {
+	PodIsolationDetector isolation.PodIsolationDetector
+}
+
+// Mount takes a vmi and makes the disks of all hotplugged volumes, whose attachment pods run on this node,
+// available in the hotplug disk directory of the VMI. From there they propagate into the virt-launcher pod.
+// Disk images of filesystem volumes are bind mounted, block volumes get a device node.
+func (m *Mounter) Mount(vmi *v1.VirtualMachineInstance) error {
+	hotplugVolumes := hotplugdisk.GetHotplugVolumes(vmi)
+	for _, volume := range vmi.Spec.Volumes {
+		status, isHotplugVolume := hotplugVolumes[volume.Name]
+		if !isHotplugVolume || status.Phase == v1.VolumePending {
+			continue
+		}
+
+		targetFile := hotplugdisk.GenerateDiskTargetPathFromHostView(vmi, volume.Name)
+		if isMounted, err := m.IsMounted(vmi, volume.Name); err != nil {
+			return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
+		} else if isMounted {
+			continue
+		}
+
+		res, err := m.PodIsolationDetector.DetectForSocket(vmi, hotplugdisk.GenerateSocketPathFromHostView(vmi, volume.Name))
+		if err != nil {
+			return fmt.Errorf("failed to detect socket for hotplugged volume %v: %v", volume.Name, err)
+		}
+
+		devicePath := filepath.Join(res.MountRoot(), hotplugdisk.AttachmentPodBlockDevicePath)
+		if deviceInfo, err := os.Stat(devicePath); err == nil && deviceInfo.Mode()&os.ModeDevice != 0 {
+			if err := m.mountBlockDevice(vmi, deviceInfo, targetFile); err != nil {
+				return fmt.Errorf("failed to create the device of hotplugged volume %v: %v", volume.Name, err)
+			}
+			continue
+		} else if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to check for a block device of hotplugged volume %v: %v", volume.Name, err)
+		}
+
+		if err := m.mountDiskImage(res, volume.Name, targetFile); err != nil {
+			return fmt.Errorf("failed to bindmount hotplugged volume %v: %v", volume.Name, err)
+		}
+	}
+	return nil
+}
+
+// mountDiskImage bind mounts the disk image of a filesystem volume from the attachment pod to the target file
+func (m *Mounter) mountDiskImage(res *isolation.IsolationResult, volumeName string, targetFile string) error {
+	nodeRes := isolation.NodeIsolationResult()
+	mountInfo, err := res.MountInfoFor(hostdisk.GetMountedHostDiskDir(volumeName))
+	if err != nil {
+		return fmt.Errorf("failed to detect mount info: %v", err)
+	}
+	nodeMountInfo, err := nodeRes.ParentMountInfoFor(mountInfo)
+	if err != nil {
+		return fmt.Errorf("failed to detect mount point on the node: %v", err)
+	}
+	sourceFile := filepath.Join(nodeMountInfo.MountPoint, strings.TrimPrefix(mountInfo.Root, nodeMountInfo.Root), "disk.img")
+	if _, err := os.Stat(filepath.Join(nodeRes.MountRoot(), sourceFile)); err != nil {
+		return fmt.Errorf("failed to find the disk image: %v", err)
+	}
+
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
+	}
+	f.Close()
+
+	out, err := exec.Command("/usr/bin/chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "bind", sourceFile, targetFile).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v : %v", string(out), err)
+	}
+	return nil
+}
+
+// mountBlockDevice creates a device node for the block device of the attachment pod at the target file,
+// and allows the virt-launcher pod to access the device through its devices cgroup
+func (m *Mounter) mountBlockDevice(vmi *v1.VirtualMachineInstance, deviceInfo os.FileInfo, targetFile string) error {
+	stat, ok := deviceInfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("failed to determine the device number")
+	}
+	launcherRes, err := m.PodIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect the virt-launcher pod: %v", err)
+	}
+
+	rule := fmt.Sprintf("b %d:%d rwm", unix.Major(uint64(stat.Rdev)), unix.Minor(uint64(stat.Rdev)))
+	allowFile := filepath.Join(isolation.NodeIsolationResult().MountRoot(), "sys/fs/cgroup/devices", launcherRes.Slice(), "devices.allow")
+	if err := ioutil.WriteFile(allowFile, []byte(rule), 0); err != nil {
+		return fmt.Errorf("failed to allow the device in the cgroup of the virt-launcher pod: %v", err)
+	}
+
+	if err := os.Remove(targetFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return unix.Mknod(targetFile, unix.S_IFBLK|0660, int(stat.Rdev))
+}
+
+// IsMounted checks if the disk of a hotplugged volume is available in the hotplug disk directory of the VMI.
+// That is either a bind mounted disk image or a device node.
+func (m *Mounter) IsMounted(vmi *v1.VirtualMachineInstance, volumeName string) (bool, error) {
+	targetFile := hotplugdisk.GenerateDiskTargetPathFromHostView(vmi, volumeName)
+	if isBlockDevice(targetFile) {
+		return true, nil
+	}
+	return isolation.NodeIsolationResult().IsMounted(targetFile)
+}
+
+// UnmountVolume unmounts the disk image of a single hotplugged volume. The disk must already be detached from the domain.
+func (m *Mounter) UnmountVolume(vmi *v1.VirtualMachineInstance, volumeName string) error {
+	return m.unmount(vmi, func(name string) bool {
+		return name == volumeName
+	})
+}
+
+// UnmountAll unmounts the disk images of all hotplugged volumes of a given VMI.
+func (m *Mounter) UnmountAll(vmi *v1.VirtualMachineInstance) error {
+	if vmi.UID == "" {
+		return nil
+	}
+	if err := m.unmount(vmi, func(string) bool { return true }); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(hotplugdisk.GenerateVolumeMountDir(vmi)); err != nil {
+		return fmt.Errorf("failed to remove hotplug disk files: %v", err)
+	}
+	return nil
+}
+
+func (m *Mounter) unmount(vmi *v1.VirtualMachineInstance, shouldUnmount func(volumeName string) bool) error {
+	mountDir := hotplugdisk.GenerateVolumeMountDir(vmi)
+
+	files, err := ioutil.ReadDir(mountDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to list hotplug disk mounts: %v", err)
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".img") || !shouldUnmount(strings.TrimSuffix(file.Name(), ".img")) {
+			continue
+		}
+		path := filepath.Join(mountDir, file.Name())
+		if isBlockDevice(path) {
+			// device nodes are not mounted, removing them is enough
+		} else if mounted, err := isolation.NodeIsolationResult().IsMounted(path); err != nil {
+			return fmt.Errorf("failed to check mount point for hotplugged volume %v: %v", path, err)
+		} else if mounted {
+			out, err := exec.Command("/usr/bin/chroot", "--mount", "/proc/1/ns/mnt", "umount", path).CombinedOutput()
+			if err != nil {
+				return fmt.Errorf("failed to unmount hotplugged volume %v: %v : %v", path, string(out), err)
+			}
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove mount point %v: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func isBlockDevice(path string) bool {
+	fileInfo, err := os.Stat(path)
+	return err == nil && fileInfo.Mode()&os.ModeDevice != 0
+}
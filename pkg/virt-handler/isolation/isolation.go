@@ -184,6 +184,47 @@ func (r *IsolationResult) MountInfoRoot() (*MountInfo, error) {
 	return nil, fmt.Errorf("process has no root entry")
 }
 
+// MountInfoFor returns information about the entry in /proc/mountinfo which is mounted at the given mount point
+func (r *IsolationResult) MountInfoFor(mountPoint string) (*MountInfo, error) {
+	in, err := os.Open(r.mountInfo())
+	if err != nil {
+		return nil, fmt.Errorf("could not open mountinfo: %v", err)
+	}
+	defer in.Close()
+	c := csv.NewReader(in)
+	c.Comma = ' '
+	c.LazyQuotes = true
+	var mountInfo *MountInfo
+	for {
+		record, err := c.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if e, ok := err.(*csv.ParseError); ok {
+				if e.Err != csv.ErrFieldCount {
+					return nil, err
+				}
+			} else {
+				return nil, err
+			}
+		}
+
+		// later entries shadow earlier ones which are mounted at the same place
+		if record[4] == mountPoint {
+			mountInfo = &MountInfo{
+				DeviceContainingFile: record[2],
+				Root:                 record[3],
+				MountPoint:           record[4],
+			}
+		}
+	}
+	if mountInfo == nil {
+		return nil, fmt.Errorf("no mount entry for %v found in the mount namespace of %d", mountPoint, r.pid)
+	}
+	return mountInfo, nil
+}
+
 // IsMounted checks if a path in the mount namespace of a
 // given process isolation result is a mount point. Works with symlinks.
 func (r *IsolationResult) IsMounted(mountPoint string) (bool, error) {
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -61,6 +62,16 @@ var _ = Describe("Isolation", func() {
 			Expect(result.MountRoot()).To(Equal(fmt.Sprintf("/proc/%d/root", os.Getpid())))
 		})
 
+		It("Should find the mount info of the root mount point of the test suite", func() {
+			result, err := NewSocketBasedIsolationDetector(tmpDir).Whitelist([]string{"devices"}).Detect(vm)
+			Expect(err).ToNot(HaveOccurred())
+			mountInfo, err := result.MountInfoFor("/")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mountInfo.MountPoint).To(Equal("/"))
+			_, err = result.MountInfoFor(filepath.Join(tmpDir, "not-a-mount-point"))
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should detect the Network namespace of the test suite", func() {
 			result, err := NewSocketBasedIsolationDetector(tmpDir).Whitelist([]string{"devices"}).Detect(vm)
 			Expect(err).ToNot(HaveOccurred())
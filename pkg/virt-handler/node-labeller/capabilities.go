@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package node_labeller
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const (
+	cpuModeHostModel     = "host-model"
+	cpuModeCustom        = "custom"
+	cpuModelUsable       = "yes"
+	featurePolicyRequire = "require"
+	featurePolicyDisable = "disable"
+)
+
+// DomainCapabilities is the subset of `virsh domcapabilities` the labeller needs
+type DomainCapabilities struct {
+	XMLName xml.Name `xml:"domainCapabilities"`
+	CPU     CPU      `xml:"cpu"`
+}
+
+type CPU struct {
+	Modes []Mode `xml:"mode"`
+}
+
+type Mode struct {
+	Name      string    `xml:"name,attr"`
+	Supported string    `xml:"supported,attr"`
+	Models    []Model   `xml:"model"`
+	Features  []Feature `xml:"feature"`
+}
+
+type Model struct {
+	Name   string `xml:",chardata"`
+	Usable string `xml:"usable,attr,omitempty"`
+}
+
+type Feature struct {
+	Name   string `xml:"name,attr"`
+	Policy string `xml:"policy,attr"`
+}
+
+// CPUMap is a CPU model definition from the libvirt cpu_map directory
+type CPUMap struct {
+	XMLName xml.Name      `xml:"cpus"`
+	Models  []CPUMapModel `xml:"model"`
+}
+
+type CPUMapModel struct {
+	Name     string    `xml:"name,attr"`
+	Features []Feature `xml:"feature"`
+	// Models this model is based on
+	Models []CPUMapModel `xml:"model"`
+}
+
+func parseDomainCapabilities(path string) (*DomainCapabilities, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := &DomainCapabilities{}
+	if err := xml.Unmarshal(data, capabilities); err != nil {
+		return nil, err
+	}
+	return capabilities, nil
+}
+
+// usableModels returns the CPU models libvirt can provide on this host, without the obsolete ones
+func (c *DomainCapabilities) usableModels(obsoleteModels []string) []string {
+	obsolete := map[string]bool{}
+	for _, model := range obsoleteModels {
+		obsolete[model] = true
+	}
+
+	models := []string{}
+	for _, mode := range c.CPU.Modes {
+		if mode.Name != cpuModeCustom {
+			continue
+		}
+		for _, model := range mode.Models {
+			if model.Usable == cpuModelUsable && !obsolete[model.Name] {
+				models = append(models, model.Name)
+			}
+		}
+	}
+	return models
+}
+
+// hostFeatures returns the full CPU feature set of the host-model CPU: the features of its
+// base model, as defined in the libvirt cpu_map, plus the required and minus the disabled ones
+func (c *DomainCapabilities) hostFeatures(cpuMapPath string) ([]string, error) {
+	features := map[string]bool{}
+	for _, mode := range c.CPU.Modes {
+		if mode.Name != cpuModeHostModel {
+			continue
+		}
+		for _, model := range mode.Models {
+			if err := addModelFeatures(cpuMapPath, model.Name, features, map[string]bool{}); err != nil {
+				return nil, err
+			}
+		}
+		for _, feature := range mode.Features {
+			switch feature.Policy {
+			case featurePolicyRequire:
+				features[feature.Name] = true
+			case featurePolicyDisable:
+				delete(features, feature.Name)
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range features {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// addModelFeatures adds the features of the given model and of the models it is based on
+func addModelFeatures(cpuMapPath string, modelName string, features map[string]bool, visited map[string]bool) error {
+	if visited[modelName] {
+		return nil
+	}
+	visited[modelName] = true
+
+	data, err := ioutil.ReadFile(filepath.Join(cpuMapPath, fmt.Sprintf("x86_%s.xml", modelName)))
+	if err != nil {
+		return fmt.Errorf("failed to read the definition of cpu model %s: %v", modelName, err)
+	}
+	cpuMap := &CPUMap{}
+	if err := xml.Unmarshal(data, cpuMap); err != nil {
+		return fmt.Errorf("failed to parse the definition of cpu model %s: %v", modelName, err)
+	}
+
+	for _, model := range cpuMap.Models {
+		for _, base := range model.Models {
+			if err := addModelFeatures(cpuMapPath, base.Name, features, visited); err != nil {
+				return err
+			}
+		}
+		for _, feature := range model.Features {
+			features[feature.Name] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package node_labeller
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+const (
+	// NodeLabellerVolumePath is where the node labeller sidecar of virt-handler stores the libvirt domain capabilities
+	NodeLabellerVolumePath = "/var/lib/kubevirt-node-labeller"
+	DomainCapabilitiesFile = "virsh_domcapabilities.xml"
+	// CPUMapDir holds the libvirt CPU model definitions of the node
+	CPUMapDir = "cpu_map"
+)
+
+// NodeLabeller labels the node with the CPU models and features libvirt supports on it,
+// so that VirtualMachineInstances which ask for them get scheduled to a matching node
+type NodeLabeller struct {
+	clientset     kubecli.KubevirtClient
+	host          string
+	clusterConfig *virtconfig.ClusterConfig
+	volumePath    string
+}
+
+func NewNodeLabeller(clientset kubecli.KubevirtClient, host string, clusterConfig *virtconfig.ClusterConfig, volumePath string) *NodeLabeller {
+	return &NodeLabeller{
+		clientset:     clientset,
+		host:          host,
+		clusterConfig: clusterConfig,
+		volumePath:    volumePath,
+	}
+}
+
+// UpdateNodeLabels adds the labels for all usable CPU models and host features and
+// removes the model and feature labels which the node does not support anymore.
+// Only labels which the labeller added itself are changed or removed, labels set by
+// others, like node-feature-discovery, are left alone.
+func (n *NodeLabeller) UpdateNodeLabels() error {
+	// the domain capabilities are refreshed periodically by the sidecar, read them on every update
+	capabilities, err := parseDomainCapabilities(filepath.Join(n.volumePath, DomainCapabilitiesFile))
+	if err != nil {
+		return fmt.Errorf("failed to read the domain capabilities: %v", err)
+	}
+
+	labels, err := n.labelsFor(capabilities)
+	if err != nil {
+		return err
+	}
+
+	node, err := n.clientset.CoreV1().Nodes().Get(n.host, v12.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %v", n.host, err)
+	}
+
+	owned := ownedLabels(node)
+	labelPatch := map[string]interface{}{}
+	for key := range owned {
+		if _, exists := labels[key]; exists {
+			continue
+		}
+		if _, onNode := node.Labels[key]; onNode {
+			labelPatch[key] = nil
+		}
+	}
+	nowOwned := []string{}
+	for key, value := range labels {
+		current, onNode := node.Labels[key]
+		if onNode && !owned[key] {
+			// the label was set by someone else
+			continue
+		}
+		nowOwned = append(nowOwned, key)
+		if !onNode || current != value {
+			labelPatch[key] = value
+		}
+	}
+	sort.Strings(nowOwned)
+
+	metadata := map[string]interface{}{}
+	if len(labelPatch) > 0 {
+		metadata["labels"] = labelPatch
+	}
+	if annotation := strings.Join(nowOwned, ","); annotation != node.Annotations[v1.NodeLabellerLabelsAnnotation] {
+		metadata["annotations"] = map[string]interface{}{
+			v1.NodeLabellerLabelsAnnotation: annotation,
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = n.clientset.CoreV1().Nodes().Patch(n.host, types.StrategicMergePatchType, data)
+	if err != nil {
+		return fmt.Errorf("failed to patch node %s: %v", n.host, err)
+	}
+	log.DefaultLogger().V(4).Infof("Updated CPU model and feature labels on node %s", n.host)
+	return nil
+}
+
+func (n *NodeLabeller) labelsFor(capabilities *DomainCapabilities) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, model := range capabilities.usableModels(n.clusterConfig.GetObsoleteCPUModels()) {
+		labels[services.NFD_CPU_MODEL_PREFIX+model] = "true"
+	}
+	features, err := capabilities.hostFeatures(filepath.Join(n.volumePath, CPUMapDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, feature := range features {
+		labels[services.NFD_CPU_FEATURE_PREFIX+feature] = "true"
+	}
+	return labels, nil
+}
+
+// ownedLabels returns the labels which the labeller added to the node
+func ownedLabels(node *k8sv1.Node) map[string]bool {
+	owned := map[string]bool{}
+	for _, key := range strings.Split(node.Annotations[v1.NodeLabellerLabelsAnnotation], ",") {
+		if key != "" {
+			owned[key] = true
+		}
+	}
+	return owned
+}
@@ -0,0 +1,255 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package node_labeller_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	node_labeller "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller"
+)
+
+const domainCapabilities = `<domainCapabilities>
+  <path>/usr/libexec/qemu-kvm</path>
+  <domain>kvm</domain>
+  <machine>pc-q35-rhel8.2.0</machine>
+  <arch>x86_64</arch>
+  <cpu>
+    <mode name='host-passthrough' supported='yes'/>
+    <mode name='host-model' supported='yes'>
+      <model fallback='forbid'>Skylake-Client-IBRS</model>
+      <vendor>Intel</vendor>
+      <feature policy='require' name='ss'/>
+      <feature policy='require' name='vmx'/>
+      <feature policy='disable' name='mpx'/>
+    </mode>
+    <mode name='custom' supported='yes'>
+      <model usable='yes'>qemu64</model>
+      <model usable='yes'>Penryn</model>
+      <model usable='yes'>Skylake-Client-IBRS</model>
+      <model usable='no'>EPYC</model>
+    </mode>
+  </cpu>
+</domainCapabilities>`
+
+const skylakeClientIBRS = `<cpus>
+  <model name='Skylake-Client-IBRS'>
+    <signatures>
+      <signature family='6' model='94'/>
+    </signatures>
+    <vendor name='Intel'/>
+    <feature name='avx2'/>
+    <feature name='mpx'/>
+    <feature name='spec-ctrl'/>
+  </model>
+</cpus>`
+
+var _ = Describe("Node labeller", func() {
+
+	var ctrl *gomock.Controller
+	var kubeClient *fake.Clientset
+	var volumePath string
+	var labeller *node_labeller.NodeLabeller
+
+	BeforeEach(func() {
+		var err error
+		volumePath, err = ioutil.TempDir("", "node-labeller")
+		Expect(err).ToNot(HaveOccurred())
+		err = ioutil.WriteFile(filepath.Join(volumePath, node_labeller.DomainCapabilitiesFile), []byte(domainCapabilities), 0644)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(volumePath, node_labeller.CPUMapDir), 0755)).To(Succeed())
+		err = ioutil.WriteFile(filepath.Join(volumePath, node_labeller.CPUMapDir, "x86_Skylake-Client-IBRS.xml"), []byte(skylakeClientIBRS), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset(&k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "testnode",
+				Labels: map[string]string{
+					services.NFD_CPU_MODEL_PREFIX + "Haswell":  "true",
+					services.NFD_CPU_FEATURE_PREFIX + "avx512": "true",
+					services.NFD_CPU_MODEL_PREFIX + "Penryn":   "true",
+					"kubernetes.io/hostname":                   "testnode",
+				},
+				Annotations: map[string]string{
+					v1.NodeLabellerLabelsAnnotation: strings.Join([]string{
+						services.NFD_CPU_FEATURE_PREFIX + "avx512",
+						services.NFD_CPU_MODEL_PREFIX + "Haswell",
+						services.NFD_CPU_MODEL_PREFIX + "Penryn",
+					}, ","),
+				},
+			},
+		})
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		labeller = node_labeller.NewNodeLabeller(virtClient, "testnode", clusterConfig, volumePath)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(volumePath)
+		ctrl.Finish()
+	})
+
+	getPatch := func() map[string]map[string]map[string]interface{} {
+		var patches []testing.PatchAction
+		for _, action := range kubeClient.Actions() {
+			if patch, isPatch := action.(testing.PatchAction); isPatch {
+				patches = append(patches, patch)
+			}
+		}
+		Expect(patches).To(HaveLen(1))
+		Expect(patches[0].GetName()).To(Equal("testnode"))
+
+		patch := map[string]map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(patches[0].GetPatch(), &patch)).To(Succeed())
+		return patch
+	}
+
+	getPatchedLabels := func() map[string]interface{} {
+		return getPatch()["metadata"]["labels"]
+	}
+
+	It("should label the node with usable models and the full host feature set and remove stale labels", func() {
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+
+		patch := getPatch()
+		Expect(patch["metadata"]["labels"]).To(Equal(map[string]interface{}{
+			services.NFD_CPU_MODEL_PREFIX + "Haswell":             nil,
+			services.NFD_CPU_FEATURE_PREFIX + "avx512":            nil,
+			services.NFD_CPU_MODEL_PREFIX + "Skylake-Client-IBRS": "true",
+			services.NFD_CPU_FEATURE_PREFIX + "avx2":              "true",
+			services.NFD_CPU_FEATURE_PREFIX + "spec-ctrl":         "true",
+			services.NFD_CPU_FEATURE_PREFIX + "ss":                "true",
+			services.NFD_CPU_FEATURE_PREFIX + "vmx":               "true",
+		}))
+		Expect(patch["metadata"]["annotations"]).To(HaveKeyWithValue(v1.NodeLabellerLabelsAnnotation, strings.Join([]string{
+			services.NFD_CPU_FEATURE_PREFIX + "avx2",
+			services.NFD_CPU_FEATURE_PREFIX + "spec-ctrl",
+			services.NFD_CPU_FEATURE_PREFIX + "ss",
+			services.NFD_CPU_FEATURE_PREFIX + "vmx",
+			services.NFD_CPU_MODEL_PREFIX + "Penryn",
+			services.NFD_CPU_MODEL_PREFIX + "Skylake-Client-IBRS",
+		}, ",")))
+	})
+
+	It("should not touch labels which it did not add", func() {
+		node, err := kubeClient.CoreV1().Nodes().Get("testnode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		node.Annotations = nil
+		_, err = kubeClient.CoreV1().Nodes().Update(node)
+		Expect(err).ToNot(HaveOccurred())
+		kubeClient.ClearActions()
+
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+
+		patch := getPatch()
+		Expect(patch["metadata"]["labels"]).ToNot(HaveKey(services.NFD_CPU_MODEL_PREFIX + "Haswell"))
+		Expect(patch["metadata"]["labels"]).ToNot(HaveKey(services.NFD_CPU_FEATURE_PREFIX + "avx512"))
+		Expect(patch["metadata"]["labels"]).ToNot(HaveKey(services.NFD_CPU_MODEL_PREFIX + "Penryn"))
+		Expect(patch["metadata"]["annotations"][v1.NodeLabellerLabelsAnnotation]).ToNot(ContainSubstring("Penryn"))
+	})
+
+	It("should not label models which are configured as obsolete", func() {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.ObsoleteCPUModelsKey: "Penryn"},
+		})
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		labeller = node_labeller.NewNodeLabeller(virtClient, "testnode", clusterConfig, volumePath)
+
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+
+		labels := getPatchedLabels()
+		Expect(labels).To(HaveKeyWithValue(services.NFD_CPU_MODEL_PREFIX+"Penryn", BeNil()))
+		Expect(labels).To(HaveKeyWithValue(services.NFD_CPU_MODEL_PREFIX+"qemu64", "true"))
+	})
+
+	It("should not patch the node if the labels are up to date", func() {
+		node, err := kubeClient.CoreV1().Nodes().Get("testnode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		node.Labels = map[string]string{
+			services.NFD_CPU_MODEL_PREFIX + "Penryn":              "true",
+			services.NFD_CPU_MODEL_PREFIX + "Skylake-Client-IBRS": "true",
+			services.NFD_CPU_FEATURE_PREFIX + "avx2":              "true",
+			services.NFD_CPU_FEATURE_PREFIX + "spec-ctrl":         "true",
+			services.NFD_CPU_FEATURE_PREFIX + "ss":                "true",
+			services.NFD_CPU_FEATURE_PREFIX + "vmx":               "true",
+		}
+		node.Annotations = map[string]string{
+			v1.NodeLabellerLabelsAnnotation: strings.Join([]string{
+				services.NFD_CPU_FEATURE_PREFIX + "avx2",
+				services.NFD_CPU_FEATURE_PREFIX + "spec-ctrl",
+				services.NFD_CPU_FEATURE_PREFIX + "ss",
+				services.NFD_CPU_FEATURE_PREFIX + "vmx",
+				services.NFD_CPU_MODEL_PREFIX + "Penryn",
+				services.NFD_CPU_MODEL_PREFIX + "Skylake-Client-IBRS",
+			}, ","),
+		}
+		_, err = kubeClient.CoreV1().Nodes().Update(node)
+		Expect(err).ToNot(HaveOccurred())
+		kubeClient.ClearActions()
+
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+		for _, action := range kubeClient.Actions() {
+			_, isPatch := action.(testing.PatchAction)
+			Expect(isPatch).To(BeFalse())
+		}
+	})
+
+	It("should fail if the domain capabilities are missing", func() {
+		Expect(os.Remove(filepath.Join(volumePath, node_labeller.DomainCapabilitiesFile))).To(Succeed())
+		Expect(labeller.UpdateNodeLabels()).ToNot(Succeed())
+	})
+
+	It("should fail if the definition of the host model is missing", func() {
+		Expect(os.RemoveAll(filepath.Join(volumePath, node_labeller.CPUMapDir))).To(Succeed())
+		Expect(labeller.UpdateNodeLabels()).ToNot(Succeed())
+	})
+
+	It("should pick up refreshed domain capabilities", func() {
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+		kubeClient.ClearActions()
+
+		refreshed := strings.Replace(domainCapabilities, "<model usable='no'>EPYC</model>", "<model usable='yes'>EPYC</model>", 1)
+		err := ioutil.WriteFile(filepath.Join(volumePath, node_labeller.DomainCapabilitiesFile), []byte(refreshed), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(labeller.UpdateNodeLabels()).To(Succeed())
+		Expect(getPatchedLabels()).To(HaveKeyWithValue(services.NFD_CPU_MODEL_PREFIX+"EPYC", "true"))
+	})
+})
@@ -0,0 +1,323 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package probes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// The same defaults as the kubelet uses for container probes
+const (
+	defaultTimeoutSeconds   = 1
+	defaultPeriodSeconds    = 10
+	defaultSuccessThreshold = 1
+	defaultFailureThreshold = 3
+)
+
+// Prober runs probes in the guest through the guest agent, it is implemented by the launcher client
+type Prober interface {
+	GuestPing(domainName string, timeoutSeconds int32) error
+	Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error)
+}
+
+// Status is the outcome of the guest agent probes of a VMI
+type Status struct {
+	// Ready is nil as long as the readiness probe did not reach its success or failure threshold
+	Ready *bool
+	// ReadinessMessage describes why the guest is not ready
+	ReadinessMessage string
+	// Alive turns false once the liveness probe reached its failure threshold
+	Alive bool
+	// LivenessMessage describes why the guest is not alive
+	LivenessMessage string
+}
+
+func (s Status) equal(other Status) bool {
+	if (s.Ready == nil) != (other.Ready == nil) || (s.Ready != nil && *s.Ready != *other.Ready) {
+		return false
+	}
+	return s.ReadinessMessage == other.ReadinessMessage && s.Alive == other.Alive && s.LivenessMessage == other.LivenessMessage
+}
+
+type result int
+
+const (
+	unknown result = iota
+	success
+	failure
+)
+
+type worker struct {
+	probe     *v1.Probe
+	started   time.Time
+	lastProbe time.Time
+	successes int32
+	failures  int32
+	result    result
+	message   string
+}
+
+type vmiWorkers struct {
+	// lock protects the results of the workers, a probe itself runs without holding it
+	lock      sync.Mutex
+	readiness *worker
+	liveness  *worker
+	stop      chan struct{}
+}
+
+// Manager runs the guest agent probes of all VMIs, since the pod can't reach into the guest. Every VMI
+// gets its own worker, a probe can block for up to its timeout and must not stall the VMI sync.
+type Manager struct {
+	lock     sync.Mutex
+	workers  map[types.UID]*vmiWorkers
+	now      func() time.Time
+	onChange func(vmi *v1.VirtualMachineInstance)
+}
+
+// NewManager returns a Manager which calls onChange whenever the probe results of a VMI changed
+func NewManager(onChange func(vmi *v1.VirtualMachineInstance)) *Manager {
+	return &Manager{
+		workers:  map[types.UID]*vmiWorkers{},
+		now:      time.Now,
+		onChange: onChange,
+	}
+}
+
+// Start starts the probe worker of the VMI, unless it is already running. The initial delay of the
+// probes starts with the first call for a VMI.
+func (m *Manager) Start(vmi *v1.VirtualMachineInstance, domainName string, prober Prober) {
+	workers, created := m.getWorkers(vmi)
+	if created {
+		go m.run(vmi.DeepCopy(), workers, domainName, prober)
+	}
+}
+
+// Status returns the latest results of the guest agent probes of the VMI without running any probe
+func (m *Manager) Status(uid types.UID) Status {
+	m.lock.Lock()
+	workers, exists := m.workers[uid]
+	m.lock.Unlock()
+
+	if !exists {
+		return Status{Alive: true}
+	}
+	return workers.status()
+}
+
+// Remove stops the probe worker of a VMI and forgets its results
+func (m *Manager) Remove(uid types.UID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if workers, exists := m.workers[uid]; exists {
+		close(workers.stop)
+		delete(m.workers, uid)
+	}
+}
+
+func (m *Manager) getWorkers(vmi *v1.VirtualMachineInstance) (*vmiWorkers, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if workers, exists := m.workers[vmi.UID]; exists {
+		return workers, false
+	}
+
+	now := m.now()
+	workers := &vmiWorkers{stop: make(chan struct{})}
+	if vmi.Spec.ReadinessProbe.IsGuestAgentProbe() {
+		workers.readiness = &worker{probe: vmi.Spec.ReadinessProbe, started: now}
+	}
+	if vmi.Spec.LivenessProbe.IsGuestAgentProbe() {
+		workers.liveness = &worker{probe: vmi.Spec.LivenessProbe, started: now}
+	}
+	m.workers[vmi.UID] = workers
+	return workers, true
+}
+
+func (m *Manager) run(vmi *v1.VirtualMachineInstance, workers *vmiWorkers, domainName string, prober Prober) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-workers.stop:
+			return
+		case <-timer.C:
+		}
+
+		if m.probe(workers, domainName, prober) && m.onChange != nil {
+			select {
+			case <-workers.stop:
+				return
+			default:
+				m.onChange(vmi)
+			}
+		}
+
+		next, hasProbes := workers.nextProbe(m.now())
+		if !hasProbes {
+			return
+		}
+		timer.Reset(next)
+	}
+}
+
+// probe runs the probes of the VMI which are due and reports whether the results changed
+func (m *Manager) probe(workers *vmiWorkers, domainName string, prober Prober) bool {
+	before := workers.status()
+	for _, w := range []*worker{workers.readiness, workers.liveness} {
+		if w == nil {
+			continue
+		}
+		now := m.now()
+		workers.lock.Lock()
+		due := w.nextProbe(now) == 0
+		workers.lock.Unlock()
+		if !due {
+			continue
+		}
+
+		err := runProbe(w.probe, domainName, prober)
+
+		workers.lock.Lock()
+		w.record(now, err)
+		workers.lock.Unlock()
+	}
+	return !workers.status().equal(before)
+}
+
+func (v *vmiWorkers) status() Status {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	status := Status{Alive: true}
+	if w := v.readiness; w != nil {
+		switch w.result {
+		case success:
+			ready := true
+			status.Ready = &ready
+		case failure:
+			ready := false
+			status.Ready = &ready
+			status.ReadinessMessage = w.message
+		}
+	}
+	if w := v.liveness; w != nil && w.result == failure {
+		status.Alive = false
+		status.LivenessMessage = w.message
+	}
+	return status
+}
+
+// nextProbe returns the time until the next probe of the VMI is due
+func (v *vmiWorkers) nextProbe(now time.Time) (time.Duration, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var next time.Duration
+	hasProbes := false
+	for _, w := range []*worker{v.readiness, v.liveness} {
+		if w == nil {
+			continue
+		}
+		if d := w.nextProbe(now); !hasProbes || d < next {
+			next = d
+		}
+		hasProbes = true
+	}
+	return next, hasProbes
+}
+
+func periodOrDefault(periodSeconds int32) time.Duration {
+	if periodSeconds < 1 {
+		periodSeconds = defaultPeriodSeconds
+	}
+	return time.Duration(periodSeconds) * time.Second
+}
+
+func thresholdOrDefault(threshold int32, defaultThreshold int32) int32 {
+	if threshold < 1 {
+		return defaultThreshold
+	}
+	return threshold
+}
+
+func (w *worker) nextProbe(now time.Time) time.Duration {
+	next := w.started.Add(time.Duration(w.probe.InitialDelaySeconds) * time.Second)
+	if !w.lastProbe.IsZero() {
+		next = w.lastProbe.Add(periodOrDefault(w.probe.PeriodSeconds))
+	}
+	if next.Before(now) {
+		return 0
+	}
+	return next.Sub(now)
+}
+
+func (w *worker) record(now time.Time, err error) {
+	w.lastProbe = now
+	if err != nil {
+		w.successes = 0
+		w.failures++
+		w.message = err.Error()
+		if w.failures >= thresholdOrDefault(w.probe.FailureThreshold, defaultFailureThreshold) {
+			w.result = failure
+		}
+		return
+	}
+	w.failures = 0
+	w.successes++
+	if w.successes >= thresholdOrDefault(w.probe.SuccessThreshold, defaultSuccessThreshold) {
+		w.result = success
+		w.message = ""
+	}
+}
+
+func runProbe(probe *v1.Probe, domainName string, prober Prober) error {
+	timeoutSeconds := probe.TimeoutSeconds
+	if timeoutSeconds < 1 {
+		timeoutSeconds = defaultTimeoutSeconds
+	}
+
+	switch {
+	case probe.GuestAgentPing != nil:
+		if err := prober.GuestPing(domainName, timeoutSeconds); err != nil {
+			return fmt.Errorf("guest agent ping failed: %v", err)
+		}
+	case probe.Exec != nil:
+		command := probe.Exec.Command
+		if len(command) == 0 {
+			return fmt.Errorf("exec probe has no command")
+		}
+		exitCode, stdOut, err := prober.Exec(domainName, command[0], command[1:], timeoutSeconds)
+		if err != nil {
+			return fmt.Errorf("failed to run %q in the guest: %v", strings.Join(command, " "), err)
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("%q exited with code %d: %s", strings.Join(command, " "), exitCode, strings.TrimSpace(stdOut))
+		}
+	}
+	return nil
+}
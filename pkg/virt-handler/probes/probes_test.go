@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package probes
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+type fakeProber struct {
+	lock     sync.Mutex
+	pingErr  error
+	exitCode int
+	stdOut   string
+	execErr  error
+	calls    int
+	command  string
+	args     []string
+	timeout  int32
+}
+
+func (p *fakeProber) GuestPing(domainName string, timeoutSeconds int32) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.calls++
+	p.timeout = timeoutSeconds
+	return p.pingErr
+}
+
+func (p *fakeProber) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.calls++
+	p.command = command
+	p.args = args
+	p.timeout = timeoutSeconds
+	return p.exitCode, p.stdOut, p.execErr
+}
+
+func (p *fakeProber) callCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.calls
+}
+
+var _ = Describe("Guest agent probes", func() {
+	var manager *Manager
+	var prober *fakeProber
+	var now time.Time
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		now = time.Now()
+		manager = NewManager(nil)
+		manager.now = func() time.Time { return now }
+		prober = &fakeProber{}
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+	})
+
+	// probe does what the worker of the VMI does on every tick, without starting it
+	probe := func() Status {
+		workers, _ := manager.getWorkers(vmi)
+		manager.probe(workers, "default_testvmi", prober)
+		return manager.Status(vmi.UID)
+	}
+
+	nextProbe := func() time.Duration {
+		workers, _ := manager.getWorkers(vmi)
+		next, _ := workers.nextProbe(now)
+		return next
+	}
+
+	It("should not probe VMIs without guest agent probes", func() {
+		vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{TCPSocket: &k8sv1.TCPSocketAction{}}}
+		status := probe()
+		Expect(status.Ready).To(BeNil())
+		Expect(status.Alive).To(BeTrue())
+		Expect(prober.calls).To(BeZero())
+	})
+
+	It("should wait for the initial delay and then probe once per period", func() {
+		vmi.Spec.ReadinessProbe = &v1.Probe{
+			Handler:             v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       5,
+		}
+
+		status := probe()
+		Expect(prober.calls).To(BeZero())
+		Expect(status.Ready).To(BeNil())
+		Expect(nextProbe()).To(Equal(30 * time.Second))
+
+		now = now.Add(30 * time.Second)
+		status = probe()
+		Expect(prober.calls).To(Equal(1))
+		Expect(prober.timeout).To(Equal(int32(defaultTimeoutSeconds)))
+		Expect(*status.Ready).To(BeTrue())
+		Expect(nextProbe()).To(Equal(5 * time.Second))
+
+		now = now.Add(time.Second)
+		probe()
+		Expect(prober.calls).To(Equal(1))
+	})
+
+	It("should only report a failed readiness probe after the failure threshold", func() {
+		vmi.Spec.ReadinessProbe = &v1.Probe{
+			Handler:          v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			FailureThreshold: 2,
+		}
+		Expect(*probe().Ready).To(BeTrue())
+
+		prober.pingErr = fmt.Errorf("agent not available")
+		now = now.Add(10 * time.Second)
+		Expect(*probe().Ready).To(BeTrue())
+
+		now = now.Add(10 * time.Second)
+		status := probe()
+		Expect(*status.Ready).To(BeFalse())
+		Expect(status.ReadinessMessage).To(ContainSubstring("agent not available"))
+	})
+
+	It("should run the exec probe command in the guest and fail on a non-zero exit code", func() {
+		vmi.Spec.LivenessProbe = &v1.Probe{
+			Handler:          v1.Handler{Exec: &k8sv1.ExecAction{Command: []string{"cat", "/tmp/healthy"}}},
+			TimeoutSeconds:   3,
+			FailureThreshold: 1,
+		}
+		prober.exitCode = 1
+		prober.stdOut = "No such file or directory\n"
+
+		status := probe()
+		Expect(prober.command).To(Equal("cat"))
+		Expect(prober.args).To(Equal([]string{"/tmp/healthy"}))
+		Expect(prober.timeout).To(Equal(int32(3)))
+		Expect(status.Alive).To(BeFalse())
+		Expect(status.LivenessMessage).To(Equal(`"cat /tmp/healthy" exited with code 1: No such file or directory`))
+	})
+
+	It("should probe again from scratch after the VMI was removed", func() {
+		vmi.Spec.LivenessProbe = &v1.Probe{
+			Handler:          v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			FailureThreshold: 1,
+		}
+		prober.pingErr = fmt.Errorf("agent not available")
+		Expect(probe().Alive).To(BeFalse())
+
+		manager.Remove(vmi.UID)
+		prober.pingErr = nil
+		Expect(probe().Alive).To(BeTrue())
+	})
+
+	Context("with a worker", func() {
+		var changed chan string
+
+		BeforeEach(func() {
+			changed = make(chan string, 10)
+			manager = NewManager(func(vmi *v1.VirtualMachineInstance) {
+				changed <- vmi.Name
+			})
+			vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+		})
+
+		AfterEach(func() {
+			manager.Remove(vmi.UID)
+		})
+
+		It("should probe in the background and report changed results", func() {
+			manager.Start(vmi, "default_testvmi", prober)
+			Eventually(changed).Should(Receive(Equal("testvmi")))
+			Expect(*manager.Status(vmi.UID).Ready).To(BeTrue())
+			Expect(prober.callCount()).To(Equal(1))
+		})
+
+		It("should not start a second worker for the same VMI", func() {
+			manager.Start(vmi, "default_testvmi", prober)
+			manager.Start(vmi, "default_testvmi", prober)
+			Eventually(changed).Should(Receive())
+			Consistently(prober.callCount, 200*time.Millisecond).Should(Equal(1))
+			Expect(changed).ToNot(Receive())
+		})
+
+		It("should not run any probe to report the status", func() {
+			status := manager.Status(vmi.UID)
+			Expect(status.Ready).To(BeNil())
+			Expect(status.Alive).To(BeTrue())
+			Expect(prober.callCount()).To(BeZero())
+		})
+
+		It("should stop the worker once the VMI was removed", func() {
+			vmi.Spec.ReadinessProbe.InitialDelaySeconds = 1
+			manager.Start(vmi, "default_testvmi", prober)
+			manager.Remove(vmi.UID)
+			Consistently(prober.callCount, 1500*time.Millisecond).Should(BeZero())
+			Expect(changed).ToNot(Receive())
+		})
+	})
+})
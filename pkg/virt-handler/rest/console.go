@@ -60,7 +60,7 @@ func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiI
 }
 
 func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.Response) {
-	vmi, code, err := t.getVMI(request)
+	vmi, code, err := getVMI(request, t.vmiInformer)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
 		response.WriteError(code, err)
@@ -81,7 +81,7 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 }
 
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
-	vmi, code, err := t.getVMI(request)
+	vmi, code, err := getVMI(request, t.vmiInformer)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
 		response.WriteError(code, err)
@@ -101,9 +101,9 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
 }
 
-func (t *ConsoleHandler) getVMI(request *restful.Request) (*v1.VirtualMachineInstance, int, error) {
+func getVMI(request *restful.Request, vmiInformer cache.SharedIndexInformer) (*v1.VirtualMachineInstance, int, error) {
 	key := fmt.Sprintf("%s/%s", request.PathParameter("namespace"), request.PathParameter("name"))
-	vmiObj, vmiExists, err := t.vmiInformer.GetStore().GetByKey(key)
+	vmiObj, vmiExists, err := vmiInformer.GetStore().GetByKey(key)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	goerror "errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	container_disk "kubevirt.io/kubevirt/pkg/virt-handler/container-disk"
+	hotplug_disk "kubevirt.io/kubevirt/pkg/virt-handler/hotplug-disk"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -50,12 +52,16 @@ import (
 	"kubevirt.io/kubevirt/pkg/controller"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	pvcutils "kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	device_manager "kubevirt.io/kubevirt/pkg/virt-handler/device-manager"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	node_labeller "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller"
+	"kubevirt.io/kubevirt/pkg/virt-handler/probes"
 	virtlauncher "kubevirt.io/kubevirt/pkg/virt-launcher"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/watchdog"
@@ -71,6 +77,7 @@ func NewController(
 	vmiTargetInformer cache.SharedIndexInformer,
 	domainInformer cache.SharedInformer,
 	gracefulShutdownInformer cache.SharedIndexInformer,
+	migrationPolicyInformer cache.SharedIndexInformer,
 	watchdogTimeoutSeconds int,
 	maxDevices int,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -91,13 +98,20 @@ func NewController(
 		vmiTargetInformer:        vmiTargetInformer,
 		domainInformer:           domainInformer,
 		gracefulShutdownInformer: gracefulShutdownInformer,
+		migrationPolicyInformer:  migrationPolicyInformer,
 		heartBeatInterval:        1 * time.Minute,
+		volumeExpansionInterval:  1 * time.Minute,
 		watchdogTimeoutSeconds:   watchdogTimeoutSeconds,
 		migrationProxy:           migrationproxy.NewMigrationProxyManager(virtShareDir, tlsConfig),
 		podIsolationDetector:     podIsolationDetector,
 		containerDiskMounter:     &container_disk.Mounter{PodIsolationDetector: podIsolationDetector},
+		hotplugDiskMounter:       &hotplug_disk.Mounter{PodIsolationDetector: podIsolationDetector},
 		clusterConfig:            clusterConfig,
+		nodeLabeller:             node_labeller.NewNodeLabeller(clientset, host, clusterConfig, node_labeller.NodeLabellerVolumePath),
 	}
+	c.probeManager = probes.NewManager(func(vmi *v1.VirtualMachineInstance) {
+		c.Queue.Add(controller.VirtualMachineKey(vmi))
+	})
 
 	vmiSourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addFunc,
@@ -141,15 +155,40 @@ type VirtualMachineController struct {
 	vmiTargetInformer        cache.SharedIndexInformer
 	domainInformer           cache.SharedInformer
 	gracefulShutdownInformer cache.SharedIndexInformer
+	migrationPolicyInformer  cache.SharedIndexInformer
 	launcherClients          map[string]cmdclient.LauncherClient
 	launcherClientLock       sync.Mutex
 	heartBeatInterval        time.Duration
+	volumeExpansionInterval  time.Duration
 	watchdogTimeoutSeconds   int
 	kvmController            *device_manager.DeviceController
 	migrationProxy           migrationproxy.ProxyManager
 	podIsolationDetector     isolation.PodIsolationDetector
 	containerDiskMounter     *container_disk.Mounter
+	hotplugDiskMounter       *hotplug_disk.Mounter
 	clusterConfig            *virtconfig.ClusterConfig
+	nodeLabeller             *node_labeller.NodeLabeller
+	probeManager             *probes.Manager
+}
+
+// getMigrationConfig applies the MigrationPolicy which virt-controller chose for the
+// migration to the cluster wide migration configuration
+func (d *VirtualMachineController) getMigrationConfig(vmi *v1.VirtualMachineInstance) (*virtconfig.MigrationConfig, error) {
+	config := d.clusterConfig.GetMigrationConfig()
+	policyName := vmi.Status.MigrationState.MigrationPolicyName
+	if policyName == "" {
+		return config, nil
+	}
+
+	obj, exists, err := d.migrationPolicyInformer.GetStore().GetByKey(policyName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		log.Log.Object(vmi).Warningf("Migration policy %s does not exist, falling back to the cluster wide migration configuration", policyName)
+		return config, nil
+	}
+	return migrations.ApplyMigrationPolicy(config, obj.(*v1.MigrationPolicy)), nil
 }
 
 // Determines if a domain's grace period has expired during shutdown.
@@ -352,6 +391,13 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			vmi.Status.MigrationState.AbortStatus = v1.MigrationAbortStatus(migrationMetadata.AbortStatus)
 			vmi.Status.MigrationState.Completed = migrationMetadata.Completed
 			vmi.Status.MigrationState.Failed = migrationMetadata.Failed
+
+			// Ignore progress which was reported for an earlier migration of the domain
+			progress := domain.Status.MigrationProgress
+			if progress != nil && progress.Timestamp != nil &&
+				(migrationMetadata.StartTimestamp == nil || !progress.Timestamp.Before(migrationMetadata.StartTimestamp)) {
+				vmi.Status.MigrationState.Progress = progress.DeepCopy()
+			}
 		}
 	}
 
@@ -426,6 +472,34 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		return err
 	}
 
+	err = d.updateHotplugVolumeStatus(vmi, domain)
+	if err != nil {
+		return err
+	}
+
+	d.expandVolumes(vmi, domain)
+
+	updateHostModelCPUStatus(vmi, domain)
+
+	updateMediaStatus(vmi, domain)
+
+	// Hotplugged volumes can't be migrated, the condition is recalculated once they are gone
+	migratableCondition := condManager.GetCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+	isHotplugCondition := migratableCondition != nil && migratableCondition.Reason == v1.VirtualMachineInstanceReasonHotplugNotMigratable
+	if len(hotplugdisk.GetHotplugVolumes(vmi)) > 0 {
+		if !isHotplugCondition || migratableCondition.Status != k8sv1.ConditionFalse {
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:    v1.VirtualMachineInstanceIsMigratable,
+				Status:  k8sv1.ConditionFalse,
+				Message: "VMI has hotplugged volumes",
+				Reason:  v1.VirtualMachineInstanceReasonHotplugNotMigratable,
+			})
+		}
+	} else if isHotplugCondition {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+	}
+
 	// Cacluate whether the VM is migratable
 	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceIsMigratable) {
 		isBlockMigration, err := d.checkVolumesForMigration(vmi)
@@ -440,7 +514,7 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			vmi.Status.Conditions = append(vmi.Status.Conditions, liveMigrationCondition)
 		}
 		err = d.checkNetworkInterfacesForMigration(vmi)
-		if err != nil {
+		if err != nil && liveMigrationCondition.Status == k8sv1.ConditionTrue {
 			liveMigrationCondition = v1.VirtualMachineInstanceCondition{
 				Type:    v1.VirtualMachineInstanceIsMigratable,
 				Status:  k8sv1.ConditionFalse,
@@ -489,6 +563,45 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAgentConnected)
 	}
 
+	// Update the condition when the VMI was paused by the user
+	isPaused := domain != nil && domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedUser
+	switch {
+	case isPaused && !condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused):
+		pausedCondition := v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstancePaused,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      v12.Now(),
+			LastTransitionTime: v12.Now(),
+			Reason:             v1.VirtualMachineInstanceReasonPausedByUser,
+			Message:            "VMI was paused by user",
+		}
+		vmi.Status.Conditions = append(vmi.Status.Conditions, pausedCondition)
+	case !isPaused:
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePaused)
+	}
+
+	// Update the condition when the guest was paused because of an I/O error, otherwise a storage
+	// outage just looks like a hung VMI
+	hasIOError := domain != nil && domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedIOError
+	switch {
+	case hasIOError && !condManager.HasCondition(vmi, v1.VirtualMachineInstanceIOError):
+		message := ioErrorMessage(domain)
+		ioErrorCondition := v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstanceIOError,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      v12.Now(),
+			LastTransitionTime: v12.Now(),
+			Reason:             v1.VirtualMachineInstanceReasonDiskIOError,
+			Message:            message,
+		}
+		vmi.Status.Conditions = append(vmi.Status.Conditions, ioErrorCondition)
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.IOError.String(), message)
+	case !hasIOError:
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIOError)
+	}
+
+	d.updateGuestAgentProbes(vmi, domain)
+
 	condManager.CheckFailure(vmi, syncError, "Synchronizing with the Domain failed.")
 
 	if !reflect.DeepEqual(oldStatus, vmi.Status) {
@@ -512,6 +625,320 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 	return nil
 }
 
+// updateHostModelCPUStatus records the CPU which libvirt expanded the host-model CPU to.
+// The domain keeps this CPU for its lifetime, migrations included, so it is only recorded once.
+func updateHostModelCPUStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || vmi.Status.HostModelCPU != nil || !vmi.IsCPUHostModel() {
+		return
+	}
+	// The CPU of a running domain is reported with the custom mode once it got expanded
+	cpu := domain.Spec.CPU
+	if cpu.Mode != "custom" || cpu.Model == "" {
+		return
+	}
+	hostModelCPU := &v1.HostModelCPU{Model: cpu.Model}
+	for _, feature := range cpu.Features {
+		if feature.Policy == "require" {
+			hostModelCPU.Features = append(hostModelCPU.Features, feature.Name)
+		}
+	}
+	vmi.Status.HostModelCPU = hostModelCPU
+}
+
+// updateHotplugVolumeStatus reports which hotplugged volumes are mounted into the pod and attached to
+// the domain. Once an unplugged volume is unmounted its status is removed, which lets virt-controller
+// delete the attachment pod.
+func (d *VirtualMachineController) updateHotplugVolumeStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	if len(hotplugdisk.GetHotplugVolumes(vmi)) == 0 {
+		return nil
+	}
+
+	specVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		specVolumes[volume.Name] = true
+	}
+	domainDisks := getDomainDisks(domain)
+	insertedMedia := getInsertedMedia(domain)
+
+	var volumeStatus []v1.VolumeStatus
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.HotplugVolume != nil {
+			mounted, err := d.hotplugDiskMounter.IsMounted(vmi, status.Name)
+			if err != nil {
+				return err
+			}
+			disk, isAttached := domainDisks[status.Name]
+			if !isAttached {
+				disk, isAttached = insertedMedia[status.Name]
+			}
+			switch {
+			case isAttached:
+				if status.Phase != v1.VolumeReady {
+					status.Phase = v1.VolumeReady
+					status.Message = fmt.Sprintf("Successfully attached hotplugged volume %s to the VirtualMachineInstance", status.Name)
+				}
+				status.Target = disk.Target.Device
+			case !specVolumes[status.Name] && !mounted:
+				// the volume is unplugged and no longer in use
+				continue
+			case status.Phase == v1.HotplugVolumeAttachedToNode && mounted:
+				status.Phase = v1.HotplugVolumeMounted
+				status.Message = fmt.Sprintf("Volume %s has been mounted in virt-launcher pod", status.Name)
+			}
+		}
+		volumeStatus = append(volumeStatus, status)
+	}
+	vmi.Status.VolumeStatus = volumeStatus
+	return nil
+}
+
+// expandVolumes lets the guest see the new size of PersistentVolumeClaims which got expanded while the
+// VirtualMachineInstance is running. Disk images on filesystem claims are grown first, but only once the
+// capacity of the claim changed, block devices already have the new size. Expanding a claim does not
+// touch the VirtualMachineInstance, so the volumes are checked again periodically while an expansion
+// is still in progress or failed.
+func (d *VirtualMachineController) expandVolumes(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if !d.clusterConfig.ExpandDisksEnabled() {
+		return
+	}
+	if !vmi.IsRunning() || domain == nil || domain.Status.Status != api.Running || d.isMigrationSource(vmi) {
+		return
+	}
+
+	domainDisks := getDomainDisks(domain)
+	var volumes []v1.Volume
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+			continue
+		}
+		if _, exists := domainDisks[volume.Name]; exists && !hotplugdisk.IsHotplugVolume(vmi, volume.Name) {
+			volumes = append(volumes, volume)
+		}
+	}
+	if len(volumes) == 0 {
+		return
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod to check the size of its volumes")
+		d.Queue.AddAfter(controller.VirtualMachineKey(vmi), d.volumeExpansionInterval)
+		return
+	}
+
+	pending := false
+	for _, volume := range volumes {
+		status := getOrAddVolumeStatus(vmi, volume.Name)
+		var oldSize, size, capacity int64
+		pvc, err := d.getVolumeClaim(vmi, &volume)
+		if err == nil {
+			pending = pending || isExpansionPending(pvc)
+			oldSize, size, capacity, err = d.growVolume(pvc, domainDisks[volume.Name], res.MountRoot(), status.Capacity)
+		}
+		if status.Size == 0 {
+			status.Size = oldSize
+		}
+		if err == nil && size > status.Size {
+			var client cmdclient.LauncherClient
+			client, err = d.getLauncherClient(vmi)
+			if err == nil {
+				err = client.ResizeDisk(api.VMINamespaceKeyFunc(vmi), volume.Name, size)
+			}
+		}
+		if err != nil {
+			message := fmt.Sprintf("Failed to expand volume %s: %v", volume.Name, err)
+			if status.Message != message {
+				log.Log.Object(vmi).Reason(err).Errorf("Failed to expand volume %s", volume.Name)
+				d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.VolumeExpansionFailed.String(), message)
+			}
+			status.Reason = v1.VolumeExpansionFailed.String()
+			status.Message = message
+			pending = true
+			continue
+		}
+		status.Capacity = capacity
+		if size > status.Size {
+			status.Size = size
+			status.Target = domainDisks[volume.Name].Target.Device
+			status.Reason = v1.VolumeExpanded.String()
+			status.Message = fmt.Sprintf("Expanded volume %s to %d bytes", volume.Name, size)
+			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.VolumeExpanded.String(), status.Message)
+		} else if status.Reason == v1.VolumeExpansionFailed.String() {
+			status.Reason = ""
+			status.Message = ""
+		}
+	}
+
+	if pending {
+		d.Queue.AddAfter(controller.VirtualMachineKey(vmi), d.volumeExpansionInterval)
+	}
+}
+
+// getVolumeClaim fetches the PersistentVolumeClaim backing the volume
+func (d *VirtualMachineController) getVolumeClaim(vmi *v1.VirtualMachineInstance, volume *v1.Volume) (*k8sv1.PersistentVolumeClaim, error) {
+	var claimName string
+	if volume.PersistentVolumeClaim != nil {
+		claimName = volume.PersistentVolumeClaim.ClaimName
+	} else {
+		claimName = volume.DataVolume.Name
+	}
+	return d.clientset.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(claimName, v12.GetOptions{})
+}
+
+// isExpansionPending returns true if the PersistentVolumeClaim requests more storage than it has got so far
+func isExpansionPending(pvc *k8sv1.PersistentVolumeClaim) bool {
+	request, requested := pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]
+	capacity, exists := pvc.Status.Capacity[k8sv1.ResourceStorage]
+	return requested && exists && request.Cmp(capacity) > 0
+}
+
+// growVolume returns the size of the disk backing the volume before and after growing it to the
+// capacity of its PersistentVolumeClaim, together with that capacity. A disk image is only grown
+// if the capacity changed since it was last seen, an image may be smaller than its claim from the start.
+func (d *VirtualMachineController) growVolume(pvc *k8sv1.PersistentVolumeClaim, disk api.Disk, mountRoot string, lastCapacity int64) (int64, int64, int64, error) {
+	capacity := lastCapacity
+	if storage, exists := pvc.Status.Capacity[k8sv1.ResourceStorage]; exists {
+		capacity = storage.Value()
+	}
+
+	if disk.Source.Dev != "" {
+		size, err := blockDeviceSize(filepath.Join(mountRoot, disk.Source.Dev))
+		return size, size, capacity, err
+	}
+
+	imagePath := filepath.Join(mountRoot, disk.Source.File)
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return 0, 0, lastCapacity, err
+	}
+	if lastCapacity == 0 || capacity <= lastCapacity {
+		return info.Size(), info.Size(), capacity, nil
+	}
+	size, err := hostdisk.NewHostDiskCreator(nil, d.clusterConfig.GetLessPVCSpaceToleration()).ExpandImage(imagePath, capacity)
+	return info.Size(), size, capacity, err
+}
+
+func blockDeviceSize(path string) (int64, error) {
+	device, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer device.Close()
+	return device.Seek(0, io.SeekEnd)
+}
+
+// getOrAddVolumeStatus returns the status of the volume, which is added if it does not exist yet
+func getOrAddVolumeStatus(vmi *v1.VirtualMachineInstance, volumeName string) *v1.VolumeStatus {
+	for i := range vmi.Status.VolumeStatus {
+		if vmi.Status.VolumeStatus[i].Name == volumeName {
+			return &vmi.Status.VolumeStatus[i]
+		}
+	}
+	vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{Name: volumeName})
+	return &vmi.Status.VolumeStatus[len(vmi.Status.VolumeStatus)-1]
+}
+
+// getDomainDisks returns the disks of the domain by their alias
+func getDomainDisks(domain *api.Domain) map[string]api.Disk {
+	disks := map[string]api.Disk{}
+	if domain == nil {
+		return disks
+	}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Alias != nil {
+			disks[disk.Alias.Name] = disk
+		}
+	}
+	return disks
+}
+
+// getInsertedMedia returns the CD-ROMs and floppies of the domain by the hotplugged volume inserted into them
+func getInsertedMedia(domain *api.Domain) map[string]api.Disk {
+	media := map[string]api.Disk{}
+	if domain == nil {
+		return media
+	}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Alias == nil || (disk.Device != "cdrom" && disk.Device != "floppy") {
+			continue
+		}
+		if volumeName, isHotplugVolume := getHotplugVolumeName(disk); isHotplugVolume && volumeName != disk.Alias.Name {
+			media[volumeName] = disk
+		}
+	}
+	return media
+}
+
+// getMediumVolume returns the name of the volume inserted into a CD-ROM or floppy of the domain,
+// which is either a hotplugged volume or the volume the drive was defined with
+func getMediumVolume(disk api.Disk) string {
+	if disk.Source.File == "" && disk.Source.Dev == "" && disk.Source.Name == "" {
+		return ""
+	}
+	if volumeName, isHotplugVolume := getHotplugVolumeName(disk); isHotplugVolume {
+		return volumeName
+	}
+	return disk.Alias.Name
+}
+
+// getHotplugVolumeName returns the hotplugged volume a disk of the domain points to, either
+// through its image file or through its block device
+func getHotplugVolumeName(disk api.Disk) (string, bool) {
+	if volumeName, isHotplugVolume := hotplugdisk.GetVolumeNameFromLauncherView(disk.Source.File); isHotplugVolume {
+		return volumeName, true
+	}
+	return hotplugdisk.GetVolumeNameFromLauncherView(disk.Source.Dev)
+}
+
+// updateMediaStatus reports the medium inserted into each CD-ROM and floppy of the domain and the
+// state of its tray
+func updateMediaStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil {
+		return
+	}
+	domainDisks := getDomainDisks(domain)
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.CDRom == nil && disk.Floppy == nil {
+			continue
+		}
+		domainDisk, exists := domainDisks[disk.Name]
+		if !exists {
+			continue
+		}
+		status := getOrAddMediaStatus(vmi, disk.Name)
+		status.Source = getMediumVolume(domainDisk)
+		status.Tray = v1.TrayStateClosed
+		if domainDisk.Target.Tray == string(v1.TrayStateOpen) {
+			status.Tray = v1.TrayStateOpen
+		}
+	}
+}
+
+func getOrAddMediaStatus(vmi *v1.VirtualMachineInstance, diskName string) *v1.MediaStatus {
+	for i := range vmi.Status.Media {
+		if vmi.Status.Media[i].Name == diskName {
+			return &vmi.Status.Media[i]
+		}
+	}
+	vmi.Status.Media = append(vmi.Status.Media, v1.MediaStatus{Name: diskName})
+	return &vmi.Status.Media[len(vmi.Status.Media)-1]
+}
+
+// needsMediaChange checks if another medium than the inserted one was requested for any CD-ROM or floppy
+func needsMediaChange(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
+	domainDisks := getDomainDisks(domain)
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		if disk.CDRom == nil && disk.Floppy == nil {
+			continue
+		}
+		if domainDisk, exists := domainDisks[disk.Name]; exists && getMediumVolume(domainDisk) != hotplugdisk.GetRequestedMedium(disk) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 	defer c.Queue.ShutDown()
 	log.Log.Info("Starting virt-handler controller.")
@@ -537,7 +964,7 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 	go c.vmiSourceInformer.Run(stopCh)
 	go c.vmiTargetInformer.Run(stopCh)
 	go c.gracefulShutdownInformer.Run(stopCh)
-	cache.WaitForCacheSync(stopCh, c.domainInformer.HasSynced, c.vmiSourceInformer.HasSynced, c.vmiTargetInformer.HasSynced, c.gracefulShutdownInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.domainInformer.HasSynced, c.vmiSourceInformer.HasSynced, c.vmiTargetInformer.HasSynced, c.gracefulShutdownInformer.HasSynced, c.migrationPolicyInformer.HasSynced)
 
 	go c.heartBeat(c.heartBeatInterval, stopCh)
 
@@ -687,7 +1114,7 @@ func (d *VirtualMachineController) migrationTargetExecute(key string,
 		d.removeStaleClientConnections(vmi)
 
 		// prepare the POD for the migration
-		err := d.processVmUpdate(vmi)
+		err := d.processVmUpdate(vmi, domain)
 		if err != nil {
 			return err
 		}
@@ -882,7 +1309,7 @@ func (d *VirtualMachineController) defaultExecute(key string,
 		syncErr = d.processVmCleanup(vmi)
 	case shouldUpdate:
 		log.Log.Object(vmi).V(3).Info("Processing vmi update")
-		syncErr = d.processVmUpdate(vmi)
+		syncErr = d.processVmUpdate(vmi, domain)
 	default:
 		log.Log.Object(vmi).V(3).Info("No update processing required")
 	}
@@ -984,12 +1411,95 @@ func (d *VirtualMachineController) execute(key string) error {
 
 }
 
+// ioErrorMessage names the disks which failed, libvirt reports them by their target device
+func ioErrorMessage(domain *api.Domain) string {
+	var failures []string
+	for _, diskError := range domain.Status.DiskErrors {
+		name := diskError.Device
+		for _, disk := range domain.Spec.Devices.Disks {
+			if disk.Target.Device == diskError.Device && disk.Alias != nil {
+				name = disk.Alias.Name
+			}
+		}
+		failures = append(failures, fmt.Sprintf("disk %s: %s", name, diskError.Error))
+	}
+	if len(failures) == 0 {
+		return "The guest was paused because of an I/O error"
+	}
+	return fmt.Sprintf("The guest was paused because of an I/O error on %s", strings.Join(failures, ", "))
+}
+
+// updateGuestAgentProbes starts the worker for the probes which need the guest agent, since they can't be
+// run from within the pod, and applies their latest results. The readiness probe drives the Ready condition,
+// a failing liveness probe fails the VMI so that it gets restarted according to its run strategy.
+func (d *VirtualMachineController) updateGuestAgentProbes(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	hasReadinessProbe := vmi.Spec.ReadinessProbe.IsGuestAgentProbe()
+	if !hasReadinessProbe && !vmi.Spec.LivenessProbe.IsGuestAgentProbe() {
+		return
+	}
+
+	// The guest agent of a paused guest does not answer, the probes start over once it runs again
+	if !vmi.IsRunning() || domain == nil || domain.Status.Status != api.Running {
+		d.probeManager.Remove(vmi.UID)
+		if hasReadinessProbe {
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonGuestNotReady, "The guest is not running")
+		}
+		return
+	}
+
+	client, err := d.getLauncherClient(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect to virt-launcher to run the guest agent probes")
+		return
+	}
+
+	d.probeManager.Start(vmi, api.VMINamespaceKeyFunc(vmi), client)
+	status := d.probeManager.Status(vmi.UID)
+	if hasReadinessProbe {
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		switch {
+		case status.Ready != nil && *status.Ready:
+			setReadyCondition(vmi, k8sv1.ConditionTrue, "", "")
+		case status.Ready != nil:
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonGuestNotReady, status.ReadinessMessage)
+		case !condManager.HasCondition(vmi, v1.VirtualMachineInstanceReady):
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonGuestNotReady, "Waiting for the readiness probe")
+		}
+	}
+
+	if !status.Alive {
+		log.Log.Object(vmi).Infof("Liveness probe failed: %s", status.LivenessMessage)
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.Unhealthy.String(), fmt.Sprintf("Liveness probe failed: %s", status.LivenessMessage))
+		vmi.Status.Phase = v1.Failed
+		d.probeManager.Remove(vmi.UID)
+	}
+}
+
+// setReadyCondition only replaces the Ready condition if it changed, to not update the VMI on every probe
+func setReadyCondition(vmi *v1.VirtualMachineInstance, status k8sv1.ConditionStatus, reason string, message string) {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceReady); cond != nil &&
+		cond.Status == status && cond.Reason == reason && cond.Message == message {
+		return
+	}
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceReady)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceReady,
+		Status:             status,
+		LastProbeTime:      v12.Now(),
+		LastTransitionTime: v12.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
 func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstance) error {
 	err := virtlauncher.VmGracefulShutdownTriggerClear(d.virtShareDir, vmi)
 	if err != nil {
 		return err
 	}
 
+	d.probeManager.Remove(vmi.UID)
 	d.closeLauncherClient(vmi)
 
 	d.migrationProxy.StopTargetListener(string(vmi.UID))
@@ -1001,6 +1511,11 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 		return err
 	}
 
+	err = d.hotplugDiskMounter.UnmountAll(vmi)
+	if err != nil {
+		return err
+	}
+
 	// Watch dog file must be the last thing removed here
 	err = watchdog.WatchdogFileRemove(d.virtShareDir, vmi)
 	if err != nil {
@@ -1221,8 +1736,17 @@ func (d *VirtualMachineController) checkNetworkInterfacesForMigration(vmi *v1.Vi
 		networks[network.Name] = network.DeepCopy()
 	}
 	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
-		if iface.Bridge != nil && networks[iface.Name].Pod != nil {
-			return fmt.Errorf("cannot migrate VMI with a bridge interface connected to a pod network")
+		network, exists := networks[iface.Name]
+		if !exists {
+			continue
+		}
+		// The guest keeps the pod IP with bridge binding, which can't move to the target pod.
+		// Masquerade hides the pod IP behind NAT and is the supported binding for migration.
+		if iface.Bridge != nil && network.Pod != nil {
+			return fmt.Errorf("cannot migrate VMI: interface %s uses bridge binding on the pod network, only masquerade binding is migratable", iface.Name)
+		}
+		if iface.SRIOV != nil {
+			return fmt.Errorf("cannot migrate VMI: interface %s uses SR-IOV binding, which passes a host device through to the guest", iface.Name)
 		}
 	}
 	return nil
@@ -1236,7 +1760,10 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 	// A relevant error will be returned in this case.
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
-		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
+		if vmi.IsFilesystemVolume(volume.Name) {
+			// the virtiofsd state can't be transferred to the target
+			return blockMigrate, fmt.Errorf("cannot migrate VMI with virtio-fs filesystems")
+		} else if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
 			var volName string
 			if volSrc.PersistentVolumeClaim != nil {
 				volName = volSrc.PersistentVolumeClaim.ClaimName
@@ -1329,7 +1856,7 @@ func (d *VirtualMachineController) handleMigrationProxy(vmi *v1.VirtualMachineIn
 	return nil
 }
 
-func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineInstance) error {
+func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineInstance, domain *api.Domain) error {
 	vmi := origVMI.DeepCopy()
 
 	isExpired, err := watchdog.WatchdogFileIsExpired(d.watchdogTimeoutSeconds, d.virtShareDir, vmi)
@@ -1388,12 +1915,18 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 				d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Migrating.String(), "VirtualMachineInstance is aborting migration.")
 			}
 		} else {
+			migrationConfig, err := d.getMigrationConfig(vmi)
+			if err != nil {
+				return err
+			}
 			options := &cmdclient.MigrationOptions{
-				Bandwidth:               *d.clusterConfig.GetMigrationConfig().BandwidthPerMigration,
-				ProgressTimeout:         *d.clusterConfig.GetMigrationConfig().ProgressTimeout,
-				CompletionTimeoutPerGiB: *d.clusterConfig.GetMigrationConfig().CompletionTimeoutPerGiB,
-				UnsafeMigration:         d.clusterConfig.GetMigrationConfig().UnsafeMigrationOverride,
-				AllowAutoConverge:       d.clusterConfig.GetMigrationConfig().AllowAutoConverge,
+				Bandwidth:               *migrationConfig.BandwidthPerMigration,
+				ProgressTimeout:         *migrationConfig.ProgressTimeout,
+				CompletionTimeoutPerGiB: *migrationConfig.CompletionTimeoutPerGiB,
+				UnsafeMigration:         migrationConfig.UnsafeMigrationOverride,
+				AllowAutoConverge:       migrationConfig.AllowAutoConverge,
+				AllowPostCopy:           migrationConfig.AllowPostCopy,
+				PostCopyAfterIterations: *migrationConfig.PostCopyAfterIterations,
 			}
 			err = client.MigrateVirtualMachine(vmi, options)
 			if err != nil {
@@ -1424,11 +1957,98 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			return err
 		}
 		d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Created.String(), "VirtualMachineInstance defined.")
+
+		if vmi.IsRunning() && len(hotplugdisk.GetHotplugVolumes(vmi)) > 0 {
+			err = d.handleHotplugVolumes(client, vmi, domain)
+			if err != nil {
+				return err
+			}
+		}
+
+		if vmi.IsRunning() && needsResourceHotplug(vmi, domain) {
+			if err := client.HotplugResources(vmi); err != nil {
+				return fmt.Errorf("hotplugging CPUs and memory failed: %v", err)
+			}
+		}
+
+		if vmi.IsRunning() && needsMediaChange(vmi, domain) {
+			if err := client.ChangeMedia(vmi); err != nil {
+				return fmt.Errorf("changing media failed: %v", err)
+			}
+		}
 	}
 
 	return err
 }
 
+// handleHotplugVolumes mounts the disks of hotplugged volumes into the pod and lets virt-launcher
+// attach them to the domain. Disks of unplugged volumes are detached and only unmounted once
+// they are gone from the domain.
+func (d *VirtualMachineController) handleHotplugVolumes(client cmdclient.LauncherClient, vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	if err := d.hotplugDiskMounter.Mount(vmi); err != nil {
+		return err
+	}
+
+	for volumeName, status := range hotplugdisk.GetHotplugVolumes(vmi) {
+		if status.Phase != v1.HotplugVolumeAttachedToNode {
+			continue
+		}
+		mounted, err := d.hotplugDiskMounter.IsMounted(vmi, volumeName)
+		if err != nil {
+			return err
+		}
+		if mounted {
+			status.Phase = v1.HotplugVolumeMounted
+		}
+	}
+
+	if err := client.HotplugDisk(vmi); err != nil {
+		return fmt.Errorf("hotplugging disks failed: %v", err)
+	}
+	if err := client.UnplugDisk(vmi); err != nil {
+		return fmt.Errorf("unplugging disks failed: %v", err)
+	}
+
+	specVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		specVolumes[volume.Name] = true
+	}
+	domainDisks := getDomainDisks(domain)
+	for volumeName := range hotplugdisk.GetHotplugVolumes(vmi) {
+		if _, isAttached := domainDisks[volumeName]; isAttached || specVolumes[volumeName] {
+			continue
+		}
+		if err := d.hotplugDiskMounter.UnmountVolume(vmi, volumeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsResourceHotplug checks if the VirtualMachineInstance requests more vCPUs or memory
+// than are currently plugged into the domain
+func needsResourceHotplug(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
+	if domain == nil {
+		return false
+	}
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.MaxSockets > 0 &&
+		api.GetRequestedVCPUs(vmi) > api.GetDomainVCPUs(&domain.Spec) {
+		return true
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+		requested, err := api.GetRequestedMemory(vmi)
+		if err != nil {
+			return false
+		}
+		current, err := api.MemoryToBytes(domain.Spec.Memory)
+		if err != nil {
+			return false
+		}
+		return requested > current
+	}
+	return false
+}
+
 func (d *VirtualMachineController) setVmPhaseForStatusReason(domain *api.Domain, vmi *v1.VirtualMachineInstance) error {
 	phase, err := d.calculateVmPhaseForStatusReason(domain, vmi)
 	if err != nil {
@@ -1482,7 +2102,13 @@ func (d *VirtualMachineController) calculateVmPhaseForStatusReason(domain *api.D
 				// if the domain migrated, we no longer know the phase.
 				return vmi.Status.Phase, nil
 			}
-		case api.Running, api.Paused, api.Blocked, api.PMSuspended:
+		case api.Paused:
+			// a failed post-copy migration leaves the guest memory split between source and target
+			if domain.Status.Reason == api.ReasonPausedPostcopyFailed {
+				return v1.Failed, nil
+			}
+			return v1.Running, nil
+		case api.Running, api.Blocked, api.PMSuspended:
 			return v1.Running, nil
 		}
 	}
@@ -1569,6 +2195,11 @@ func (d *VirtualMachineController) heartBeat(interval time.Duration, stopCh chan
 			if d.clusterConfig.CPUManagerEnabled() {
 				d.updateNodeCpuManagerLabel()
 			}
+			if d.clusterConfig.CPUNodeDiscoveryEnabled() {
+				if err := d.nodeLabeller.UpdateNodeLabels(); err != nil {
+					log.DefaultLogger().Reason(err).Errorf("failed to update the cpu model and feature labels on host %s", d.host)
+				}
+			}
 		}, interval, 1.2, true, stopCh)
 	}
 }
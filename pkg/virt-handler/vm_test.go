@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -43,6 +44,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/certificates"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -70,6 +72,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 	var domainSource *framework.FakeControllerSource
 	var domainInformer cache.SharedIndexInformer
 	var gracefulShutdownInformer cache.SharedIndexInformer
+	var migrationPolicyInformer cache.SharedIndexInformer
 	var mockQueue *testutils.MockWorkQueue
 	var mockWatchdog *MockWatchdog
 	var mockGracefulShutdown *MockGracefulShutdown
@@ -116,6 +119,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 		vmiTargetInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		domainInformer, domainSource = testutils.NewFakeInformerFor(&api.Domain{})
 		gracefulShutdownInformer, _ = testutils.NewFakeInformerFor(&api.Domain{})
+		migrationPolicyInformer, _ = testutils.NewFakeInformerFor(&v1.MigrationPolicy{})
 		recorder = record.NewFakeRecorder(100)
 
 		ctrl = gomock.NewController(GinkgoT())
@@ -136,6 +140,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmiTargetInformer,
 			domainInformer,
 			gracefulShutdownInformer,
+			migrationPolicyInformer,
 			1,
 			10,
 			config,
@@ -158,7 +163,8 @@ var _ = Describe("VirtualMachineInstance", func() {
 		go vmiTargetInformer.Run(stop)
 		go domainInformer.Run(stop)
 		go gracefulShutdownInformer.Run(stop)
-		Expect(cache.WaitForCacheSync(stop, vmiSourceInformer.HasSynced, vmiTargetInformer.HasSynced, domainInformer.HasSynced, gracefulShutdownInformer.HasSynced)).To(BeTrue())
+		go migrationPolicyInformer.Run(stop)
+		Expect(cache.WaitForCacheSync(stop, vmiSourceInformer.HasSynced, vmiTargetInformer.HasSynced, domainInformer.HasSynced, gracefulShutdownInformer.HasSynced, migrationPolicyInformer.HasSynced)).To(BeTrue())
 	})
 
 	AfterEach(func() {
@@ -455,6 +461,39 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should record the expanded host-model CPU in the status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.CPU = api.CPU{
+				Mode:  "custom",
+				Model: "Skylake-Client-IBRS",
+				Features: []api.CPUFeature{
+					{Name: "vmx", Policy: "require"},
+					{Name: "mpx", Policy: "disable"},
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.HostModelCPU).To(Equal(&v1.HostModelCPU{
+					Model:    "Skylake-Client-IBRS",
+					Features: []string{"vmx"},
+				}))
+			})
+
+			controller.Execute()
+		})
+
 		It("should remove guest agent condition when there is no channel connected", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -507,6 +546,289 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should add paused condition when the domain was paused by the user", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedUser
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstancePaused,
+					Status: k8sv1.ConditionTrue,
+					Reason: v1.VirtualMachineInstanceReasonPausedByUser,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		It("should remove paused condition when the domain is running again", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstancePaused,
+					Status: k8sv1.ConditionTrue,
+					Reason: v1.VirtualMachineInstanceReasonPausedByUser,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		It("should add an I/O error condition naming the failed disk when the domain was paused because of an I/O error", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedIOError
+			domain.Spec.Devices.Disks = []api.Disk{
+				{Target: api.DiskTarget{Device: "vda"}, Alias: &api.Alias{Name: "rootdisk"}},
+			}
+			domain.Status.DiskErrors = []api.DiskError{{Device: "vda", Error: "no space left on the storage"}}
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceIOError,
+					Status: k8sv1.ConditionTrue,
+					Reason: v1.VirtualMachineInstanceReasonDiskIOError,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI)).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Conditions[1].Message).To(Equal("The guest was paused because of an I/O error on disk rootdisk: no space left on the storage"))
+			})
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder.(*record.FakeRecorder), v1.Created.String(), v1.IOError.String())
+		})
+
+		It("should set the ready condition from the guest agent readiness probe", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceReady,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().GuestPing("default_testvmi", int32(1)).Return(nil)
+			controller.probeManager.Start(vmi, "default_testvmi", client)
+			Eventually(func() *bool { return controller.probeManager.Status(vmi.UID).Ready }).ShouldNot(BeNil())
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI)).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Conditions[1].Status).To(Equal(k8sv1.ConditionTrue))
+			})
+
+			controller.Execute()
+			controller.probeManager.Remove(vmi.UID)
+		})
+
+		It("should not wait for the guest agent readiness probe during the sync", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceReady,
+					Status: k8sv1.ConditionFalse,
+					Reason: v1.VirtualMachineInstanceReasonGuestNotReady,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			probed := make(chan struct{})
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().GuestPing("default_testvmi", int32(1)).DoAndReturn(func(domainName string, timeoutSeconds int32) error {
+				<-probed
+				return nil
+			})
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI)).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Conditions[1].Message).To(Equal("Waiting for the readiness probe"))
+			})
+
+			controller.Execute()
+
+			// the finished probe enqueues the VMI again
+			close(probed)
+			Eventually(controller.Queue.Len).Should(Equal(1))
+			controller.probeManager.Remove(vmi.UID)
+		})
+
+		It("should not probe the guest agent of a paused domain", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.ReadinessProbe = &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedUser
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstancePaused,
+					Status: k8sv1.ConditionTrue,
+					Reason: v1.VirtualMachineInstanceReasonPausedByUser,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceReady,
+					Status: k8sv1.ConditionFalse,
+					Reason: v1.VirtualMachineInstanceReasonGuestNotReady,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		It("should move VirtualMachineInstance from Running to Failed if the liveness probe fails", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler:          v1.Handler{Exec: &k8sv1.ExecAction{Command: []string{"cat", "/tmp/healthy"}}},
+				FailureThreshold: 1,
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().Exec("default_testvmi", "cat", []string{"/tmp/healthy"}, int32(1)).Return(1, "", nil)
+			controller.probeManager.Start(vmi, "default_testvmi", client)
+			Eventually(func() bool { return controller.probeManager.Status(vmi.UID).Alive }).Should(BeFalse())
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Phase).To(Equal(v1.Failed))
+			})
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder.(*record.FakeRecorder), v1.Created.String(), v1.Unhealthy.String())
+		})
+
 		It("should move VirtualMachineInstance from Scheduled to Failed if watchdog file is missing", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.ObjectMeta.ResourceVersion = "1"
@@ -544,6 +866,27 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should move VirtualMachineInstance from Running to Failed if post-copy migration failed", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedPostcopyFailed
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Phase).To(Equal(v1.Failed))
+			})
+			controller.Execute()
+		})
+
 		It("should remove an error condition if a synchronization run succeeds", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -691,6 +1034,58 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		}, 3)
 
+		It("should migrate vmi with the settings of the migration policy", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Labels = make(map[string]string)
+			vmi.Status.NodeName = host
+			vmi.Labels[v1.MigrationTargetNodeNameLabel] = "othernode"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:                     "othernode",
+				TargetNodeAddress:              "127.0.0.1:12345",
+				SourceNode:                     host,
+				MigrationUID:                   "123",
+				TargetDirectMigrationNodePorts: map[int]int{49152: 12132},
+				MigrationPolicyName:            "testpolicy",
+			}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			bandwidth := resource.MustParse("1Gi")
+			allowPostCopy := true
+			progressTimeout := int64(300)
+			migrationPolicyInformer.GetStore().Add(&v1.MigrationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpolicy"},
+				Spec: v1.MigrationPolicySpec{
+					Selectors:             &v1.MigrationPolicySelectors{},
+					BandwidthPerMigration: &bandwidth,
+					AllowPostCopy:         &allowPostCopy,
+					ProgressTimeout:       &progressTimeout,
+				},
+			})
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			domainFeeder.Add(domain)
+			vmiFeeder.Add(vmi)
+			options := &cmdclient.MigrationOptions{
+				Bandwidth:               resource.MustParse("1Gi"),
+				ProgressTimeout:         300,
+				CompletionTimeoutPerGiB: 800,
+				UnsafeMigration:         false,
+				AllowPostCopy:           true,
+			}
+			client.EXPECT().MigrateVirtualMachine(vmi, options)
+			controller.Execute()
+		}, 3)
+
 		It("should abort vmi migration vmi when migration object indicates deletion", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -730,6 +1125,60 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		}, 3)
 
+		table.DescribeTable("should report the migration progress of the domain", func(progressAge time.Duration, expectProgress bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Labels = make(map[string]string)
+			vmi.Status.NodeName = host
+			vmi.Labels[v1.MigrationTargetNodeNameLabel] = "othernode"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:                     "othernode",
+				TargetNodeAddress:              "127.0.0.1:12345",
+				SourceNode:                     host,
+				MigrationUID:                   "123",
+				TargetDirectMigrationNodePorts: map[int]int{49152: 12132},
+			}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			start := metav1.Time{Time: time.Unix(time.Now().UTC().Unix(), 0)}
+			domain.Spec.Metadata.KubeVirt.Migration = &api.MigrationMetadata{
+				UID:            "123",
+				StartTimestamp: &start,
+			}
+			reported := metav1.NewTime(start.Add(progressAge))
+			domain.Status.MigrationProgress = &v1.VirtualMachineInstanceMigrationProgress{
+				Timestamp:          &reported,
+				DataRemainingBytes: 1024,
+				MemoryIteration:    3,
+			}
+			domainFeeder.Add(domain)
+			vmiFeeder.Add(vmi)
+
+			client.EXPECT().MigrateVirtualMachine(gomock.Any(), gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				migrationState := arg.(*v1.VirtualMachineInstance).Status.MigrationState
+				if expectProgress {
+					Expect(migrationState.Progress).To(Equal(domain.Status.MigrationProgress))
+				} else {
+					Expect(migrationState.Progress).To(BeNil())
+				}
+			})
+			controller.Execute()
+		},
+			table.Entry("if it belongs to the current migration", 5*time.Second, true),
+			table.Entry("but not if it is older than the current migration", -5*time.Second, false),
+		)
+
 		It("Handoff domain to other node after completed migration", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -1087,9 +1536,65 @@ var _ = Describe("VirtualMachineInstance", func() {
 				},
 			}
 
-			blockMigrate, err := controller.checkVolumesForMigration(vmi)
-			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
+		})
+		It("should not be allowed to live-migrate a VMI with virtio-fs filesystems", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "myconfig", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myconfig",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: k8sv1.LocalObjectReference{Name: "config"},
+						},
+					},
+				},
+			}
+
+			_, err := controller.checkVolumesForMigration(vmi)
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with virtio-fs filesystems")))
+		})
+	})
+	Context("VirtualMachineInstance controller checks network interfaces for migration", func() {
+		newVMIWithInterface := func(iface v1.Interface, network v1.Network) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Networks = []v1.Network{network}
+			return vmi
+		}
+
+		It("should allow migrating masquerade interfaces on the pod network", func() {
+			vmi := newVMIWithInterface(*v1.DefaultMasqueradeNetworkInterface(), *v1.DefaultPodNetwork())
+			Expect(controller.checkNetworkInterfacesForMigration(vmi)).To(Succeed())
+		})
+
+		It("should name the bridge interface on the pod network which blocks migration", func() {
+			vmi := newVMIWithInterface(*v1.DefaultBridgeNetworkInterface(), *v1.DefaultPodNetwork())
+			err := controller.checkNetworkInterfacesForMigration(vmi)
+			Expect(err).To(MatchError("cannot migrate VMI: interface default uses bridge binding on the pod network, only masquerade binding is migratable"))
+		})
+
+		It("should allow migrating bridge interfaces on multus networks", func() {
+			vmi := newVMIWithInterface(
+				v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue"}}},
+			)
+			Expect(controller.checkNetworkInterfacesForMigration(vmi)).To(Succeed())
+		})
+
+		It("should name the SR-IOV interface which blocks migration", func() {
+			vmi := newVMIWithInterface(
+				v1.Interface{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+				v1.Network{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "sriov"}}},
+			)
+			err := controller.checkNetworkInterfacesForMigration(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("interface sriov uses SR-IOV binding"))
 		})
 	})
 	Context("VirtualMachineInstance controller gets informed about interfaces in a Domain", func() {
@@ -1286,6 +1791,493 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 	})
+
+	Context("VirtualMachineInstance controller gets informed about hotplugged volumes", func() {
+
+		BeforeEach(func() {
+			Expect(hotplugdisk.SetLocalDirectory(filepath.Join(shareDir, "hotplug-disks"))).To(Succeed())
+		})
+
+		newRunningVMIWithHotplugVolume := func(phase v1.VolumePhase) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "hpvolume",
+					DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{Bus: "scsi"},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "hpvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hppvc"},
+					},
+				},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name:          "hpvolume",
+					Phase:         phase,
+					HotplugVolume: &v1.HotplugVolumeStatus{AttachPodName: "hp-volume-abcde"},
+				},
+			}
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+			return vmi
+		}
+
+		It("should mark the VirtualMachineInstance as not migratable and sync the hotplugged disks", func() {
+			vmi := newRunningVMIWithHotplugVolume(v1.VolumePending)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionFalse,
+					Reason: v1.VirtualMachineInstanceReasonHotplugNotMigratable,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().HotplugDisk(gomock.Any())
+			client.EXPECT().UnplugDisk(gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
+		It("should remove the status of unplugged volumes once they are detached", func() {
+			vmi := newRunningVMIWithHotplugVolume(v1.VolumeReady)
+			vmi.Spec.Domain.Devices.Disks = nil
+			vmi.Spec.Volumes = nil
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionFalse,
+					Reason: v1.VirtualMachineInstanceReasonHotplugNotMigratable,
+				},
+			}
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().HotplugDisk(gomock.Any())
+			client.EXPECT().UnplugDisk(gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.VolumeStatus).To(BeEmpty())
+				Expect(vmi.Status.Conditions).To(HaveLen(1))
+				Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+				Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+			})
+
+			controller.Execute()
+		})
+
+		It("should report hotplugged volumes which are attached to the domain as ready", func() {
+			vmi := newRunningVMIWithHotplugVolume(v1.HotplugVolumeMounted)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Spec.Devices.Disks = []api.Disk{
+				{
+					Target: api.DiskTarget{Bus: "scsi", Device: "sda"},
+					Alias:  &api.Alias{Name: "hpvolume"},
+				},
+			}
+
+			Expect(controller.updateHotplugVolumeStatus(vmi, domain)).To(Succeed())
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.VolumeReady))
+			Expect(vmi.Status.VolumeStatus[0].Target).To(Equal("sda"))
+		})
+	})
+
+	Context("VirtualMachineInstance controller checks the size of PVC volumes", func() {
+		var imagePath string
+		var vmi *v1.VirtualMachineInstance
+		var domain *api.Domain
+		var pvc *k8sv1.PersistentVolumeClaim
+		var kubeClient *fake.Clientset
+
+		updatePVC := func(request, capacity string) {
+			pvc = pvc.DeepCopy()
+			pvc.Spec.Resources.Requests[k8sv1.ResourceStorage] = resource.MustParse(request)
+			pvc.Status.Capacity[k8sv1.ResourceStorage] = resource.MustParse(capacity)
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(pvc)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		expandPVC := func(capacity string) {
+			updatePVC(capacity, capacity)
+		}
+
+		BeforeEach(func() {
+			imagePath = filepath.Join(shareDir, "disk0", "disk.img")
+			Expect(os.MkdirAll(filepath.Dir(imagePath), 0755)).To(Succeed())
+			image, err := os.Create(imagePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Truncate(64 * 1024 * 1024)).To(Succeed())
+			image.Close()
+
+			// the image was imported into a larger claim
+			pvc = &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpvc", Namespace: metav1.NamespaceDefault},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("128Mi")},
+					},
+				},
+				Status: k8sv1.PersistentVolumeClaimStatus{
+					Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("128Mi")},
+				},
+			}
+			kubeClient = fake.NewSimpleClientset(pvc)
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			controller.volumeExpansionInterval = 0
+
+			controller.clusterConfig, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.ExpandDisksGate},
+			})
+
+			// the launcher pod shares its mount root with the test
+			isolationDetector := isolation.NewMockPodIsolationDetector(ctrl)
+			isolationDetector.EXPECT().Detect(gomock.Any()).Return(isolation.NewIsolationResult(os.Getpid(), "", nil), nil).AnyTimes()
+			controller.podIsolationDetector = isolationDetector
+
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+					},
+				},
+			}
+
+			domain = api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.Disks = []api.Disk{
+				{
+					Source: api.DiskSource{File: imagePath},
+					Target: api.DiskTarget{Bus: "virtio", Device: "vda"},
+					Alias:  &api.Alias{Name: "disk0"},
+				},
+			}
+		})
+
+		It("should not grow a disk image which is smaller than its PVC before the PVC got expanded", func() {
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Size).To(Equal(int64(64 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Capacity).To(Equal(int64(128 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Reason).To(BeEmpty())
+
+			info, err := os.Stat(imagePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Size()).To(Equal(int64(64 * 1024 * 1024)))
+		})
+
+		It("should grow the disk image and resize the disk in the guest once the PVC got expanded", func() {
+			controller.expandVolumes(vmi, domain)
+
+			expandPVC("256Mi")
+			client.EXPECT().ResizeDisk("default_testvmi", "disk0", int64(256*1024*1024))
+
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Size).To(Equal(int64(256 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Capacity).To(Equal(int64(256 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Target).To(Equal("vda"))
+			Expect(vmi.Status.VolumeStatus[0].Reason).To(Equal(v1.VolumeExpanded.String()))
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.VolumeExpanded.String())
+
+			info, err := os.Stat(imagePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Size()).To(Equal(int64(256 * 1024 * 1024)))
+
+			By("Not resizing the disk again")
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+		})
+
+		It("should record the failure if the disk could not be resized", func() {
+			controller.expandVolumes(vmi, domain)
+
+			expandPVC("256Mi")
+			client.EXPECT().ResizeDisk("default_testvmi", "disk0", int64(256*1024*1024)).Return(fmt.Errorf("resize failed"))
+
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Size).To(Equal(int64(64 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Capacity).To(Equal(int64(128 * 1024 * 1024)))
+			Expect(vmi.Status.VolumeStatus[0].Reason).To(Equal(v1.VolumeExpansionFailed.String()))
+			Expect(vmi.Status.VolumeStatus[0].Message).To(ContainSubstring("resize failed"))
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.VolumeExpansionFailed.String())
+		})
+
+		It("should record the failure if the PVC does not exist", func() {
+			Expect(kubeClient.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(pvc.Name, &metav1.DeleteOptions{})).To(Succeed())
+
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Reason).To(Equal(v1.VolumeExpansionFailed.String()))
+			Expect(vmi.Status.VolumeStatus[0].Message).To(ContainSubstring(`persistentvolumeclaims "testpvc" not found`))
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.VolumeExpansionFailed.String())
+			Expect(mockQueue.Len()).To(Equal(1))
+		})
+
+		It("should not check the volumes again if no expansion is pending", func() {
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(mockQueue.Len()).To(Equal(0))
+		})
+
+		It("should check the volumes again while the PVC is being expanded", func() {
+			controller.expandVolumes(vmi, domain)
+
+			updatePVC("256Mi", "128Mi")
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus[0].Size).To(Equal(int64(64 * 1024 * 1024)))
+			Expect(mockQueue.Len()).To(Equal(1))
+		})
+
+		It("should not check the volumes of a paused domain", func() {
+			domain.Status.Status = api.Paused
+
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(BeEmpty())
+		})
+
+		It("should not check the volumes if the ExpandDisks feature gate is disabled", func() {
+			controller.clusterConfig, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+
+			controller.expandVolumes(vmi, domain)
+			Expect(vmi.Status.VolumeStatus).To(BeEmpty())
+		})
+	})
+
+	Context("VirtualMachineInstance controller gets informed about CPU and memory hotplug", func() {
+		newRunningVMIWithMaximums := func(sockets uint32, guestMemory string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+			guest := resource.MustParse(guestMemory)
+			maxGuest := resource.MustParse("4Gi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: sockets, MaxSockets: 4}
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+			return vmi
+		}
+
+		newRunningDomain := func(vcpus uint32, memoryKiB uint64) *api.Domain {
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.VCPU = &api.VCPU{Placement: "static", Current: vcpus, CPUs: 4}
+			domain.Spec.Memory = api.Memory{Value: memoryKiB, Unit: "KiB"}
+			return domain
+		}
+
+		It("should hotplug CPUs and memory if the VMI requests more than the domain has", func() {
+			vmi := newRunningVMIWithMaximums(2, "1Gi")
+			mockWatchdog.CreateFile(vmi)
+			domain := newRunningDomain(1, 1048576)
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().HotplugResources(vmi)
+
+			controller.Execute()
+		})
+
+		It("should not hotplug CPUs and memory if the domain matches the VMI", func() {
+			vmi := newRunningVMIWithMaximums(2, "1Gi")
+			mockWatchdog.CreateFile(vmi)
+			domain := newRunningDomain(2, 1048576)
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+
+			controller.Execute()
+		})
+	})
+
+	Context("VirtualMachineInstance controller gets informed about media changes", func() {
+
+		BeforeEach(func() {
+			Expect(hotplugdisk.SetLocalDirectory(filepath.Join(shareDir, "hotplug-disks"))).To(Succeed())
+		})
+
+		newRunningVMIWithCDRom := func(medium ...string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "cdrom",
+					DiskDevice: v1.DiskDevice{
+						CDRom: &v1.CDRomTarget{Bus: "sata"},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "cdrom",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "installer"},
+					},
+				},
+			}
+			if len(medium) > 0 {
+				vmi.Spec.Domain.Devices.Disks[0].Medium = &medium[0]
+			}
+			return vmi
+		}
+
+		newRunningDomainWithCDRom := func(source api.DiskSource, tray string) *api.Domain {
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.Disks = []api.Disk{
+				{
+					Device: "cdrom",
+					Type:   "file",
+					Source: source,
+					Target: api.DiskTarget{Bus: "sata", Device: "sda", Tray: tray},
+					Alias:  &api.Alias{Name: "cdrom"},
+				},
+			}
+			return domain
+		}
+
+		originalMedium := api.DiskSource{File: "/var/run/kubevirt-ephemeral-disks/container-disk-data/cdrom/disk.img"}
+
+		It("should ask virt-launcher to eject the medium and report the open tray", func() {
+			vmi := newRunningVMIWithCDRom("")
+			vmi.Status.Media = []v1.MediaStatus{{Name: "cdrom", Source: "cdrom", Tray: v1.TrayStateClosed}}
+			mockWatchdog.CreateFile(vmi)
+			domain := newRunningDomainWithCDRom(originalMedium, "")
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().ChangeMedia(vmi)
+
+			controller.Execute()
+
+			domainFeeder.Modify(newRunningDomainWithCDRom(api.DiskSource{}, "open"))
+
+			client.EXPECT().SyncVirtualMachine(gomock.Any(), gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Media).To(Equal([]v1.MediaStatus{{Name: "cdrom", Tray: v1.TrayStateOpen}}))
+			})
+
+			controller.Execute()
+		})
+
+		It("should not change media if the requested medium is inserted", func() {
+			vmi := newRunningVMIWithCDRom()
+			vmi.Status.Media = []v1.MediaStatus{{Name: "cdrom", Source: "cdrom", Tray: v1.TrayStateClosed}}
+			mockWatchdog.CreateFile(vmi)
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(newRunningDomainWithCDRom(originalMedium, ""))
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+
+			controller.Execute()
+		})
+
+		It("should report the medium of new drives", func() {
+			vmi := newRunningVMIWithCDRom()
+			updateMediaStatus(vmi, newRunningDomainWithCDRom(originalMedium, ""))
+			Expect(vmi.Status.Media).To(Equal([]v1.MediaStatus{{Name: "cdrom", Source: "cdrom", Tray: v1.TrayStateClosed}}))
+		})
+
+		It("should ask virt-launcher to insert the medium requested in the spec", func() {
+			vmi := newRunningVMIWithCDRom("iso")
+			domain := newRunningDomainWithCDRom(originalMedium, "")
+			Expect(needsMediaChange(vmi, domain)).To(BeTrue())
+
+			vmi.Spec.Domain.Devices.Disks[0].Medium = nil
+			Expect(needsMediaChange(vmi, domain)).To(BeFalse())
+		})
+
+		It("should report inserted hotplugged volumes as ready", func() {
+			vmi := newRunningVMIWithCDRom("iso")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "iso",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "isopvc"},
+				},
+			})
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name:          "iso",
+					Phase:         v1.HotplugVolumeMounted,
+					HotplugVolume: &v1.HotplugVolumeStatus{AttachPodName: "hp-volume-abcde"},
+				},
+			}
+			hotplugdisk.AddHotplugVolumeName(vmi, "iso")
+			domain := newRunningDomainWithCDRom(api.DiskSource{File: hotplugdisk.GenerateDiskTargetPathFromLauncherView("iso")}, "")
+
+			Expect(controller.updateHotplugVolumeStatus(vmi, domain)).To(Succeed())
+			Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.VolumeReady))
+			Expect(vmi.Status.VolumeStatus[0].Target).To(Equal("sda"))
+
+			updateMediaStatus(vmi, domain)
+			Expect(vmi.Status.Media[0].Source).To(Equal("iso"))
+			Expect(needsMediaChange(vmi, domain)).To(BeFalse())
+		})
+
+		It("should report hotplugged block volumes as inserted media", func() {
+			vmi := newRunningVMIWithCDRom("iso")
+			domain := newRunningDomainWithCDRom(api.DiskSource{Dev: hotplugdisk.GenerateDiskTargetPathFromLauncherView("iso")}, "")
+
+			Expect(getInsertedMedia(domain)).To(HaveKey("iso"))
+			updateMediaStatus(vmi, domain)
+			Expect(vmi.Status.Media[0].Source).To(Equal("iso"))
+		})
+	})
 })
 
 type MockGracefulShutdown struct {
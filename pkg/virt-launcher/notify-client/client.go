@@ -26,6 +26,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
@@ -33,11 +34,21 @@ var (
 	// add older version when supported
 	// don't use the variable in pkg/handler-launcher-com/notify/v1/version.go in order to detect version mismatches early
 	supportedNotifyVersions = []uint32{1}
+
+	// allow mocking for tests
+	podInterfaceStatuses = network.ReadCachedInterfaceStatuses
 )
 
 type Notifier struct {
 	v1client notifyv1.NotifyClient
 	conn     *grpc.ClientConn
+
+	migrationProgressChan chan migrationProgressEvent
+}
+
+type migrationProgressEvent struct {
+	Domain   string
+	Progress *v1.VirtualMachineInstanceMigrationProgress
 }
 
 type libvirtEvent struct {
@@ -86,8 +97,9 @@ func NewNotifierWithInfoClient(infoClient info.NotifyInfoClient, conn *grpc.Clie
 
 func newV1Notifier(client notifyv1.NotifyClient, conn *grpc.ClientConn) *Notifier {
 	return &Notifier{
-		v1client: client,
-		conn:     conn,
+		v1client:              client,
+		conn:                  conn,
+		migrationProgressChan: make(chan migrationProgressEvent, 10),
 	}
 }
 
@@ -134,7 +146,7 @@ func newWatchEventError(err error) watch.Event {
 	return watch.Event{Type: watch.Error, Object: &metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}}
 }
 
-func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event, interfaceStatus *[]api.InterfaceStatus) {
+func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event, interfaceStatus *[]api.InterfaceStatus, migrationProgress *v1.VirtualMachineInstanceMigrationProgress) {
 	d, err := c.LookupDomainByName(util.DomainFromNamespaceName(domain.ObjectMeta.Namespace, domain.ObjectMeta.Name))
 	if err != nil {
 		if !domainerrors.IsNotFound(err) {
@@ -161,6 +173,12 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 			domain.SetState(util.ConvState(status), util.ConvReason(status, reason))
 		}
 
+		// The pause reason doesn't tell which disk failed
+		domain.Status.DiskErrors = nil
+		if domain.Status.Reason == api.ReasonPausedIOError {
+			domain.Status.DiskErrors = getDiskErrors(d)
+		}
+
 		spec, err := util.GetDomainSpecWithRuntimeInfo(status, d)
 		if err != nil {
 			// NOTE: Getting domain metadata for a live-migrating VM isn't allowed
@@ -179,6 +197,9 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 		log.Log.Infof("kubevirt domain status: %v(%v):%v(%v)", domain.Status.Status, status, domain.Status.Reason, reason)
 	}
 
+	domain.Status.MigrationProgress = migrationProgress
+	domain.Status.Interfaces = mergeInterfaceStatuses(interfaceStatus)
+
 	switch domain.Status.Reason {
 	case api.ReasonNonExistent:
 		watchEvent := watch.Event{Type: watch.Deleted, Object: domain}
@@ -197,7 +218,6 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 			}
 		}
 		if interfaceStatus != nil {
-			domain.Status.Interfaces = *interfaceStatus
 			event := watch.Event{Type: watch.Modified, Object: domain}
 			client.SendDomainEvent(event)
 			events <- event
@@ -206,7 +226,53 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 	}
 }
 
-func (n *Notifier) StartDomainNotifier(domainConn cli.Connection, deleteNotificationSent chan watch.Event, vmiUID types.UID, qemuAgentPollerInterval *time.Duration) error {
+func getDiskErrors(d cli.VirDomain) []api.DiskError {
+	diskErrors, err := d.GetDiskErrors(0)
+	if err != nil {
+		log.Log.Reason(err).Error("Could not fetch the disk errors of the Domain.")
+		return nil
+	}
+
+	var errors []api.DiskError
+	for _, diskError := range diskErrors {
+		switch diskError.Error {
+		case libvirt.DOMAIN_DISK_ERROR_NONE:
+			continue
+		case libvirt.DOMAIN_DISK_ERROR_NO_SPACE:
+			errors = append(errors, api.DiskError{Device: diskError.Disk, Error: "no space left on the storage"})
+		default:
+			errors = append(errors, api.DiskError{Device: diskError.Disk, Error: "unspecified I/O error"})
+		}
+	}
+	return errors
+}
+
+// mergeInterfaceStatuses reports the pod IPs of the interfaces for which the guest agent reports no IPs
+func mergeInterfaceStatuses(agentStatuses *[]api.InterfaceStatus) []api.InterfaceStatus {
+	podStatuses, err := podInterfaceStatuses()
+	if err != nil {
+		log.Log.Reason(err).Error("Could not read the pod interface statuses.")
+	}
+	if agentStatuses == nil {
+		return podStatuses
+	}
+
+	statuses := append([]api.InterfaceStatus{}, *agentStatuses...)
+	for i, status := range statuses {
+		if len(status.IPs) > 0 {
+			continue
+		}
+		for _, podStatus := range podStatuses {
+			if podStatus.Mac == status.Mac {
+				statuses[i].Ip = podStatus.Ip
+				statuses[i].IPs = podStatus.IPs
+			}
+		}
+	}
+	return statuses
+}
+
+func (n *Notifier) StartDomainNotifier(domainConn cli.Connection, deleteNotificationSent chan watch.Event, vmiUID types.UID, agentStore *agentpoller.AsyncAgentStore, qemuAgentPollerInterval *time.Duration) error {
 	eventChan := make(chan libvirtEvent, 10)
 	agentUpdateChan := make(chan agentpoller.AgentUpdateEvent, 10)
 
@@ -214,16 +280,17 @@ func (n *Notifier) StartDomainNotifier(domainConn cli.Connection, deleteNotifica
 
 	domainConn.SetReconnectChan(reconnectChan)
 
-	agentPoller := agentpoller.CreatePoller(domainConn, vmiUID, agentUpdateChan, qemuAgentPollerInterval)
+	agentPoller := agentpoller.CreatePoller(domainConn, vmiUID, agentUpdateChan, agentStore, qemuAgentPollerInterval)
 
 	// Run the event process logic in a separate go-routine to not block libvirt
 	go func() {
 		var interfaceStatuses *[]api.InterfaceStatus
+		var migrationProgress *v1.VirtualMachineInstanceMigrationProgress
 		for {
 			select {
 			case event := <-eventChan:
 				domain := util.NewDomainFromName(event.Domain, vmiUID)
-				eventCallback(domainConn, domain, event, n, deleteNotificationSent, interfaceStatuses)
+				eventCallback(domainConn, domain, event, n, deleteNotificationSent, interfaceStatuses, migrationProgress)
 				agentPoller.UpdateDomain(domain)
 				if event.AgentEvent != nil {
 					if event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED {
@@ -236,7 +303,10 @@ func (n *Notifier) StartDomainNotifier(domainConn cli.Connection, deleteNotifica
 			case agentUpdate := <-agentUpdateChan:
 				interfaceStatuses = agentUpdate.InterfaceStatuses
 				domainName := agentUpdate.DomainName
-				eventCallback(domainConn, util.NewDomainFromName(domainName, vmiUID), libvirtEvent{}, n, deleteNotificationSent, interfaceStatuses)
+				eventCallback(domainConn, util.NewDomainFromName(domainName, vmiUID), libvirtEvent{}, n, deleteNotificationSent, interfaceStatuses, migrationProgress)
+			case progressUpdate := <-n.migrationProgressChan:
+				migrationProgress = progressUpdate.Progress
+				eventCallback(domainConn, util.NewDomainFromName(progressUpdate.Domain, vmiUID), libvirtEvent{}, n, deleteNotificationSent, interfaceStatuses, migrationProgress)
 			case <-reconnectChan:
 				n.SendDomainEvent(newWatchEventError(fmt.Errorf("Libvirt reconnect")))
 				return
@@ -280,10 +350,39 @@ func (n *Notifier) StartDomainNotifier(domainConn cli.Connection, deleteNotifica
 		return err
 	}
 
+	// the guest can open and close the trays of CD-ROMs and floppies, report them like any other domain change
+	domainEventTrayChangeCallback := func(c *libvirt.Connect, d *libvirt.Domain, event *libvirt.DomainEventTrayChange) {
+		log.Log.Infof("TrayChange event for %s with reason %d received", event.DevAlias, event.Reason)
+		name, err := d.GetName()
+		if err != nil {
+			log.Log.Reason(err).Info("Could not determine name of libvirt domain in event callback.")
+		}
+		select {
+		case eventChan <- libvirtEvent{Domain: name}:
+		default:
+			log.Log.Infof("Libvirt event channel is full, dropping event.")
+		}
+	}
+	err = domainConn.DomainEventTrayChangeRegister(domainEventTrayChangeCallback)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to register event callback with libvirt")
+		return err
+	}
+
 	log.Log.Infof("Registered libvirt event notify callback")
 	return nil
 }
 
+// SendMigrationProgress reports the progress of a running migration of the domain to virt-handler,
+// together with the rest of the domain status. Updates are dropped if the notifier can't keep up.
+func (n *Notifier) SendMigrationProgress(domainName string, progress *v1.VirtualMachineInstanceMigrationProgress) {
+	select {
+	case n.migrationProgressChan <- migrationProgressEvent{Domain: domainName, Progress: progress}:
+	default:
+		log.Log.Infof("Migration progress channel is full, dropping progress update.")
+	}
+}
+
 func (n *Notifier) SendK8sEvent(vmi *v1.VirtualMachineInstance, severity string, reason string, message string) error {
 
 	vmiRef, err := reference.GetReference(v1.Scheme, vmi)
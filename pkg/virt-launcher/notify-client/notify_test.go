@@ -33,6 +33,7 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -43,6 +44,7 @@ import (
 	notifyserver "kubevirt.io/kubevirt/pkg/virt-handler/notify-server"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
@@ -105,7 +107,7 @@ var _ = Describe("Notify", func() {
 				mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).Return(string(x), nil)
 				mockDomain.EXPECT().GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).Return(`<kubevirt></kubevirt>`, nil)
 
-				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{Event: &libvirt.DomainEventLifecycle{Event: event}}, client, deleteNotificationSent, nil)
+				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{Event: &libvirt.DomainEventLifecycle{Event: event}}, client, deleteNotificationSent, nil, nil)
 
 				timedOut := false
 				timeout := time.After(2 * time.Second)
@@ -136,7 +138,7 @@ var _ = Describe("Notify", func() {
 				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_NOSTATE, -1, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
 				mockDomain.EXPECT().GetName().Return("test", nil).AnyTimes()
 
-				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{Event: &libvirt.DomainEventLifecycle{Event: libvirt.DOMAIN_EVENT_UNDEFINED}}, client, deleteNotificationSent, nil)
+				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{Event: &libvirt.DomainEventLifecycle{Event: libvirt.DOMAIN_EVENT_UNDEFINED}}, client, deleteNotificationSent, nil, nil)
 
 				timedOut := false
 				timeout := time.After(2 * time.Second)
@@ -178,7 +180,7 @@ var _ = Describe("Notify", func() {
 					},
 				}
 
-				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{}, client, deleteNotificationSent, &interfaceStatus)
+				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{}, client, deleteNotificationSent, &interfaceStatus, nil)
 
 				timedOut := false
 				timeout := time.After(2 * time.Second)
@@ -193,6 +195,70 @@ var _ = Describe("Notify", func() {
 				}
 				Expect(timedOut).To(BeFalse())
 			})
+
+		It("should report the disk errors of a Domain paused because of an I/O error",
+			func() {
+				domain := api.NewMinimalDomain("test")
+				x, err := xml.Marshal(domain.Spec)
+				Expect(err).ToNot(HaveOccurred())
+				mockDomain.EXPECT().Free()
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, int(libvirt.DOMAIN_PAUSED_IOERROR), nil)
+				mockDomain.EXPECT().GetDiskErrors(uint32(0)).Return([]libvirt.DomainDiskError{
+					{Disk: "vda", Error: libvirt.DOMAIN_DISK_ERROR_NO_SPACE},
+					{Disk: "vdb", Error: libvirt.DOMAIN_DISK_ERROR_NONE},
+				}, nil)
+				mockDomain.EXPECT().GetName().Return("test", nil).AnyTimes()
+				mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).Return(string(x), nil)
+				mockDomain.EXPECT().GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).Return(`<kubevirt></kubevirt>`, nil)
+
+				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{}, client, deleteNotificationSent, nil, nil)
+
+				timedOut := false
+				timeout := time.After(2 * time.Second)
+				select {
+				case <-timeout:
+					timedOut = true
+				case event := <-eventChan:
+					newDomain, _ := event.Object.(*api.Domain)
+					Expect(newDomain.Status.Reason).To(Equal(api.ReasonPausedIOError))
+					Expect(newDomain.Status.DiskErrors).To(Equal([]api.DiskError{{Device: "vda", Error: "no space left on the storage"}}))
+				}
+				Expect(timedOut).To(BeFalse())
+			})
+
+		It("should update the migration progress",
+			func() {
+				domain := api.NewMinimalDomain("test")
+				x, err := xml.Marshal(domain.Spec)
+				Expect(err).ToNot(HaveOccurred())
+				mockDomain.EXPECT().Free()
+				mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, -1, nil)
+				mockDomain.EXPECT().GetName().Return("test", nil).AnyTimes()
+				mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).Return(string(x), nil)
+				mockDomain.EXPECT().GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).Return(`<kubevirt></kubevirt>`, nil)
+
+				now := metav1.Now()
+				progress := &v1.VirtualMachineInstanceMigrationProgress{
+					Timestamp:          &now,
+					DataRemainingBytes: 1024,
+					MemoryIteration:    3,
+				}
+
+				eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{}, client, deleteNotificationSent, nil, progress)
+
+				timedOut := false
+				timeout := time.After(2 * time.Second)
+				select {
+				case <-timeout:
+					timedOut = true
+				case event := <-eventChan:
+					newDomain, _ := event.Object.(*api.Domain)
+					Expect(newDomain.Status.MigrationProgress).ToNot(BeNil())
+					Expect(newDomain.Status.MigrationProgress.DataRemainingBytes).To(Equal(int64(1024)))
+					Expect(newDomain.Status.MigrationProgress.MemoryIteration).To(Equal(int64(3)))
+				}
+				Expect(timedOut).To(BeFalse())
+			})
 	})
 
 	Describe("K8s Events", func() {
@@ -284,4 +350,42 @@ var _ = Describe("Notify", func() {
 		})
 
 	})
+
+	Context("Interface statuses", func() {
+		podStatuses := []api.InterfaceStatus{{
+			Name: "default",
+			Mac:  "02:00:00:00:00:01",
+			Ip:   "10.244.0.5",
+			IPs:  []string{"10.244.0.5", "fd00:10:244::5"},
+		}}
+
+		BeforeEach(func() {
+			podInterfaceStatuses = func() ([]api.InterfaceStatus, error) {
+				return podStatuses, nil
+			}
+		})
+
+		AfterEach(func() {
+			podInterfaceStatuses = network.ReadCachedInterfaceStatuses
+		})
+
+		It("should report the pod IPs without guest agent", func() {
+			Expect(mergeInterfaceStatuses(nil)).To(Equal(podStatuses))
+		})
+
+		It("should prefer the IPs reported by the guest agent", func() {
+			agentStatuses := []api.InterfaceStatus{
+				{Name: "default", Mac: "02:00:00:00:00:01", Ip: "10.0.2.2/24", IPs: []string{"10.0.2.2/24"}, InterfaceName: "eth0"},
+			}
+			Expect(mergeInterfaceStatuses(&agentStatuses)).To(Equal(agentStatuses))
+		})
+
+		It("should complete the interfaces the guest agent reports no IPs for", func() {
+			agentStatuses := []api.InterfaceStatus{{Name: "default", Mac: "02:00:00:00:00:01"}}
+			merged := mergeInterfaceStatuses(&agentStatuses)
+			Expect(merged).To(HaveLen(1))
+			Expect(merged[0].IPs).To(Equal(podStatuses[0].IPs))
+			Expect(agentStatuses[0].IPs).To(BeEmpty())
+		})
+	})
 })
@@ -44,6 +44,7 @@ type AgentPoller struct {
 	domainUpdate    chan *api.Domain
 	pollTime        time.Duration
 	agentUpdateChan chan AgentUpdateEvent
+	agentStore      *AsyncAgentStore
 }
 
 type DomainData struct {
@@ -71,12 +72,13 @@ type IP struct {
 	Prefix int    `json:"prefix"`
 }
 
-func CreatePoller(connecton cli.Connection, vmiUID types.UID, agentUpdateChan chan AgentUpdateEvent, qemuAgentPollerInterval *time.Duration) *AgentPoller {
+func CreatePoller(connecton cli.Connection, vmiUID types.UID, agentUpdateChan chan AgentUpdateEvent, agentStore *AsyncAgentStore, qemuAgentPollerInterval *time.Duration) *AgentPoller {
 	p := &AgentPoller{
 		Connection:      connecton,
 		VmiUID:          vmiUID,
 		pollTime:        *qemuAgentPollerInterval,
 		agentUpdateChan: agentUpdateChan,
+		agentStore:      agentStore,
 		domainUpdate:    make(chan *api.Domain, 10),
 	}
 	return p
@@ -97,6 +99,7 @@ func (p *AgentPoller) Start() {
 			case domain := <-p.domainUpdate:
 				p.domainData = p.createDomainData(domain)
 			case <-time.After(time.Duration(p.pollTime) * time.Second):
+				p.updateGuestInfo()
 				cmdResult, err := p.pollQemuAgent(p.domainData.name)
 				if err != nil {
 					log.Log.Reason(err).Error("Qemu agent poller error")
@@ -122,6 +125,16 @@ func (p *AgentPoller) Stop() {
 		close(p.agentDone)
 		p.agentDone = nil
 	}
+	// the guest info is stale without a connected agent
+	p.agentStore.setGuestInfo(nil)
+}
+
+func (p *AgentPoller) updateGuestInfo() {
+	guestInfo, err := p.pollGuestInfo(p.domainData.name)
+	if err != nil {
+		log.Log.Reason(err).V(3).Info("Qemu agent poller could not gather the full guest info")
+	}
+	p.agentStore.setGuestInfo(guestInfo)
 }
 
 func (p *AgentPoller) GetInterfaceStatuses(cmdResult string) []api.InterfaceStatus {
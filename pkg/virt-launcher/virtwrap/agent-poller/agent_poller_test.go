@@ -20,10 +20,15 @@
 package agentpoller
 
 import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 )
 
 var _ = Describe("Qemu agent poller", func() {
@@ -70,4 +75,80 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(interfaceStatuses).To(Equal(expectedStatuses))
 		})
 	})
+
+	Context("gathering the guest info", func() {
+		var ctrl *gomock.Controller
+		var mockConn *cli.MockConnection
+		var agentPoller *AgentPoller
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			mockConn = cli.NewMockConnection(ctrl)
+			agentPoller = &AgentPoller{Connection: mockConn}
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		expectCommand := func(command string, reply string, err error) {
+			mockConn.EXPECT().QemuAgentCommand(fmt.Sprintf("{\"execute\":\"%s\"}", command), "fake").Return(reply, err)
+		}
+
+		It("should parse the replies of all commands", func() {
+			expectCommand(getOSInfoCommand, `{"return":{"name":"Fedora","kernel-release":"5.0.9-301.fc30.x86_64","version":"30 (Cloud Edition)","pretty-name":"Fedora 30 (Cloud Edition)","version-id":"30","kernel-version":"#1 SMP Tue Apr 23 23:57:35 UTC 2019","machine":"x86_64","id":"fedora"}}`, nil)
+			expectCommand(getHostnameCommand, `{"return":{"host-name":"testvmi"}}`, nil)
+			expectCommand(getTimezoneCommand, `{"return":{"zone":"UTC","offset":0}}`, nil)
+			expectCommand(getUsersCommand, `{"return":[{"user":"fedora","domain":"","login-time":1580310153.5}]}`, nil)
+			expectCommand(getFSInfoCommand, `{"return":[{"name":"vda1","mountpoint":"/","type":"ext4","used-bytes":1000,"total-bytes":4000}]}`, nil)
+
+			info, err := agentPoller.pollGuestInfo("fake")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(Equal(&v1.VirtualMachineInstanceGuestAgentInfo{
+				Hostname: "testvmi",
+				Timezone: "UTC, 0",
+				OS: v1.VirtualMachineInstanceGuestOSInfo{
+					Name:          "Fedora",
+					KernelRelease: "5.0.9-301.fc30.x86_64",
+					Version:       "30 (Cloud Edition)",
+					PrettyName:    "Fedora 30 (Cloud Edition)",
+					VersionID:     "30",
+					KernelVersion: "#1 SMP Tue Apr 23 23:57:35 UTC 2019",
+					Machine:       "x86_64",
+					ID:            "fedora",
+				},
+				UserList: []v1.VirtualMachineInstanceGuestOSUser{
+					{UserName: "fedora", LoginTime: 1580310153.5},
+				},
+				FSInfo: v1.VirtualMachineInstanceFileSystemInfo{
+					Filesystems: []v1.VirtualMachineInstanceFileSystem{
+						{DiskName: "vda1", MountPoint: "/", FileSystemType: "ext4", UsedBytes: 1000, TotalBytes: 4000},
+					},
+				},
+			}))
+		})
+
+		It("should return the partial info if some commands fail", func() {
+			expectCommand(getOSInfoCommand, "", fmt.Errorf("unsupported"))
+			expectCommand(getHostnameCommand, `{"return":{"host-name":"testvmi"}}`, nil)
+			expectCommand(getTimezoneCommand, "", fmt.Errorf("unsupported"))
+			expectCommand(getUsersCommand, "", fmt.Errorf("unsupported"))
+			expectCommand(getFSInfoCommand, "", fmt.Errorf("unsupported"))
+
+			info, err := agentPoller.pollGuestInfo("fake")
+			Expect(err).To(HaveOccurred())
+			Expect(info).ToNot(BeNil())
+			Expect(info.Hostname).To(Equal("testvmi"))
+		})
+
+		It("should return no info if all commands fail", func() {
+			for _, c := range guestInfoCommands {
+				expectCommand(c.command, "", fmt.Errorf("unsupported"))
+			}
+
+			info, err := agentPoller.pollGuestInfo("fake")
+			Expect(err).To(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package agentpoller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	getOSInfoCommand   = "guest-get-osinfo"
+	getHostnameCommand = "guest-get-host-name"
+	getTimezoneCommand = "guest-get-timezone"
+	getUsersCommand    = "guest-get-users"
+	getFSInfoCommand   = "guest-get-fsinfo"
+)
+
+// guestInfoCommands are the guest agent commands which make up the guest info,
+// together with the parsers which apply their replies to it
+var guestInfoCommands = []struct {
+	command string
+	parse   func(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error
+}{
+	{getOSInfoCommand, parseOSInfo},
+	{getHostnameCommand, parseHostname},
+	{getTimezoneCommand, parseTimezone},
+	{getUsersCommand, parseUsers},
+	{getFSInfoCommand, parseFSInfo},
+}
+
+// AsyncAgentStore caches the guest info gathered by the AgentPoller, so that
+// it can be served without waiting for the guest agent
+type AsyncAgentStore struct {
+	lock      *sync.Mutex
+	guestInfo *v1.VirtualMachineInstanceGuestAgentInfo
+}
+
+func NewAsyncAgentStore() *AsyncAgentStore {
+	return &AsyncAgentStore{
+		lock: &sync.Mutex{},
+	}
+}
+
+// GetGuestInfo returns a copy of the latest guest info, or nil if none is available
+func (s *AsyncAgentStore) GetGuestInfo() *v1.VirtualMachineInstanceGuestAgentInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.guestInfo == nil {
+		return nil
+	}
+	return s.guestInfo.DeepCopy()
+}
+
+func (s *AsyncAgentStore) setGuestInfo(guestInfo *v1.VirtualMachineInstanceGuestAgentInfo) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.guestInfo = guestInfo
+}
+
+// OSInfo for json unmarshalling
+type OSInfo struct {
+	Name          string `json:"name"`
+	KernelRelease string `json:"kernel-release"`
+	Version       string `json:"version"`
+	PrettyName    string `json:"pretty-name"`
+	VersionID     string `json:"version-id"`
+	KernelVersion string `json:"kernel-version"`
+	Machine       string `json:"machine"`
+	ID            string `json:"id"`
+}
+
+// Hostname for json unmarshalling
+type Hostname struct {
+	Hostname string `json:"host-name"`
+}
+
+// Timezone for json unmarshalling
+type Timezone struct {
+	Zone   string `json:"zone"`
+	Offset int    `json:"offset"`
+}
+
+// User for json unmarshalling
+type User struct {
+	Name      string  `json:"user"`
+	Domain    string  `json:"domain"`
+	LoginTime float64 `json:"login-time"`
+}
+
+// Filesystem for json unmarshalling
+type Filesystem struct {
+	Name       string `json:"name"`
+	Mountpoint string `json:"mountpoint"`
+	Type       string `json:"type"`
+	UsedBytes  int64  `json:"used-bytes"`
+	TotalBytes int64  `json:"total-bytes"`
+}
+
+func parseOSInfo(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error {
+	result := struct {
+		OSInfo OSInfo `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(agentReply), &result); err != nil {
+		return err
+	}
+	info.OS = v1.VirtualMachineInstanceGuestOSInfo{
+		Name:          result.OSInfo.Name,
+		KernelRelease: result.OSInfo.KernelRelease,
+		Version:       result.OSInfo.Version,
+		PrettyName:    result.OSInfo.PrettyName,
+		VersionID:     result.OSInfo.VersionID,
+		KernelVersion: result.OSInfo.KernelVersion,
+		Machine:       result.OSInfo.Machine,
+		ID:            result.OSInfo.ID,
+	}
+	return nil
+}
+
+func parseHostname(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error {
+	result := struct {
+		Hostname Hostname `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(agentReply), &result); err != nil {
+		return err
+	}
+	info.Hostname = result.Hostname.Hostname
+	return nil
+}
+
+func parseTimezone(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error {
+	result := struct {
+		Timezone Timezone `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(agentReply), &result); err != nil {
+		return err
+	}
+	info.Timezone = fmt.Sprintf("%s, %d", result.Timezone.Zone, result.Timezone.Offset)
+	return nil
+}
+
+func parseUsers(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error {
+	result := struct {
+		Users []User `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(agentReply), &result); err != nil {
+		return err
+	}
+	info.UserList = nil
+	for _, user := range result.Users {
+		info.UserList = append(info.UserList, v1.VirtualMachineInstanceGuestOSUser{
+			UserName:  user.Name,
+			Domain:    user.Domain,
+			LoginTime: user.LoginTime,
+		})
+	}
+	return nil
+}
+
+func parseFSInfo(agentReply string, info *v1.VirtualMachineInstanceGuestAgentInfo) error {
+	result := struct {
+		Filesystems []Filesystem `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(agentReply), &result); err != nil {
+		return err
+	}
+	info.FSInfo.Filesystems = nil
+	for _, fs := range result.Filesystems {
+		info.FSInfo.Filesystems = append(info.FSInfo.Filesystems, v1.VirtualMachineInstanceFileSystem{
+			DiskName:       fs.Name,
+			MountPoint:     fs.Mountpoint,
+			FileSystemType: fs.Type,
+			UsedBytes:      fs.UsedBytes,
+			TotalBytes:     fs.TotalBytes,
+		})
+	}
+	return nil
+}
+
+// pollGuestInfo runs all guest info commands. Commands which are not supported by
+// the guest agent are skipped, only if none succeeds no guest info is returned.
+func (p *AgentPoller) pollGuestInfo(domainName string) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	info := &v1.VirtualMachineInstanceGuestAgentInfo{}
+	var failures []string
+	for _, c := range guestInfoCommands {
+		cmdResult, err := p.Connection.QemuAgentCommand(fmt.Sprintf("{\"execute\":\"%s\"}", c.command), domainName)
+		if err == nil {
+			err = c.parse(cmdResult, info)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", c.command, err))
+		}
+	}
+
+	if len(failures) == len(guestInfoCommands) {
+		return nil, fmt.Errorf("failed to gather guest info: %s", strings.Join(failures, ", "))
+	}
+	if len(failures) > 0 {
+		return info, fmt.Errorf("guest info is incomplete: %s", strings.Join(failures, ", "))
+	}
+	return info, nil
+}
@@ -26,6 +26,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -43,9 +44,11 @@ import (
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
+	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
 const (
@@ -54,6 +57,13 @@ const (
 	defaultIOThread        = uint(1)
 	EFIPath                = "/usr/share/OVMF/OVMF_CODE.fd"
 	EFIVarsPath            = "/usr/share/OVMF/OVMF_VARS.fd"
+	// MaxMemorySlots is the number of memory slots available for hotplugging memory
+	MaxMemorySlots = uint32(16)
+	// MemoryHotplugBlockSize is the size of the memory blocks the guest onlines hotplugged memory in,
+	// DIMMs are sized in multiples of it
+	MemoryHotplugBlockSize = uint64(128 * 1024 * 1024)
+	// DefaultBalloonStatsPeriod is the interval in seconds at which the balloon collects guest memory statistics
+	DefaultBalloonStatsPeriod = uint32(10)
 )
 
 // +k8s:deepcopy-gen=false
@@ -62,10 +72,12 @@ type ConverterContext struct {
 	Secrets        map[string]*k8sv1.Secret
 	VirtualMachine *v1.VirtualMachineInstance
 	CPUSet         []int
+	CPUNUMANodes   map[int]int
 	IsBlockPVC     map[string]bool
 	DiskType       map[string]*containerdisk.DiskInfo
 	SRIOVDevices   map[string][]string
 	SMBios         *cmdv1.SMBios
+	HotplugVolumes map[string]bool
 }
 
 func Convert_v1_Disk_To_api_Disk(diskDevice *v1.Disk, disk *Disk, devicePerBus map[string]int, numQueues *uint) error {
@@ -109,8 +121,12 @@ func Convert_v1_Disk_To_api_Disk(diskDevice *v1.Disk, disk *Disk, devicePerBus m
 		}
 	}
 	disk.Driver = &DiskDriver{
-		Name:  "qemu",
-		Cache: string(diskDevice.Cache),
+		Name:         "qemu",
+		Cache:        string(diskDevice.Cache),
+		IO:           string(diskDevice.IO),
+		ErrorPolicy:  string(diskDevice.ErrorPolicy),
+		Discard:      string(diskDevice.Discard),
+		DetectZeroes: string(diskDevice.DetectZeroes),
 	}
 	if numQueues != nil {
 		disk.Driver.Queues = numQueues
@@ -119,6 +135,22 @@ func Convert_v1_Disk_To_api_Disk(diskDevice *v1.Disk, disk *Disk, devicePerBus m
 	if diskDevice.BootOrder != nil {
 		disk.BootOrder = &BootOrder{Order: *diskDevice.BootOrder}
 	}
+	if diskDevice.IOTune != nil {
+		disk.IOTune = &IOTune{
+			TotalBytesSec:    diskDevice.IOTune.TotalBytesSec,
+			ReadBytesSec:     diskDevice.IOTune.ReadBytesSec,
+			WriteBytesSec:    diskDevice.IOTune.WriteBytesSec,
+			TotalIOPSSec:     diskDevice.IOTune.TotalIOPSSec,
+			ReadIOPSSec:      diskDevice.IOTune.ReadIOPSSec,
+			WriteIOPSSec:     diskDevice.IOTune.WriteIOPSSec,
+			TotalBytesSecMax: diskDevice.IOTune.TotalBytesSecMax,
+			ReadBytesSecMax:  diskDevice.IOTune.ReadBytesSecMax,
+			WriteBytesSecMax: diskDevice.IOTune.WriteBytesSecMax,
+			TotalIOPSSecMax:  diskDevice.IOTune.TotalIOPSSecMax,
+			ReadIOPSSecMax:   diskDevice.IOTune.ReadIOPSSecMax,
+			WriteIOPSSecMax:  diskDevice.IOTune.WriteIOPSSecMax,
+		}
+	}
 
 	return nil
 }
@@ -159,6 +191,11 @@ func SetDriverCacheMode(disk *Disk) error {
 		return fmt.Errorf("Unable to use '%s' cache mode, file system where %s is stored does not support direct I/O", mode, path)
 	}
 
+	// native I/O needs direct I/O, so don't fall back to the host cache
+	if v1.DriverIO(disk.Driver.IO) == v1.IONative && !supportDirectIO {
+		return fmt.Errorf("Unable to use '%s' I/O mode, file system where %s is stored does not support direct I/O", disk.Driver.IO, path)
+	}
+
 	// if user did not set a cache mode and fs supports direct I/O then set cache = 'none'
 	// else set cache = 'writethrough
 	if mode == "" && supportDirectIO {
@@ -177,19 +214,41 @@ func makeDeviceName(bus string, devicePerBus map[string]int) string {
 	index := devicePerBus[bus]
 	devicePerBus[bus] += 1
 
-	prefix := ""
+	prefix := getDeviceNamePrefix(bus)
+	if prefix == "" {
+		return ""
+	}
+	return formatDeviceName(prefix, index)
+}
+
+// MakeFreeDeviceName returns the first device name on the bus which is not in use yet.
+// Hotplugged disks can not rely on the order of the disks in the spec, since other
+// disks may have been unplugged in the meantime.
+func MakeFreeDeviceName(bus string, usedDeviceNames map[string]bool) string {
+	prefix := getDeviceNamePrefix(bus)
+	if prefix == "" {
+		return ""
+	}
+	for index := 0; ; index++ {
+		name := formatDeviceName(prefix, index)
+		if !usedDeviceNames[name] {
+			return name
+		}
+	}
+}
+
+func getDeviceNamePrefix(bus string) string {
 	switch bus {
 	case "virtio":
-		prefix = "vd"
+		return "vd"
 	case "sata", "scsi":
-		prefix = "sd"
+		return "sd"
 	case "fdc":
-		prefix = "fd"
+		return "fd"
 	default:
 		log.Log.Errorf("Unrecognized bus '%s'", bus)
 		return ""
 	}
-	return formatDeviceName(prefix, index)
 }
 
 // port of http://elixir.free-electrons.com/linux/v4.15/source/drivers/scsi/sd.c#L3211
@@ -226,6 +285,10 @@ func Add_Agent_To_api_Channel() (channel Channel) {
 
 func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *Disk, c *ConverterContext, diskIndex int) error {
 
+	if c.HotplugVolumes[source.Name] {
+		return Convert_v1_Hotplug_Volume_To_api_Disk(source.Name, disk, c)
+	}
+
 	if source.ContainerDisk != nil {
 		return Convert_v1_ContainerDiskSource_To_api_Disk(source.Name, source.ContainerDisk, disk, c, diskIndex)
 	}
@@ -265,6 +328,35 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *Disk, c *ConverterCo
 	return fmt.Errorf("disk %s references an unsupported source", disk.Alias.Name)
 }
 
+func Convert_v1_Filesystem_To_api_FilesystemDevice(filesystem *v1.Filesystem, volume *v1.Volume, fs *FilesystemDevice, c *ConverterContext) error {
+	if filesystem.Virtiofs == nil {
+		return fmt.Errorf("filesystem %s has no supported type", filesystem.Name)
+	}
+	if c.IsBlockPVC[volume.Name] {
+		return fmt.Errorf("filesystem %s can't be backed by a block volume", filesystem.Name)
+	}
+	if _, err := virtiofs.SourceDir(volume); err != nil {
+		return err
+	}
+
+	fs.Type = "mount"
+	fs.AccessMode = "passthrough"
+	fs.Driver = &FilesystemDriver{
+		Type:  "virtiofs",
+		Queue: "1024",
+	}
+	// virtiofsd is started by virt-launcher, libvirt only connects QEMU to its socket
+	fs.Source = &FilesystemSource{
+		Socket: virtiofs.SocketPath(volume.Name),
+	}
+	// the target dir is the mount tag in the guest
+	fs.Target = &FilesystemTarget{
+		Dir: filesystem.Name,
+	}
+	fs.Alias = &Alias{Name: filesystem.Name}
+	return nil
+}
+
 func Convert_v1_Config_To_api_Disk(volumeName string, disk *Disk, configType config.Type) error {
 	disk.Type = "file"
 	disk.Driver.Type = "raw"
@@ -308,6 +400,21 @@ func Convert_v1_FilesystemVolumeSource_To_api_Disk(volumeName string, disk *Disk
 	return nil
 }
 
+// Convert_v1_Hotplug_Volume_To_api_Disk points the disk to the image or the block device which virt-handler
+// made available in the pod
+func Convert_v1_Hotplug_Volume_To_api_Disk(volumeName string, disk *Disk, c *ConverterContext) error {
+	if c != nil && c.IsBlockPVC[volumeName] {
+		disk.Type = "block"
+		disk.Driver.Type = "raw"
+		disk.Source.Dev = hotplugdisk.GenerateDiskTargetPathFromLauncherView(volumeName)
+		return nil
+	}
+	disk.Type = "file"
+	disk.Driver.Type = "raw"
+	disk.Source.File = hotplugdisk.GenerateDiskTargetPathFromLauncherView(volumeName)
+	return nil
+}
+
 func Convert_v1_BlockVolumeSource_To_api_Disk(volumeName string, disk *Disk, c *ConverterContext) error {
 	disk.Type = "block"
 	disk.Driver.Type = "raw"
@@ -432,6 +539,27 @@ func Convert_v1_Rng_To_api_Rng(source *v1.Rng, rng *Rng, _ *ConverterContext) er
 	return nil
 }
 
+func Convert_v1_Ballooning_To_api_Ballooning(source *v1.Ballooning, ballooning *Ballooning, _ *ConverterContext) error {
+	if source.Enabled != nil && !*source.Enabled {
+		ballooning.Model = "none"
+		return nil
+	}
+
+	ballooning.Model = source.Model
+	if ballooning.Model == "" {
+		ballooning.Model = "virtio"
+	}
+
+	statsPeriod := DefaultBalloonStatsPeriod
+	if source.StatsPeriod != nil {
+		statsPeriod = *source.StatsPeriod
+	}
+	if statsPeriod > 0 {
+		ballooning.Stats = &BalloonStats{Period: uint(statsPeriod)}
+	}
+	return nil
+}
+
 func Convert_v1_Input_To_api_InputDevice(input *v1.Input, inputDevice *Input, _ *ConverterContext) error {
 	if input.Bus != "virtio" && input.Bus != "usb" && input.Bus != "" {
 		return fmt.Errorf("input contains unsupported bus %s", input.Bus)
@@ -747,6 +875,19 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		return err
 	}
 
+	// Leave room for hotplugging memory up to the maximum guest memory
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+		maxMemory, err := QuantityToByte(*vmi.Spec.Domain.Memory.MaxGuest)
+		if err != nil {
+			return err
+		}
+		domain.Spec.MaxMemory = &MaxMemory{
+			Value: maxMemory.Value,
+			Unit:  maxMemory.Unit,
+			Slots: MaxMemorySlots,
+		}
+	}
+
 	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
 		domain.Spec.MemoryBacking = &MemoryBacking{
 			HugePages: &HugePages{},
@@ -865,6 +1006,30 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
 	}
 
+	for _, filesystem := range vmi.Spec.Domain.Devices.Filesystems {
+		volume := volumes[filesystem.Name]
+		if volume == nil {
+			return fmt.Errorf("No matching volume with name %s found", filesystem.Name)
+		}
+		newFilesystem := FilesystemDevice{}
+		err := Convert_v1_Filesystem_To_api_FilesystemDevice(&filesystem, volume, &newFilesystem, c)
+		if err != nil {
+			return err
+		}
+		domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, newFilesystem)
+	}
+
+	if len(domain.Spec.Devices.Filesystems) > 0 {
+		// virtiofsd has to access the guest memory
+		if domain.Spec.MemoryBacking == nil {
+			domain.Spec.MemoryBacking = &MemoryBacking{}
+		}
+		if domain.Spec.MemoryBacking.HugePages == nil {
+			domain.Spec.MemoryBacking.Source = &MemoryBackingSource{Type: "memfd"}
+		}
+		domain.Spec.MemoryBacking.Access = &MemoryBackingAccess{Mode: "shared"}
+	}
+
 	if vmi.Spec.Domain.Devices.Watchdog != nil {
 		newWatchdog := &Watchdog{}
 		err := Convert_v1_Watchdog_To_api_Watchdog(vmi.Spec.Domain.Devices.Watchdog, newWatchdog, c)
@@ -883,6 +1048,15 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		domain.Spec.Devices.Rng = newRng
 	}
 
+	if vmi.Spec.Domain.Devices.Ballooning != nil {
+		newBallooning := &Ballooning{}
+		err := Convert_v1_Ballooning_To_api_Ballooning(vmi.Spec.Domain.Devices.Ballooning, newBallooning, c)
+		if err != nil {
+			return err
+		}
+		domain.Spec.Devices.Ballooning = newBallooning
+	}
+
 	//usb controller is turned on, only when user specify input device with usb bus,
 	//otherwise it is turned off
 	if usbDeviceExists := isUSBDevicePresent(vmi); !usbDeviceExists {
@@ -940,6 +1114,28 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		CPUs:      calculateRequestedVCPUs(domain.Spec.CPU.Topology),
 	}
 
+	// Define the maximum sockets in the topology and only enable the requested ones,
+	// so that further sockets can be plugged into the running domain
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.MaxSockets > 0 {
+		domain.Spec.VCPU.Current = domain.Spec.VCPU.CPUs
+		domain.Spec.CPU.Topology.Sockets = vmi.Spec.Domain.CPU.MaxSockets
+		domain.Spec.VCPU.CPUs = calculateRequestedVCPUs(domain.Spec.CPU.Topology)
+	}
+
+	// Memory hotplug requires a NUMA topology, the DIMMs get plugged into its only cell
+	if domain.Spec.MaxMemory != nil {
+		domain.Spec.CPU.NUMA = &NUMA{
+			Cells: []NUMACell{
+				{
+					ID:     "0",
+					CPUs:   fmt.Sprintf("0-%d", domain.Spec.VCPU.CPUs-1),
+					Memory: domain.Spec.Memory.Value,
+					Unit:   domain.Spec.Memory.Unit,
+				},
+			},
+		}
+	}
+
 	if vmi.Spec.Domain.CPU != nil {
 		// Set VM CPU model and vendor
 		if vmi.Spec.Domain.CPU.Model != "" {
@@ -974,6 +1170,12 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 				}
 
 			}
+			if vmi.IsNUMAPassthrough() {
+				if err := formatDomainNUMA(vmi, domain, c); err != nil {
+					log.Log.Reason(err).Error("failed to format the guest NUMA topology.")
+					return err
+				}
+			}
 		}
 	}
 
@@ -1269,6 +1471,31 @@ func calculateRequestedVCPUs(cpuTopology *CPUTopology) uint32 {
 	return cpuTopology.Cores * cpuTopology.Sockets * cpuTopology.Threads
 }
 
+// GetRequestedVCPUs returns the number of vCPUs which should be enabled for the VirtualMachineInstance
+func GetRequestedVCPUs(vmi *v1.VirtualMachineInstance) uint32 {
+	return calculateRequestedVCPUs(getCPUTopology(vmi))
+}
+
+// GetDomainVCPUs returns the number of vCPUs which are enabled in the domain
+func GetDomainVCPUs(spec *DomainSpec) uint32 {
+	if spec.VCPU == nil {
+		return 0
+	}
+	if spec.VCPU.Current > 0 {
+		return spec.VCPU.Current
+	}
+	return spec.VCPU.CPUs
+}
+
+// GetRequestedMemory returns the amount of memory in bytes which should be visible inside the guest
+func GetRequestedMemory(vmi *v1.VirtualMachineInstance) (uint64, error) {
+	memory, err := QuantityToByte(*getVirtualMemory(vmi))
+	if err != nil {
+		return 0, err
+	}
+	return memory.Value, nil
+}
+
 func formatDomainCPUTune(vmi *v1.VirtualMachineInstance, domain *Domain, c *ConverterContext) error {
 	if len(c.CPUSet) == 0 {
 		return fmt.Errorf("failed for get pods pinned cpus")
@@ -1285,6 +1512,93 @@ func formatDomainCPUTune(vmi *v1.VirtualMachineInstance, domain *Domain, c *Conv
 	return nil
 }
 
+// formatDomainNUMA creates one guest NUMA cell per host NUMA node of the pinned vCPUs.
+// The memory of each cell is backed by hugepages of the host NUMA node it mirrors.
+func formatDomainNUMA(vmi *v1.VirtualMachineInstance, domain *Domain, c *ConverterContext) error {
+	vcpus := int(calculateRequestedVCPUs(domain.Spec.CPU.Topology))
+	if len(c.CPUSet) < vcpus {
+		return fmt.Errorf("failed for get pods pinned cpus")
+	}
+
+	vcpusPerHostNode := map[int][]string{}
+	hostNodes := []int{}
+	for idx := 0; idx < vcpus; idx++ {
+		hostNode, exists := c.CPUNUMANodes[c.CPUSet[idx]]
+		if !exists {
+			return fmt.Errorf("failed to find the NUMA node of cpu %d", c.CPUSet[idx])
+		}
+		if _, exists := vcpusPerHostNode[hostNode]; !exists {
+			hostNodes = append(hostNodes, hostNode)
+		}
+		vcpusPerHostNode[hostNode] = append(vcpusPerHostNode[hostNode], strconv.Itoa(idx))
+	}
+	sort.Ints(hostNodes)
+
+	if domain.Spec.MemoryBacking == nil || vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil {
+		return fmt.Errorf("guest NUMA cells have to be backed by hugepages")
+	}
+	pageSize, err := resource.ParseQuantity(vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		return fmt.Errorf("failed to parse the hugepage size: %v", err)
+	}
+	pageBytes := uint64(pageSize.Value())
+	memoryBytes, err := MemoryToBytes(domain.Spec.Memory)
+	if err != nil {
+		return err
+	}
+	if pageBytes == 0 || memoryBytes%pageBytes != 0 {
+		return fmt.Errorf("guest memory of %d bytes is not a multiple of the hugepage size %s", memoryBytes, vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	}
+	pages := memoryBytes / pageBytes
+
+	numa := &NUMA{}
+	numaTune := &NUMATune{
+		Memory: NUMATuneMemory{
+			Mode: "strict",
+		},
+	}
+	hostNodeSet := []string{}
+	cellSet := []string{}
+	assignedPages := uint64(0)
+	for i, hostNode := range hostNodes {
+		// Split the memory between the cells like the vCPUs, the last cell gets the remaining pages
+		cellPages := pages * uint64(len(vcpusPerHostNode[hostNode])) / uint64(vcpus)
+		if i == len(hostNodes)-1 {
+			cellPages = pages - assignedPages
+		}
+		if cellPages == 0 {
+			return fmt.Errorf("not enough hugepages to back the memory of %d guest NUMA cells", len(hostNodes))
+		}
+		assignedPages += cellPages
+
+		numa.Cells = append(numa.Cells, NUMACell{
+			ID:     strconv.Itoa(i),
+			CPUs:   strings.Join(vcpusPerHostNode[hostNode], ","),
+			Memory: cellPages * pageBytes / 1024,
+			Unit:   "KiB",
+		})
+		numaTune.MemNodes = append(numaTune.MemNodes, MemNode{
+			CellID:  uint32(i),
+			Mode:    "strict",
+			NodeSet: strconv.Itoa(hostNode),
+		})
+		hostNodeSet = append(hostNodeSet, strconv.Itoa(hostNode))
+		cellSet = append(cellSet, strconv.Itoa(i))
+	}
+	numaTune.Memory.NodeSet = strings.Join(hostNodeSet, ",")
+
+	domain.Spec.CPU.NUMA = numa
+	domain.Spec.NUMATune = numaTune
+	domain.Spec.MemoryBacking.HugePages.HugePage = []HugePage{
+		{
+			Size:    strconv.FormatUint(pageBytes/1024, 10),
+			Unit:    "KiB",
+			NodeSet: strings.Join(cellSet, ","),
+		},
+	}
+	return nil
+}
+
 func appendDomainIOThreadPin(domain *Domain, thread uint, cpuset string) {
 	iothreadPin := CPUTuneIOThreadPin{}
 	iothreadPin.IOThread = thread
@@ -1421,6 +1735,31 @@ func QuantityToByte(quantity resource.Quantity) (Memory, error) {
 	}, nil
 }
 
+// MemoryToBytes converts a libvirt memory size with its unit into bytes
+func MemoryToBytes(memory Memory) (uint64, error) {
+	switch memory.Unit {
+	case "", "b", "B", "bytes":
+		return memory.Value, nil
+	case "KB":
+		return memory.Value * 1000, nil
+	case "k", "KiB":
+		return memory.Value << 10, nil
+	case "MB":
+		return memory.Value * 1000 * 1000, nil
+	case "M", "MiB":
+		return memory.Value << 20, nil
+	case "GB":
+		return memory.Value * 1000 * 1000 * 1000, nil
+	case "G", "GiB":
+		return memory.Value << 30, nil
+	case "TB":
+		return memory.Value * 1000 * 1000 * 1000 * 1000, nil
+	case "T", "TiB":
+		return memory.Value << 40, nil
+	}
+	return 0, fmt.Errorf("unknown memory unit %s", memory.Unit)
+}
+
 func QuantityToMebiByte(quantity resource.Quantity) (uint64, error) {
 	q := int64(float64(0.953674) * float64(quantity.ScaledValue(resource.Mega)))
 	if q < 0 {
@@ -1474,6 +1813,15 @@ func GetResolvConfDetailsFromPod() ([][]byte, []string, error) {
 	return nameservers, searchDomains, err
 }
 
+// GetIpv6NameserversFromPod returns the IPv6 nameservers the pod is configured with
+func GetIpv6NameserversFromPod() ([][]byte, error) {
+	b, err := ioutil.ReadFile(resolvConf)
+	if err != nil {
+		return nil, err
+	}
+	return dns.ParseIpv6Nameservers(string(b))
+}
+
 func decoratePciAddressField(addressField string) (*Address, error) {
 	dbsfFields, err := util.ParsePciAddress(addressField)
 	if err != nil {
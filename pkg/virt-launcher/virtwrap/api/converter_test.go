@@ -82,6 +82,104 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(convertedDisk))
 		})
 
+		It("Should set the driver error policy, I/O mode, discard and zero detection when provided", func() {
+			kubevirtDisk := &v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: "virtio",
+					},
+				},
+				IO:           v1.IONative,
+				ErrorPolicy:  v1.DiskErrorPolicyStop,
+				Discard:      v1.DiscardUnmap,
+				DetectZeroes: v1.DetectZeroesUnmap,
+			}
+			var convertedDisk = `<Disk device="disk" type="">
+  <source></source>
+  <target bus="virtio" dev="vda"></target>
+  <driver error_policy="stop" io="native" discard="unmap" detect_zeroes="unmap" name="qemu" type=""></driver>
+  <alias name="ua-mydisk"></alias>
+</Disk>`
+			xml := diskToDiskXML(kubevirtDisk)
+			Expect(xml).To(Equal(convertedDisk))
+		})
+
+		It("Should add the I/O limits when provided", func() {
+			kubevirtDisk := &v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: "virtio",
+					},
+				},
+				IOTune: &v1.DiskIOTune{
+					TotalBytesSec:    1048576,
+					TotalBytesSecMax: 2097152,
+					ReadIOPSSec:      100,
+					WriteIOPSSec:     50,
+				},
+			}
+			var convertedDisk = `<Disk device="disk" type="">
+  <source></source>
+  <target bus="virtio" dev="vda"></target>
+  <driver name="qemu" type=""></driver>
+  <alias name="ua-mydisk"></alias>
+  <iotune>
+    <total_bytes_sec>1048576</total_bytes_sec>
+    <read_iops_sec>100</read_iops_sec>
+    <write_iops_sec>50</write_iops_sec>
+    <total_bytes_sec_max>2097152</total_bytes_sec_max>
+  </iotune>
+</Disk>`
+			xml := diskToDiskXML(kubevirtDisk)
+			Expect(xml).To(Equal(convertedDisk))
+		})
+
+		It("Should point hotplugged volumes to the image mounted by virt-handler", func() {
+			volume := &v1.Volume{
+				Name: "hpvolume",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hppvc"},
+				},
+			}
+			disk := &Disk{Driver: &DiskDriver{}}
+			c := &ConverterContext{HotplugVolumes: map[string]bool{"hpvolume": true}}
+			Expect(Convert_v1_Volume_To_api_Disk(volume, disk, c, 0)).To(Succeed())
+			Expect(disk.Type).To(Equal("file"))
+			Expect(disk.Source.File).To(Equal("/var/run/kubevirt/hotplug-disks/hpvolume.img"))
+		})
+
+		It("Should point hotplugged block volumes to the device created by virt-handler", func() {
+			volume := &v1.Volume{
+				Name: "hpvolume",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hppvc"},
+				},
+			}
+			disk := &Disk{Driver: &DiskDriver{}}
+			c := &ConverterContext{
+				HotplugVolumes: map[string]bool{"hpvolume": true},
+				IsBlockPVC:     map[string]bool{"hpvolume": true},
+			}
+			Expect(Convert_v1_Volume_To_api_Disk(volume, disk, c, 0)).To(Succeed())
+			Expect(disk.Type).To(Equal("block"))
+			Expect(disk.Source.File).To(BeEmpty())
+			Expect(disk.Source.Dev).To(Equal("/var/run/kubevirt/hotplug-disks/hpvolume.img"))
+		})
+
+		table.DescribeTable("Should find the first free device name", func(bus string, used []string, expected string) {
+			usedDeviceNames := map[string]bool{}
+			for _, name := range used {
+				usedDeviceNames[name] = true
+			}
+			Expect(MakeFreeDeviceName(bus, usedDeviceNames)).To(Equal(expected))
+		},
+			table.Entry("on an empty bus", "scsi", []string{"vda"}, "sda"),
+			table.Entry("after the used names", "scsi", []string{"sda", "sdb"}, "sdc"),
+			table.Entry("in a gap", "scsi", []string{"sda", "sdc"}, "sdb"),
+			table.Entry("for an unknown bus", "ide", []string{}, ""),
+		)
 	})
 
 	Context("with v1.VirtualMachineInstance", func() {
@@ -704,6 +802,22 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(3)), "Expect vcpus")
 			})
 
+			It("should define the maximum sockets and enable only the requested ones", func() {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Sockets:    2,
+					MaxSockets: 4,
+					Cores:      2,
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.CPU.Topology.Cores).To(Equal(uint32(2)), "Expect cores")
+				Expect(domainSpec.CPU.Topology.Sockets).To(Equal(uint32(4)), "Expect sockets")
+				Expect(domainSpec.CPU.Topology.Threads).To(Equal(uint32(1)), "Expect threads")
+				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(8)), "Expect vcpus")
+				Expect(domainSpec.VCPU.Current).To(Equal(uint32(4)), "Expect current vcpus")
+			})
+
 			table.DescribeTable("should convert CPU model", func(model string) {
 				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 				vmi.Spec.Domain.CPU = &v1.CPU{
@@ -903,6 +1017,28 @@ var _ = Describe("Converter", func() {
 			Expect(domainSpec.Memory.Unit).To(Equal("B"))
 		})
 
+		It("should define the maximum memory and a NUMA cell if the guest memory can be increased", func() {
+			guestMemory := resource.MustParse("1Gi")
+			maxGuestMemory := resource.MustParse("4Gi")
+			vmi.Spec.Domain.Memory = &v1.Memory{
+				Guest:    &guestMemory,
+				MaxGuest: &maxGuestMemory,
+			}
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Sockets:    1,
+				MaxSockets: 2,
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+			Expect(domainSpec.Memory.Value).To(Equal(uint64(1073741824)))
+			Expect(domainSpec.MaxMemory).To(Equal(&MaxMemory{Value: 4294967296, Unit: "B", Slots: MaxMemorySlots}))
+			Expect(domainSpec.CPU.NUMA.Cells).To(Equal([]NUMACell{
+				{ID: "0", CPUs: "0-1", Memory: 1073741824, Unit: "B"},
+			}))
+		})
+
 		It("should not add RNG when not present", func() {
 			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
 			Expect(domainSpec.Devices.Rng).To(BeNil())
@@ -1559,6 +1695,151 @@ var _ = Describe("Converter", func() {
 			Expect(isExpectedThreadsLayout).To(BeTrue())
 		})
 	})
+	Context("with memory ballooning", func() {
+		_true := true
+		_false := false
+		statsPeriod := uint32(5)
+		noStats := uint32(0)
+
+		table.DescribeTable("should configure the balloon device", func(ballooning *v1.Ballooning, expected *Ballooning) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Ballooning = ballooning
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(domain.Spec.Devices.Ballooning).To(Equal(expected))
+		},
+			table.Entry("and disable it by default", nil, &Ballooning{Model: "none"}),
+			table.Entry("and enable it with the default stats period",
+				&v1.Ballooning{}, &Ballooning{Model: "virtio", Stats: &BalloonStats{Period: 10}}),
+			table.Entry("and enable it explicitly with a custom stats period",
+				&v1.Ballooning{Enabled: &_true, Model: "virtio", StatsPeriod: &statsPeriod}, &Ballooning{Model: "virtio", Stats: &BalloonStats{Period: 5}}),
+			table.Entry("and enable it without stats",
+				&v1.Ballooning{StatsPeriod: &noStats}, &Ballooning{Model: "virtio"}),
+			table.Entry("and disable it explicitly",
+				&v1.Ballooning{Enabled: &_false, StatsPeriod: &statsPeriod}, &Ballooning{Model: "none"}),
+		)
+	})
+
+	Context("with virtio-fs filesystems", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "config", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "config",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: k8sv1.LocalObjectReference{Name: "test-config"},
+						},
+					},
+				},
+			}
+		})
+
+		It("should connect the filesystem to the virtiofsd socket and share the guest memory", func() {
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(domain.Spec.Devices.Disks).To(BeEmpty())
+			Expect(domain.Spec.Devices.Filesystems).To(Equal([]FilesystemDevice{
+				{
+					Type:       "mount",
+					AccessMode: "passthrough",
+					Driver:     &FilesystemDriver{Type: "virtiofs", Queue: "1024"},
+					Source:     &FilesystemSource{Socket: "/var/run/kubevirt-private/virtiofs/config.sock"},
+					Target:     &FilesystemTarget{Dir: "config"},
+					Alias:      &Alias{Name: "config"},
+				},
+			}))
+			Expect(domain.Spec.MemoryBacking).To(Equal(&MemoryBacking{
+				Source: &MemoryBackingSource{Type: "memfd"},
+				Access: &MemoryBackingAccess{Mode: "shared"},
+			}))
+		})
+
+		It("should keep hugepages as shared memory source", func() {
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(domain.Spec.MemoryBacking.HugePages).ToNot(BeNil())
+			Expect(domain.Spec.MemoryBacking.Source).To(BeNil())
+			Expect(domain.Spec.MemoryBacking.Access).To(Equal(&MemoryBackingAccess{Mode: "shared"}))
+		})
+
+		It("should reject filesystems backed by block volumes", func() {
+			vmi.Spec.Volumes[0].VolumeSource = v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "block-pvc"},
+			}
+			c := &ConverterContext{UseEmulation: true, IsBlockPVC: map[string]bool{"config": true}}
+			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, &Domain{}, c)).ToNot(Succeed())
+		})
+	})
+
+	Context("with guest NUMA passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{
+							Cores:                 4,
+							DedicatedCPUPlacement: true,
+							NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+						},
+						Memory: &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse("6Mi"),
+							},
+						},
+					},
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		It("should mirror the host NUMA nodes of the pinned cpus", func() {
+			c := &ConverterContext{
+				CPUSet:       []int{2, 3, 10, 11},
+				CPUNUMANodes: map[int]int{2: 0, 3: 0, 10: 1, 11: 0},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+			}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]NUMACell{
+				{ID: "0", CPUs: "0,1,3", Memory: 4096, Unit: "KiB"},
+				{ID: "1", CPUs: "2", Memory: 2048, Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune).To(Equal(&NUMATune{
+				Memory: NUMATuneMemory{Mode: "strict", NodeSet: "0,1"},
+				MemNodes: []MemNode{
+					{CellID: 0, Mode: "strict", NodeSet: "0"},
+					{CellID: 1, Mode: "strict", NodeSet: "1"},
+				},
+			}))
+			Expect(domain.Spec.MemoryBacking.HugePages.HugePage).To(Equal([]HugePage{
+				{Size: "2048", Unit: "KiB", NodeSet: "0,1"},
+			}))
+		})
+
+		It("should fail if a pinned cpu has no known NUMA node", func() {
+			c := &ConverterContext{
+				CPUSet:       []int{2, 3, 10, 11},
+				CPUNUMANodes: map[int]int{2: 0, 3: 0},
+				UseEmulation: true,
+				SMBios:       &cmdv1.SMBios{},
+			}
+			domain := &Domain{}
+			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+		})
+	})
 	Context("virtio-net multi-queue", func() {
 		var vmi *v1.VirtualMachineInstance
 
@@ -22,6 +22,8 @@ package api
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -138,9 +140,34 @@ func (in *BackingStoreFormat) DeepCopy() *BackingStoreFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalloonStats) DeepCopyInto(out *BalloonStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalloonStats.
+func (in *BalloonStats) DeepCopy() *BalloonStats {
+	if in == nil {
+		return nil
+	}
+	out := new(BalloonStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ballooning) DeepCopyInto(out *Ballooning) {
 	*out = *in
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BalloonStats)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -244,6 +271,15 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 			**out = **in
 		}
 	}
+	if in.NUMA != nil {
+		in, out := &in.NUMA, &out.NUMA
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(NUMA)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -654,7 +690,7 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			*out = nil
 		} else {
 			*out = new(Ballooning)
-			**out = **in
+			(*in).DeepCopyInto(*out)
 		}
 	}
 	if in.Disks != nil {
@@ -664,6 +700,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]FilesystemDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Inputs != nil {
 		in, out := &in.Inputs, &out.Inputs
 		*out = make([]Input, len(*in))
@@ -703,6 +746,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]MemoryDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -784,6 +834,15 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 			**out = **in
 		}
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(IOTune)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -856,6 +915,22 @@ func (in *DiskDriver) DeepCopy() *DiskDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskError) DeepCopyInto(out *DiskError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskError.
+func (in *DiskError) DeepCopy() *DiskError {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSecret) DeepCopyInto(out *DiskSecret) {
 	*out = *in
@@ -995,6 +1070,15 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	*out = *in
 	out.XMLName = in.XMLName
 	out.Memory = in.Memory
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaxMemory)
+			**out = **in
+		}
+	}
 	if in.MemoryBacking != nil {
 		in, out := &in.MemoryBacking, &out.MemoryBacking
 		if *in == nil {
@@ -1071,6 +1155,15 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.NUMATune != nil {
+		in, out := &in.NUMATune, &out.NUMATune
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(NUMATune)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.IOThreads != nil {
 		in, out := &in.IOThreads, &out.IOThreads
 		if *in == nil {
@@ -1103,6 +1196,20 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MigrationProgress != nil {
+		in, out := &in.MigrationProgress, &out.MigrationProgress
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.VirtualMachineInstanceMigrationProgress)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.DiskErrors != nil {
+		in, out := &in.DiskErrors, &out.DiskErrors
+		*out = make([]DiskError, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1415,6 +1522,106 @@ func (in *Features) DeepCopy() *Features {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemDevice) DeepCopyInto(out *FilesystemDevice) {
+	*out = *in
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(FilesystemDriver)
+			**out = **in
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(FilesystemSource)
+			**out = **in
+		}
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(FilesystemTarget)
+			**out = **in
+		}
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Alias)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemDevice.
+func (in *FilesystemDevice) DeepCopy() *FilesystemDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemDriver) DeepCopyInto(out *FilesystemDriver) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemDriver.
+func (in *FilesystemDriver) DeepCopy() *FilesystemDriver {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemSource) DeepCopyInto(out *FilesystemSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemSource.
+func (in *FilesystemSource) DeepCopy() *FilesystemSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemTarget) DeepCopyInto(out *FilesystemTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemTarget.
+func (in *FilesystemTarget) DeepCopy() *FilesystemTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterRef) DeepCopyInto(out *FilterRef) {
 	*out = *in
@@ -1600,6 +1807,22 @@ func (in *IOThreads) DeepCopy() *IOThreads {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOTune) DeepCopyInto(out *IOTune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOTune.
+func (in *IOTune) DeepCopy() *IOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(IOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1926,6 +2149,38 @@ func (in *MTU) DeepCopy() *MTU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxMemory) DeepCopyInto(out *MaxMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxMemory.
+func (in *MaxMemory) DeepCopy() *MaxMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemNode) DeepCopyInto(out *MemNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemNode.
+func (in *MemNode) DeepCopy() *MemNode {
+	if in == nil {
+		return nil
+	}
+	out := new(MemNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Memory) DeepCopyInto(out *Memory) {
 	*out = *in
@@ -1954,6 +2209,24 @@ func (in *MemoryBacking) DeepCopyInto(out *MemoryBacking) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MemoryBackingSource)
+			**out = **in
+		}
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MemoryBackingAccess)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -1967,6 +2240,89 @@ func (in *MemoryBacking) DeepCopy() *MemoryBacking {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryBackingAccess) DeepCopyInto(out *MemoryBackingAccess) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryBackingAccess.
+func (in *MemoryBackingAccess) DeepCopy() *MemoryBackingAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryBackingAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryBackingSource) DeepCopyInto(out *MemoryBackingSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryBackingSource.
+func (in *MemoryBackingSource) DeepCopy() *MemoryBackingSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryBackingSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryDevice) DeepCopyInto(out *MemoryDevice) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MemoryTarget)
+			**out = **in
+		}
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Alias)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDevice.
+func (in *MemoryDevice) DeepCopy() *MemoryDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryTarget) DeepCopyInto(out *MemoryTarget) {
+	*out = *in
+	out.Size = in.Size
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryTarget.
+func (in *MemoryTarget) DeepCopy() *MemoryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -2032,6 +2388,81 @@ func (in *Model) DeepCopy() *Model {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMA) DeepCopyInto(out *NUMA) {
+	*out = *in
+	if in.Cells != nil {
+		in, out := &in.Cells, &out.Cells
+		*out = make([]NUMACell, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMA.
+func (in *NUMA) DeepCopy() *NUMA {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMACell) DeepCopyInto(out *NUMACell) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMACell.
+func (in *NUMACell) DeepCopy() *NUMACell {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMACell)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMATune) DeepCopyInto(out *NUMATune) {
+	*out = *in
+	out.Memory = in.Memory
+	if in.MemNodes != nil {
+		in, out := &in.MemNodes, &out.MemNodes
+		*out = make([]MemNode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMATune.
+func (in *NUMATune) DeepCopy() *NUMATune {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMATune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMATuneMemory) DeepCopyInto(out *NUMATuneMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMATuneMemory.
+func (in *NUMATuneMemory) DeepCopy() *NUMATuneMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMATuneMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NVRam) DeepCopyInto(out *NVRam) {
 	*out = *in
@@ -4,13 +4,16 @@ const (
 	resolvConf        = "/etc/resolv.conf"
 	DefaultProtocol   = "TCP"
 	DefaultVMCIDR     = "10.0.2.0/24"
+	DefaultVMIpv6CIDR = "fd10:0:2::/120"
 	DefaultBridgeName = "k6t-eth0"
 )
 
 func SetDefaults_Devices(devices *Devices) {
 	// Set default memballoon, "none" means that controller disabled
-	devices.Ballooning = &Ballooning{
-		Model: "none",
+	if devices.Ballooning == nil {
+		devices.Ballooning = &Ballooning{
+			Model: "none",
+		}
 	}
 
 }
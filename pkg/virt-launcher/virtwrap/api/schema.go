@@ -103,9 +103,18 @@ type Domain struct {
 }
 
 type DomainStatus struct {
-	Status     LifeCycle
-	Reason     StateChangeReason
-	Interfaces []InterfaceStatus
+	Status            LifeCycle
+	Reason            StateChangeReason
+	Interfaces        []InterfaceStatus
+	MigrationProgress *v1.VirtualMachineInstanceMigrationProgress
+	DiskErrors        []DiskError
+}
+
+// DiskError is an I/O error which libvirt reported for a disk
+type DiskError struct {
+	// Device is the target device of the disk, like vda
+	Device string
+	Error  string
 }
 
 type InterfaceStatus struct {
@@ -133,6 +142,7 @@ type DomainSpec struct {
 	Name          string         `xml:"name"`
 	UUID          string         `xml:"uuid,omitempty"`
 	Memory        Memory         `xml:"memory"`
+	MaxMemory     *MaxMemory     `xml:"maxMemory,omitempty"`
 	MemoryBacking *MemoryBacking `xml:"memoryBacking,omitempty"`
 	OS            OS             `xml:"os"`
 	SysInfo       *SysInfo       `xml:"sysinfo,omitempty"`
@@ -145,6 +155,7 @@ type DomainSpec struct {
 	CPU           CPU            `xml:"cpu"`
 	VCPU          *VCPU          `xml:"vcpu"`
 	CPUTune       *CPUTune       `xml:"cputune"`
+	NUMATune      *NUMATune      `xml:"numatune,omitempty"`
 	IOThreads     *IOThreads     `xml:"iothreads,omitempty"`
 }
 
@@ -163,8 +174,26 @@ type CPUTuneIOThreadPin struct {
 	CPUSet   string `xml:"cpuset,attr"`
 }
 
+// NUMATune mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsNUMATuning
+type NUMATune struct {
+	Memory   NUMATuneMemory `xml:"memory"`
+	MemNodes []MemNode      `xml:"memnode"`
+}
+
+type NUMATuneMemory struct {
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
+type MemNode struct {
+	CellID  uint32 `xml:"cellid,attr"`
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
 type VCPU struct {
 	Placement string `xml:"placement,attr"`
+	Current   uint32 `xml:"current,attr,omitempty"`
 	CPUs      uint32 `xml:",chardata"`
 }
 
@@ -173,6 +202,19 @@ type CPU struct {
 	Model    string       `xml:"model,omitempty"`
 	Features []CPUFeature `xml:"feature"`
 	Topology *CPUTopology `xml:"topology"`
+	NUMA     *NUMA        `xml:"numa,omitempty"`
+}
+
+// NUMA mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsCPU
+type NUMA struct {
+	Cells []NUMACell `xml:"cell"`
+}
+
+type NUMACell struct {
+	ID     string `xml:"id,attr"`
+	CPUs   string `xml:"cpus,attr"`
+	Memory uint64 `xml:"memory,attr"`
+	Unit   string `xml:"unit,attr,omitempty"`
 }
 
 type CPUFeature struct {
@@ -277,9 +319,28 @@ type Memory struct {
 	Unit  string `xml:"unit,attr"`
 }
 
+// MaxMemory mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsMemoryAllocation
+type MaxMemory struct {
+	Value uint64 `xml:",chardata"`
+	Unit  string `xml:"unit,attr"`
+	Slots uint32 `xml:"slots,attr"`
+}
+
 // MemoryBacking mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsMemoryBacking
 type MemoryBacking struct {
-	HugePages *HugePages `xml:"hugepages,omitempty"`
+	HugePages *HugePages           `xml:"hugepages,omitempty"`
+	Source    *MemoryBackingSource `xml:"source,omitempty"`
+	Access    *MemoryBackingAccess `xml:"access,omitempty"`
+}
+
+// MemoryBackingSource mirroring libvirt XML under memoryBacking
+type MemoryBackingSource struct {
+	Type string `xml:"type,attr"`
+}
+
+// MemoryBackingAccess mirroring libvirt XML under memoryBacking
+type MemoryBackingAccess struct {
+	Mode string `xml:"mode,attr"`
 }
 
 // HugePages mirroring libvirt XML under memoryBacking
@@ -289,25 +350,63 @@ type HugePages struct {
 
 // HugePage mirroring libvirt XML under hugepages
 type HugePage struct {
-	Size string `xml:"size,attr"`
-	Unit string `xml:"unit,attr"`
+	Size    string `xml:"size,attr"`
+	Unit    string `xml:"unit,attr"`
+	NodeSet string `xml:"nodeset,attr,omitempty"`
 }
 
 type Devices struct {
-	Emulator    string       `xml:"emulator,omitempty"`
-	Interfaces  []Interface  `xml:"interface"`
-	Channels    []Channel    `xml:"channel"`
-	HostDevices []HostDevice `xml:"hostdev,omitempty"`
-	Controllers []Controller `xml:"controller,omitempty"`
-	Video       []Video      `xml:"video"`
-	Graphics    []Graphics   `xml:"graphics"`
-	Ballooning  *Ballooning  `xml:"memballoon,omitempty"`
-	Disks       []Disk       `xml:"disk"`
-	Inputs      []Input      `xml:"input"`
-	Serials     []Serial     `xml:"serial"`
-	Consoles    []Console    `xml:"console"`
-	Watchdog    *Watchdog    `xml:"watchdog,omitempty"`
-	Rng         *Rng         `xml:"rng,omitempty"`
+	Emulator    string             `xml:"emulator,omitempty"`
+	Interfaces  []Interface        `xml:"interface"`
+	Channels    []Channel          `xml:"channel"`
+	HostDevices []HostDevice       `xml:"hostdev,omitempty"`
+	Controllers []Controller       `xml:"controller,omitempty"`
+	Video       []Video            `xml:"video"`
+	Graphics    []Graphics         `xml:"graphics"`
+	Ballooning  *Ballooning        `xml:"memballoon,omitempty"`
+	Disks       []Disk             `xml:"disk"`
+	Filesystems []FilesystemDevice `xml:"filesystem,omitempty"`
+	Inputs      []Input            `xml:"input"`
+	Serials     []Serial           `xml:"serial"`
+	Consoles    []Console          `xml:"console"`
+	Watchdog    *Watchdog          `xml:"watchdog,omitempty"`
+	Rng         *Rng               `xml:"rng,omitempty"`
+	Memory      []MemoryDevice     `xml:"memory,omitempty"`
+}
+
+// FilesystemDevice mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsFilesystems
+type FilesystemDevice struct {
+	Type       string            `xml:"type,attr"`
+	AccessMode string            `xml:"accessmode,attr,omitempty"`
+	Driver     *FilesystemDriver `xml:"driver,omitempty"`
+	Source     *FilesystemSource `xml:"source,omitempty"`
+	Target     *FilesystemTarget `xml:"target,omitempty"`
+	Alias      *Alias            `xml:"alias,omitempty"`
+}
+
+type FilesystemDriver struct {
+	Type  string `xml:"type,attr"`
+	Queue string `xml:"queue,attr,omitempty"`
+}
+
+type FilesystemSource struct {
+	Socket string `xml:"socket,attr,omitempty"`
+}
+
+type FilesystemTarget struct {
+	Dir string `xml:"dir,attr"`
+}
+
+// MemoryDevice mirroring libvirt XML under https://libvirt.org/formatdomain.html#elementsMemory
+type MemoryDevice struct {
+	Model  string        `xml:"model,attr"`
+	Target *MemoryTarget `xml:"target"`
+	Alias  *Alias        `xml:"alias,omitempty"`
+}
+
+type MemoryTarget struct {
+	Size Memory `xml:"size"`
+	Node uint32 `xml:"node"`
 }
 
 // Input represents input device, e.g. tablet
@@ -366,6 +465,22 @@ type Disk struct {
 	BackingStore *BackingStore `xml:"backingStore,omitempty"`
 	BootOrder    *BootOrder    `xml:"boot,omitempty"`
 	Address      *Address      `xml:"address,omitempty"`
+	IOTune       *IOTune       `xml:"iotune,omitempty"`
+}
+
+type IOTune struct {
+	TotalBytesSec    int64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec     int64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec    int64 `xml:"write_bytes_sec,omitempty"`
+	TotalIOPSSec     int64 `xml:"total_iops_sec,omitempty"`
+	ReadIOPSSec      int64 `xml:"read_iops_sec,omitempty"`
+	WriteIOPSSec     int64 `xml:"write_iops_sec,omitempty"`
+	TotalBytesSecMax int64 `xml:"total_bytes_sec_max,omitempty"`
+	ReadBytesSecMax  int64 `xml:"read_bytes_sec_max,omitempty"`
+	WriteBytesSecMax int64 `xml:"write_bytes_sec_max,omitempty"`
+	TotalIOPSSecMax  int64 `xml:"total_iops_sec_max,omitempty"`
+	ReadIOPSSecMax   int64 `xml:"read_iops_sec_max,omitempty"`
+	WriteIOPSSecMax  int64 `xml:"write_iops_sec_max,omitempty"`
 }
 
 type DiskAuth struct {
@@ -397,13 +512,15 @@ type DiskTarget struct {
 }
 
 type DiskDriver struct {
-	Cache       string `xml:"cache,attr,omitempty"`
-	ErrorPolicy string `xml:"error_policy,attr,omitempty"`
-	IO          string `xml:"io,attr,omitempty"`
-	Name        string `xml:"name,attr"`
-	Type        string `xml:"type,attr"`
-	IOThread    *uint  `xml:"iothread,attr,omitempty"`
-	Queues      *uint  `xml:"queues,attr,omitempty"`
+	Cache        string `xml:"cache,attr,omitempty"`
+	ErrorPolicy  string `xml:"error_policy,attr,omitempty"`
+	IO           string `xml:"io,attr,omitempty"`
+	Discard      string `xml:"discard,attr,omitempty"`
+	DetectZeroes string `xml:"detect_zeroes,attr,omitempty"`
+	Name         string `xml:"name,attr"`
+	Type         string `xml:"type,attr"`
+	IOThread     *uint  `xml:"iothread,attr,omitempty"`
+	Queues       *uint  `xml:"queues,attr,omitempty"`
 }
 
 type DiskSourceHost struct {
@@ -705,7 +822,12 @@ type Address struct {
 //END Video -------------------
 
 type Ballooning struct {
-	Model string `xml:"model,attr"`
+	Model string        `xml:"model,attr"`
+	Stats *BalloonStats `xml:"stats,omitempty"`
+}
+
+type BalloonStats struct {
+	Period uint `xml:"period,attr"`
 }
 
 type Watchdog struct {
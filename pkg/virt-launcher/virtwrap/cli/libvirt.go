@@ -45,6 +45,7 @@ type Connection interface {
 	DomainDefineXML(xml string) (VirDomain, error)
 	Close() (int, error)
 	DomainEventLifecycleRegister(callback libvirt.DomainEventLifecycleCallback) error
+	DomainEventTrayChangeRegister(callback libvirt.DomainEventTrayChangeCallback) error
 	AgentEventLifecycleRegister(callback libvirt.DomainEventAgentLifecycleCallback) error
 	ListAllDomains(flags libvirt.ConnectListAllDomainsFlags) ([]VirDomain, error)
 	NewStream(flags libvirt.StreamFlags) (Stream, error)
@@ -139,6 +140,16 @@ func (l *LibvirtConnection) DomainEventLifecycleRegister(callback libvirt.Domain
 	return
 }
 
+func (l *LibvirtConnection) DomainEventTrayChangeRegister(callback libvirt.DomainEventTrayChangeCallback) (err error) {
+	if err = l.reconnectIfNecessary(); err != nil {
+		return
+	}
+
+	_, err = l.Connect.DomainEventTrayChangeRegister(nil, callback)
+	l.checkConnectionLost(err)
+	return
+}
+
 func (l *LibvirtConnection) AgentEventLifecycleRegister(callback libvirt.DomainEventAgentLifecycleCallback) (err error) {
 	if err = l.reconnectIfNecessary(); err != nil {
 		return
@@ -327,6 +338,7 @@ type VirDomain interface {
 	GetState() (libvirt.DomainState, int, error)
 	Create() error
 	Resume() error
+	Suspend() error
 	DestroyFlags(flags libvirt.DomainDestroyFlags) error
 	ShutdownFlags(flags libvirt.DomainShutdownFlags) error
 	UndefineFlags(flags libvirt.DomainUndefineFlagsValues) error
@@ -340,6 +352,14 @@ type VirDomain interface {
 	GetJobStats(flags libvirt.DomainGetJobStatsFlags) (*libvirt.DomainJobInfo, error)
 	GetJobInfo() (*libvirt.DomainJobInfo, error)
 	AbortJob() error
+	MigrateStartPostCopy(flags uint32) error
+	AttachDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
+	DetachDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
+	UpdateDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error
+	SetVcpusFlags(vcpu uint, flags libvirt.DomainVcpuFlags) error
+	QemuAgentCommand(command string, timeout libvirt.DomainQemuAgentCommandTimeout, flags uint32) (string, error)
+	GetDiskErrors(flags uint32) ([]libvirt.DomainDiskError, error)
+	BlockResize(disk string, size uint64, flags libvirt.DomainBlockResizeFlags) error
 	Free() error
 }
 
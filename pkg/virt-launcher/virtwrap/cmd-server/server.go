@@ -131,6 +131,166 @@ func (l *Launcher) CancelVirtualMachineMigration(ctx context.Context, request *c
 
 }
 
+func (l *Launcher) PauseVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.PauseVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to pause vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Paused vmi")
+	return response, nil
+}
+
+func (l *Launcher) UnpauseVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UnpauseVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to unpause vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Unpaused vmi")
+	return response, nil
+}
+
+func (l *Launcher) FreezeVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.FreezeVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to freeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Froze vmi")
+	return response, nil
+}
+
+func (l *Launcher) UnfreezeVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UnfreezeVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to unfreeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Unfroze vmi")
+	return response, nil
+}
+
+func (l *Launcher) HotplugDisk(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.HotplugDisk(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to hotplug disks")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Hotplugged disks")
+	return response, nil
+}
+
+func (l *Launcher) UnplugDisk(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UnplugDisk(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to unplug disks")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Unplugged disks")
+	return response, nil
+}
+
+func (l *Launcher) HotplugResources(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.HotplugResources(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to hotplug CPUs and memory")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Hotplugged CPUs and memory")
+	return response, nil
+}
+
+func (l *Launcher) ChangeMedia(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.ChangeMedia(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to change media")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Changed media")
+	return response, nil
+}
+
+func (l *Launcher) ResizeDisk(ctx context.Context, request *cmdv1.ResizeDiskRequest) (*cmdv1.Response, error) {
+	response := &cmdv1.Response{
+		Success: true,
+	}
+
+	if err := l.domainManager.ResizeDisk(request.DomainName, request.DiskName, request.Size); err != nil {
+		log.Log.Reason(err).Errorf("Failed to resize disk %s of domain %s", request.DiskName, request.DomainName)
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Infof("Resized disk %s of domain %s to %d bytes", request.DiskName, request.DomainName, request.Size)
+	return response, nil
+}
+
 func (l *Launcher) SyncMigrationTarget(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -280,6 +440,60 @@ func (l *Launcher) GetDomainStats(ctx context.Context, request *cmdv1.EmptyReque
 	return response, nil
 }
 
+func (l *Launcher) GetGuestInfo(ctx context.Context, request *cmdv1.EmptyRequest) (*cmdv1.GuestInfoResponse, error) {
+
+	response := &cmdv1.GuestInfoResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	if guestInfo := l.domainManager.GetGuestInfo(); guestInfo != nil {
+		if jGuestInfo, err := json.Marshal(guestInfo); err != nil {
+			log.Log.Reason(err).Errorf("Failed to marshal guest info")
+			response.Response.Success = false
+			response.Response.Message = getErrorMessage(err)
+			return response, nil
+		} else {
+			response.GuestInfo = string(jGuestInfo)
+		}
+	}
+
+	return response, nil
+}
+
+func (l *Launcher) GuestPing(ctx context.Context, request *cmdv1.GuestPingRequest) (*cmdv1.Response, error) {
+	response := &cmdv1.Response{
+		Success: true,
+	}
+
+	if err := l.domainManager.GuestPing(request.DomainName, request.TimeoutSeconds); err != nil {
+		log.Log.Reason(err).V(4).Infof("Guest agent of domain %s did not answer the ping", request.DomainName)
+		response.Success = false
+		response.Message = getErrorMessage(err)
+	}
+	return response, nil
+}
+
+func (l *Launcher) Exec(ctx context.Context, request *cmdv1.ExecRequest) (*cmdv1.ExecResponse, error) {
+	response := &cmdv1.ExecResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	exitCode, stdOut, err := l.domainManager.Exec(request.DomainName, request.Command, request.Args, request.TimeoutSeconds)
+	if err != nil {
+		log.Log.Reason(err).V(4).Infof("Failed to run %s in domain %s", request.Command, request.DomainName)
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+		return response, nil
+	}
+	response.ExitCode = int32(exitCode)
+	response.StdOut = stdOut
+	return response, nil
+}
+
 func RunServer(socketPath string,
 	domainManager virtwrap.DomainManager,
 	stopChan chan struct{},
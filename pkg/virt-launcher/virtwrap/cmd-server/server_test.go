@@ -20,6 +20,7 @@
 package cmdserver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -106,6 +107,68 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should pause a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().PauseVMI(vmi)
+			err := client.PauseVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unpause a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnpauseVMI(vmi)
+			err := client.UnpauseVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should freeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FreezeVMI(vmi)
+			err := client.FreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unfreeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnfreezeVMI(vmi)
+			err := client.UnfreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should hotplug disks", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().HotplugDisk(vmi)
+			err := client.HotplugDisk(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unplug disks", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnplugDisk(vmi)
+			err := client.UnplugDisk(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should hotplug CPUs and memory", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().HotplugResources(vmi)
+			err := client.HotplugResources(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should change media", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ChangeMedia(vmi)
+			err := client.ChangeMedia(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should resize disks", func() {
+			domainManager.EXPECT().ResizeDisk("default_testvmi", "disk0", int64(134217728))
+			err := client.ResizeDisk("default_testvmi", "disk0", 134217728)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should list domains", func() {
 			var list []*api.Domain
 			list = append(list, api.NewMinimalDomain("testvmi1"))
@@ -154,6 +217,53 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(domStats.Name).To(Equal(list[0].Name))
 			Expect(domStats.UUID).To(Equal(list[0].UUID))
 		})
+
+		It("should return the guest info", func() {
+			guestInfo := &v1.VirtualMachineInstanceGuestAgentInfo{
+				Hostname: "testhost",
+				OS:       v1.VirtualMachineInstanceGuestOSInfo{Name: "Fedora"},
+			}
+
+			domainManager.EXPECT().GetGuestInfo().Return(guestInfo)
+			fetchedInfo, exists, err := client.GetGuestInfo()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(exists).To(BeTrue())
+			Expect(fetchedInfo).To(Equal(guestInfo))
+		})
+
+		It("should report missing guest info", func() {
+			domainManager.EXPECT().GetGuestInfo().Return(nil)
+			_, exists, err := client.GetGuestInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("should ping the guest agent", func() {
+			domainManager.EXPECT().GuestPing("default_testvmi", int32(2)).Return(nil)
+			Expect(client.GuestPing("default_testvmi", 2)).To(Succeed())
+		})
+
+		It("should report a guest agent which does not answer the ping", func() {
+			domainManager.EXPECT().GuestPing("default_testvmi", int32(1)).Return(fmt.Errorf("agent not available"))
+			err := client.GuestPing("default_testvmi", 1)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("agent not available"))
+		})
+
+		It("should run a command in the guest", func() {
+			domainManager.EXPECT().Exec("default_testvmi", "cat", []string{"/tmp/healthy"}, int32(1)).Return(1, "not yet", nil)
+			exitCode, stdOut, err := client.Exec("default_testvmi", "cat", []string{"/tmp/healthy"}, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exitCode).To(Equal(1))
+			Expect(stdOut).To(Equal("not yet"))
+		})
+
+		It("should report commands which could not be run in the guest", func() {
+			domainManager.EXPECT().Exec("default_testvmi", "cat", []string{"/tmp/healthy"}, int32(1)).Return(-1, "", fmt.Errorf("agent not available"))
+			_, _, err := client.Exec("default_testvmi", "cat", []string{"/tmp/healthy"}, 1)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("Version mismatch", func() {
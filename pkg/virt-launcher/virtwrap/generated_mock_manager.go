@@ -126,3 +126,125 @@ func (_m *MockDomainManager) CancelVMIMigration(_param0 *v1.VirtualMachineInstan
 func (_mr *_MockDomainManagerRecorder) CancelVMIMigration(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "CancelVMIMigration", arg0)
 }
+
+func (_m *MockDomainManager) PauseVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "PauseVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) PauseVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PauseVMI", arg0)
+}
+
+func (_m *MockDomainManager) UnpauseVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UnpauseVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) UnpauseVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnpauseVMI", arg0)
+}
+
+func (_m *MockDomainManager) FreezeVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "FreezeVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) FreezeVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FreezeVMI", arg0)
+}
+
+func (_m *MockDomainManager) UnfreezeVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UnfreezeVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) UnfreezeVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVMI", arg0)
+}
+
+func (_m *MockDomainManager) HotplugDisk(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "HotplugDisk", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) HotplugDisk(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HotplugDisk", arg0)
+}
+
+func (_m *MockDomainManager) UnplugDisk(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UnplugDisk", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) UnplugDisk(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnplugDisk", arg0)
+}
+
+func (_m *MockDomainManager) HotplugResources(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "HotplugResources", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) HotplugResources(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HotplugResources", arg0)
+}
+
+func (_m *MockDomainManager) ChangeMedia(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "ChangeMedia", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) ChangeMedia(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ChangeMedia", arg0)
+}
+
+func (_m *MockDomainManager) ResizeDisk(domainName string, diskName string, size int64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", domainName, diskName, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) ResizeDisk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0, arg1, arg2)
+}
+
+func (_m *MockDomainManager) GetGuestInfo() *v1.VirtualMachineInstanceGuestAgentInfo {
+	ret := _m.ctrl.Call(_m, "GetGuestInfo")
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestAgentInfo)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) GetGuestInfo() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetGuestInfo")
+}
+
+func (_m *MockDomainManager) GuestPing(domainName string, timeoutSeconds int32) error {
+	ret := _m.ctrl.Call(_m, "GuestPing", domainName, timeoutSeconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) GuestPing(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0, arg1)
+}
+
+func (_m *MockDomainManager) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	ret := _m.ctrl.Call(_m, "Exec", domainName, command, args, timeoutSeconds)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockDomainManagerRecorder) Exec(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Exec", arg0, arg1, arg2, arg3)
+}
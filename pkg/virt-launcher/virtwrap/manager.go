@@ -26,12 +26,15 @@ package virtwrap
 */
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +46,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilwait "k8s.io/apimachinery/pkg/util/wait"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -55,18 +59,28 @@ import (
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
 const LibvirtLocalConnectionPort = 22222
 
+// migrationProgressReportInterval is the minimal time in seconds between two reports of the migration progress
+const migrationProgressReportInterval = 5
+
+// guestExecStatusInterval is the time between two checks whether a command run through the guest agent exited
+const guestExecStatusInterval = 100 * time.Millisecond
+
 type DomainManager interface {
 	SyncVMI(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	KillVMI(*v1.VirtualMachineInstance) error
@@ -77,6 +91,18 @@ type DomainManager interface {
 	PrepareMigrationTarget(*v1.VirtualMachineInstance, bool) error
 	GetDomainStats() ([]*stats.DomainStats, error)
 	CancelVMIMigration(*v1.VirtualMachineInstance) error
+	PauseVMI(*v1.VirtualMachineInstance) error
+	UnpauseVMI(*v1.VirtualMachineInstance) error
+	FreezeVMI(*v1.VirtualMachineInstance) error
+	UnfreezeVMI(*v1.VirtualMachineInstance) error
+	HotplugDisk(*v1.VirtualMachineInstance) error
+	UnplugDisk(*v1.VirtualMachineInstance) error
+	HotplugResources(*v1.VirtualMachineInstance) error
+	ChangeMedia(*v1.VirtualMachineInstance) error
+	ResizeDisk(domainName string, diskName string, size int64) error
+	GetGuestInfo() *v1.VirtualMachineInstanceGuestAgentInfo
+	GuestPing(domainName string, timeoutSeconds int32) error
+	Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error)
 }
 
 type LibvirtDomainManager struct {
@@ -88,19 +114,53 @@ type LibvirtDomainManager struct {
 	virtShareDir           string
 	notifier               *eventsclient.Notifier
 	lessPVCSpaceToleration int
+	paused                 pausedVMIs
+	agentData              *agentpoller.AsyncAgentStore
+}
+
+// pausedVMIs keeps track of the VMIs which were paused on user request,
+// so that a sync does not resume them behind the user's back
+type pausedVMIs struct {
+	mutex  *sync.Mutex
+	paused map[types.UID]bool
+}
+
+func (s pausedVMIs) add(uid types.UID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.paused[uid] = true
+}
+
+func (s pausedVMIs) remove(uid types.UID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.paused, uid)
+}
+
+func (s pausedVMIs) contains(uid types.UID) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.paused[uid]
+	return ok
 }
 
 type migrationDisks struct {
-	shared    map[string]bool
-	generated map[string]bool
+	shared      map[string]bool
+	generated   map[string]bool
+	filesystems map[string]bool
 }
 
-func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int) (DomainManager, error) {
+func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, agentStore *agentpoller.AsyncAgentStore) (DomainManager, error) {
 	manager := LibvirtDomainManager{
 		virConn:                connection,
 		virtShareDir:           virtShareDir,
 		notifier:               notifier,
 		lessPVCSpaceToleration: lessPVCSpaceToleration,
+		paused: pausedVMIs{
+			mutex:  &sync.Mutex{},
+			paused: make(map[types.UID]bool),
+		},
+		agentData: agentStore,
 	}
 
 	return &manager, nil
@@ -244,7 +304,7 @@ func (l *LibvirtDomainManager) setMigrationResultHelper(vmi *v1.VirtualMachineIn
 
 }
 
-func prepareMigrationFlags(isBlockMigration bool, isUnsafeMigration bool, allowAutoConverge bool) libvirt.DomainMigrateFlags {
+func prepareMigrationFlags(isBlockMigration bool, isUnsafeMigration bool, allowAutoConverge bool, allowPostCopy bool) libvirt.DomainMigrateFlags {
 	migrateFlags := libvirt.MIGRATE_LIVE | libvirt.MIGRATE_PEER2PEER
 
 	if isBlockMigration {
@@ -256,6 +316,10 @@ func prepareMigrationFlags(isBlockMigration bool, isUnsafeMigration bool, allowA
 	if allowAutoConverge {
 		migrateFlags |= libvirt.MIGRATE_AUTO_CONVERGE
 	}
+	if allowPostCopy {
+		// only enables the switch to post-copy, the migration still starts in pre-copy mode
+		migrateFlags |= libvirt.MIGRATE_POSTCOPY
+	}
 	return migrateFlags
 
 }
@@ -278,11 +342,17 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	// should be filtered out earlier in the process
 
 	disks := &migrationDisks{
-		shared:    make(map[string]bool),
-		generated: make(map[string]bool),
+		shared:      make(map[string]bool),
+		generated:   make(map[string]bool),
+		filesystems: make(map[string]bool),
 	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
+		// filesystems are not block devices which can be copied or shared by QEMU
+		if vmi.IsFilesystemVolume(volume.Name) {
+			disks.filesystems[volume.Name] = true
+			continue
+		}
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil ||
 			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) {
 			disks.shared[volume.Name] = true
@@ -381,7 +451,7 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 			return
 		}
 
-		migrateFlags := prepareMigrationFlags(isBlockMigration, options.UnsafeMigration, options.AllowAutoConverge)
+		migrateFlags := prepareMigrationFlags(isBlockMigration, options.UnsafeMigration, options.AllowAutoConverge, options.AllowPostCopy)
 		if options.UnsafeMigration {
 			log.Log.Object(vmi).Info("UNSAFE_MIGRATION flag is set, libvirt's migration checks will be disabled!")
 		}
@@ -458,7 +528,9 @@ func liveMigrationMonitor(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, l *
 	logger := log.Log.Object(vmi)
 	start := time.Now().UTC().Unix()
 	lastProgressUpdate := start
+	lastProgressReport := int64(0)
 	progressWatermark := int64(0)
+	postCopy := false
 
 	// update timeouts from migration config
 	progressTimeout := options.ProgressTimeout
@@ -490,16 +562,42 @@ monitorLoop:
 			now := time.Now().UTC().Unix()
 			elapsed := now - start
 
+			if now-lastProgressReport >= migrationProgressReportInterval {
+				l.reportMigrationProgress(vmi, stats)
+				lastProgressReport = now
+			}
+
 			if (progressWatermark == 0) ||
 				(progressWatermark > remainingData) {
 				progressWatermark = remainingData
 				lastProgressUpdate = now
 			}
+
+			// In post-copy the guest already runs on the target, the migration can't be aborted anymore
+			if postCopy {
+				break
+			}
+
+			if options.AllowPostCopy && options.PostCopyAfterIterations > 0 &&
+				int64(stats.MemIteration) >= options.PostCopyAfterIterations {
+				logger.Infof("Live migration did not finish after %d memory iterations", stats.MemIteration)
+				postCopy = startPostCopy(dom, logger)
+				if postCopy {
+					break
+				}
+			}
+
 			// check if the migration is progressing
 			progressDelay := now - lastProgressUpdate
 			if progressTimeout != 0 &&
 				progressDelay > progressTimeout {
 				logger.Warningf("Live migration stuck for %d sec", progressDelay)
+				if options.AllowPostCopy {
+					postCopy = startPostCopy(dom, logger)
+					if postCopy {
+						break
+					}
+				}
 				err := dom.AbortJob()
 				if err != nil {
 					logger.Reason(err).Error("failed to abort migration")
@@ -513,6 +611,12 @@ monitorLoop:
 				elapsed > acceptableCompletionTime {
 				logger.Warningf("Live migration is not completed after %d sec",
 					acceptableCompletionTime)
+				if options.AllowPostCopy {
+					postCopy = startPostCopy(dom, logger)
+					if postCopy {
+						break
+					}
+				}
 				err := dom.AbortJob()
 				if err != nil {
 					logger.Reason(err).Error("failed to abort migration")
@@ -529,6 +633,11 @@ monitorLoop:
 			break monitorLoop
 		case libvirt.DOMAIN_JOB_FAILED:
 			logger.Info("Migration job failed")
+			if postCopy {
+				// the domain is left paused with reason PostcopyFailed, which fails the VirtualMachineInstance
+				l.setMigrationResult(vmi, true, "Post-copy live migration failed", "")
+				break monitorLoop
+			}
 			l.setMigrationResult(vmi, true, fmt.Sprintf("%v", err), "")
 			break monitorLoop
 		case libvirt.DOMAIN_JOB_CANCELLED:
@@ -540,6 +649,43 @@ monitorLoop:
 	}
 }
 
+// startPostCopy switches a running migration to post-copy and reports whether that succeeded
+func startPostCopy(dom cli.VirDomain, logger *log.FilteredLogger) bool {
+	if err := dom.MigrateStartPostCopy(0); err != nil {
+		logger.Reason(err).Error("failed to switch migration to post-copy")
+		return false
+	}
+	logger.Info("Switched live migration to post-copy")
+	return true
+}
+
+// reportMigrationProgress hands the transfer statistics of the migration job over to the
+// notifier, which passes them on to virt-handler with the next domain event
+func (l *LibvirtDomainManager) reportMigrationProgress(vmi *v1.VirtualMachineInstance, stats *libvirt.DomainJobInfo) {
+	if l.notifier == nil {
+		return
+	}
+	l.notifier.SendMigrationProgress(api.VMINamespaceKeyFunc(vmi), newMigrationProgress(stats))
+}
+
+func newMigrationProgress(stats *libvirt.DomainJobInfo) *v1.VirtualMachineInstanceMigrationProgress {
+	now := metav1.Now()
+	progress := &v1.VirtualMachineInstanceMigrationProgress{
+		Timestamp:               &now,
+		TimeElapsedMilliseconds: int64(stats.TimeElapsed),
+		DataTotalBytes:          int64(stats.DataTotal),
+		DataProcessedBytes:      int64(stats.DataProcessed),
+		DataRemainingBytes:      int64(stats.DataRemaining),
+		MemoryTransferRateBytes: int64(stats.MemBps),
+		MemoryIteration:         int64(stats.MemIteration),
+	}
+	// libvirt reports the dirty rate in pages per second
+	if stats.MemDirtyRateSet && stats.MemPageSizeSet {
+		progress.MemoryDirtyRateBytes = int64(stats.MemDirtyRate * stats.MemPageSize)
+	}
+	return progress
+}
+
 func (l *LibvirtDomainManager) CancelVMIMigration(vmi *v1.VirtualMachineInstance) error {
 	if vmi.Status.MigrationState == nil || vmi.Status.MigrationState.Completed ||
 		vmi.Status.MigrationState.Failed || vmi.Status.MigrationState.StartTimestamp == nil {
@@ -632,6 +778,14 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		logger.Reason(err).Error("failed to read pod cpuset.")
 		return fmt.Errorf("failed to read pod cpuset: %v", err)
 	}
+	var cpuNUMANodes map[int]int
+	if vmi.IsNUMAPassthrough() {
+		cpuNUMANodes, err = hardware.GetCPUNUMANodes(hardware.NUMA_NODES_PATH, podCPUSet)
+		if err != nil {
+			logger.Reason(err).Error("failed to detect the NUMA nodes of the pod cpuset.")
+			return fmt.Errorf("failed to detect the NUMA nodes of the pod cpuset: %v", err)
+		}
+	}
 	// Check if PVC volumes are block volumes
 	isBlockPVCMap := make(map[string]bool)
 	diskInfo := make(map[string]*containerdisk.DiskInfo)
@@ -661,6 +815,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		VirtualMachine: vmi,
 		UseEmulation:   useEmulation,
 		CPUSet:         podCPUSet,
+		CPUNUMANodes:   cpuNUMANodes,
 		IsBlockPVC:     isBlockPVCMap,
 		DiskType:       diskInfo,
 	}
@@ -790,6 +945,10 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if err := config.CreateServiceAccountDisk(vmi); err != nil {
 		return domain, fmt.Errorf("creating service account disk failed: %v", err)
 	}
+	// start the virtiofsd processes before QEMU connects to their sockets
+	if err := virtiofs.StartDaemons(vmi); err != nil {
+		return domain, fmt.Errorf("starting virtiofsd failed: %v", err)
+	}
 
 	// set drivers cache mode
 	for i := range domain.Spec.Devices.Disks {
@@ -872,12 +1031,25 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		logger.Reason(err).Error("failed to read pod cpuset.")
 		return nil, err
 	}
+	var cpuNUMANodes map[int]int
+	if vmi.IsNUMAPassthrough() {
+		cpuNUMANodes, err = hardware.GetCPUNUMANodes(hardware.NUMA_NODES_PATH, podCPUSet)
+		if err != nil {
+			logger.Reason(err).Error("failed to detect the NUMA nodes of the pod cpuset.")
+			return nil, err
+		}
+	}
 
 	// Check if PVC volumes are block volumes
 	isBlockPVCMap := make(map[string]bool)
 	diskInfo := make(map[string]*containerdisk.DiskInfo)
+	hotplugVolumes := make(map[string]bool)
 	for i, volume := range vmi.Spec.Volumes {
-		if volume.VolumeSource.PersistentVolumeClaim != nil {
+		if hotplugdisk.IsHotplugVolume(vmi, volume.Name) {
+			// hotplugged volumes are attached to the running domain by HotplugDisk
+			hotplugVolumes[volume.Name] = true
+			isBlockPVCMap[volume.Name] = isHotplugBlockDevice(volume.Name)
+		} else if volume.VolumeSource.PersistentVolumeClaim != nil {
 			isBlockPVC, err := isBlockDeviceVolume(volume.Name)
 			if err != nil {
 				logger.Reason(err).Errorf("failed to detect volume mode for Volume %v and PVC %v.",
@@ -903,9 +1075,11 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		VirtualMachine: vmi,
 		UseEmulation:   useEmulation,
 		CPUSet:         podCPUSet,
+		CPUNUMANodes:   cpuNUMANodes,
 		IsBlockPVC:     isBlockPVCMap,
 		DiskType:       diskInfo,
 		SRIOVDevices:   getSRIOVPCIAddresses(vmi.Spec.Domain.Devices.Interfaces),
+		HotplugVolumes: hotplugVolumes,
 	}
 	if options != nil && options.VirtualMachineSMBios != nil {
 		c.SMBios = options.VirtualMachineSMBios
@@ -965,7 +1139,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 			return nil, err
 		}
 		logger.Info("Domain started.")
-	} else if cli.IsPaused(domState) {
+	} else if cli.IsPaused(domState) && !l.paused.contains(vmi.UID) {
 		// TODO: if state change reason indicates a system error, we could try something smarter
 		err := dom.Resume()
 		if err != nil {
@@ -993,6 +1167,12 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 	return &newSpec, nil
 }
 
+// isHotplugBlockDevice checks if virt-handler provided a hotplugged volume as block device
+func isHotplugBlockDevice(volumeName string) bool {
+	fileInfo, err := os.Stat(hotplugdisk.GenerateDiskTargetPathFromLauncherView(volumeName))
+	return err == nil && fileInfo.Mode()&os.ModeDevice != 0
+}
+
 func isBlockDeviceVolume(volumeName string) (bool, error) {
 	// check for block device
 	path := api.GetBlockDeviceVolumePath(volumeName)
@@ -1119,6 +1299,546 @@ func (l *LibvirtDomainManager) KillVMI(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+func (l *LibvirtDomainManager) PauseVMI(vmi *v1.VirtualMachineInstance) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+
+	if domState != libvirt.DOMAIN_RUNNING {
+		return fmt.Errorf("VMI %s is not running", vmi.Name)
+	}
+
+	// remember the request before suspending, so that a concurrent sync does not resume the domain
+	l.paused.add(vmi.UID)
+	err = dom.Suspend()
+	if err != nil {
+		l.paused.remove(vmi.UID)
+		log.Log.Object(vmi).Reason(err).Error("Signalling suspension failed.")
+		return err
+	}
+	log.Log.Object(vmi).Info("Signaled pause")
+	return nil
+}
+
+func (l *LibvirtDomainManager) UnpauseVMI(vmi *v1.VirtualMachineInstance) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+
+	if domState != libvirt.DOMAIN_PAUSED {
+		return fmt.Errorf("VMI %s is not paused", vmi.Name)
+	}
+
+	err = dom.Resume()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Signalling unpause failed.")
+		return err
+	}
+	l.paused.remove(vmi.UID)
+	log.Log.Object(vmi).Info("Signaled unpause")
+	return nil
+}
+
+// FreezeVMI asks the guest agent to flush and freeze all guest filesystems,
+// so that a consistent snapshot of the disks can be taken. Freezing a guest
+// which is already frozen is a no-op.
+func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	status, err := l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the guest filesystem freeze status failed.")
+		return err
+	}
+	fsfreezeStatus := struct {
+		Return string `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(status), &fsfreezeStatus); err != nil {
+		return fmt.Errorf("failed to parse the guest filesystem freeze status: %v", err)
+	}
+	if fsfreezeStatus.Return == "frozen" {
+		log.Log.Object(vmi).Info("Guest filesystems are already frozen")
+		return nil
+	}
+
+	_, err = l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Freezing the guest filesystems failed.")
+		return err
+	}
+	log.Log.Object(vmi).Info("Signaled freeze")
+	return nil
+}
+
+// UnfreezeVMI thaws all guest filesystems. Thawing a guest which is not frozen is a no-op.
+func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	_, err := l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Thawing the guest filesystems failed.")
+		return err
+	}
+	log.Log.Object(vmi).Info("Signaled unfreeze")
+	return nil
+}
+
+// GuestPing checks whether the guest agent answers within the timeout
+func (l *LibvirtDomainManager) GuestPing(domainName string, timeoutSeconds int32) error {
+	dom, err := l.virConn.LookupDomainByName(domainName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	_, err = dom.QemuAgentCommand(`{"execute":"guest-ping"}`, libvirt.DomainQemuAgentCommandTimeout(timeoutSeconds), 0)
+	return err
+}
+
+// ResizeDisk lets QEMU pick up the grown image or block device behind a disk, which makes the
+// new size visible to the guest
+func (l *LibvirtDomainManager) ResizeDisk(domainName string, diskName string, size int64) error {
+	dom, err := l.virConn.LookupDomainByName(domainName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	disks, err := getAllDomainDisks(dom)
+	if err != nil {
+		return err
+	}
+	for _, disk := range disks {
+		if disk.Alias != nil && disk.Alias.Name == diskName {
+			return dom.BlockResize(disk.Target.Device, uint64(size), libvirt.DOMAIN_BLOCK_RESIZE_BYTES)
+		}
+	}
+	return fmt.Errorf("disk %s not found in domain %s", diskName, domainName)
+}
+
+type guestExecCommand struct {
+	Execute   string `json:"execute"`
+	Arguments struct {
+		Path          string   `json:"path"`
+		Arg           []string `json:"arg,omitempty"`
+		CaptureOutput bool     `json:"capture-output"`
+	} `json:"arguments"`
+}
+
+type guestExecResult struct {
+	Return struct {
+		Pid int `json:"pid"`
+	} `json:"return"`
+}
+
+type guestExecStatusResult struct {
+	Return struct {
+		Exited   bool   `json:"exited"`
+		ExitCode int    `json:"exitcode"`
+		OutData  string `json:"out-data"`
+	} `json:"return"`
+}
+
+// Exec runs a command in the guest through the guest agent and waits until it exits or the timeout expires.
+// It returns the exit code and the standard output of the command.
+func (l *LibvirtDomainManager) Exec(domainName string, command string, args []string, timeoutSeconds int32) (int, string, error) {
+	dom, err := l.virConn.LookupDomainByName(domainName)
+	if err != nil {
+		return -1, "", err
+	}
+	defer dom.Free()
+
+	timeout := libvirt.DomainQemuAgentCommandTimeout(timeoutSeconds)
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+
+	execCommand := guestExecCommand{Execute: "guest-exec"}
+	execCommand.Arguments.Path = command
+	execCommand.Arguments.Arg = args
+	execCommand.Arguments.CaptureOutput = true
+	cmd, err := json.Marshal(&execCommand)
+	if err != nil {
+		return -1, "", err
+	}
+	result, err := dom.QemuAgentCommand(string(cmd), timeout, 0)
+	if err != nil {
+		return -1, "", err
+	}
+	execResult := guestExecResult{}
+	if err := json.Unmarshal([]byte(result), &execResult); err != nil {
+		return -1, "", fmt.Errorf("failed to parse the guest-exec result: %v", err)
+	}
+
+	statusCmd := fmt.Sprintf(`{"execute":"guest-exec-status","arguments":{"pid":%d}}`, execResult.Return.Pid)
+	for {
+		result, err := dom.QemuAgentCommand(statusCmd, timeout, 0)
+		if err != nil {
+			return -1, "", err
+		}
+		statusResult := guestExecStatusResult{}
+		if err := json.Unmarshal([]byte(result), &statusResult); err != nil {
+			return -1, "", fmt.Errorf("failed to parse the guest-exec-status result: %v", err)
+		}
+		if statusResult.Return.Exited {
+			stdOut, err := base64.StdEncoding.DecodeString(statusResult.Return.OutData)
+			if err != nil {
+				return -1, "", fmt.Errorf("failed to decode the output of %s: %v", command, err)
+			}
+			return statusResult.Return.ExitCode, string(stdOut), nil
+		}
+		if time.Now().After(deadline) {
+			return -1, "", fmt.Errorf("%s did not exit within %d seconds", command, timeoutSeconds)
+		}
+		time.Sleep(guestExecStatusInterval)
+	}
+}
+
+// HotplugDisk attaches the disks of all hotplugged volumes, which virt-handler
+// already mounted into the pod, to the running domain
+func (l *LibvirtDomainManager) HotplugDisk(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed.")
+		return err
+	}
+
+	attachedDisks := map[string]bool{}
+	usedDeviceNames := map[string]bool{}
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias != nil {
+			attachedDisks[disk.Alias.Name] = true
+		}
+		usedDeviceNames[disk.Target.Device] = true
+	}
+
+	volumes := map[string]*v1.Volume{}
+	for i, volume := range vmi.Spec.Volumes {
+		volumes[volume.Name] = &vmi.Spec.Volumes[i]
+	}
+
+	hotplugVolumes := hotplugdisk.GetHotplugVolumes(vmi)
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		status, isHotplugVolume := hotplugVolumes[disk.Name]
+		if !isHotplugVolume || attachedDisks[disk.Name] {
+			continue
+		}
+		if status.Phase != v1.HotplugVolumeMounted && status.Phase != v1.VolumeReady {
+			continue
+		}
+		volume, exists := volumes[disk.Name]
+		if !exists {
+			continue
+		}
+
+		newDisk := api.Disk{}
+		if err := api.Convert_v1_Disk_To_api_Disk(&disk, &newDisk, map[string]int{}, nil); err != nil {
+			return err
+		}
+		newDisk.Target.Device = api.MakeFreeDeviceName(newDisk.Target.Bus, usedDeviceNames)
+		c := &api.ConverterContext{
+			VirtualMachine: vmi,
+			HotplugVolumes: map[string]bool{volume.Name: true},
+			IsBlockPVC:     map[string]bool{volume.Name: isHotplugBlockDevice(volume.Name)},
+		}
+		if err := api.Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, 0); err != nil {
+			return err
+		}
+
+		diskXML, err := encodeDisk(&newDisk)
+		if err != nil {
+			return err
+		}
+		err = dom.AttachDeviceFlags(diskXML, libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG)
+		if err != nil {
+			logger.Reason(err).Errorf("Attaching disk %s failed.", disk.Name)
+			return err
+		}
+		usedDeviceNames[newDisk.Target.Device] = true
+		logger.Infof("Attached disk %s as %s.", disk.Name, newDisk.Target.Device)
+	}
+	return nil
+}
+
+// UnplugDisk detaches all hotplugged disks from the running domain, which are
+// no longer part of the VirtualMachineInstance spec
+func (l *LibvirtDomainManager) UnplugDisk(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed.")
+		return err
+	}
+
+	volumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		volumes[volume.Name] = true
+	}
+
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias == nil || volumes[disk.Alias.Name] {
+			continue
+		}
+		if !strings.HasPrefix(disk.Source.File, hotplugdisk.GetMountBaseDir()) && !strings.HasPrefix(disk.Source.Dev, hotplugdisk.GetMountBaseDir()) {
+			continue
+		}
+
+		diskXML, err := encodeDisk(&disk)
+		if err != nil {
+			return err
+		}
+		err = dom.DetachDeviceFlags(diskXML, libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG)
+		if err != nil {
+			logger.Reason(err).Errorf("Detaching disk %s failed.", disk.Alias.Name)
+			return err
+		}
+		logger.Infof("Detached disk %s.", disk.Alias.Name)
+	}
+	return nil
+}
+
+// ChangeMedia inserts the media requested in the VirtualMachineInstance spec into the CD-ROMs
+// and floppies of the running domain, or ejects them. Media are only changed in the live domain,
+// the persistent definition keeps the media the domain was started with.
+func (l *LibvirtDomainManager) ChangeMedia(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed.")
+		return err
+	}
+	inactiveSpec, err := util.GetDomainSpecWithFlags(dom, libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		logger.Reason(err).Error("Getting the inactive domain spec failed.")
+		return err
+	}
+
+	drives := map[string]api.Disk{}
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias != nil && (disk.Device == "cdrom" || disk.Device == "floppy") {
+			drives[disk.Alias.Name] = disk
+		}
+	}
+	originalMedia := map[string]api.Disk{}
+	for _, disk := range inactiveSpec.Devices.Disks {
+		if disk.Alias != nil {
+			originalMedia[disk.Alias.Name] = disk
+		}
+	}
+	hotplugVolumes := hotplugdisk.GetHotplugVolumes(vmi)
+
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i]
+		drive, exists := drives[disk.Name]
+		if !exists {
+			continue
+		}
+		volumeName := hotplugdisk.GetRequestedMedium(disk)
+
+		newDisk := drive.DeepCopy()
+		newDisk.Source = api.DiskSource{}
+		newDisk.BackingStore = nil
+		switch volumeName {
+		case "":
+			newDisk.Target.Tray = "open"
+		case disk.Name:
+			original, exists := originalMedia[disk.Name]
+			if !exists {
+				return fmt.Errorf("no original medium found for %s", disk.Name)
+			}
+			newDisk.Type = original.Type
+			newDisk.Source = original.Source
+			newDisk.Driver = original.Driver
+			newDisk.Target.Tray = "closed"
+		default:
+			status, isHotplugVolume := hotplugVolumes[volumeName]
+			if !isHotplugVolume {
+				return fmt.Errorf("volume %s is no hotplugged volume and can't be inserted into %s", volumeName, disk.Name)
+			}
+			// the medium is inserted once virt-handler mounted it into the pod
+			if status.Phase != v1.HotplugVolumeMounted && status.Phase != v1.VolumeReady {
+				continue
+			}
+			if newDisk.Driver == nil {
+				newDisk.Driver = &api.DiskDriver{Name: "qemu"}
+			}
+			c := &api.ConverterContext{
+				IsBlockPVC: map[string]bool{volumeName: isHotplugBlockDevice(volumeName)},
+			}
+			if err := api.Convert_v1_Hotplug_Volume_To_api_Disk(volumeName, newDisk, c); err != nil {
+				return err
+			}
+			newDisk.Target.Tray = "closed"
+		}
+
+		if reflect.DeepEqual(newDisk.Source, drive.Source) {
+			continue
+		}
+
+		diskXML, err := encodeDisk(newDisk)
+		if err != nil {
+			return err
+		}
+		err = dom.UpdateDeviceFlags(diskXML, libvirt.DOMAIN_DEVICE_MODIFY_LIVE)
+		if err != nil {
+			logger.Reason(err).Errorf("Changing the medium of %s failed.", disk.Name)
+			return err
+		}
+		if volumeName == "" {
+			logger.Infof("Ejected the medium of %s.", disk.Name)
+		} else {
+			logger.Infof("Inserted %s into %s.", volumeName, disk.Name)
+		}
+	}
+	return nil
+}
+
+// HotplugResources enables further vCPUs and plugs DIMMs into the running domain,
+// until it matches the sockets and the guest memory of the VirtualMachineInstance
+func (l *LibvirtDomainManager) HotplugResources(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain failed.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed.")
+		return err
+	}
+
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.MaxSockets > 0 {
+		requested := api.GetRequestedVCPUs(vmi)
+		if requested > api.GetDomainVCPUs(domainSpec) {
+			err = dom.SetVcpusFlags(uint(requested), libvirt.DOMAIN_VCPU_LIVE|libvirt.DOMAIN_VCPU_CONFIG)
+			if err != nil {
+				logger.Reason(err).Errorf("Enabling %d vCPUs failed.", requested)
+				return err
+			}
+			logger.Infof("Enabled %d vCPUs.", requested)
+		}
+	}
+
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil {
+		requested, err := api.GetRequestedMemory(vmi)
+		if err != nil {
+			return err
+		}
+		current, err := api.MemoryToBytes(domainSpec.Memory)
+		if err != nil {
+			return err
+		}
+		// the guest can not online partial memory blocks
+		size := uint64(0)
+		if requested > current {
+			size = (requested - current) / api.MemoryHotplugBlockSize * api.MemoryHotplugBlockSize
+		}
+		if size > 0 {
+			if uint32(len(domainSpec.Devices.Memory)) >= api.MaxMemorySlots {
+				return fmt.Errorf("all %d memory slots are used, %d bytes of memory can not be attached", api.MaxMemorySlots, size)
+			}
+			dimm := api.MemoryDevice{
+				Model: "dimm",
+				Target: &api.MemoryTarget{
+					Size: api.Memory{Value: size, Unit: "b"},
+					Node: 0,
+				},
+			}
+			dimmXML, err := encodeMemoryDevice(&dimm)
+			if err != nil {
+				return err
+			}
+			err = dom.AttachDeviceFlags(dimmXML, libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG)
+			if err != nil {
+				logger.Reason(err).Error("Attaching memory failed.")
+				return err
+			}
+			logger.Infof("Attached %d bytes of memory.", size)
+		}
+	}
+	return nil
+}
+
+func encodeMemoryDevice(memory *api.MemoryDevice) (string, error) {
+	var buf bytes.Buffer
+	err := xml.NewEncoder(&buf).EncodeElement(memory, xml.StartElement{Name: xml.Name{Local: "memory"}})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// encodeDisk creates the device XML of a single disk, as expected by libvirt on attach and detach
+func encodeDisk(disk *api.Disk) (string, error) {
+	var buf bytes.Buffer
+	err := xml.NewEncoder(&buf).EncodeElement(disk, xml.StartElement{Name: xml.Name{Local: "disk"}})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 func (l *LibvirtDomainManager) DeleteVMI(vmi *v1.VirtualMachineInstance) error {
 	domName := api.VMINamespaceKeyFunc(vmi)
 	dom, err := l.virConn.LookupDomainByName(domName)
@@ -1198,6 +1918,11 @@ func (l *LibvirtDomainManager) GetDomainStats() ([]*stats.DomainStats, error) {
 	return l.virConn.GetDomainStats(statsTypes, flags)
 }
 
+// GetGuestInfo returns the guest info last gathered from the guest agent, or nil if none is available
+func (l *LibvirtDomainManager) GetGuestInfo() *v1.VirtualMachineInstanceGuestAgentInfo {
+	return l.agentData.GetGuestInfo()
+}
+
 func GetImageInfo(imagePath string) (*containerdisk.DiskInfo, error) {
 
 	out, err := exec.Command(
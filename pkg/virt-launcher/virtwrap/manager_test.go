@@ -42,6 +42,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
@@ -112,7 +113,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -134,7 +135,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -155,7 +156,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -170,7 +171,7 @@ var _ = Describe("Manager", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -188,7 +189,7 @@ var _ = Describe("Manager", func() {
 				mockConn.EXPECT().DomainDefineXML(string(xml)).Return(mockDomain, nil)
 				mockDomain.EXPECT().Create().Return(nil)
 				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 				newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 				Expect(err).To(BeNil())
 				Expect(newspec).ToNot(BeNil())
@@ -209,12 +210,400 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
 			mockDomain.EXPECT().Resume().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
 		})
 	})
+	Context("on successful VirtualMachineInstance pause", func() {
+		It("should pause a running VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().Suspend().Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			err := manager.PauseVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should not pause a VirtualMachineInstance which is not running", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			err := manager.PauseVMI(vmi)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should not resume a VirtualMachineInstance paused by the user on sync", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().Times(2)
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			xml, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(2)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().Suspend().Return(nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.PauseVMI(vmi)).To(Succeed())
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(BeNil())
+			Expect(newspec).ToNot(BeNil())
+		})
+		It("should unpause a paused VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			mockDomain.EXPECT().Resume().Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			err := manager.UnpauseVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+	})
+	Context("on VirtualMachineInstance freeze", func() {
+		It("should freeze the guest filesystems through the guest agent", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"thawed"}`, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return(`{"return":2}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.FreezeVMI(vmi)).To(Succeed())
+		})
+		It("should not freeze the guest filesystems again if they are already frozen", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return(`{"return":"frozen"}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.FreezeVMI(vmi)).To(Succeed())
+		})
+		It("should thaw the guest filesystems through the guest agent", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return(`{"return":2}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
+		})
+		It("should report an error if the guest agent is not reachable", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-status"}`, testDomainName).Return("", fmt.Errorf("guest agent is not connected"))
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.FreezeVMI(vmi)).ToNot(Succeed())
+		})
+	})
+	Context("on guest agent probes", func() {
+		It("should ping the guest agent", func() {
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().Free()
+			mockDomain.EXPECT().QemuAgentCommand(`{"execute":"guest-ping"}`, libvirt.DomainQemuAgentCommandTimeout(2), uint32(0)).Return(`{"return":{}}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.GuestPing(testDomainName, 2)).To(Succeed())
+		})
+		It("should run a command in the guest and return its exit code and output", func() {
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().Free()
+			gomock.InOrder(
+				mockDomain.EXPECT().QemuAgentCommand(`{"execute":"guest-exec","arguments":{"path":"cat","arg":["/tmp/healthy"],"capture-output":true}}`, libvirt.DomainQemuAgentCommandTimeout(1), uint32(0)).Return(`{"return":{"pid":42}}`, nil),
+				mockDomain.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":42}}`, libvirt.DomainQemuAgentCommandTimeout(1), uint32(0)).Return(`{"return":{"exited":false}}`, nil),
+				mockDomain.EXPECT().QemuAgentCommand(`{"execute":"guest-exec-status","arguments":{"pid":42}}`, libvirt.DomainQemuAgentCommandTimeout(1), uint32(0)).Return(`{"return":{"exited":true,"exitcode":3,"out-data":"aGVhbHRoeQo="}}`, nil),
+			)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			exitCode, stdOut, err := manager.Exec(testDomainName, "cat", []string{"/tmp/healthy"}, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exitCode).To(Equal(3))
+			Expect(stdOut).To(Equal("healthy\n"))
+		})
+		It("should report an error if the guest agent can't run the command", func() {
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().Free()
+			mockDomain.EXPECT().QemuAgentCommand(`{"execute":"guest-exec","arguments":{"path":"true","capture-output":true}}`, libvirt.DomainQemuAgentCommandTimeout(1), uint32(0)).Return("", fmt.Errorf("guest agent is not connected"))
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			_, _, err := manager.Exec(testDomainName, "true", nil, 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("on disk hotplug", func() {
+		newDomainSpecWithDisks := func(disks ...api.Disk) string {
+			domainSpec := &api.DomainSpec{}
+			domainSpec.Devices.Disks = disks
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			return string(domainXML)
+		}
+
+		newVMIWithHotplugVolume := func(phase v1.VolumePhase) *v1.VirtualMachineInstance {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "hpvolume",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "scsi"},
+				},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "hpvolume",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hppvc"},
+				},
+			})
+			vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+				Name:          "hpvolume",
+				Phase:         phase,
+				HotplugVolume: &v1.HotplugVolumeStatus{},
+			})
+			hotplugdisk.AddHotplugVolumeName(vmi, "hpvolume")
+			return vmi
+		}
+
+		rootDisk := api.Disk{
+			Device: "disk",
+			Type:   "file",
+			Target: api.DiskTarget{Bus: "scsi", Device: "sda"},
+			Source: api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"},
+			Alias:  &api.Alias{Name: "rootdisk"},
+		}
+
+		It("should attach mounted volumes with the next free device name", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithHotplugVolume(v1.HotplugVolumeMounted)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithDisks(rootDisk), nil)
+			mockDomain.EXPECT().AttachDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(diskXML string, _ libvirt.DomainDeviceModifyFlags) {
+				Expect(diskXML).To(HavePrefix("<disk "))
+				disk := &api.Disk{}
+				Expect(xml.Unmarshal([]byte(diskXML), disk)).To(Succeed())
+				Expect(disk.Alias.Name).To(Equal("hpvolume"))
+				Expect(disk.Target.Device).To(Equal("sdb"))
+				Expect(disk.Source.File).To(Equal(hotplugdisk.GenerateDiskTargetPathFromLauncherView("hpvolume")))
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugDisk(vmi)).To(Succeed())
+		})
+
+		It("should not attach volumes which are not mounted yet", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithHotplugVolume(v1.HotplugVolumeAttachedToNode)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithDisks(rootDisk), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugDisk(vmi)).To(Succeed())
+		})
+
+		It("should only detach hotplugged disks which were removed from the spec", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			hotplugDisk := api.Disk{
+				Device: "disk",
+				Type:   "file",
+				Target: api.DiskTarget{Bus: "scsi", Device: "sdb"},
+				Source: api.DiskSource{File: hotplugdisk.GenerateDiskTargetPathFromLauncherView("hpvolume")},
+				Alias:  &api.Alias{Name: "hpvolume"},
+			}
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithDisks(rootDisk, hotplugDisk), nil)
+			mockDomain.EXPECT().DetachDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(diskXML string, _ libvirt.DomainDeviceModifyFlags) {
+				Expect(diskXML).To(HavePrefix("<disk "))
+				Expect(diskXML).To(ContainSubstring(`<alias name="ua-hpvolume">`))
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.UnplugDisk(vmi)).To(Succeed())
+		})
+	})
+	Context("on media change", func() {
+		originalMedium := api.DiskSource{File: "/var/run/kubevirt-private/vmi-disks/cdrom/disk.img"}
+
+		newDomainSpecWithMedium := func(source api.DiskSource, tray string) string {
+			domainSpec := &api.DomainSpec{}
+			domainSpec.Devices.Disks = []api.Disk{{
+				Device: "cdrom",
+				Type:   "file",
+				Target: api.DiskTarget{Bus: "sata", Device: "sda", Tray: tray},
+				Source: source,
+				Driver: &api.DiskDriver{Name: "qemu", Type: "raw"},
+				Alias:  &api.Alias{Name: "cdrom"},
+			}}
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			return string(domainXML)
+		}
+
+		newVMIWithMedium := func(volumeName string) *v1.VirtualMachineInstance {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+				Name:          "iso",
+				Phase:         v1.HotplugVolumeMounted,
+				HotplugVolume: &v1.HotplugVolumeStatus{},
+			}}
+			hotplugdisk.AddHotplugVolumeName(vmi, "iso")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "cdrom",
+				DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}},
+				Medium:     &volumeName,
+			}}
+			return vmi
+		}
+
+		expectMediumChange := func(currentMedium api.DiskSource, expectedMedium api.DiskSource, expectedTray string) {
+			mockDomain.EXPECT().Free()
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithMedium(currentMedium, ""), nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(newDomainSpecWithMedium(originalMedium, ""), nil)
+			mockDomain.EXPECT().UpdateDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE).Do(func(diskXML string, _ libvirt.DomainDeviceModifyFlags) {
+				disk := &api.Disk{}
+				Expect(xml.Unmarshal([]byte(diskXML), disk)).To(Succeed())
+				Expect(disk.Alias.Name).To(Equal("cdrom"))
+				Expect(disk.Source).To(Equal(expectedMedium))
+				Expect(disk.Target.Tray).To(Equal(expectedTray))
+			})
+		}
+
+		It("should insert a mounted hotplugged volume", func() {
+			expectMediumChange(originalMedium, api.DiskSource{File: hotplugdisk.GenerateDiskTargetPathFromLauncherView("iso")}, "closed")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ChangeMedia(newVMIWithMedium("iso"))).To(Succeed())
+		})
+
+		It("should eject the medium and open the tray", func() {
+			expectMediumChange(originalMedium, api.DiskSource{}, "open")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ChangeMedia(newVMIWithMedium(""))).To(Succeed())
+		})
+
+		It("should insert the medium the domain was started with again", func() {
+			expectMediumChange(api.DiskSource{}, originalMedium, "closed")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ChangeMedia(newVMIWithMedium("cdrom"))).To(Succeed())
+		})
+
+		It("should leave drives alone which already hold the requested medium", func() {
+			mockDomain.EXPECT().Free()
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithMedium(originalMedium, "open"), nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE).Return(newDomainSpecWithMedium(originalMedium, "open"), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ChangeMedia(newVMIWithMedium("cdrom"))).To(Succeed())
+		})
+	})
+	Context("on CPU and memory hotplug", func() {
+		newVMIWithMaximums := func(sockets uint32, guestMemory string) *v1.VirtualMachineInstance {
+			vmi := newVMI(testNamespace, testVmName)
+			guest := resource.MustParse(guestMemory)
+			maxGuest := resource.MustParse("4Gi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: sockets, MaxSockets: 4, Cores: 2}
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &maxGuest}
+			return vmi
+		}
+
+		newDomainSpecWithResources := func(vcpus uint32, memoryKiB uint64) string {
+			domainSpec := &api.DomainSpec{}
+			domainSpec.VCPU = &api.VCPU{Placement: "static", Current: vcpus, CPUs: 8}
+			domainSpec.Memory = api.Memory{Value: memoryKiB, Unit: "KiB"}
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			return string(domainXML)
+		}
+
+		It("should enable vCPUs and attach a DIMM if more sockets and memory are requested", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithMaximums(3, "3Gi")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithResources(4, 1048576), nil)
+			mockDomain.EXPECT().SetVcpusFlags(uint(6), libvirt.DOMAIN_VCPU_LIVE|libvirt.DOMAIN_VCPU_CONFIG)
+			mockDomain.EXPECT().AttachDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(memoryXML string, _ libvirt.DomainDeviceModifyFlags) {
+				Expect(memoryXML).To(HavePrefix(`<memory model="dimm">`))
+				dimm := &api.MemoryDevice{}
+				Expect(xml.Unmarshal([]byte(memoryXML), dimm)).To(Succeed())
+				Expect(dimm.Target.Size).To(Equal(api.Memory{Value: 2147483648, Unit: "b"}))
+				Expect(dimm.Target.Node).To(Equal(uint32(0)))
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugResources(vmi)).To(Succeed())
+		})
+
+		It("should align the DIMM to the memory block size", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithMaximums(2, "1300Mi")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithResources(4, 1048576), nil)
+			mockDomain.EXPECT().AttachDeviceFlags(gomock.Any(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE|libvirt.DOMAIN_DEVICE_MODIFY_CONFIG).Do(func(memoryXML string, _ libvirt.DomainDeviceModifyFlags) {
+				dimm := &api.MemoryDevice{}
+				Expect(xml.Unmarshal([]byte(memoryXML), dimm)).To(Succeed())
+				Expect(dimm.Target.Size).To(Equal(api.Memory{Value: 268435456, Unit: "b"}))
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugResources(vmi)).To(Succeed())
+		})
+
+		It("should not attach a DIMM smaller than the memory block size", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithMaximums(2, "1100Mi")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithResources(4, 1048576), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugResources(vmi)).To(Succeed())
+		})
+
+		It("should fail if all memory slots are used", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithMaximums(2, "3Gi")
+			domainSpec := &api.DomainSpec{}
+			domainSpec.VCPU = &api.VCPU{Placement: "static", Current: 4, CPUs: 8}
+			domainSpec.Memory = api.Memory{Value: 1048576, Unit: "KiB"}
+			for i := uint32(0); i < api.MaxMemorySlots; i++ {
+				domainSpec.Devices.Memory = append(domainSpec.Devices.Memory, api.MemoryDevice{Model: "dimm"})
+			}
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXML), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugResources(vmi)).To(MatchError("all 16 memory slots are used, 2147483648 bytes of memory can not be attached"))
+		})
+
+		It("should do nothing if the domain already has the requested sockets and memory", func() {
+			mockDomain.EXPECT().Free()
+			vmi := newVMIWithMaximums(2, "1Gi")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithResources(4, 1048576), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.HotplugResources(vmi)).To(Succeed())
+		})
+	})
+	Context("on disk resize", func() {
+		newDomainSpecWithDisk := func() string {
+			domainSpec := &api.DomainSpec{}
+			domainSpec.Devices.Disks = []api.Disk{
+				{
+					Target: api.DiskTarget{Bus: "virtio", Device: "vda"},
+					Alias:  &api.Alias{Name: "disk0"},
+				},
+			}
+			domainXML, err := xml.Marshal(domainSpec)
+			Expect(err).ToNot(HaveOccurred())
+			return string(domainXML)
+		}
+
+		It("should resize the disk by its target device", func() {
+			mockDomain.EXPECT().Free()
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithDisk(), nil)
+			mockDomain.EXPECT().BlockResize("vda", uint64(134217728), libvirt.DOMAIN_BLOCK_RESIZE_BYTES)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ResizeDisk(testDomainName, "disk0", 134217728)).To(Succeed())
+		})
+
+		It("should fail if the disk is not part of the domain", func() {
+			mockDomain.EXPECT().Free()
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(newDomainSpecWithDisk(), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
+			Expect(manager.ResizeDisk(testDomainName, "disk1", 134217728)).ToNot(Succeed())
+		})
+	})
 	Context("test migration monitor", func() {
 		It("migration should be canceled if it's not progressing", func() {
 			migrationErrorChan := make(chan error)
@@ -297,6 +686,50 @@ var _ = Describe("Manager", func() {
 
 			liveMigrationMonitor(vmi, mockDomain, manager, options, migrationErrorChan)
 		})
+		It("migration should switch to post-copy after the configured memory iterations", func() {
+			migrationErrorChan := make(chan error)
+			defer close(migrationErrorChan)
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().AnyTimes()
+
+			options := &cmdclient.MigrationOptions{
+				Bandwidth:               resource.MustParse("64Mi"),
+				ProgressTimeout:         150,
+				CompletionTimeoutPerGiB: 800,
+				AllowPostCopy:           true,
+				PostCopyAfterIterations: 3,
+			}
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "111222333",
+			}
+
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			xml, err := xml.Marshal(domainSpec)
+			Expect(err).To(BeNil())
+			manager := &LibvirtDomainManager{
+				virConn:                mockConn,
+				virtShareDir:           "fake",
+				notifier:               nil,
+				lessPVCSpaceToleration: 0,
+			}
+			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
+			mockDomain.EXPECT().GetJobInfo().Return(&libvirt.DomainJobInfo{
+				Type:          libvirt.DOMAIN_JOB_UNBOUNDED,
+				DataRemaining: uint64(32479827394),
+				MemIteration:  3,
+			}, nil).Times(1)
+			mockDomain.EXPECT().GetJobInfo().Return(&libvirt.DomainJobInfo{
+				Type: libvirt.DOMAIN_JOB_COMPLETED,
+			}, nil)
+			mockDomain.EXPECT().MigrateStartPostCopy(uint32(0)).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_MIGRATABLE)).AnyTimes().Return(string(xml), nil)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_INACTIVE)).AnyTimes().Return(string(xml), nil)
+
+			liveMigrationMonitor(vmi, mockDomain, manager, options, migrationErrorChan)
+		})
 		It("migration should be canceled when requested", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free().AnyTimes()
@@ -321,7 +754,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().AbortJob().MaxTimes(1)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_MIGRATABLE)).AnyTimes().Return(string(xml), nil)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_INACTIVE)).AnyTimes().Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			manager.CancelVMIMigration(vmi)
 
 		})
@@ -349,11 +782,35 @@ var _ = Describe("Manager", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_MIGRATABLE)).AnyTimes().Return(string(xml), nil)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_INACTIVE)).AnyTimes().Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			err = manager.CancelVMIMigration(vmi)
 			Expect(err).To(BeNil())
 		})
 
+		It("should convert the migration job info into a migration progress", func() {
+			progress := newMigrationProgress(&libvirt.DomainJobInfo{
+				Type:            libvirt.DOMAIN_JOB_UNBOUNDED,
+				TimeElapsed:     1500,
+				DataTotal:       4096,
+				DataProcessed:   1024,
+				DataRemaining:   3072,
+				MemBps:          512,
+				MemDirtyRateSet: true,
+				MemDirtyRate:    10,
+				MemPageSizeSet:  true,
+				MemPageSize:     4096,
+				MemIteration:    2,
+			})
+
+			Expect(progress.Timestamp).ToNot(BeNil())
+			Expect(progress.TimeElapsedMilliseconds).To(Equal(int64(1500)))
+			Expect(progress.DataTotalBytes).To(Equal(int64(4096)))
+			Expect(progress.DataProcessedBytes).To(Equal(int64(1024)))
+			Expect(progress.DataRemainingBytes).To(Equal(int64(3072)))
+			Expect(progress.MemoryTransferRateBytes).To(Equal(int64(512)))
+			Expect(progress.MemoryDirtyRateBytes).To(Equal(int64(40960)))
+			Expect(progress.MemoryIteration).To(Equal(int64(2)))
+		})
 	})
 
 	Context("on successful VirtualMachineInstance migrate", func() {
@@ -368,7 +825,7 @@ var _ = Describe("Manager", func() {
 				TargetPod:    "fakepod",
 			}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			err := manager.PrepareMigrationTarget(vmi, true)
 			Expect(err).To(BeNil())
 		})
@@ -397,7 +854,7 @@ var _ = Describe("Manager", func() {
 			domainSpec := expectIsolationDetectionForVMI(vmi)
 			domainSpec.Metadata.KubeVirt.Migration = &api.MigrationMetadata{}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
@@ -447,7 +904,7 @@ var _ = Describe("Manager", func() {
 				UID: vmi.Status.MigrationState.MigrationUID,
 			}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
@@ -542,6 +999,35 @@ var _ = Describe("Manager", func() {
 			Expect(copyDisks).Should(ConsistOf("vdb", "vdd"))
 		})
 
+		It("should classify volumes shared as filesystems neither as shared nor as generated disks", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "myconfig", Virtiofs: &v1.FilesystemVirtiofs{}},
+				{Name: "mypvc", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myconfig",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: k8sv1.LocalObjectReference{Name: "config"},
+						},
+					},
+				},
+				{
+					Name: "mypvc",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testclaim"},
+					},
+				},
+			}
+
+			disks := classifyVolumesForMigration(vmi)
+			Expect(disks.filesystems).To(Equal(map[string]bool{"myconfig": true, "mypvc": true}))
+			Expect(disks.shared).To(BeEmpty())
+			Expect(disks.generated).To(BeEmpty())
+		})
+
 	})
 
 	Context("on successful VirtualMachineInstance kill", func() {
@@ -551,7 +1037,7 @@ var _ = Describe("Manager", func() {
 				mockDomain.EXPECT().Free()
 				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 				mockDomain.EXPECT().UndefineFlags(libvirt.DOMAIN_UNDEFINE_NVRAM).Return(nil)
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 				err := manager.DeleteVMI(newVMI(testNamespace, testVmName))
 				Expect(err).To(BeNil())
 			},
@@ -565,7 +1051,7 @@ var _ = Describe("Manager", func() {
 				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 				mockDomain.EXPECT().GetState().Return(state, 1, nil)
 				mockDomain.EXPECT().DestroyFlags(libvirt.DOMAIN_DESTROY_GRACEFUL).Return(nil)
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 				err := manager.KillVMI(newVMI(testNamespace, testVmName))
 				Expect(err).To(BeNil())
 			},
@@ -579,7 +1065,8 @@ var _ = Describe("Manager", func() {
 			isBlockMigration := migrationType == "block"
 			isUnsafeMigration := migrationType == "unsafe"
 			allowAutoConverge := migrationType == "autoConverge"
-			flags := prepareMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge)
+			allowPostCopy := migrationType == "postCopy"
+			flags := prepareMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge, allowPostCopy)
 			expectedMigrateFlags := libvirt.MIGRATE_LIVE | libvirt.MIGRATE_PEER2PEER
 
 			if isBlockMigration {
@@ -590,12 +1077,16 @@ var _ = Describe("Manager", func() {
 			if allowAutoConverge {
 				expectedMigrateFlags |= libvirt.MIGRATE_AUTO_CONVERGE
 			}
+			if allowPostCopy {
+				expectedMigrateFlags |= libvirt.MIGRATE_POSTCOPY
+			}
 			Expect(flags).To(Equal(expectedMigrateFlags))
 		},
 		table.Entry("with block migration", "block"),
 		table.Entry("without block migration", "live"),
 		table.Entry("unsafe migration", "unsafe"),
 		table.Entry("migration auto converge", "autoConverge"),
+		table.Entry("migration with post-copy", "postCopy"),
 	)
 
 	table.DescribeTable("on successful list all domains",
@@ -613,7 +1104,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_INACTIVE)).Return(string(x), nil)
 			mockConn.EXPECT().ListAllDomains(gomock.Eq(libvirt.CONNECT_LIST_DOMAINS_ACTIVE|libvirt.CONNECT_LIST_DOMAINS_INACTIVE)).Return([]cli.VirDomain{mockDomain}, nil)
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			doms, err := manager.ListAllDomains()
 
 			Expect(len(doms)).To(Equal(1))
@@ -642,7 +1133,7 @@ var _ = Describe("Manager", func() {
 				&stats.DomainStats{},
 			}, nil)
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil)
 			domStats, err := manager.GetDomainStats()
 
 			Expect(err).To(BeNil())
@@ -23,6 +23,7 @@ package network
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os/exec"
@@ -30,6 +31,7 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 
 	"github.com/coreos/go-iptables/iptables"
 
@@ -44,23 +46,29 @@ import (
 
 const randomMacGenerationAttempts = 10
 
+const ipv6ForwardingPath = "/proc/sys/net/ipv6/conf/all/forwarding"
+
 type VIF struct {
-	Name    string
-	IP      netlink.Addr
-	MAC     net.HardwareAddr
-	Gateway net.IP
-	Routes  *[]netlink.Route
-	Mtu     uint16
+	Name        string
+	IP          netlink.Addr
+	IPv6        netlink.Addr
+	MAC         net.HardwareAddr
+	Gateway     net.IP
+	GatewayIpv6 net.IP
+	Routes      *[]netlink.Route
+	Mtu         uint16
 }
 
 func (vif VIF) String() string {
 	return fmt.Sprintf(
-		"VIF: { Name: %s, IP: %s, Mask: %s, MAC: %s, Gateway: %s, MTU: %d}",
+		"VIF: { Name: %s, IP: %s, Mask: %s, IPv6: %s, MAC: %s, Gateway: %s, GatewayIpv6: %s, MTU: %d}",
 		vif.Name,
 		vif.IP.IP,
 		vif.IP.Mask,
+		vif.IPv6,
 		vif.MAC,
 		vif.Gateway,
+		vif.GatewayIpv6,
 		vif.Mtu,
 	)
 }
@@ -79,14 +87,16 @@ type NetworkHandler interface {
 	GetHostAndGwAddressesFromCIDR(s string) (string, string, error)
 	SetRandomMac(iface string) (net.HardwareAddr, error)
 	GenerateRandomMac() (net.HardwareAddr, error)
+	GenerateMacFromSeed(seed string) net.HardwareAddr
 	GetMacDetails(iface string) (net.HardwareAddr, error)
 	LinkSetMaster(link netlink.Link, master *netlink.Bridge) error
 	StartDHCP(nic *VIF, serverAddr *netlink.Addr, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions)
-	UseIptables() bool
-	IptablesNewChain(table, chain string) error
-	IptablesAppendRule(table, chain string, rulespec ...string) error
-	NftablesNewChain(table, chain string) error
-	NftablesAppendRule(table, chain string, rulespec ...string) error
+	ConfigureIpv6Forwarding() error
+	UseIptables(proto iptables.Protocol) bool
+	IptablesNewChain(proto iptables.Protocol, table, chain string) error
+	IptablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
+	NftablesNewChain(proto iptables.Protocol, table, chain string) error
+	NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
 	NftablesNewTable(table string) error
 	NftablesLoad(fnName string) error
 }
@@ -128,8 +138,11 @@ func (h *NetworkUtilsHandler) AddrAdd(link netlink.Link, addr *netlink.Addr) err
 func (h *NetworkUtilsHandler) LinkSetMaster(link netlink.Link, master *netlink.Bridge) error {
 	return netlink.LinkSetMaster(link, master)
 }
-func (h *NetworkUtilsHandler) UseIptables() bool {
-	iptablesObject, err := iptables.New()
+func (h *NetworkUtilsHandler) ConfigureIpv6Forwarding() error {
+	return ioutil.WriteFile(ipv6ForwardingPath, []byte("1"), 0644)
+}
+func (h *NetworkUtilsHandler) UseIptables(proto iptables.Protocol) bool {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
 	if err != nil {
 		return false
 	}
@@ -141,32 +154,32 @@ func (h *NetworkUtilsHandler) UseIptables() bool {
 
 	return true
 }
-func (h *NetworkUtilsHandler) IptablesNewChain(table, chain string) error {
-	iptablesObject, err := iptables.New()
+func (h *NetworkUtilsHandler) IptablesNewChain(proto iptables.Protocol, table, chain string) error {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
 	if err != nil {
 		return err
 	}
 
 	return iptablesObject.NewChain(table, chain)
 }
-func (h *NetworkUtilsHandler) IptablesAppendRule(table, chain string, rulespec ...string) error {
-	iptablesObject, err := iptables.New()
+func (h *NetworkUtilsHandler) IptablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
 	if err != nil {
 		return err
 	}
 
 	return iptablesObject.Append(table, chain, rulespec...)
 }
-func (h *NetworkUtilsHandler) NftablesNewChain(table, chain string) error {
-	output, err := exec.Command("nft", "add", "chain", "ip", table, chain).CombinedOutput()
+func (h *NetworkUtilsHandler) NftablesNewChain(proto iptables.Protocol, table, chain string) error {
+	output, err := exec.Command("nft", "add", "chain", getNFTIPString(proto), table, chain).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%s", string(output))
 	}
 
 	return nil
 }
-func (h *NetworkUtilsHandler) NftablesAppendRule(table, chain string, rulespec ...string) error {
-	cmd := append([]string{"add", "rule", "ip", table, chain}, rulespec...)
+func (h *NetworkUtilsHandler) NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	cmd := append([]string{"add", "rule", getNFTIPString(proto), table, chain}, rulespec...)
 	output, err := exec.Command("nft", cmd...).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to apped new nfrule error %s", string(output))
@@ -190,6 +203,15 @@ func (h *NetworkUtilsHandler) NftablesLoad(fnName string) error {
 
 	return nil
 }
+
+// getNFTIPString returns the nftables address family and address match keyword for the protocol
+func getNFTIPString(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 {
+		return "ip6"
+	}
+	return "ip"
+}
+
 func (h *NetworkUtilsHandler) GetHostAndGwAddressesFromCIDR(s string) (string, string, error) {
 	ip, ipnet, err := net.ParseCIDR(s)
 	if err != nil {
@@ -272,6 +294,15 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr *netlink.Addr, brid
 		panic(err)
 	}
 
+	if nic.IPv6.IPNet != nil {
+		h.startDHCPv6(nic, bridgeInterfaceName, searchDomains)
+	}
+
+	// IPv6 only pod networks have nothing to offer over DHCPv4
+	if nic.IP.IPNet == nil {
+		return
+	}
+
 	// panic in case the DHCP server failed during the vm creation
 	// but ignore dhcp errors when the vm is destroyed or shutting down
 	go func() {
@@ -294,6 +325,30 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr *netlink.Addr, brid
 	}()
 }
 
+func (h *NetworkUtilsHandler) startDHCPv6(nic *VIF, bridgeInterfaceName string, searchDomains []string) {
+	nameservers, err := api.GetIpv6NameserversFromPod()
+	if err != nil {
+		log.Log.Errorf("Failed to get IPv6 DNS servers from resolv.conf: %v", err)
+		panic(err)
+	}
+
+	go func() {
+		if err := DHCPv6Server(nic.IPv6.IP, bridgeInterfaceName, nameservers, searchDomains); err != nil {
+			log.Log.Errorf("failed to run DHCPv6: %v", err)
+			panic(err)
+		}
+	}()
+
+	// the guest only routes its traffic through the bridge if there is a gateway on it
+	prefix := &net.IPNet{IP: nic.IPv6.IP.Mask(nic.IPv6.Mask), Mask: nic.IPv6.Mask}
+	go func() {
+		if err := RouterAdvertiser(bridgeInterfaceName, prefix, nic.GatewayIpv6 != nil, nic.Mtu); err != nil {
+			log.Log.Errorf("failed to run the router advertiser: %v", err)
+			panic(err)
+		}
+	}()
+}
+
 // Generate a random mac for interface
 // Avoid MAC address starting with reserved value 0xFE (https://github.com/kubevirt/kubevirt/issues/1494)
 func (h *NetworkUtilsHandler) GenerateRandomMac() (net.HardwareAddr, error) {
@@ -306,9 +361,19 @@ func (h *NetworkUtilsHandler) GenerateRandomMac() (net.HardwareAddr, error) {
 	return net.HardwareAddr(append(prefix, suffix...)), nil
 }
 
+// GenerateMacFromSeed returns the same locally administered mac address for the same seed,
+// so that launchers on different nodes agree on the mac of a guest
+func (h *NetworkUtilsHandler) GenerateMacFromSeed(seed string) net.HardwareAddr {
+	prefix := []byte{0x02, 0x00, 0x00} // local unicast prefix
+	hash := sha256.Sum256([]byte(seed))
+	return net.HardwareAddr(append(prefix, hash[:3]...))
+}
+
 // Allow mocking for tests
 var SetupPodNetwork = SetupNetworkInterfaces
 var DHCPServer = dhcp.SingleClientDHCPServer
+var DHCPv6Server = dhcp.SingleClientDHCPv6Server
+var RouterAdvertiser = dhcp.RouterAdvertiser
 
 func initHandler() {
 	if Handler == nil {
@@ -348,6 +413,44 @@ func getInterfaceCacheFile(filePath, name string) string {
 	return fmt.Sprintf(filePath, name)
 }
 
+// setCachedInterfaceStatus stores the IPs under which the guest is reachable, to report them
+// as long as the guest agent does not report any
+func setCachedInterfaceStatus(name string, mac net.HardwareAddr, addrs ...netlink.Addr) error {
+	status := api.InterfaceStatus{Name: name, Mac: mac.String()}
+	for _, addr := range addrs {
+		if addr.IPNet == nil {
+			continue
+		}
+		if status.Ip == "" {
+			status.Ip = addr.IP.String()
+		}
+		status.IPs = append(status.IPs, addr.IP.String())
+	}
+	return writeToCachedFile(&status, interfaceStatusCacheFile, name)
+}
+
+// ReadCachedInterfaceStatuses returns the IPs of all pod interfaces which were plugged into the guest
+func ReadCachedInterfaceStatuses() ([]api.InterfaceStatus, error) {
+	files, err := filepath.Glob(getInterfaceCacheFile(interfaceStatusCacheFile, "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []api.InterfaceStatus
+	for _, file := range files {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		status := api.InterfaceStatus{}
+		if err := json.Unmarshal(buf, &status); err != nil {
+			return nil, fmt.Errorf("error unmarshaling cached interface status: %v", err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
 // filter out irrelevant routes
 func filterPodNetworkRoutes(routes []netlink.Route, nic *VIF) (filteredRoutes []netlink.Route) {
 	for _, route := range routes {
@@ -370,3 +473,8 @@ func filterPodNetworkRoutes(routes []netlink.Route, nic *VIF) (filteredRoutes []
 func setInterfaceCacheFile(path string) {
 	interfaceCacheFile = path
 }
+
+// only used by unit test suite
+func setInterfaceStatusCacheFile(path string) {
+	interfaceStatusCacheFile = path
+}
@@ -21,10 +21,12 @@ package network
 
 import (
 	"io/ioutil"
+	"net"
 	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -64,6 +66,47 @@ var _ = Describe("Common Methods", func() {
 			Expect(qemuArg).To(Equal(cached_qemuArg))
 		})
 	})
+	Context("startDHCPv6 function", func() {
+		var originalDHCPv6Server = DHCPv6Server
+		var originalRouterAdvertiser = RouterAdvertiser
+		var advertisedDefaultRouter chan bool
+
+		BeforeEach(func() {
+			advertisedDefaultRouter = make(chan bool, 1)
+			DHCPv6Server = func(net.IP, string, [][]byte, []string) error {
+				return nil
+			}
+			RouterAdvertiser = func(_ string, _ *net.IPNet, isDefaultRouter bool, _ uint16) error {
+				advertisedDefaultRouter <- isDefaultRouter
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			DHCPv6Server = originalDHCPv6Server
+			RouterAdvertiser = originalRouterAdvertiser
+		})
+
+		newIpv6Nic := func(gateway net.IP) *VIF {
+			return &VIF{
+				IPv6:        netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("fd00::6"), Mask: net.CIDRMask(64, 128)}},
+				GatewayIpv6: gateway,
+				Mtu:         1410,
+			}
+		}
+
+		It("should advertise a default router with a nonzero lifetime if the pod has an IPv6 gateway", func() {
+			networkHandler := NetworkUtilsHandler{}
+			networkHandler.startDHCPv6(newIpv6Nic(net.ParseIP("fe80::1")), api.DefaultBridgeName, nil)
+			Eventually(advertisedDefaultRouter).Should(Receive(BeTrue()))
+		})
+
+		It("should advertise the router with a zero lifetime if the pod has no IPv6 gateway", func() {
+			networkHandler := NetworkUtilsHandler{}
+			networkHandler.startDHCPv6(newIpv6Nic(nil), api.DefaultBridgeName, nil)
+			Eventually(advertisedDefaultRouter).Should(Receive(BeFalse()))
+		})
+	})
 	Context("GetAvailableAddrsFromCIDR function", func() {
 		It("Should return 2 addresses", func() {
 			networkHandler := NetworkUtilsHandler{}
@@ -86,4 +129,13 @@ var _ = Describe("Common Methods", func() {
 			Expect(strings.HasPrefix(mac.String(), "02:00:00")).To(BeTrue())
 		})
 	})
+	Context("GenerateMacFromSeed function", func() {
+		It("should return the same mac address for the same seed", func() {
+			networkHandler := NetworkUtilsHandler{}
+			mac := networkHandler.GenerateMacFromSeed("1234/default")
+			Expect(strings.HasPrefix(mac.String(), "02:00:00")).To(BeTrue())
+			Expect(networkHandler.GenerateMacFromSeed("1234/default")).To(Equal(mac))
+			Expect(networkHandler.GenerateMacFromSeed("1234/other")).ToNot(Equal(mac))
+		})
+	})
 })
@@ -0,0 +1,275 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package dhcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"kubevirt.io/client-go/log"
+)
+
+// See RFC8415 (https://tools.ietf.org/html/rfc8415) for the message and option formats
+
+const (
+	dhcpv6ServerPort = 547
+	// used for lifetimes and renewal times, the address never expires
+	dhcpv6Infinity = 0xffffffff
+	// DUID based on the link-layer address, see RFC8415 section 11.4
+	duidTypeLL           = 3
+	hardwareTypeEthernet = 1
+	statusCodeSuccess    = 0
+)
+
+var allDHCPRelayAgentsAndServers = net.ParseIP("ff02::1:2")
+
+type dhcpv6MessageType byte
+
+const (
+	dhcpv6Solicit            dhcpv6MessageType = 1
+	dhcpv6Advertise          dhcpv6MessageType = 2
+	dhcpv6Request            dhcpv6MessageType = 3
+	dhcpv6Confirm            dhcpv6MessageType = 4
+	dhcpv6Renew              dhcpv6MessageType = 5
+	dhcpv6Rebind             dhcpv6MessageType = 6
+	dhcpv6Reply              dhcpv6MessageType = 7
+	dhcpv6Release            dhcpv6MessageType = 8
+	dhcpv6Decline            dhcpv6MessageType = 9
+	dhcpv6InformationRequest dhcpv6MessageType = 11
+)
+
+const (
+	dhcpv6OptionClientID    uint16 = 1
+	dhcpv6OptionServerID    uint16 = 2
+	dhcpv6OptionIANA        uint16 = 3
+	dhcpv6OptionIAAddr      uint16 = 5
+	dhcpv6OptionStatusCode  uint16 = 13
+	dhcpv6OptionRapidCommit uint16 = 14
+	dhcpv6OptionDNSServers  uint16 = 23
+	dhcpv6OptionDomainList  uint16 = 24
+)
+
+type dhcpv6Option struct {
+	code uint16
+	data []byte
+}
+
+type dhcpv6Message struct {
+	msgType       dhcpv6MessageType
+	transactionID [3]byte
+	options       []dhcpv6Option
+}
+
+func parseDHCPv6Message(data []byte) (*dhcpv6Message, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("DHCPv6 message is too short: %d bytes", len(data))
+	}
+	msg := &dhcpv6Message{msgType: dhcpv6MessageType(data[0])}
+	copy(msg.transactionID[:], data[1:4])
+
+	for offset := 4; offset < len(data); {
+		if offset+4 > len(data) {
+			return nil, fmt.Errorf("truncated DHCPv6 option header at offset %d", offset)
+		}
+		code := binary.BigEndian.Uint16(data[offset:])
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		offset += 4
+		if offset+length > len(data) {
+			return nil, fmt.Errorf("truncated DHCPv6 option %d at offset %d", code, offset)
+		}
+		msg.options = append(msg.options, dhcpv6Option{code: code, data: data[offset : offset+length]})
+		offset += length
+	}
+	return msg, nil
+}
+
+func (m *dhcpv6Message) marshal() []byte {
+	data := []byte{byte(m.msgType), m.transactionID[0], m.transactionID[1], m.transactionID[2]}
+	for _, option := range m.options {
+		data = append(data, marshalDHCPv6Option(option.code, option.data)...)
+	}
+	return data
+}
+
+func marshalDHCPv6Option(code uint16, data []byte) []byte {
+	option := make([]byte, 4, 4+len(data))
+	binary.BigEndian.PutUint16(option, code)
+	binary.BigEndian.PutUint16(option[2:], uint16(len(data)))
+	return append(option, data...)
+}
+
+func (m *dhcpv6Message) option(code uint16) ([]byte, bool) {
+	for _, option := range m.options {
+		if option.code == code {
+			return option.data, true
+		}
+	}
+	return nil, false
+}
+
+func (m *dhcpv6Message) addOption(code uint16, data []byte) {
+	m.options = append(m.options, dhcpv6Option{code: code, data: data})
+}
+
+func SingleClientDHCPv6Server(
+	clientIP net.IP,
+	serverIface string,
+	dnsIPs [][]byte,
+	searchDomains []string) error {
+
+	log.Log.Info("Starting SingleClientDHCPv6Server")
+
+	iface, err := net.InterfaceByName(serverIface)
+	if err != nil {
+		return err
+	}
+
+	searchDomainBytes, err := convertSearchDomainsToBytes(searchDomains)
+	if err != nil {
+		return err
+	}
+
+	handler := &DHCPv6Handler{
+		clientIP:      clientIP,
+		serverID:      prepareDUID(iface.HardwareAddr),
+		dnsIPs:        dnsIPs,
+		searchDomains: searchDomainBytes,
+	}
+
+	conn, err := net.ListenMulticastUDP("udp6", iface, &net.UDPAddr{IP: allDHCPRelayAgentsAndServers, Port: dhcpv6ServerPort})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	buffer := make([]byte, 1500)
+	for {
+		n, clientAddr, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			return err
+		}
+		// clients send from their link-local address, so the zone tells on which interface the request arrived
+		if clientAddr.Zone != serverIface {
+			continue
+		}
+
+		request, err := parseDHCPv6Message(buffer[:n])
+		if err != nil {
+			log.Log.V(4).Reason(err).Info("Ignoring malformed DHCPv6 message")
+			continue
+		}
+		reply := handler.ServeDHCPv6(request)
+		if reply == nil {
+			continue
+		}
+		if _, err := conn.WriteToUDP(reply.marshal(), clientAddr); err != nil {
+			return err
+		}
+	}
+}
+
+func prepareDUID(mac net.HardwareAddr) []byte {
+	duid := make([]byte, 4, 4+len(mac))
+	binary.BigEndian.PutUint16(duid, duidTypeLL)
+	binary.BigEndian.PutUint16(duid[2:], hardwareTypeEthernet)
+	return append(duid, mac...)
+}
+
+type DHCPv6Handler struct {
+	clientIP      net.IP
+	serverID      []byte
+	dnsIPs        [][]byte
+	searchDomains []byte
+}
+
+func (h *DHCPv6Handler) ServeDHCPv6(request *dhcpv6Message) *dhcpv6Message {
+	log.Log.V(4).Info("Serving a new DHCPv6 request")
+	clientID, hasClientID := request.option(dhcpv6OptionClientID)
+	if !hasClientID && request.msgType != dhcpv6InformationRequest {
+		log.Log.V(4).Info("The DHCPv6 request has no client identifier")
+		return nil
+	}
+	if serverID, hasServerID := request.option(dhcpv6OptionServerID); hasServerID && !bytes.Equal(serverID, h.serverID) {
+		log.Log.V(4).Info("The DHCPv6 request is for another server")
+		return nil
+	}
+
+	reply := &dhcpv6Message{msgType: dhcpv6Reply, transactionID: request.transactionID}
+	if hasClientID {
+		reply.addOption(dhcpv6OptionClientID, clientID)
+	}
+	reply.addOption(dhcpv6OptionServerID, h.serverID)
+
+	switch request.msgType {
+	case dhcpv6Solicit:
+		log.Log.V(4).Info("The DHCPv6 request has message type SOLICIT")
+		if _, rapidCommit := request.option(dhcpv6OptionRapidCommit); rapidCommit {
+			reply.addOption(dhcpv6OptionRapidCommit, nil)
+		} else {
+			reply.msgType = dhcpv6Advertise
+		}
+		h.addAddresses(request, reply)
+	case dhcpv6Request, dhcpv6Renew, dhcpv6Rebind:
+		log.Log.V(4).Info("The DHCPv6 request has message type REQUEST, RENEW or REBIND")
+		h.addAddresses(request, reply)
+	case dhcpv6Confirm, dhcpv6Release, dhcpv6Decline:
+		log.Log.V(4).Info("The DHCPv6 request has message type CONFIRM, RELEASE or DECLINE")
+		reply.addOption(dhcpv6OptionStatusCode, []byte{0, statusCodeSuccess})
+		return reply
+	case dhcpv6InformationRequest:
+		log.Log.V(4).Info("The DHCPv6 request has message type INFORMATION-REQUEST")
+	default:
+		log.Log.V(4).Info("The DHCPv6 request has unhandled message type")
+		return nil
+	}
+
+	if len(h.dnsIPs) > 0 {
+		reply.addOption(dhcpv6OptionDNSServers, bytes.Join(h.dnsIPs, nil))
+	}
+	if len(h.searchDomains) > 0 {
+		reply.addOption(dhcpv6OptionDomainList, h.searchDomains)
+	}
+	return reply
+}
+
+// addAddresses assigns the client address to every non-temporary address association in the request
+func (h *DHCPv6Handler) addAddresses(request *dhcpv6Message, reply *dhcpv6Message) {
+	for _, option := range request.options {
+		if option.code != dhcpv6OptionIANA || len(option.data) < 4 {
+			continue
+		}
+
+		address := make([]byte, 24)
+		copy(address, h.clientIP.To16())
+		binary.BigEndian.PutUint32(address[16:], dhcpv6Infinity)
+		binary.BigEndian.PutUint32(address[20:], dhcpv6Infinity)
+
+		// IAID of the client, followed by the renewal and rebinding times
+		iana := make([]byte, 12)
+		copy(iana, option.data[:4])
+		binary.BigEndian.PutUint32(iana[4:], dhcpv6Infinity)
+		binary.BigEndian.PutUint32(iana[8:], dhcpv6Infinity)
+		iana = append(iana, marshalDHCPv6Option(dhcpv6OptionIAAddr, address)...)
+
+		reply.addOption(dhcpv6OptionIANA, iana)
+	}
+}
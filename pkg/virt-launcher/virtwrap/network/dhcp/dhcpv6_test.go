@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package dhcp
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DHCPv6", func() {
+	clientIP := net.ParseIP("fd10:0:2::2")
+	serverMAC, _ := net.ParseMAC("02:00:00:00:00:01")
+	clientID := []byte{0, 3, 0, 1, 2, 0, 0, 0, 0, 2}
+	iaid := []byte{0, 0, 0, 7}
+
+	var handler *DHCPv6Handler
+
+	BeforeEach(func() {
+		handler = &DHCPv6Handler{
+			clientIP: clientIP,
+			serverID: prepareDUID(serverMAC),
+			dnsIPs:   [][]byte{net.ParseIP("fd00::a")},
+		}
+	})
+
+	newRequest := func(msgType dhcpv6MessageType, options ...dhcpv6Option) *dhcpv6Message {
+		request := &dhcpv6Message{msgType: msgType, transactionID: [3]byte{1, 2, 3}}
+		request.addOption(dhcpv6OptionClientID, clientID)
+		request.options = append(request.options, options...)
+		// go through the wire format to make sure parsing and marshalling match
+		parsed, err := parseDHCPv6Message(request.marshal())
+		Expect(err).ToNot(HaveOccurred())
+		return parsed
+	}
+
+	option := func(reply *dhcpv6Message, code uint16) []byte {
+		data, exists := reply.option(code)
+		Expect(exists).To(BeTrue())
+		return data
+	}
+
+	expectAddress := func(reply *dhcpv6Message) {
+		iana, exists := reply.option(dhcpv6OptionIANA)
+		Expect(exists).To(BeTrue())
+		Expect(iana[:4]).To(Equal(iaid))
+		Expect(iana[12:16]).To(Equal([]byte{0, byte(dhcpv6OptionIAAddr), 0, 24}))
+		Expect(net.IP(iana[16:32]).Equal(clientIP)).To(BeTrue())
+	}
+
+	It("should create a link-layer DUID", func() {
+		Expect(prepareDUID(serverMAC)).To(Equal([]byte{0, 3, 0, 1, 2, 0, 0, 0, 0, 1}))
+	})
+
+	It("should reject truncated messages", func() {
+		_, err := parseDHCPv6Message([]byte{1, 2})
+		Expect(err).To(HaveOccurred())
+		_, err = parseDHCPv6Message([]byte{1, 2, 3, 4, 0, 1, 0, 10, 1})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should advertise the client address on solicit", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6Solicit, dhcpv6Option{code: dhcpv6OptionIANA, data: append(iaid, make([]byte, 8)...)}))
+		Expect(reply.msgType).To(Equal(dhcpv6Advertise))
+		Expect(reply.transactionID).To(Equal([3]byte{1, 2, 3}))
+		Expect(option(reply, dhcpv6OptionClientID)).To(Equal(clientID))
+		Expect(option(reply, dhcpv6OptionServerID)).To(Equal(handler.serverID))
+		expectAddress(reply)
+		Expect(net.IP(option(reply, dhcpv6OptionDNSServers)).Equal(net.ParseIP("fd00::a"))).To(BeTrue())
+	})
+
+	It("should reply directly on solicit with rapid commit", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6Solicit,
+			dhcpv6Option{code: dhcpv6OptionRapidCommit},
+			dhcpv6Option{code: dhcpv6OptionIANA, data: append(iaid, make([]byte, 8)...)}))
+		Expect(reply.msgType).To(Equal(dhcpv6Reply))
+		_, rapidCommit := reply.option(dhcpv6OptionRapidCommit)
+		Expect(rapidCommit).To(BeTrue())
+		expectAddress(reply)
+	})
+
+	It("should reply with the client address on request", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6Request,
+			dhcpv6Option{code: dhcpv6OptionServerID, data: handler.serverID},
+			dhcpv6Option{code: dhcpv6OptionIANA, data: append(iaid, make([]byte, 8)...)}))
+		Expect(reply.msgType).To(Equal(dhcpv6Reply))
+		expectAddress(reply)
+	})
+
+	It("should ignore requests for other servers", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6Request,
+			dhcpv6Option{code: dhcpv6OptionServerID, data: []byte{0, 3, 0, 1, 1, 2, 3, 4, 5, 6}},
+			dhcpv6Option{code: dhcpv6OptionIANA, data: append(iaid, make([]byte, 8)...)}))
+		Expect(reply).To(BeNil())
+	})
+
+	It("should only return the configuration on information requests", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6InformationRequest))
+		Expect(reply.msgType).To(Equal(dhcpv6Reply))
+		_, hasAddress := reply.option(dhcpv6OptionIANA)
+		Expect(hasAddress).To(BeFalse())
+		_, hasDNS := reply.option(dhcpv6OptionDNSServers)
+		Expect(hasDNS).To(BeTrue())
+	})
+
+	It("should confirm releases", func() {
+		reply := handler.ServeDHCPv6(newRequest(dhcpv6Release, dhcpv6Option{code: dhcpv6OptionServerID, data: handler.serverID}))
+		Expect(reply.msgType).To(Equal(dhcpv6Reply))
+		Expect(option(reply, dhcpv6OptionStatusCode)).To(Equal([]byte{0, statusCodeSuccess}))
+	})
+
+	It("should ignore messages sent by servers", func() {
+		Expect(handler.ServeDHCPv6(newRequest(dhcpv6Reply))).To(BeNil())
+	})
+})
@@ -0,0 +1,184 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package dhcp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"syscall"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+// See RFC4861 (https://tools.ietf.org/html/rfc4861) for the message and option formats
+
+const (
+	icmpv6RouterSolicitation  = 133
+	icmpv6RouterAdvertisement = 134
+
+	raOptionSourceLinkLayerAddress = 1
+	raOptionPrefixInformation      = 3
+	raOptionMTU                    = 5
+
+	// the guest has to ask the DHCPv6 server for its address and the other configuration
+	raFlagManagedAddress = 0x80
+	raFlagOtherConfig    = 0x40
+	raFlagOnLink         = 0x80
+
+	raHopLimit       = 64
+	raRouterLifetime = 1800 * time.Second
+	raInterval       = 60 * time.Second
+	// neighbor discovery packets with a lower hop limit are dropped by the receiver
+	ndpHopLimit = 255
+)
+
+var (
+	allNodes   = net.ParseIP("ff02::1")
+	allRouters = net.ParseIP("ff02::2")
+)
+
+// RouterAdvertiser periodically and on request advertises the prefix as on-link and tells the
+// guest to get its address from DHCPv6. Only if isDefaultRouter is set the guest will route its
+// traffic through the server interface.
+func RouterAdvertiser(serverIface string, prefix *net.IPNet, isDefaultRouter bool, mtu uint16) error {
+	log.Log.Info("Starting RouterAdvertiser")
+
+	iface, err := net.InterfaceByName(serverIface)
+	if err != nil {
+		return err
+	}
+
+	advertisement := prepareRouterAdvertisement(iface.HardwareAddr, prefix, isDefaultRouter, mtu)
+
+	listenConfig := net.ListenConfig{Control: func(network, address string, c syscall.RawConn) error {
+		var sockErr error
+		err := c.Control(func(fd uintptr) {
+			sockErr = setRouterAdvertiserSockopts(int(fd), iface)
+		})
+		if err != nil {
+			return err
+		}
+		return sockErr
+	}}
+	conn, err := listenConfig.ListenPacket(context.Background(), "ip6:ipv6-icmp", "::")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	solicitations := make(chan struct{}, 1)
+	readErrors := make(chan error, 1)
+	go func() {
+		buffer := make([]byte, 1500)
+		for {
+			n, _, err := conn.ReadFrom(buffer)
+			if err != nil {
+				readErrors <- err
+				return
+			}
+			if n > 0 && buffer[0] == icmpv6RouterSolicitation {
+				select {
+				case solicitations <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(raInterval)
+	defer ticker.Stop()
+	destination := &net.IPAddr{IP: allNodes, Zone: serverIface}
+	for {
+		// the kernel fills in the ICMPv6 checksum on raw sockets
+		if _, err := conn.WriteTo(advertisement, destination); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-solicitations:
+			log.Log.V(4).Info("Answering a router solicitation")
+		case err := <-readErrors:
+			return err
+		}
+	}
+}
+
+func setRouterAdvertiserSockopts(fd int, iface *net.Interface) error {
+	if err := syscall.SetsockoptString(fd, syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, iface.Name); err != nil {
+		return err
+	}
+	if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_MULTICAST_HOPS, ndpHopLimit); err != nil {
+		return err
+	}
+	if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_UNICAST_HOPS, ndpHopLimit); err != nil {
+		return err
+	}
+	// router solicitations are sent to the all routers group
+	mreq := &syscall.IPv6Mreq{Interface: uint32(iface.Index)}
+	copy(mreq.Multiaddr[:], allRouters)
+	return syscall.SetsockoptIPv6Mreq(fd, syscall.IPPROTO_IPV6, syscall.IPV6_JOIN_GROUP, mreq)
+}
+
+func prepareRouterAdvertisement(mac net.HardwareAddr, prefix *net.IPNet, isDefaultRouter bool, mtu uint16) []byte {
+	// a router lifetime of zero tells the guest not to use the server as default router
+	routerLifetime := time.Duration(0)
+	if isDefaultRouter {
+		routerLifetime = raRouterLifetime
+	}
+
+	// type, code, checksum, hop limit, flags, router lifetime, reachable time and retransmission timer
+	advertisement := make([]byte, 16)
+	advertisement[0] = icmpv6RouterAdvertisement
+	advertisement[4] = raHopLimit
+	advertisement[5] = raFlagManagedAddress | raFlagOtherConfig
+	binary.BigEndian.PutUint16(advertisement[6:], uint16(routerLifetime/time.Second))
+
+	if len(mac) == 6 {
+		advertisement = append(advertisement, raOptionSourceLinkLayerAddress, 1)
+		advertisement = append(advertisement, mac...)
+	}
+
+	if mtu > 0 {
+		option := make([]byte, 8)
+		option[0] = raOptionMTU
+		option[1] = 1
+		binary.BigEndian.PutUint32(option[4:], uint32(mtu))
+		advertisement = append(advertisement, option...)
+	}
+
+	if prefix != nil {
+		// a single address is not worth advertising as on-link prefix
+		if ones, bits := prefix.Mask.Size(); ones < bits {
+			option := make([]byte, 32)
+			option[0] = raOptionPrefixInformation
+			option[1] = 4
+			option[2] = byte(ones)
+			// the prefix is not meant for stateless address autoconfiguration, only the on-link flag is set
+			option[3] = raFlagOnLink
+			binary.BigEndian.PutUint32(option[4:], dhcpv6Infinity)
+			binary.BigEndian.PutUint32(option[8:], dhcpv6Infinity)
+			copy(option[16:], prefix.IP.Mask(prefix.Mask).To16())
+			advertisement = append(advertisement, option...)
+		}
+	}
+	return advertisement
+}
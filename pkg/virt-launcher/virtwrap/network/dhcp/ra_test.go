@@ -0,0 +1,99 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package dhcp
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Router Advertisement", func() {
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+	_, prefix, _ := net.ParseCIDR("fd10:0:2::/120")
+
+	It("should advertise a default router with the on-link prefix", func() {
+		advertisement := prepareRouterAdvertisement(mac, prefix, true, 1450)
+		Expect(advertisement).To(HaveLen(16 + 8 + 8 + 32))
+		Expect(advertisement[0]).To(Equal(byte(icmpv6RouterAdvertisement)))
+		Expect(advertisement[4]).To(Equal(byte(raHopLimit)))
+		Expect(advertisement[5]).To(Equal(byte(raFlagManagedAddress | raFlagOtherConfig)))
+		Expect(advertisement[6:8]).To(Equal([]byte{0x07, 0x08}))
+
+		Expect(advertisement[16:24]).To(Equal([]byte{raOptionSourceLinkLayerAddress, 1, 2, 0, 0, 0, 0, 1}))
+		Expect(advertisement[24:32]).To(Equal([]byte{raOptionMTU, 1, 0, 0, 0, 0, 0x05, 0xaa}))
+
+		prefixOption := advertisement[32:]
+		Expect(prefixOption[:4]).To(Equal([]byte{raOptionPrefixInformation, 4, 120, raFlagOnLink}))
+		Expect(net.IP(prefixOption[16:]).Equal(net.ParseIP("fd10:0:2::"))).To(BeTrue())
+	})
+
+	table.DescribeTable("should set the router lifetime", func(isDefaultRouter bool, lifetime []byte) {
+		advertisement := prepareRouterAdvertisement(mac, prefix, isDefaultRouter, 1450)
+		Expect(advertisement[6:8]).To(Equal(lifetime))
+	},
+		table.Entry("to 1800 seconds if the interface has a gateway", true, []byte{0x07, 0x08}),
+		table.Entry("to zero if the interface has no gateway", false, []byte{0, 0}),
+	)
+
+	Context("the MTU option", func() {
+		It("should carry the MTU", func() {
+			advertisement := prepareRouterAdvertisement(nil, nil, false, 9000)
+			Expect(advertisement).To(HaveLen(16 + 8))
+			Expect(advertisement[16:]).To(Equal([]byte{raOptionMTU, 1, 0, 0, 0, 0, 0x23, 0x28}))
+		})
+
+		It("should be left out if no MTU is known", func() {
+			advertisement := prepareRouterAdvertisement(nil, prefix, false, 0)
+			Expect(advertisement).To(HaveLen(16 + 32))
+			Expect(advertisement[16]).To(Equal(byte(raOptionPrefixInformation)))
+		})
+	})
+
+	Context("the prefix option", func() {
+		It("should advertise the masked prefix as on-link with infinite lifetimes", func() {
+			_, hostPrefix, _ := net.ParseCIDR("fd10:0:2::2/64")
+			hostPrefix.IP = net.ParseIP("fd10:0:2::2")
+			advertisement := prepareRouterAdvertisement(nil, hostPrefix, false, 0)
+			Expect(advertisement).To(HaveLen(16 + 32))
+
+			prefixOption := advertisement[16:]
+			Expect(prefixOption[:4]).To(Equal([]byte{raOptionPrefixInformation, 4, 64, raFlagOnLink}))
+			Expect(prefixOption[4:8]).To(Equal([]byte{0xff, 0xff, 0xff, 0xff}))
+			Expect(prefixOption[8:12]).To(Equal([]byte{0xff, 0xff, 0xff, 0xff}))
+			Expect(prefixOption[12:16]).To(Equal([]byte{0, 0, 0, 0}))
+			Expect(net.IP(prefixOption[16:]).Equal(net.ParseIP("fd10:0:2::"))).To(BeTrue())
+		})
+
+		It("should be left out for a single address", func() {
+			_, single, _ := net.ParseCIDR("fd10:0:2::2/128")
+			advertisement := prepareRouterAdvertisement(mac, single, false, 0)
+			Expect(advertisement).To(HaveLen(16 + 8))
+			Expect(advertisement[16]).To(Equal(byte(raOptionSourceLinkLayerAddress)))
+		})
+
+		It("should be left out if there is no prefix", func() {
+			advertisement := prepareRouterAdvertisement(nil, nil, true, 0)
+			Expect(advertisement).To(HaveLen(16))
+		})
+	})
+})
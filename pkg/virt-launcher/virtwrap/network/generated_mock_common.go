@@ -6,6 +6,7 @@ package network
 import (
 	net "net"
 
+	iptables "github.com/coreos/go-iptables/iptables"
 	gomock "github.com/golang/mock/gomock"
 	netlink "github.com/vishvananda/netlink"
 
@@ -171,6 +172,16 @@ func (_mr *_MockNetworkHandlerRecorder) GenerateRandomMac() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GenerateRandomMac")
 }
 
+func (_m *MockNetworkHandler) GenerateMacFromSeed(seed string) net.HardwareAddr {
+	ret := _m.ctrl.Call(_m, "GenerateMacFromSeed", seed)
+	ret0, _ := ret[0].(net.HardwareAddr)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) GenerateMacFromSeed(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GenerateMacFromSeed", arg0)
+}
+
 func (_m *MockNetworkHandler) GetMacDetails(iface string) (net.HardwareAddr, error) {
 	ret := _m.ctrl.Call(_m, "GetMacDetails", iface)
 	ret0, _ := ret[0].(net.HardwareAddr)
@@ -200,28 +211,38 @@ func (_mr *_MockNetworkHandlerRecorder) StartDHCP(arg0, arg1, arg2, arg3 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartDHCP", arg0, arg1, arg2, arg3)
 }
 
-func (_m *MockNetworkHandler) UseIptables() bool {
-	ret := _m.ctrl.Call(_m, "UseIptables")
+func (_m *MockNetworkHandler) ConfigureIpv6Forwarding() error {
+	ret := _m.ctrl.Call(_m, "ConfigureIpv6Forwarding")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) ConfigureIpv6Forwarding() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConfigureIpv6Forwarding")
+}
+
+func (_m *MockNetworkHandler) UseIptables(proto iptables.Protocol) bool {
+	ret := _m.ctrl.Call(_m, "UseIptables", proto)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) UseIptables() *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "UseIptables")
+func (_mr *_MockNetworkHandlerRecorder) UseIptables(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UseIptables", arg0)
 }
 
-func (_m *MockNetworkHandler) IptablesNewChain(table string, chain string) error {
-	ret := _m.ctrl.Call(_m, "IptablesNewChain", table, chain)
+func (_m *MockNetworkHandler) IptablesNewChain(proto iptables.Protocol, table string, chain string) error {
+	ret := _m.ctrl.Call(_m, "IptablesNewChain", proto, table, chain)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) IptablesNewChain(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesNewChain", arg0, arg1)
+func (_mr *_MockNetworkHandlerRecorder) IptablesNewChain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesNewChain", arg0, arg1, arg2)
 }
 
-func (_m *MockNetworkHandler) IptablesAppendRule(table string, chain string, rulespec ...string) error {
-	_s := []interface{}{table, chain}
+func (_m *MockNetworkHandler) IptablesAppendRule(proto iptables.Protocol, table string, chain string, rulespec ...string) error {
+	_s := []interface{}{proto, table, chain}
 	for _, _x := range rulespec {
 		_s = append(_s, _x)
 	}
@@ -230,23 +251,23 @@ func (_m *MockNetworkHandler) IptablesAppendRule(table string, chain string, rul
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) IptablesAppendRule(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
-	_s := append([]interface{}{arg0, arg1}, arg2...)
+func (_mr *_MockNetworkHandlerRecorder) IptablesAppendRule(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesAppendRule", _s...)
 }
 
-func (_m *MockNetworkHandler) NftablesNewChain(table string, chain string) error {
-	ret := _m.ctrl.Call(_m, "NftablesNewChain", table, chain)
+func (_m *MockNetworkHandler) NftablesNewChain(proto iptables.Protocol, table string, chain string) error {
+	ret := _m.ctrl.Call(_m, "NftablesNewChain", proto, table, chain)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) NftablesNewChain(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesNewChain", arg0, arg1)
+func (_mr *_MockNetworkHandlerRecorder) NftablesNewChain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesNewChain", arg0, arg1, arg2)
 }
 
-func (_m *MockNetworkHandler) NftablesAppendRule(table string, chain string, rulespec ...string) error {
-	_s := []interface{}{table, chain}
+func (_m *MockNetworkHandler) NftablesAppendRule(proto iptables.Protocol, table string, chain string, rulespec ...string) error {
+	_s := []interface{}{proto, table, chain}
 	for _, _x := range rulespec {
 		_s = append(_s, _x)
 	}
@@ -255,8 +276,8 @@ func (_m *MockNetworkHandler) NftablesAppendRule(table string, chain string, rul
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) NftablesAppendRule(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
-	_s := append([]interface{}{arg0, arg1}, arg2...)
+func (_mr *_MockNetworkHandlerRecorder) NftablesAppendRule(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesAppendRule", _s...)
 }
 
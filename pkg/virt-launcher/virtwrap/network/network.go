@@ -36,6 +36,7 @@ const podInterface = "eth0"
 
 var interfaceCacheFile = "/var/run/kubevirt-private/interface-cache-%s.json"
 var qemuArgCacheFile = "/var/run/kubevirt-private/qemu-arg-%s.json"
+var interfaceStatusCacheFile = "/var/run/kubevirt-private/interface-status-%s.json"
 var NetworkInterfaceFactory = getNetworkClass
 
 var podInterfaceName = podInterface
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/coreos/go-iptables/iptables"
 	"github.com/vishvananda/netlink"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -145,6 +146,7 @@ func getBinding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1
 			podInterfaceNum:     podInterfaceNum,
 			podInterfaceName:    podInterfaceName,
 			vmNetworkCIDR:       network.Pod.VMNetworkCIDR,
+			vmIpv6NetworkCIDR:   network.Pod.VMIPv6NetworkCIDR,
 			bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName)}, nil
 	}
 	if iface.Slirp != nil {
@@ -179,13 +181,20 @@ func (b *BridgePodInterface) discoverPodNetworkInterface() error {
 		log.Log.Reason(err).Errorf("failed to get an ip address for %s", b.podInterfaceName)
 		return err
 	}
-	if len(addrList) == 0 {
-		b.isLayer2 = true
-	} else {
+	if len(addrList) > 0 {
 		b.vif.IP = addrList[0]
-		b.isLayer2 = false
 	}
 
+	ipv6Addr, err := getGlobalIpv6Address(b.podNicLink)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get an ipv6 address for %s", b.podInterfaceName)
+		return err
+	}
+	if ipv6Addr != nil {
+		b.vif.IPv6 = *ipv6Addr
+	}
+	b.isLayer2 = b.vif.IP.IPNet == nil && b.vif.IPv6.IPNet == nil
+
 	if len(b.vif.MAC) == 0 {
 		// Get interface MAC address
 		mac, err := Handler.GetMacDetails(b.podInterfaceName)
@@ -203,15 +212,34 @@ func (b *BridgePodInterface) discoverPodNetworkInterface() error {
 	// Get interface MTU
 	b.vif.Mtu = uint16(b.podNicLink.Attrs().MTU)
 
-	if !b.isLayer2 {
+	if b.vif.IP.IPNet != nil {
 		// Handle interface routes
 		if err := b.setInterfaceRoutes(); err != nil {
 			return err
 		}
 	}
+	if b.vif.IPv6.IPNet != nil {
+		if err := b.setInterfaceIpv6Routes(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// getGlobalIpv6Address returns the first IPv6 address of the link which is not link-local, if there is one
+func getGlobalIpv6Address(link netlink.Link) (*netlink.Addr, error) {
+	addrList, err := Handler.AddrList(link, netlink.FAMILY_V6)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrList {
+		if addr.IP.IsGlobalUnicast() {
+			return &addr, nil
+		}
+	}
+	return nil, nil
+}
+
 func (b *BridgePodInterface) preparePodNetworkInterfaces() error {
 	// Set interface link to down to change its MAC address
 	if err := Handler.LinkSetDown(b.podNicLink); err != nil {
@@ -233,12 +261,15 @@ func (b *BridgePodInterface) preparePodNetworkInterfaces() error {
 	}
 
 	if !b.isLayer2 {
-		// Remove IP from POD interface
-		err := Handler.AddrDel(b.podNicLink, &b.vif.IP)
-
-		if err != nil {
-			log.Log.Reason(err).Errorf("failed to delete address for interface: %s", b.podInterfaceName)
-			return err
+		// Remove IPs from POD interface
+		for _, addr := range []netlink.Addr{b.vif.IP, b.vif.IPv6} {
+			if addr.IPNet == nil {
+				continue
+			}
+			if err := Handler.AddrDel(b.podNicLink, &addr); err != nil {
+				log.Log.Reason(err).Errorf("failed to delete address for interface: %s", b.podInterfaceName)
+				return err
+			}
 		}
 
 		b.startDHCPServer()
@@ -284,7 +315,11 @@ func (b *BridgePodInterface) loadCachedInterface(name string) (bool, error) {
 
 func (b *BridgePodInterface) setCachedInterface(name string) error {
 	err := writeToCachedFile(&b.domain.Spec.Devices.Interfaces[b.podInterfaceNum], interfaceCacheFile, name)
-	return err
+	if err != nil {
+		return err
+	}
+	// the guest got the pod IPs
+	return setCachedInterfaceStatus(name, b.vif.MAC, b.vif.IP, b.vif.IPv6)
 }
 
 func (b *BridgePodInterface) setInterfaceRoutes() error {
@@ -304,6 +339,23 @@ func (b *BridgePodInterface) setInterfaceRoutes() error {
 	return nil
 }
 
+// setInterfaceIpv6Routes looks up the IPv6 default gateway of the pod, without it
+// the guest is not advertised a default router
+func (b *BridgePodInterface) setInterfaceIpv6Routes() error {
+	routes, err := Handler.RouteList(b.podNicLink, netlink.FAMILY_V6)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get ipv6 routes for %s", b.podInterfaceName)
+		return err
+	}
+	for _, route := range routes {
+		if route.Dst == nil && route.Gw != nil {
+			b.vif.GatewayIpv6 = route.Gw
+			return nil
+		}
+	}
+	return fmt.Errorf("No ipv6 gateway address found in routes for %s", b.podInterfaceName)
+}
+
 func (b *BridgePodInterface) createBridge() error {
 	// Create a bridge
 	bridge := &netlink.Bridge{
@@ -354,7 +406,11 @@ type MasqueradePodInterface struct {
 	podInterfaceName    string
 	bridgeInterfaceName string
 	vmNetworkCIDR       string
+	vmIpv6NetworkCIDR   string
 	gatewayAddr         *netlink.Addr
+	gatewayIpv6Addr     *netlink.Addr
+	podIP               netlink.Addr
+	podIpv6             netlink.Addr
 }
 
 func (p *MasqueradePodInterface) discoverPodNetworkInterface() error {
@@ -395,6 +451,52 @@ func (p *MasqueradePodInterface) discoverPodNetworkInterface() error {
 	}
 	p.vif.IP = *vmAddr
 
+	addrList, err := Handler.AddrList(p.podNicLink, netlink.FAMILY_V4)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get an ip address for %s", p.podInterfaceName)
+		return err
+	}
+	if len(addrList) > 0 {
+		p.podIP = addrList[0]
+	}
+
+	podIpv6, err := getGlobalIpv6Address(p.podNicLink)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get an ipv6 address for %s", p.podInterfaceName)
+		return err
+	}
+	if podIpv6 != nil {
+		p.podIpv6 = *podIpv6
+		return p.discoverIpv6Addresses()
+	}
+
+	return nil
+}
+
+func (p *MasqueradePodInterface) discoverIpv6Addresses() error {
+	if p.vmIpv6NetworkCIDR == "" {
+		p.vmIpv6NetworkCIDR = api.DefaultVMIpv6CIDR
+	}
+
+	defaultGatewayIpv6, vmIpv6, err := Handler.GetHostAndGwAddressesFromCIDR(p.vmIpv6NetworkCIDR)
+	if err != nil {
+		log.Log.Errorf("failed to get gw and vm available ipv6 addresses from CIDR %s", p.vmIpv6NetworkCIDR)
+		return err
+	}
+
+	gatewayIpv6Addr, err := Handler.ParseAddr(defaultGatewayIpv6)
+	if err != nil {
+		return fmt.Errorf("failed to parse gateway ipv6 address %s", defaultGatewayIpv6)
+	}
+	p.vif.GatewayIpv6 = gatewayIpv6Addr.IP.To16()
+	p.gatewayIpv6Addr = gatewayIpv6Addr
+
+	vmIpv6Addr, err := Handler.ParseAddr(vmIpv6)
+	if err != nil {
+		return fmt.Errorf("failed to parse vm ipv6 address %s", vmIpv6)
+	}
+	p.vif.IPv6 = *vmIpv6Addr
+
 	return nil
 }
 
@@ -413,11 +515,9 @@ func (p *MasqueradePodInterface) preparePodNetworkInterfaces() error {
 	}
 
 	if p.iface.MacAddress == "" {
-		p.vif.MAC, err = Handler.GenerateRandomMac()
-		if err != nil {
-			log.Log.Reason(err).Errorf("failed to generate random mac address")
-			return err
-		}
+		// The DHCP server only answers to the guest mac, so the launcher on a migration
+		// target has to come up with the same mac as the launcher on the source
+		p.vif.MAC = Handler.GenerateMacFromSeed(fmt.Sprintf("%s/%s", p.vmi.UID, p.iface.Name))
 	}
 
 	err = Handler.LinkSetUp(bridgeNic)
@@ -430,12 +530,26 @@ func (p *MasqueradePodInterface) preparePodNetworkInterfaces() error {
 		return err
 	}
 
-	err = p.createNatRules()
+	err = p.createNatRules(iptables.ProtocolIPv4)
 	if err != nil {
 		log.Log.Errorf("failed to create nat rules for vm error: %v", err)
 		return err
 	}
 
+	if p.vif.IPv6.IPNet != nil {
+		err = Handler.ConfigureIpv6Forwarding()
+		if err != nil {
+			log.Log.Reason(err).Errorf("failed to configure ipv6 forwarding")
+			return err
+		}
+
+		err = p.createNatRules(iptables.ProtocolIPv6)
+		if err != nil {
+			log.Log.Errorf("failed to create ipv6 nat rules for vm error: %v", err)
+			return err
+		}
+	}
+
 	p.startDHCPServer()
 
 	return nil
@@ -472,7 +586,11 @@ func (p *MasqueradePodInterface) loadCachedInterface(name string) (bool, error)
 
 func (p *MasqueradePodInterface) setCachedInterface(name string) error {
 	err := writeToCachedFile(&p.domain.Spec.Devices.Interfaces[p.podInterfaceNum], interfaceCacheFile, name)
-	return err
+	if err != nil {
+		return err
+	}
+	// the guest is reachable through the pod IPs
+	return setCachedInterfaceStatus(name, p.vif.MAC, p.podIP, p.podIpv6)
 }
 
 func (p *MasqueradePodInterface) createBridge() error {
@@ -512,47 +630,75 @@ func (p *MasqueradePodInterface) createBridge() error {
 		return err
 	}
 
+	if p.gatewayIpv6Addr != nil {
+		if err := Handler.AddrAdd(bridge, p.gatewayIpv6Addr); err != nil {
+			log.Log.Reason(err).Errorf("failed to set bridge IPv6")
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (p *MasqueradePodInterface) createNatRules() error {
-	if Handler.UseIptables() {
-		return p.createNatRulesUsingIptables()
+func (p *MasqueradePodInterface) createNatRules(proto iptables.Protocol) error {
+	if Handler.UseIptables(proto) {
+		return p.createNatRulesUsingIptables(proto)
+	}
+	return p.createNatRulesUsingNftables(proto)
+}
+
+func (p *MasqueradePodInterface) getVifIpByProtocol(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 {
+		return p.vif.IPv6.IP.String()
 	}
-	return p.createNatRulesUsingNftables()
+	return p.vif.IP.IP.String()
 }
 
-func (p *MasqueradePodInterface) createNatRulesUsingIptables() error {
-	err := Handler.IptablesNewChain("nat", "KUBEVIRT_PREINBOUND")
+func (p *MasqueradePodInterface) getGatewayByProtocol(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 {
+		return p.gatewayIpv6Addr.IP.String()
+	}
+	return p.gatewayAddr.IP.String()
+}
+
+func getLoopbackAddress(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 {
+		return "::1"
+	}
+	return "127.0.0.1"
+}
+
+func (p *MasqueradePodInterface) createNatRulesUsingIptables(proto iptables.Protocol) error {
+	err := Handler.IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.IptablesNewChain("nat", "KUBEVIRT_POSTINBOUND")
+	err = Handler.IptablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.IptablesAppendRule("nat", "POSTROUTING", "-s", p.vif.IP.IP.String(), "-j", "MASQUERADE")
+	err = Handler.IptablesAppendRule(proto, "nat", "POSTROUTING", "-s", p.getVifIpByProtocol(proto), "-j", "MASQUERADE")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.IptablesAppendRule("nat", "PREROUTING", "-i", p.podInterfaceName, "-j", "KUBEVIRT_PREINBOUND")
+	err = Handler.IptablesAppendRule(proto, "nat", "PREROUTING", "-i", p.podInterfaceName, "-j", "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.IptablesAppendRule("nat", "POSTROUTING", "-o", p.bridgeInterfaceName, "-j", "KUBEVIRT_POSTINBOUND")
+	err = Handler.IptablesAppendRule(proto, "nat", "POSTROUTING", "-o", p.bridgeInterfaceName, "-j", "KUBEVIRT_POSTINBOUND")
 	if err != nil {
 		return err
 	}
 
 	if len(p.iface.Ports) == 0 {
-		err = Handler.IptablesAppendRule("nat", "KUBEVIRT_PREINBOUND",
+		err = Handler.IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
 			"-j",
 			"DNAT",
-			"--to-destination", p.vif.IP.IP.String())
+			"--to-destination", p.getVifIpByProtocol(proto))
 
 		return err
 	}
@@ -562,39 +708,39 @@ func (p *MasqueradePodInterface) createNatRulesUsingIptables() error {
 			port.Protocol = "tcp"
 		}
 
-		err = Handler.IptablesAppendRule("nat", "KUBEVIRT_POSTINBOUND",
+		err = Handler.IptablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
 			"-p",
 			strings.ToLower(port.Protocol),
 			"--dport",
 			strconv.Itoa(int(port.Port)),
 			"-j",
 			"SNAT",
-			"--to-source", p.gatewayAddr.IP.String())
+			"--to-source", p.getGatewayByProtocol(proto))
 		if err != nil {
 			return err
 		}
 
-		err = Handler.IptablesAppendRule("nat", "KUBEVIRT_PREINBOUND",
+		err = Handler.IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
 			"-p",
 			strings.ToLower(port.Protocol),
 			"--dport",
 			strconv.Itoa(int(port.Port)),
 			"-j",
 			"DNAT",
-			"--to-destination", p.vif.IP.IP.String())
+			"--to-destination", p.getVifIpByProtocol(proto))
 		if err != nil {
 			return err
 		}
 
-		err = Handler.IptablesAppendRule("nat", "OUTPUT",
+		err = Handler.IptablesAppendRule(proto, "nat", "OUTPUT",
 			"-p",
 			strings.ToLower(port.Protocol),
 			"--dport",
 			strconv.Itoa(int(port.Port)),
-			"--destination", "127.0.0.1",
+			"--destination", getLoopbackAddress(proto),
 			"-j",
 			"DNAT",
-			"--to-destination", p.vif.IP.IP.String())
+			"--to-destination", p.getVifIpByProtocol(proto))
 		if err != nil {
 			return err
 		}
@@ -603,40 +749,44 @@ func (p *MasqueradePodInterface) createNatRulesUsingIptables() error {
 	return nil
 }
 
-func (p *MasqueradePodInterface) createNatRulesUsingNftables() error {
-	err := Handler.NftablesLoad("ipv4-nat")
+func (p *MasqueradePodInterface) createNatRulesUsingNftables(proto iptables.Protocol) error {
+	nftablesConfig := "ipv4-nat"
+	if proto == iptables.ProtocolIPv6 {
+		nftablesConfig = "ipv6-nat"
+	}
+	err := Handler.NftablesLoad(nftablesConfig)
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesNewChain("nat", "KUBEVIRT_PREINBOUND")
+	err = Handler.NftablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesNewChain("nat", "KUBEVIRT_POSTINBOUND")
+	err = Handler.NftablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule("nat", "postrouting", "ip", "saddr", p.vif.IP.IP.String(), "counter", "masquerade")
+	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", getNFTIPString(proto), "saddr", p.getVifIpByProtocol(proto), "counter", "masquerade")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule("nat", "prerouting", "iifname", p.podInterfaceName, "counter", "jump", "KUBEVIRT_PREINBOUND")
+	err = Handler.NftablesAppendRule(proto, "nat", "prerouting", "iifname", p.podInterfaceName, "counter", "jump", "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule("nat", "postrouting", "oifname", p.bridgeInterfaceName, "counter", "jump", "KUBEVIRT_POSTINBOUND")
+	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", "oifname", p.bridgeInterfaceName, "counter", "jump", "KUBEVIRT_POSTINBOUND")
 	if err != nil {
 		return err
 	}
 
 	if len(p.iface.Ports) == 0 {
-		err = Handler.NftablesAppendRule("nat", "KUBEVIRT_PREINBOUND",
-			"counter", "dnat", "to", p.vif.IP.IP.String())
+		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
 
 		return err
 	}
@@ -646,30 +796,30 @@ func (p *MasqueradePodInterface) createNatRulesUsingNftables() error {
 			port.Protocol = "tcp"
 		}
 
-		err = Handler.NftablesAppendRule("nat", "KUBEVIRT_POSTINBOUND",
+		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
 			strings.ToLower(port.Protocol),
 			"dport",
 			strconv.Itoa(int(port.Port)),
-			"counter", "snat", "to", p.gatewayAddr.IP.String())
+			"counter", "snat", "to", p.getGatewayByProtocol(proto))
 		if err != nil {
 			return err
 		}
 
-		err = Handler.NftablesAppendRule("nat", "KUBEVIRT_PREINBOUND",
+		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
 			strings.ToLower(port.Protocol),
 			"dport",
 			strconv.Itoa(int(port.Port)),
-			"counter", "dnat", "to", p.vif.IP.IP.String())
+			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
 		if err != nil {
 			return err
 		}
 
-		err = Handler.NftablesAppendRule("nat", "output",
-			"ip", "daddr", "127.0.0.1",
+		err = Handler.NftablesAppendRule(proto, "nat", "output",
+			getNFTIPString(proto), "daddr", getLoopbackAddress(proto),
 			strings.ToLower(port.Protocol),
 			"dport",
 			strconv.Itoa(int(port.Port)),
-			"counter", "dnat", "to", p.vif.IP.IP.String())
+			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
 		if err != nil {
 			return err
 		}
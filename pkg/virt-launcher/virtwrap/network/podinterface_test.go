@@ -27,6 +27,7 @@ import (
 	"net"
 	"os"
 
+	"github.com/coreos/go-iptables/iptables"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -64,6 +65,7 @@ var _ = Describe("Pod Network", func() {
 	BeforeEach(func() {
 		tmpDir, _ := ioutil.TempDir("", "networktest")
 		setInterfaceCacheFile(tmpDir + "/cache-%s.json")
+		setInterfaceStatusCacheFile(tmpDir + "/status-%s.json")
 
 		ctrl = gomock.NewController(GinkgoT())
 		mockNetwork = NewMockNetworkHandler(ctrl)
@@ -117,6 +119,7 @@ var _ = Describe("Pod Network", func() {
 		//For Bridge tests
 		mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
 		mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(addrList, nil)
+		mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return(nil, nil)
 		mockNetwork.EXPECT().RouteList(dummy, netlink.FAMILY_V4).Return(routeList, nil)
 		mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
 		mockNetwork.EXPECT().AddrDel(dummy, &fakeAddr).Return(nil)
@@ -138,32 +141,32 @@ var _ = Describe("Pod Network", func() {
 		mockNetwork.EXPECT().LinkAdd(masqueradeDummy).Return(nil)
 		mockNetwork.EXPECT().LinkByName(masqueradeDummyName).Return(masqueradeDummy, nil)
 		mockNetwork.EXPECT().LinkSetUp(masqueradeDummy).Return(nil)
-		mockNetwork.EXPECT().GenerateRandomMac().Return(fakeMac, nil)
+		mockNetwork.EXPECT().GenerateMacFromSeed(fmt.Sprintf("%s/default", vm.UID)).Return(fakeMac)
 		mockNetwork.EXPECT().LinkSetMaster(masqueradeDummy, bridgeTest).Return(nil)
 		mockNetwork.EXPECT().AddrAdd(bridgeTest, masqueradeGwAddr).Return(nil)
 		mockNetwork.EXPECT().StartDHCP(masqueradeTestNic, masqueradeGwAddr, api.DefaultBridgeName, nil)
 		mockNetwork.EXPECT().GetHostAndGwAddressesFromCIDR(api.DefaultVMCIDR).Return("10.0.2.1/30", "10.0.2.2/30", nil)
 		// Global nat rules using iptables
-		mockNetwork.EXPECT().IptablesNewChain("nat", gomock.Any()).Return(nil).AnyTimes()
-		mockNetwork.EXPECT().IptablesAppendRule("nat",
+		mockNetwork.EXPECT().IptablesNewChain(iptables.ProtocolIPv4, "nat", gomock.Any()).Return(nil).AnyTimes()
+		mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 			"POSTROUTING",
 			"-s",
 			"10.0.2.2",
 			"-j",
 			"MASQUERADE").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().IptablesAppendRule("nat",
+		mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 			"PREROUTING",
 			"-i",
 			"eth0",
 			"-j",
 			"KUBEVIRT_PREINBOUND").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().IptablesAppendRule("nat",
+		mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 			"POSTROUTING",
 			"-o",
 			"k6t-eth0",
 			"-j",
 			"KUBEVIRT_POSTINBOUND").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().IptablesAppendRule("nat",
+		mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 			"KUBEVIRT_PREINBOUND",
 			"-j",
 			"DNAT",
@@ -171,11 +174,11 @@ var _ = Describe("Pod Network", func() {
 			"10.0.2.2").Return(nil).AnyTimes()
 		//Global net rules using nftable
 		mockNetwork.EXPECT().NftablesLoad("ipv4-nat").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().NftablesNewChain("nat", "KUBEVIRT_PREINBOUND").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().NftablesNewChain("nat", "KUBEVIRT_POSTINBOUND").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().NftablesAppendRule("nat", "postrouting", "ip", "saddr", "10.0.2.2", "counter", "masquerade").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().NftablesAppendRule("nat", "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil).AnyTimes()
-		mockNetwork.EXPECT().NftablesAppendRule("nat", "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil).AnyTimes()
+		mockNetwork.EXPECT().NftablesNewChain(iptables.ProtocolIPv4, "nat", "KUBEVIRT_PREINBOUND").Return(nil).AnyTimes()
+		mockNetwork.EXPECT().NftablesNewChain(iptables.ProtocolIPv4, "nat", "KUBEVIRT_POSTINBOUND").Return(nil).AnyTimes()
+		mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat", "postrouting", "ip", "saddr", "10.0.2.2", "counter", "masquerade").Return(nil).AnyTimes()
+		mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat", "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil).AnyTimes()
+		mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat", "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil).AnyTimes()
 
 		err := SetupPodNetwork(vm, domain)
 		Expect(err).To(BeNil())
@@ -226,6 +229,7 @@ var _ = Describe("Pod Network", func() {
 				mockNetwork.EXPECT().LinkSetDown(dummy).Return(nil)
 				mockNetwork.EXPECT().SetRandomMac(podInterface).Return(updateFakeMac, nil)
 				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(addrList, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return(nil, nil)
 				mockNetwork.EXPECT().LinkAdd(bridgeTest).Return(nil)
 				mockNetwork.EXPECT().LinkByName(api.DefaultBridgeName).Return(bridgeTest, nil)
 				mockNetwork.EXPECT().LinkSetUp(bridgeTest).Return(nil)
@@ -251,6 +255,7 @@ var _ = Describe("Pod Network", func() {
 
 			mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
 			mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(addrList, nil)
+			mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return(nil, nil)
 			mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
 
 			err := SetupPodNetwork(vm, domain)
@@ -393,8 +398,8 @@ var _ = Describe("Pod Network", func() {
 		Context("Masquerade Plug", func() {
 			It("should define a new VIF bind to a bridge and create a default nat rule using iptables", func() {
 				// forward all the traffic
-				mockNetwork.EXPECT().UseIptables().Return(true).AnyTimes()
-				mockNetwork.EXPECT().IptablesAppendRule("nat",
+				mockNetwork.EXPECT().UseIptables(iptables.ProtocolIPv4).Return(true).AnyTimes()
+				mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_PREINBOUND",
 					"-j",
 					"DNAT",
@@ -408,20 +413,20 @@ var _ = Describe("Pod Network", func() {
 			})
 			It("should define a new VIF bind to a bridge and create a specific nat rule using iptables", func() {
 				// Forward a specific port
-				mockNetwork.EXPECT().UseIptables().Return(true).AnyTimes()
-				mockNetwork.EXPECT().IptablesAppendRule("nat",
+				mockNetwork.EXPECT().UseIptables(iptables.ProtocolIPv4).Return(true).AnyTimes()
+				mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_POSTINBOUND",
 					"-p",
 					"tcp",
 					"--dport",
 					"80", "-j", "SNAT", "--to-source", "10.0.2.1").Return(nil).AnyTimes()
-				mockNetwork.EXPECT().IptablesAppendRule("nat",
+				mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_PREINBOUND",
 					"-p",
 					"tcp",
 					"--dport",
 					"80", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil).AnyTimes()
-				mockNetwork.EXPECT().IptablesAppendRule("nat",
+				mockNetwork.EXPECT().IptablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"OUTPUT",
 					"-p",
 					"tcp",
@@ -437,8 +442,8 @@ var _ = Describe("Pod Network", func() {
 			})
 			It("should define a new VIF bind to a bridge and create a default nat rule using nftables", func() {
 				// forward all the traffic
-				mockNetwork.EXPECT().UseIptables().Return(false).AnyTimes()
-				mockNetwork.EXPECT().NftablesAppendRule("nat",
+				mockNetwork.EXPECT().UseIptables(iptables.ProtocolIPv4).Return(false).AnyTimes()
+				mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_PREINBOUND",
 					"counter",
 					"dnat",
@@ -452,20 +457,20 @@ var _ = Describe("Pod Network", func() {
 			})
 			It("should define a new VIF bind to a bridge and create a specific nat rule using nftables", func() {
 				// Forward a specific port
-				mockNetwork.EXPECT().UseIptables().Return(false).AnyTimes()
-				mockNetwork.EXPECT().NftablesAppendRule("nat",
+				mockNetwork.EXPECT().UseIptables(iptables.ProtocolIPv4).Return(false).AnyTimes()
+				mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_POSTINBOUND",
 					"tcp",
 					"dport",
 					"80",
 					"counter", "snat", "to", "10.0.2.1").Return(nil).AnyTimes()
-				mockNetwork.EXPECT().NftablesAppendRule("nat",
+				mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"KUBEVIRT_PREINBOUND",
 					"tcp",
 					"dport",
 					"80",
 					"counter", "dnat", "to", "10.0.2.2").Return(nil).AnyTimes()
-				mockNetwork.EXPECT().NftablesAppendRule("nat",
+				mockNetwork.EXPECT().NftablesAppendRule(iptables.ProtocolIPv4, "nat",
 					"output",
 					"ip", "daddr", "127.0.0.1",
 					"tcp",
@@ -479,7 +484,179 @@ var _ = Describe("Pod Network", func() {
 				api.SetObjectDefaults_Domain(domain)
 				TestPodInterfaceIPBinding(vm, domain)
 			})
+		})
+		Context("with IPv6 on the pod network", func() {
+			var podIpv6Addr netlink.Addr
+			var linkLocalAddr netlink.Addr
+			var ipv6RouteList []netlink.Route
+
+			BeforeEach(func() {
+				podIpv6Addr = netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("fd00::6"), Mask: net.CIDRMask(64, 128)}}
+				linkLocalAddr = netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("fe80::6"), Mask: net.CIDRMask(64, 128)}}
+				_, podIpv6Network, _ := net.ParseCIDR("fd00::/64")
+				ipv6RouteList = []netlink.Route{{Dst: podIpv6Network}, {Gw: net.ParseIP("fe80::1")}}
+			})
+
+			expectInterfaceStatus := func(driver BindMechanism, mac net.HardwareAddr) {
+				Expect(driver.setCachedInterface("default")).To(Succeed())
+				statuses, err := ReadCachedInterfaceStatuses()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statuses).To(Equal([]api.InterfaceStatus{{
+					Name: "default",
+					Mac:  mac.String(),
+					Ip:   "10.35.0.6",
+					IPs:  []string{"10.35.0.6", "fd00::6"},
+				}}))
+			}
+
+			It("should hand both pod addresses to the vm with bridge binding", func() {
+				domain := NewDomainWithBridgeInterface()
+				vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+				api.SetObjectDefaults_Domain(domain)
+
+				expectedNic := *testNic
+				expectedNic.IPv6 = podIpv6Addr
+				expectedNic.GatewayIpv6 = net.ParseIP("fe80::1")
+
+				mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(addrList, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return([]netlink.Addr{linkLocalAddr, podIpv6Addr}, nil)
+				mockNetwork.EXPECT().RouteList(dummy, netlink.FAMILY_V4).Return(routeList, nil)
+				mockNetwork.EXPECT().RouteList(dummy, netlink.FAMILY_V6).Return(ipv6RouteList, nil)
+				mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
+				mockNetwork.EXPECT().LinkSetDown(dummy).Return(nil)
+				mockNetwork.EXPECT().SetRandomMac(podInterface).Return(updateFakeMac, nil)
+				mockNetwork.EXPECT().LinkSetUp(dummy).Return(nil)
+				mockNetwork.EXPECT().LinkAdd(bridgeTest).Return(nil)
+				mockNetwork.EXPECT().LinkSetMaster(dummy, bridgeTest).Return(nil)
+				mockNetwork.EXPECT().LinkSetUp(bridgeTest).Return(nil)
+				mockNetwork.EXPECT().ParseAddr(fmt.Sprintf(bridgeFakeIP, 0)).Return(bridgeAddr, nil)
+				mockNetwork.EXPECT().AddrAdd(bridgeTest, bridgeAddr).Return(nil)
+				mockNetwork.EXPECT().AddrDel(dummy, &fakeAddr).Return(nil)
+				mockNetwork.EXPECT().AddrDel(dummy, &podIpv6Addr).Return(nil)
+				mockNetwork.EXPECT().LinkSetLearningOff(dummy).Return(nil)
+				mockNetwork.EXPECT().StartDHCP(&expectedNic, bridgeAddr, api.DefaultBridgeName, nil)
+
+				driver, err := getBinding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+				Expect(err).ToNot(HaveOccurred())
+				TestRunPlug(driver)
+				expectInterfaceStatus(driver, fakeMac)
+			})
+
+			It("should not treat an IPv6 only pod network as layer 2 network with bridge binding", func() {
+				domain := NewDomainWithBridgeInterface()
+				vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+				api.SetObjectDefaults_Domain(domain)
+
+				mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(nil, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return([]netlink.Addr{linkLocalAddr, podIpv6Addr}, nil)
+				mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
+				mockNetwork.EXPECT().RouteList(dummy, netlink.FAMILY_V6).Return(ipv6RouteList, nil)
 
+				driver, err := getBinding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(driver.discoverPodNetworkInterface()).To(Succeed())
+				bridge := driver.(*BridgePodInterface)
+				Expect(bridge.isLayer2).To(BeFalse())
+				Expect(bridge.vif.IP.IPNet).To(BeNil())
+				Expect(bridge.vif.IPv6).To(Equal(podIpv6Addr))
+				Expect(bridge.vif.GatewayIpv6).To(Equal(net.ParseIP("fe80::1")))
+			})
+
+			It("should fail with bridge binding if the pod has no IPv6 default gateway", func() {
+				domain := NewDomainWithBridgeInterface()
+				vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+				api.SetObjectDefaults_Domain(domain)
+
+				mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(nil, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return([]netlink.Addr{linkLocalAddr, podIpv6Addr}, nil)
+				mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
+				mockNetwork.EXPECT().RouteList(dummy, netlink.FAMILY_V6).Return(ipv6RouteList[:1], nil)
+
+				driver, err := getBinding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(driver.discoverPodNetworkInterface()).To(MatchError("No ipv6 gateway address found in routes for eth0"))
+			})
+
+			It("should create IPv6 nat rules and serve the vm an IPv6 address with masquerade binding", func() {
+				domain := NewDomainWithBridgeInterface()
+				vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+				api.SetObjectDefaults_Domain(domain)
+
+				gatewayIpv6Str := "fd10:0:2::1/120"
+				gatewayIpv6Addr, _ := netlink.ParseAddr(gatewayIpv6Str)
+				vmIpv6Str := "fd10:0:2::2/120"
+				vmIpv6Addr, _ := netlink.ParseAddr(vmIpv6Str)
+
+				expectedNic := *masqueradeTestNic
+				expectedNic.IPv6 = *vmIpv6Addr
+				expectedNic.GatewayIpv6 = gatewayIpv6Addr.IP.To16()
+
+				mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+				mockNetwork.EXPECT().GetHostAndGwAddressesFromCIDR(api.DefaultVMCIDR).Return(masqueradeGwStr, masqueradeVmStr, nil)
+				mockNetwork.EXPECT().ParseAddr(masqueradeGwStr).Return(masqueradeGwAddr, nil)
+				mockNetwork.EXPECT().ParseAddr(masqueradeVmStr).Return(masqueradeVmAddr, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return(addrList, nil)
+				mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V6).Return([]netlink.Addr{linkLocalAddr, podIpv6Addr}, nil)
+				mockNetwork.EXPECT().GetHostAndGwAddressesFromCIDR(api.DefaultVMIpv6CIDR).Return(gatewayIpv6Str, vmIpv6Str, nil)
+				mockNetwork.EXPECT().ParseAddr(gatewayIpv6Str).Return(gatewayIpv6Addr, nil)
+				mockNetwork.EXPECT().ParseAddr(vmIpv6Str).Return(vmIpv6Addr, nil)
+				mockNetwork.EXPECT().LinkAdd(masqueradeDummy).Return(nil)
+				mockNetwork.EXPECT().GenerateMacFromSeed(fmt.Sprintf("%s/default", vmi.UID)).Return(fakeMac)
+				mockNetwork.EXPECT().LinkSetUp(masqueradeDummy).Return(nil)
+				mockNetwork.EXPECT().LinkByName(masqueradeDummyName).Return(masqueradeDummy, nil)
+				mockNetwork.EXPECT().LinkAdd(bridgeTest).Return(nil)
+				mockNetwork.EXPECT().LinkSetMaster(masqueradeDummy, bridgeTest).Return(nil)
+				mockNetwork.EXPECT().LinkSetUp(bridgeTest).Return(nil)
+				mockNetwork.EXPECT().AddrAdd(bridgeTest, masqueradeGwAddr).Return(nil)
+				mockNetwork.EXPECT().AddrAdd(bridgeTest, gatewayIpv6Addr).Return(nil)
+				mockNetwork.EXPECT().ConfigureIpv6Forwarding().Return(nil)
+				mockNetwork.EXPECT().UseIptables(gomock.Any()).Return(true).Times(2)
+				for proto, vmIP := range map[iptables.Protocol]string{iptables.ProtocolIPv4: "10.0.2.2", iptables.ProtocolIPv6: "fd10:0:2::2"} {
+					mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
+					mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
+					mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "POSTROUTING", "-s", vmIP, "-j", "MASQUERADE").Return(nil)
+					mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "PREROUTING", "-i", "eth0", "-j", "KUBEVIRT_PREINBOUND").Return(nil)
+					mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "POSTROUTING", "-o", "k6t-eth0", "-j", "KUBEVIRT_POSTINBOUND").Return(nil)
+					mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND", "-j", "DNAT", "--to-destination", vmIP).Return(nil)
+				}
+				mockNetwork.EXPECT().StartDHCP(&expectedNic, masqueradeGwAddr, api.DefaultBridgeName, nil)
+
+				driver, err := getBinding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+				Expect(err).ToNot(HaveOccurred())
+				TestRunPlug(driver)
+				expectInterfaceStatus(driver, fakeMac)
+			})
+
+			It("should use the ip6 family for IPv6 nat rules using nftables", func() {
+				vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+				vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "test", Port: 80, Protocol: "TCP"}}
+				gatewayIpv6Addr, _ := netlink.ParseAddr("fd10:0:2::1/120")
+				vmIpv6Addr, _ := netlink.ParseAddr("fd10:0:2::2/120")
+				driver := &MasqueradePodInterface{
+					iface:               &vmi.Spec.Domain.Devices.Interfaces[0],
+					vif:                 &VIF{Name: podInterface, IPv6: *vmIpv6Addr},
+					podInterfaceName:    podInterface,
+					bridgeInterfaceName: api.DefaultBridgeName,
+					gatewayIpv6Addr:     gatewayIpv6Addr,
+				}
+
+				proto := iptables.ProtocolIPv6
+				mockNetwork.EXPECT().UseIptables(proto).Return(false)
+				mockNetwork.EXPECT().NftablesLoad("ipv6-nat").Return(nil)
+				mockNetwork.EXPECT().NftablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
+				mockNetwork.EXPECT().NftablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "postrouting", "ip6", "saddr", "fd10:0:2::2", "counter", "masquerade").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND", "tcp", "dport", "80", "counter", "snat", "to", "fd10:0:2::1").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND", "tcp", "dport", "80", "counter", "dnat", "to", "fd10:0:2::2").Return(nil)
+				mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "output", "ip6", "daddr", "::1", "tcp", "dport", "80", "counter", "dnat", "to", "fd10:0:2::2").Return(nil)
+
+				Expect(driver.createNatRules(proto)).To(Succeed())
+			})
 		})
 		Context("Slirp Plug", func() {
 			It("Should create an interface in the qemu command line and remove it from the interfaces", func() {
@@ -145,4 +145,10 @@ type DomainStatsMemory struct {
 	SwapIn           uint64
 	SwapOutSet       bool
 	SwapOut          uint64
+	UsableSet        bool
+	Usable           uint64
+	MajorFaultSet    bool
+	MajorFault       uint64
+	MinorFaultSet    bool
+	MinorFault       uint64
 }
@@ -84,6 +84,31 @@ var _ = Describe("StatsConverter", func() {
 			Expect(len(out.Block)).To(Equal(len(testStats[0].Block)))
 		})
 
+		It("should convert the guest memory stats", func() {
+			inMem := []libvirt.DomainMemoryStat{
+				{Tag: int32(libvirt.DOMAIN_MEMORY_STAT_UNUSED), Val: 1024},
+				{Tag: int32(libvirt.DOMAIN_MEMORY_STAT_USABLE), Val: 2048},
+				{Tag: int32(libvirt.DOMAIN_MEMORY_STAT_ACTUAL_BALLOON), Val: 4096},
+				{Tag: int32(libvirt.DOMAIN_MEMORY_STAT_MAJOR_FAULT), Val: 3},
+				{Tag: int32(libvirt.DOMAIN_MEMORY_STAT_MINOR_FAULT), Val: 7},
+			}
+
+			out := Convert_libvirt_MemoryStat_to_stats_DomainStatsMemory(inMem)
+
+			Expect(*out).To(Equal(stats.DomainStatsMemory{
+				UnusedSet:        true,
+				Unused:           1024,
+				UsableSet:        true,
+				Usable:           2048,
+				ActualBalloonSet: true,
+				ActualBalloon:    4096,
+				MajorFaultSet:    true,
+				MajorFault:       3,
+				MinorFaultSet:    true,
+				MinorFault:       7,
+			}))
+		})
+
 		It("should convert valid input", func() {
 			in := &testStats[0]
 			inMem := []libvirt.DomainMemoryStat{}
@@ -140,6 +140,7 @@ func Execute() {
 		InstallStrategyJob:       app.informerFactory.OperatorInstallStrategyJob(),
 		InfrastructurePod:        app.informerFactory.OperatorPod(),
 		PodDisruptionBudget:      app.informerFactory.OperatorPodDisruptionBudget(),
+		KubeVirtConfigMap:        app.informerFactory.ConfigMap(),
 	}
 
 	app.stores = util.Stores{
@@ -157,6 +158,7 @@ func Execute() {
 		InstallStrategyJobCache:       app.informerFactory.OperatorInstallStrategyJob().GetStore(),
 		InfrastructurePodCache:        app.informerFactory.OperatorPod().GetStore(),
 		PodDisruptionBudgetCache:      app.informerFactory.OperatorPodDisruptionBudget().GetStore(),
+		KubeVirtConfigMapCache:        app.informerFactory.ConfigMap().GetStore(),
 	}
 
 	onOpenShift, err := util.IsOnOpenshift(app.clientSet)
@@ -91,6 +91,7 @@ func NewVirtualMachineCrd() *extv1beta1.CustomResourceDefinition {
 		},
 		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
 			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+			{Name: "Status", Type: "string", JSONPath: ".status.printableStatus"},
 			{Name: "Running", Type: "boolean", JSONPath: ".spec.running"},
 			{Name: "Volume", Description: "Primary Volume", Type: "string", JSONPath: ".spec.volumes[0].name"},
 		},
@@ -188,6 +189,68 @@ func NewVirtualMachineInstanceMigrationCrd() *extv1beta1.CustomResourceDefinitio
 	return crd
 }
 
+func NewVirtualMachineSnapshotCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinesnapshots." + virtv1.VirtualMachineSnapshotGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineSnapshotGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinesnapshots",
+			Singular:   "virtualmachinesnapshot",
+			Kind:       virtv1.VirtualMachineSnapshotGroupVersionKind.Kind,
+			ShortNames: []string{"vmsnapshot", "vmsnapshots"},
+		},
+	}
+
+	return crd
+}
+
+func NewVirtualMachineRestoreCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinerestores." + virtv1.VirtualMachineRestoreGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineRestoreGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinerestores",
+			Singular:   "virtualmachinerestore",
+			Kind:       virtv1.VirtualMachineRestoreGroupVersionKind.Kind,
+			ShortNames: []string{"vmrestore", "vmrestores"},
+		},
+	}
+
+	return crd
+}
+
+func NewMigrationPolicyCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "migrationpolicies." + virtv1.MigrationPolicyGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.MigrationPolicyGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Cluster",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:   "migrationpolicies",
+			Singular: "migrationpolicy",
+			Kind:     virtv1.MigrationPolicyGroupVersionKind.Kind,
+		},
+	}
+
+	return crd
+}
+
 // Used by manifest generation
 // If you change something here, you probably need to change the CSV manifest too,
 // see /manifests/release/kubevirt.VERSION.csv.yaml.in
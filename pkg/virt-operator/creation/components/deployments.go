@@ -310,7 +310,7 @@ func NewControllerDeployment(namespace string, repository string, controllerVers
 	return deployment, nil
 }
 
-func NewHandlerDaemonSet(namespace string, repository string, version string, pullPolicy corev1.PullPolicy, verbosity string) (*appsv1.DaemonSet, error) {
+func NewHandlerDaemonSet(namespace string, repository string, version string, launcherVersion string, pullPolicy corev1.PullPolicy, verbosity string, cpuNodeDiscovery bool) (*appsv1.DaemonSet, error) {
 	podTemplateSpec, err := newPodTemplateSpec("virt-handler", repository, version, pullPolicy, nil)
 	if err != nil {
 		return nil, err
@@ -424,10 +424,48 @@ func NewHandlerDaemonSet(namespace string, repository string, version string, pu
 		})
 	}
 
+	if cpuNodeDiscovery {
+		addNodeLabellerContainer(pod, container, repository, launcherVersion, pullPolicy)
+	}
+
 	return daemonset, nil
 
 }
 
+// addNodeLabellerContainer adds a sidecar which periodically stores the libvirt domain capabilities
+// of the node in a volume shared with virt-handler, where the node labeller reads them
+func addNodeLabellerContainer(pod *corev1.PodSpec, handler *corev1.Container, repository string, launcherVersion string, pullPolicy corev1.PullPolicy) {
+	nodeLabellerVolumeMount := corev1.VolumeMount{
+		Name:      "node-labeller",
+		MountPath: "/var/lib/kubevirt-node-labeller",
+	}
+	handler.VolumeMounts = append(handler.VolumeMounts, nodeLabellerVolumeMount)
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: nodeLabellerVolumeMount.Name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	launcherVersion = AddVersionSeparatorPrefix(launcherVersion)
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:            "node-labeller",
+		Image:           fmt.Sprintf("%s/%s%s", repository, "virt-launcher", launcherVersion),
+		ImagePullPolicy: pullPolicy,
+		Command: []string{
+			"/bin/sh",
+			"-c",
+		},
+		Args: []string{
+			"node-labeller.sh",
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: boolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{nodeLabellerVolumeMount},
+	})
+}
+
 // Used for manifest generation only
 func NewOperatorDeployment(namespace string, repository string, version string,
 	pullPolicy corev1.PullPolicy, verbosity string,
@@ -123,6 +123,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/console",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/guestosinfo",
 				},
 				Verbs: []string{
 					"get",
@@ -133,9 +134,18 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"subresources.kubevirt.io",
 				},
 				Resources: []string{
+					"virtualmachineinstances/pause",
+					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/addvolume",
+					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/migrate",
 				},
 				Verbs: []string{
 					"update",
@@ -151,6 +161,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinesnapshots",
+					"virtualmachinerestores",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -181,6 +193,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/console",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/guestosinfo",
 				},
 				Verbs: []string{
 					"get",
@@ -191,9 +204,18 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"subresources.kubevirt.io",
 				},
 				Resources: []string{
+					"virtualmachineinstances/pause",
+					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/addvolume",
+					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/insertmedia",
+					"virtualmachineinstances/ejectmedia",
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/migrate",
 				},
 				Verbs: []string{
 					"update",
@@ -209,6 +231,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinesnapshots",
+					"virtualmachinerestores",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -232,6 +256,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 			},
 		},
 		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/guestosinfo",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -242,6 +277,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinesnapshots",
+					"virtualmachinerestores",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -123,12 +123,34 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"",
 				},
 				Resources: []string{
-					"persistentvolumeclaims",
+					"namespaces",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"persistentvolumeclaims",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "create", "update", "delete",
+				},
+			},
+			{
+				APIGroups: []string{
+					"snapshot.storage.k8s.io",
+				},
+				Resources: []string{
+					"volumesnapshots",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "create", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -140,6 +162,18 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"*",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"cdi.kubevirt.io",
@@ -77,6 +77,17 @@ func newHandlerClusterRole() *rbacv1.ClusterRole {
 					"update", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"migrationpolicies",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -96,7 +107,7 @@ func newHandlerClusterRole() *rbacv1.ClusterRole {
 					"nodes",
 				},
 				Verbs: []string{
-					"patch",
+					"get", "patch",
 				},
 			},
 			{
@@ -188,6 +188,9 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 	strategy.crds = append(strategy.crds, components.NewReplicaSetCrd())
 	strategy.crds = append(strategy.crds, components.NewVirtualMachineCrd())
 	strategy.crds = append(strategy.crds, components.NewVirtualMachineInstanceMigrationCrd())
+	strategy.crds = append(strategy.crds, components.NewVirtualMachineSnapshotCrd())
+	strategy.crds = append(strategy.crds, components.NewVirtualMachineRestoreCrd())
+	strategy.crds = append(strategy.crds, components.NewMigrationPolicyCrd())
 
 	rbaclist := make([]interface{}, 0)
 	rbaclist = append(rbaclist, rbac.GetAllCluster(config.GetNamespace())...)
@@ -236,7 +239,7 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 	}
 	strategy.deployments = append(strategy.deployments, controller)
 
-	handler, err := components.NewHandlerDaemonSet(config.GetNamespace(), config.GetImageRegistry(), config.GetHandlerVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
+	handler, err := components.NewHandlerDaemonSet(config.GetNamespace(), config.GetImageRegistry(), config.GetHandlerVersion(), config.GetLauncherVersion(), config.GetImagePullPolicy(), config.GetVerbosity(), config.CPUNodeDiscoveryEnabled())
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-handler deployment %v", err)
 	}
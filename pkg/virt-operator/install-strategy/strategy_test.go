@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
 
@@ -61,12 +62,43 @@ var _ = Describe("Install Strategy", func() {
 				ImageRegistry: registry,
 				ImageTag:      version,
 			},
-		})
+		}, nil)
 	}
 
 	config := getConfig("fake-registry", "v9.9.9")
 
 	Context("should generate", func() {
+		table.DescribeTable("the virt-handler node labeller only with the CPUNodeDiscovery feature gate", func(featureGates string, expectNodeLabeller bool) {
+			config := util.GetTargetConfigFromKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+				},
+				Spec: v1.KubeVirtSpec{
+					ImageRegistry: "fake-registry",
+					ImageTag:      "v9.9.9",
+				},
+			}, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: featureGates},
+			})
+
+			strategy, err := GenerateCurrentInstallStrategy(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strategy.daemonSets).To(HaveLen(1))
+
+			containers := []string{}
+			for _, container := range strategy.daemonSets[0].Spec.Template.Spec.Containers {
+				containers = append(containers, container.Name)
+			}
+			if expectNodeLabeller {
+				Expect(containers).To(ConsistOf("virt-handler", "node-labeller"))
+			} else {
+				Expect(containers).To(ConsistOf("virt-handler"))
+			}
+		},
+			table.Entry("with the gate enabled", virtconfig.CPUNodeDiscoveryGate, true),
+			table.Entry("with the gate disabled", virtconfig.HotplugVolumesGate, false),
+		)
+
 		It("latest install strategy with lossless byte conversion.", func() {
 
 			strategy, err := GenerateCurrentInstallStrategy(config)
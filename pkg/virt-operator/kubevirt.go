@@ -278,9 +278,32 @@ func NewKubeVirtController(
 		},
 	})
 
+	// feature gates in the kubevirt-config ConfigMap can change the install strategy
+	c.informers.KubeVirtConfigMap.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.genericAddHandler(obj, nil)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.genericDeleteHandler(obj, nil)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.genericUpdateHandler(oldObj, newObj, nil)
+		},
+	})
+
 	return &c
 }
 
+// getKubeVirtConfigMap returns the kubevirt-config ConfigMap of the KubeVirt deployment, or nil if there is none
+func (c *KubeVirtController) getKubeVirtConfigMap(kv *v1.KubeVirt) *k8sv1.ConfigMap {
+	for _, obj := range c.stores.KubeVirtConfigMapCache.List() {
+		if configMap, ok := obj.(*k8sv1.ConfigMap); ok && configMap.Namespace == kv.Namespace {
+			return configMap
+		}
+	}
+	return nil
+}
+
 func (c *KubeVirtController) getKubeVirtKey() (string, error) {
 	// XXX use owner references instead in general
 	kvs := c.kubeVirtInformer.GetStore().List()
@@ -445,6 +468,7 @@ func (c *KubeVirtController) Run(threadiness int, stopCh <-chan struct{}) {
 	cache.WaitForCacheSync(stopCh, c.informers.InstallStrategyJob.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.InfrastructurePod.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.PodDisruptionBudget.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.informers.KubeVirtConfigMap.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -717,7 +741,7 @@ func (c *KubeVirtController) loadInstallStrategy(kv *v1.KubeVirt, loadObservedVe
 		return nil, true, err
 	}
 
-	config := operatorutil.GetTargetConfigFromKV(kv)
+	config := operatorutil.GetTargetConfigFromKV(kv, c.getKubeVirtConfigMap(kv))
 	if loadObservedVersion {
 		config = operatorutil.GetObservedConfigFromKV(kv)
 	}
@@ -863,7 +887,7 @@ func (c *KubeVirtController) syncDeployment(kv *v1.KubeVirt) error {
 		return nil
 	}
 
-	config := operatorutil.GetTargetConfigFromKV(kv)
+	config := operatorutil.GetTargetConfigFromKV(kv, c.getKubeVirtConfigMap(kv))
 
 	// Record current operator version to status section
 	util.SetOperatorVersion(kv)
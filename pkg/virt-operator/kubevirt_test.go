@@ -119,7 +119,7 @@ var _ = Describe("KubeVirt Operator", func() {
 				ImageRegistry: registry,
 				ImageTag:      version,
 			},
-		})
+		}, nil)
 	}
 
 	var totalAdds int
@@ -128,8 +128,8 @@ var _ = Describe("KubeVirt Operator", func() {
 	var totalDeletions int
 	var resourceChanges map[string]map[string]int
 
-	resourceCount := 33
-	patchCount := 15
+	resourceCount := 36
+	patchCount := 18
 	updateCount := 18
 
 	deleteFromCache := true
@@ -152,6 +152,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		go informers.InstallStrategyConfigMap.Run(stop)
 		go informers.InfrastructurePod.Run(stop)
 		go informers.PodDisruptionBudget.Run(stop)
+		go informers.KubeVirtConfigMap.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop, kvInformer.HasSynced)).To(BeTrue())
 
@@ -170,6 +171,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		cache.WaitForCacheSync(stop, informers.InstallStrategyConfigMap.HasSynced)
 		cache.WaitForCacheSync(stop, informers.InfrastructurePod.HasSynced)
 		cache.WaitForCacheSync(stop, informers.PodDisruptionBudget.HasSynced)
+		cache.WaitForCacheSync(stop, informers.KubeVirtConfigMap.HasSynced)
 	}
 
 	getSCC := func() secv1.SecurityContextConstraints {
@@ -251,6 +253,9 @@ var _ = Describe("KubeVirt Operator", func() {
 		informers.PodDisruptionBudget, podDisruptionBudgetSource = testutils.NewFakeInformerFor(&policyv1beta1.PodDisruptionBudget{})
 		stores.PodDisruptionBudgetCache = informers.PodDisruptionBudget.GetStore()
 
+		informers.KubeVirtConfigMap, _ = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
+		stores.KubeVirtConfigMapCache = informers.KubeVirtConfigMap.GetStore()
+
 		// test OpenShift components
 		stores.IsOnOpenshift = true
 
@@ -552,7 +557,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		injectMetadata(&pod.ObjectMeta, config)
 		addPod(pod)
 
-		handler, _ := components.NewHandlerDaemonSet(NAMESPACE, config.GetImageRegistry(), config.GetHandlerVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
+		handler, _ := components.NewHandlerDaemonSet(NAMESPACE, config.GetImageRegistry(), config.GetHandlerVersion(), config.GetLauncherVersion(), config.GetImagePullPolicy(), config.GetVerbosity(), config.CPUNodeDiscoveryEnabled())
 		pod = &k8sv1.Pod{
 			ObjectMeta: handler.Spec.Template.ObjectMeta,
 			Spec:       handler.Spec.Template.Spec,
@@ -713,6 +718,9 @@ var _ = Describe("KubeVirt Operator", func() {
 		all = append(all, components.NewReplicaSetCrd())
 		all = append(all, components.NewVirtualMachineCrd())
 		all = append(all, components.NewVirtualMachineInstanceMigrationCrd())
+		all = append(all, components.NewVirtualMachineSnapshotCrd())
+		all = append(all, components.NewVirtualMachineRestoreCrd())
+		all = append(all, components.NewMigrationPolicyCrd())
 		// sccs
 		all = append(all, components.NewKubeVirtControllerSCC(NAMESPACE))
 		all = append(all, components.NewKubeVirtHandlerSCC(NAMESPACE))
@@ -723,7 +731,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		apiDeploymentPdb := components.NewPodDisruptionBudgetForDeployment(apiDeployment)
 		controller, _ := components.NewControllerDeployment(NAMESPACE, config.GetImageRegistry(), config.GetControllerVersion(), config.GetLauncherVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
 		controllerPdb := components.NewPodDisruptionBudgetForDeployment(controller)
-		handler, _ := components.NewHandlerDaemonSet(NAMESPACE, config.GetImageRegistry(), config.GetApiVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
+		handler, _ := components.NewHandlerDaemonSet(NAMESPACE, config.GetImageRegistry(), config.GetApiVersion(), config.GetLauncherVersion(), config.GetImagePullPolicy(), config.GetVerbosity(), config.CPUNodeDiscoveryEnabled())
 		all = append(all, apiDeployment, apiDeploymentPdb, controller, controllerPdb, handler)
 
 		for _, obj := range all {
@@ -1445,7 +1453,7 @@ var _ = Describe("KubeVirt Operator", func() {
 				Status: v1.KubeVirtStatus{},
 			}
 
-			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv))
+			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv, nil))
 			Expect(err).ToNot(HaveOccurred())
 
 			// will only create a new job after 10 seconds has passed.
@@ -1479,7 +1487,7 @@ var _ = Describe("KubeVirt Operator", func() {
 				Status: v1.KubeVirtStatus{},
 			}
 
-			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv))
+			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv, nil))
 			Expect(err).ToNot(HaveOccurred())
 
 			job.Status.CompletionTime = now()
@@ -1506,7 +1514,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			addKubeVirt(kv)
 			addInstallStrategy(defaultConfig)
 
-			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv))
+			job, err := controller.generateInstallStrategyJob(util.GetTargetConfigFromKV(kv, nil))
 			Expect(err).ToNot(HaveOccurred())
 
 			job.Status.CompletionTime = now()
@@ -1540,7 +1548,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(len(controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(controller.stores.RoleCache.List())).To(Equal(2))
 			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(2))
-			Expect(len(controller.stores.CrdCache.List())).To(Equal(5))
+			Expect(len(controller.stores.CrdCache.List())).To(Equal(8))
 			Expect(len(controller.stores.ServiceCache.List())).To(Equal(2))
 			Expect(len(controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(controller.stores.DaemonSetCache.List())).To(Equal(0))
@@ -33,6 +33,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	clientutil "kubevirt.io/client-go/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -52,6 +53,8 @@ const (
 
 	// these names need to match field names from KubeVirt Spec if they are set from there
 	AdditionalPropertiesNamePullPolicy = "ImagePullPolicy"
+	// set from the feature gates of the kubevirt-config ConfigMap, enables the node labeller in virt-handler
+	AdditionalPropertiesNameCPUNodeDiscovery = "CPUNodeDiscovery"
 
 	// the regex used to parse the operator image
 	operatorImageRegex = "^(.*)/virt-operator([@:].*)?$"
@@ -107,14 +110,29 @@ func GetConfigFromEnv() (*KubeVirtDeploymentConfig, error) {
 
 }
 
-func GetTargetConfigFromKV(kv *v1.KubeVirt) *KubeVirtDeploymentConfig {
+func GetTargetConfigFromKV(kv *v1.KubeVirt, kubeVirtConfig *k8sv1.ConfigMap) *KubeVirtDeploymentConfig {
 	// don't use status.target* here, as that is always set, but we need to know if it was set by the spec and with that
 	// overriding shasums from env vars
-	return getConfig(kv.Spec.ImageRegistry, kv.Spec.ImageTag, kv.Namespace, getKVMapFromSpec(kv.Spec))
+	return getConfig(kv.Spec.ImageRegistry, kv.Spec.ImageTag, kv.Namespace, getKVMap(kv.Spec, kubeVirtConfig))
 }
 
 func GetObservedConfigFromKV(kv *v1.KubeVirt) *KubeVirtDeploymentConfig {
-	return getConfig(kv.Status.ObservedKubeVirtRegistry, kv.Status.ObservedKubeVirtVersion, kv.Namespace, getKVMapFromSpec(kv.Spec))
+	kvMap := getKVMapFromSpec(kv.Spec)
+	// the feature gates may have changed since, use the ones the observed version was deployed with
+	observed := &KubeVirtDeploymentConfig{}
+	if err := json.Unmarshal([]byte(kv.Status.ObservedDeploymentConfig), observed); err == nil && observed.CPUNodeDiscoveryEnabled() {
+		kvMap[AdditionalPropertiesNameCPUNodeDiscovery] = "true"
+	}
+	return getConfig(kv.Status.ObservedKubeVirtRegistry, kv.Status.ObservedKubeVirtVersion, kv.Namespace, kvMap)
+}
+
+func getKVMap(spec v1.KubeVirtSpec, kubeVirtConfig *k8sv1.ConfigMap) map[string]string {
+	kvMap := getKVMapFromSpec(spec)
+	// only set when enabled, so that the install strategy of existing deployments does not change
+	if kubeVirtConfig != nil && strings.Contains(kubeVirtConfig.Data[virtconfig.FeatureGatesKey], virtconfig.CPUNodeDiscoveryGate) {
+		kvMap[AdditionalPropertiesNameCPUNodeDiscovery] = "true"
+	}
+	return kvMap
 }
 
 func getKVMapFromSpec(spec v1.KubeVirtSpec) map[string]string {
@@ -317,6 +335,10 @@ func (c *KubeVirtDeploymentConfig) GetImagePullPolicy() k8sv1.PullPolicy {
 	return k8sv1.PullIfNotPresent
 }
 
+func (c *KubeVirtDeploymentConfig) CPUNodeDiscoveryEnabled() bool {
+	return c.AdditionalProperties[AdditionalPropertiesNameCPUNodeDiscovery] == "true"
+}
+
 func (c *KubeVirtDeploymentConfig) GetNamespace() string {
 	return c.Namespace
 }
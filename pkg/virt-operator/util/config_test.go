@@ -26,6 +26,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Operator Config", func() {
@@ -146,4 +150,42 @@ var _ = Describe("Operator Config", func() {
 		})
 	})
 
+	Describe("Config from KubeVirt CR", func() {
+		kv := &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				ImageRegistry: "registry:5000/kubevirt",
+				ImageTag:      "devel",
+			},
+		}
+
+		It("should enable the node labeller if the CPUNodeDiscovery feature gate is enabled", func() {
+			config := GetTargetConfigFromKV(kv, &k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.CPUNodeDiscoveryGate},
+			})
+			Expect(config.CPUNodeDiscoveryEnabled()).To(BeTrue())
+			Expect(config.GetDeploymentID()).ToNot(Equal(GetTargetConfigFromKV(kv, nil).GetDeploymentID()))
+		})
+
+		It("should keep the deployment id if the CPUNodeDiscovery feature gate is disabled", func() {
+			config := GetTargetConfigFromKV(kv, &k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.HotplugVolumesGate},
+			})
+			Expect(config.CPUNodeDiscoveryEnabled()).To(BeFalse())
+			Expect(config.GetDeploymentID()).To(Equal(GetTargetConfigFromKV(kv, nil).GetDeploymentID()))
+		})
+
+		It("should keep the feature gates the observed version was deployed with", func() {
+			config := GetTargetConfigFromKV(kv, &k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.CPUNodeDiscoveryGate},
+			})
+			observedKV := kv.DeepCopy()
+			observedKV.Status.ObservedKubeVirtRegistry = "registry:5000/kubevirt"
+			observedKV.Status.ObservedKubeVirtVersion = "devel"
+			Expect(config.SetObservedDeploymentConfig(observedKV)).To(Succeed())
+
+			Expect(GetObservedConfigFromKV(observedKV).GetDeploymentID()).To(Equal(config.GetDeploymentID()))
+		})
+	})
+
 })
@@ -42,6 +42,7 @@ type Stores struct {
 	InstallStrategyJobCache       cache.Store
 	InfrastructurePodCache        cache.Store
 	PodDisruptionBudgetCache      cache.Store
+	KubeVirtConfigMapCache        cache.Store
 	IsOnOpenshift                 bool
 }
 
@@ -116,6 +117,7 @@ type Informers struct {
 	InstallStrategyJob       cache.SharedIndexInformer
 	InfrastructurePod        cache.SharedIndexInformer
 	PodDisruptionBudget      cache.SharedIndexInformer
+	KubeVirtConfigMap        cache.SharedIndexInformer
 }
 
 func (e *Expectations) DeleteExpectations(key string) {
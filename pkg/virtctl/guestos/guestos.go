@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package guestos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_GUESTOSINFO = "guestosinfo"
+
+func NewGuestOsInfoCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "guestosinfo (VMI)",
+		Short:   "Return guest agent info about operating system.",
+		Long:    `Returns the operating system, hostname, timezone, logged in users and filesystems of a virtual machine instance, as reported by its guest agent.`,
+		Example: usage(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	usage := "  # Get the guest OS info of a virtual machine instance called 'myvmi':\n"
+	usage += "  {{ProgramName}} guestosinfo myvmi"
+	return usage
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	vmiName := args[0]
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	guestInfo, err := virtClient.VirtualMachineInstance(namespace).GuestOsInfo(vmiName)
+	if err != nil {
+		return fmt.Errorf("Error getting guest OS info of VirtualMachineInstance %s: %v", vmiName, err)
+	}
+
+	data, err := json.MarshalIndent(guestInfo, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Cannot marshal guest OS info: %v", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
@@ -0,0 +1,66 @@
+package guestos_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Guest OS info", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should print the guest OS info", func() {
+		guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{
+			Hostname: "testhost",
+			OS: v1.VirtualMachineInstanceGuestOSInfo{
+				Name:          "Fedora",
+				KernelRelease: "5.3.7-301.fc31.x86_64",
+			},
+			UserList: []v1.VirtualMachineInstanceGuestOSUser{{UserName: "fedora"}},
+		}
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestOsInfo(vmiName).Return(guestInfo, nil).Times(1)
+
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand("guestosinfo", vmiName)
+		cmd.SetOutput(out)
+		Expect(cmd.Execute()).To(Succeed())
+
+		printedInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+		Expect(json.Unmarshal(out.Bytes(), &printedInfo)).To(Succeed())
+		Expect(printedInfo).To(Equal(guestInfo))
+	})
+
+	It("should fail if the guest OS info is not available", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestOsInfo(vmiName).Return(v1.VirtualMachineInstanceGuestAgentInfo{}, fmt.Errorf("no guest agent")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("guestosinfo", vmiName)
+		Expect(cmd.Execute()).ToNot(Succeed())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+})
@@ -22,10 +22,16 @@ package vm
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/clientcmd"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
@@ -34,6 +40,27 @@ const (
 	COMMAND_START   = "start"
 	COMMAND_STOP    = "stop"
 	COMMAND_RESTART = "restart"
+	COMMAND_PAUSE   = "pause"
+	COMMAND_UNPAUSE = "unpause"
+	COMMAND_MIGRATE = "migrate"
+
+	COMMAND_MIGRATE_CANCEL = "migrate-cancel"
+	COMMAND_INSERT_MEDIA   = "insertmedia"
+	COMMAND_EJECT_MEDIA    = "ejectmedia"
+
+	ARG_VM_SHORT  = "vm"
+	ARG_VM_LONG   = "virtualmachine"
+	ARG_VMI_SHORT = "vmi"
+	ARG_VMI_LONG  = "virtualmachineinstance"
+
+	migrationPollInterval = 2 * time.Second
+)
+
+var (
+	diskName       string
+	volumeName     string
+	claimName      string
+	dataVolumeName string
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -81,9 +108,112 @@ func NewRestartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	return cmd
 }
 
+func NewPauseCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pause (vm|vmi) (VM|VMI)",
+		Short:   "Pause a virtual machine.",
+		Long:    `Pauses a virtual machine by freezing it. Machine state is kept in memory.`,
+		Example: usageLifecycle(COMMAND_PAUSE),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_PAUSE, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewUnpauseCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unpause (vm|vmi) (VM|VMI)",
+		Short:   "Unpause a virtual machine.",
+		Long:    `Unpauses a virtual machine which was paused before.`,
+		Example: usageLifecycle(COMMAND_UNPAUSE),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_UNPAUSE, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := Command{command: COMMAND_MIGRATE, clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:     "migrate (VM)",
+		Short:   "Migrate a virtual machine.",
+		Long:    `Live migrates a running virtual machine to another node.`,
+		Example: usageMigrate(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.Run,
+	}
+	cmd.Flags().BoolVar(&c.waitForMigration, "wait", false, "Follow the progress of the migration until it succeeded or failed.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewMigrateCancelCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-cancel (VM)",
+		Short:   "Cancel the migration of a virtual machine.",
+		Long:    `Cancels the ongoing live migration of a virtual machine by deleting its migration object.`,
+		Example: usage(COMMAND_MIGRATE_CANCEL),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_MIGRATE_CANCEL, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewInsertMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "insertmedia (VMI)",
+		Short: "Insert a medium into a CD-ROM or floppy of a running virtual machine instance.",
+		Long: `Inserts a medium into a CD-ROM or floppy of a running virtual machine instance and closes its tray.
+The medium is either the volume the drive was defined with, a volume which was inserted before,
+or a new volume which is hotplugged from a PersistentVolumeClaim or DataVolume.`,
+		Example: usageInsertMedia(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_INSERT_MEDIA, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&diskName, "disk", "", "Name of the CD-ROM or floppy.")
+	cmd.Flags().StringVar(&volumeName, "volume-name", "", "Name of the volume with the medium, defaults to the name of the claim or DataVolume.")
+	cmd.Flags().StringVar(&claimName, "claim-name", "", "Name of a PersistentVolumeClaim to hotplug as medium.")
+	cmd.Flags().StringVar(&dataVolumeName, "datavolume-name", "", "Name of a DataVolume to hotplug as medium.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewEjectMediaCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ejectmedia (VMI)",
+		Short:   "Eject the medium from a CD-ROM or floppy of a running virtual machine instance.",
+		Long:    `Ejects the medium from a CD-ROM or floppy of a running virtual machine instance and leaves its tray open.`,
+		Example: usageEjectMedia(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_EJECT_MEDIA, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&diskName, "disk", "", "Name of the CD-ROM or floppy.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
 type Command struct {
-	clientConfig clientcmd.ClientConfig
-	command      string
+	clientConfig     clientcmd.ClientConfig
+	command          string
+	waitForMigration bool
 }
 
 func NewCommand(command string) *Command {
@@ -96,9 +226,77 @@ func usage(cmd string) string {
 	return usage
 }
 
+func usageLifecycle(cmd string) string {
+	usage := fmt.Sprintf("  # %s a virtual machine called 'myvm':\n", strings.Title(cmd))
+	usage += fmt.Sprintf("  {{ProgramName}} %s vm myvm\n\n", cmd)
+	usage += fmt.Sprintf("  # %s a virtual machine instance called 'myvmi':\n", strings.Title(cmd))
+	usage += fmt.Sprintf("  {{ProgramName}} %s vmi myvmi", cmd)
+	return usage
+}
+
+func usageMigrate() string {
+	usage := usage(COMMAND_MIGRATE) + "\n\n"
+	usage += "  # Migrate a virtual machine called 'myvm' and wait for the migration to finish:\n"
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvm --wait", COMMAND_MIGRATE)
+	return usage
+}
+
+func usageInsertMedia() string {
+	usage := "  # Insert a new medium from the PersistentVolumeClaim 'installer-iso' into the CD-ROM 'cdrom' of 'myvmi':\n"
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi --disk=cdrom --claim-name=installer-iso\n\n", COMMAND_INSERT_MEDIA)
+	usage += "  # Insert the medium the CD-ROM 'cdrom' of 'myvmi' was defined with again:\n"
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi --disk=cdrom --volume-name=cdrom", COMMAND_INSERT_MEDIA)
+	return usage
+}
+
+func usageEjectMedia() string {
+	usage := "  # Eject the medium from the CD-ROM 'cdrom' of 'myvmi':\n"
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi --disk=cdrom", COMMAND_EJECT_MEDIA)
+	return usage
+}
+
+// getInsertMediaOptions creates the options to insert a medium from the command line flags
+func getInsertMediaOptions() (*v1.InsertMediaOptions, error) {
+	if diskName == "" {
+		return nil, fmt.Errorf("the CD-ROM or floppy has to be specified with --disk")
+	}
+	if claimName != "" && dataVolumeName != "" {
+		return nil, fmt.Errorf("only one of --claim-name and --datavolume-name can be specified")
+	}
+
+	options := &v1.InsertMediaOptions{
+		Name:       diskName,
+		VolumeName: volumeName,
+	}
+	if claimName != "" {
+		options.VolumeSource = &v1.HotplugVolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		}
+		if options.VolumeName == "" {
+			options.VolumeName = claimName
+		}
+	}
+	if dataVolumeName != "" {
+		options.VolumeSource = &v1.HotplugVolumeSource{
+			DataVolume: &v1.DataVolumeSource{Name: dataVolumeName},
+		}
+		if options.VolumeName == "" {
+			options.VolumeName = dataVolumeName
+		}
+	}
+	if options.VolumeName == "" {
+		return nil, fmt.Errorf("the medium has to be specified with --volume-name, --claim-name or --datavolume-name")
+	}
+	return options, nil
+}
+
 func (o *Command) Run(cmd *cobra.Command, args []string) error {
 
 	vmiName := args[0]
+	resourceType := ""
+	if len(args) > 1 {
+		resourceType, vmiName = args[0], args[1]
+	}
 
 	namespace, _, err := o.clientConfig.Namespace()
 	if err != nil {
@@ -126,8 +324,131 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("Error restarting VirtualMachine %v", err)
 		}
+	case COMMAND_PAUSE, COMMAND_UNPAUSE:
+		switch resourceType {
+		case ARG_VM_SHORT, ARG_VM_LONG, ARG_VMI_SHORT, ARG_VMI_LONG:
+			// the VirtualMachineInstance of a VirtualMachine has the same name, so both are handled alike
+		default:
+			return fmt.Errorf("Unsupported resource type '%s'", resourceType)
+		}
+		if o.command == COMMAND_PAUSE {
+			err = virtClient.VirtualMachineInstance(namespace).Pause(vmiName)
+			if err != nil {
+				return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", vmiName, err)
+			}
+		} else {
+			err = virtClient.VirtualMachineInstance(namespace).Unpause(vmiName)
+			if err != nil {
+				return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", vmiName, err)
+			}
+		}
+		fmt.Printf("VMI %s was scheduled to %s\n", vmiName, o.command)
+		return nil
+	case COMMAND_MIGRATE:
+		migration, err := virtClient.VirtualMachine(namespace).Migrate(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error migrating VirtualMachine %v", err)
+		}
+		if o.waitForMigration {
+			return waitForMigrationToFinish(virtClient, namespace, vmiName, migration.Name, migrationPollInterval)
+		}
+	case COMMAND_MIGRATE_CANCEL:
+		migration, err := findActiveMigration(virtClient, namespace, vmiName)
+		if err != nil {
+			return fmt.Errorf("Error looking up the migration of VirtualMachine %s: %v", vmiName, err)
+		}
+		if migration == nil {
+			return fmt.Errorf("Found no ongoing migration for VirtualMachine %s", vmiName)
+		}
+		err = virtClient.VirtualMachineInstanceMigration(namespace).Delete(migration.Name, &k8smetav1.DeleteOptions{})
+		if err != nil {
+			return fmt.Errorf("Error canceling migration %s of VirtualMachine %s: %v", migration.Name, vmiName, err)
+		}
+		fmt.Printf("Migration %s of VM %s was canceled\n", migration.Name, vmiName)
+		return nil
+	case COMMAND_INSERT_MEDIA:
+		options, err := getInsertMediaOptions()
+		if err != nil {
+			return err
+		}
+		err = virtClient.VirtualMachineInstance(namespace).InsertMedia(vmiName, options)
+		if err != nil {
+			return fmt.Errorf("Error inserting %s into %s of VirtualMachineInstance %s: %v", options.VolumeName, options.Name, vmiName, err)
+		}
+		fmt.Printf("Medium %s was scheduled to be inserted into %s of VMI %s\n", options.VolumeName, options.Name, vmiName)
+		return nil
+	case COMMAND_EJECT_MEDIA:
+		if diskName == "" {
+			return fmt.Errorf("the CD-ROM or floppy has to be specified with --disk")
+		}
+		err = virtClient.VirtualMachineInstance(namespace).EjectMedia(vmiName, &v1.EjectMediaOptions{Name: diskName})
+		if err != nil {
+			return fmt.Errorf("Error ejecting the medium from %s of VirtualMachineInstance %s: %v", diskName, vmiName, err)
+		}
+		fmt.Printf("Medium in %s was scheduled to be ejected from VMI %s\n", diskName, vmiName)
+		return nil
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
 	return nil
 }
+
+// findLatestMigration returns the most recently created migration of the VirtualMachineInstance, or nil if it was never migrated
+func findLatestMigration(virtClient kubecli.KubevirtClient, namespace string, vmiName string) (*v1.VirtualMachineInstanceMigration, error) {
+	migrations, err := virtClient.VirtualMachineInstanceMigration(namespace).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *v1.VirtualMachineInstanceMigration
+	for i, migration := range migrations.Items {
+		if migration.Spec.VMIName != vmiName {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&migration.CreationTimestamp) {
+			latest = &migrations.Items[i]
+		}
+	}
+	return latest, nil
+}
+
+// findActiveMigration returns the migration of the VirtualMachineInstance which did not finish yet, or nil if there is none
+func findActiveMigration(virtClient kubecli.KubevirtClient, namespace string, vmiName string) (*v1.VirtualMachineInstanceMigration, error) {
+	migration, err := findLatestMigration(virtClient, namespace, vmiName)
+	if err != nil || migration == nil || migration.IsFinal() {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// waitForMigrationToFinish follows the phases of the migration which was created for the VirtualMachine
+func waitForMigrationToFinish(virtClient kubecli.KubevirtClient, namespace string, vmiName string, migrationName string, interval time.Duration) error {
+	var lastPhase v1.VirtualMachineInstanceMigrationPhase
+	var migration *v1.VirtualMachineInstanceMigration
+
+	err := wait.PollImmediateInfinite(interval, func() (bool, error) {
+		var err error
+		migration, err = virtClient.VirtualMachineInstanceMigration(namespace).Get(migrationName, &k8smetav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return false, fmt.Errorf("migration %s was deleted", migrationName)
+		} else if err != nil {
+			return false, err
+		}
+		if migration.Status.Phase != lastPhase {
+			lastPhase = migration.Status.Phase
+			if lastPhase != v1.MigrationPhaseUnset {
+				fmt.Printf("Migration %s of VM %s is in phase %s\n", migration.Name, vmiName, lastPhase)
+			}
+		}
+		return migration.IsFinal(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for the migration of VirtualMachine %s: %v", vmiName, err)
+	}
+
+	if migration.Status.Phase == v1.MigrationFailed {
+		return fmt.Errorf("Migration %s of VirtualMachine %s failed", migration.Name, vmiName)
+	}
+	fmt.Printf("VM %s was migrated\n", vmiName)
+	return nil
+}
@@ -1,11 +1,18 @@
 package vm_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -17,6 +24,7 @@ var _ = Describe("VirtualMachine", func() {
 	const unknownVM = "unknown-vm"
 	const vmName = "testvm"
 	var vmInterface *kubecli.MockVirtualMachineInterface
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
 	var ctrl *gomock.Controller
 
 	running := true
@@ -30,6 +38,7 @@ var _ = Describe("VirtualMachine", func() {
 		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
 		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
 		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 	})
 
 	Context("should patch VM", func() {
@@ -128,6 +137,166 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("with pause and unpause cmds", func() {
+		table.DescribeTable("should pause", func(resourceType string) {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().Pause(vmName).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("pause", resourceType, vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		},
+			table.Entry("a VM", "vm"),
+			table.Entry("a VMI", "vmi"),
+		)
+
+		table.DescribeTable("should unpause", func(resourceType string) {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().Unpause(vmName).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("unpause", resourceType, vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		},
+			table.Entry("a VM", "vm"),
+			table.Entry("a VMI", "vmi"),
+		)
+
+		It("should fail with an unknown resource type", func() {
+			cmd := tests.NewVirtctlCommand("pause", "pod", vmName)
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+	})
+
+	Context("with migrate and migrate-cancel cmds", func() {
+		var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
+
+		newMigration := func(name string, vmiName string, phase v1.VirtualMachineInstanceMigrationPhase, created time.Time) v1.VirtualMachineInstanceMigration {
+			migration := kubecli.NewMinimalMigration(name)
+			migration.Spec.VMIName = vmiName
+			migration.Status.Phase = phase
+			migration.CreationTimestamp = k8smetav1.NewTime(created)
+			return *migration
+		}
+
+		BeforeEach(func() {
+			migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(k8smetav1.NamespaceDefault).Return(migrationInterface).AnyTimes()
+		})
+
+		It("should migrate a vm", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Migrate(vmName).Return(kubecli.NewMinimalMigration("new"), nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName)
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail if the migration could not be created", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Migrate(vmName).Return(nil, fmt.Errorf("VM is not running")).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName)
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		table.DescribeTable("should wait for the created migration of the vm", func(phase v1.VirtualMachineInstanceMigrationPhase, shouldSucceed bool) {
+			created := newMigration("new", vmName, v1.MigrationPhaseUnset, time.Now())
+			finished := newMigration("new", vmName, phase, time.Now())
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Migrate(vmName).Return(&created, nil).Times(1)
+			migrationInterface.EXPECT().List(gomock.Any()).Times(0)
+			migrationInterface.EXPECT().Get("new", gomock.Any()).Return(&finished, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName, "--wait")
+			if shouldSucceed {
+				Expect(cmd.Execute()).To(Succeed())
+			} else {
+				Expect(cmd.Execute()).ToNot(Succeed())
+			}
+		},
+			table.Entry("and succeed if it succeeded", v1.MigrationSucceeded, true),
+			table.Entry("and fail if it failed", v1.MigrationFailed, false),
+		)
+
+		It("should fail waiting if the created migration gets deleted", func() {
+			created := newMigration("new", vmName, v1.MigrationPhaseUnset, time.Now())
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Migrate(vmName).Return(&created, nil).Times(1)
+			migrationInterface.EXPECT().Get("new", gomock.Any()).Return(nil, errors.NewNotFound(schema.GroupResource{Group: v1.GroupVersion.Group, Resource: "virtualmachineinstancemigrations"}, "new")).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName, "--wait")
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		It("should cancel an ongoing migration by deleting it", func() {
+			now := time.Now()
+			migrationInterface.EXPECT().List(gomock.Any()).Return(kubecli.NewMigrationList(
+				newMigration("old", vmName, v1.MigrationFailed, now.Add(-time.Hour)),
+				newMigration("new", vmName, v1.MigrationRunning, now),
+			), nil).Times(1)
+			migrationInterface.EXPECT().Delete("new", gomock.Any()).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate-cancel", vmName)
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail to cancel if no migration is ongoing", func() {
+			migrationInterface.EXPECT().List(gomock.Any()).Return(kubecli.NewMigrationList(
+				newMigration("old", vmName, v1.MigrationSucceeded, time.Now()),
+			), nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate-cancel", vmName)
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+	})
+
+	Context("with insertmedia and ejectmedia cmds", func() {
+		table.DescribeTable("should insert", func(expectedOptions *v1.InsertMediaOptions, args ...string) {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().InsertMedia(vmName, expectedOptions).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand(append([]string{"insertmedia", vmName, "--disk=cdrom"}, args...)...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			table.Entry("an existing volume", &v1.InsertMediaOptions{Name: "cdrom", VolumeName: "iso"}, "--volume-name=iso"),
+			table.Entry("a new PersistentVolumeClaim", &v1.InsertMediaOptions{
+				Name:       "cdrom",
+				VolumeName: "isopvc",
+				VolumeSource: &v1.HotplugVolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "isopvc"},
+				},
+			}, "--claim-name=isopvc"),
+			table.Entry("a new DataVolume with a volume name", &v1.InsertMediaOptions{
+				Name:       "cdrom",
+				VolumeName: "iso",
+				VolumeSource: &v1.HotplugVolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "isodv"},
+				},
+			}, "--datavolume-name=isodv", "--volume-name=iso"),
+		)
+
+		table.DescribeTable("should fail to insert", func(args ...string) {
+			cmd := tests.NewVirtctlCommand(append([]string{"insertmedia", vmName}, args...)...)
+			Expect(cmd.Execute()).ToNot(Succeed())
+		},
+			table.Entry("without a disk", "--volume-name=iso"),
+			table.Entry("without a medium", "--disk=cdrom"),
+			table.Entry("with a claim and a DataVolume", "--disk=cdrom", "--claim-name=isopvc", "--datavolume-name=isodv"),
+		)
+
+		It("should eject", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().EjectMedia(vmName, &v1.EjectMediaOptions{Name: "cdrom"}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("ejectmedia", vmName, "--disk=cdrom")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail to eject without a disk", func() {
+			cmd := tests.NewVirtctlCommand("ejectmedia", vmName)
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
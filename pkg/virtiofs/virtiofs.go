@@ -0,0 +1,153 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package virtiofs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	utilwait "k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/config"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+)
+
+const (
+	socketTimeout  = 10 * time.Second
+	socketInterval = 100 * time.Millisecond
+)
+
+var (
+	SocketDir         = "/var/run/kubevirt-private/virtiofs"
+	VirtiofsdBinary   = "/usr/libexec/virtiofsd"
+	startedDaemonsMux sync.Mutex
+	startedDaemons    = map[string]bool{}
+)
+
+// SocketPath returns the vhost-user socket of the virtiofsd process sharing the volume
+func SocketPath(volumeName string) string {
+	return filepath.Join(SocketDir, volumeName+".sock")
+}
+
+// SourceDir returns the directory in the virt-launcher pod which is shared with the guest.
+// ConfigMaps, Secrets and ServiceAccounts are shared directly from the kubelet mount, so that
+// updates reach the guest.
+func SourceDir(volume *v1.Volume) (string, error) {
+	switch {
+	case volume.PersistentVolumeClaim != nil, volume.DataVolume != nil:
+		return hostdisk.GetMountedHostDiskDir(volume.Name), nil
+	case volume.ConfigMap != nil:
+		return config.GetConfigMapSourcePath(volume.Name), nil
+	case volume.Secret != nil:
+		return config.GetSecretSourcePath(volume.Name), nil
+	case volume.ServiceAccount != nil:
+		return config.ServiceAccountSourceDir, nil
+	}
+	return "", fmt.Errorf("volume %s can't be shared through virtio-fs", volume.Name)
+}
+
+func daemonArgs(volume *v1.Volume) ([]string, error) {
+	sourceDir, err := SourceDir(volume)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		"--socket-path=" + SocketPath(volume.Name),
+		"-o", "source=" + sourceDir,
+		// the shared directories can change behind the back of the guest
+		"-o", "cache=none",
+		"-o", "no_posix_lock",
+	}, nil
+}
+
+// StartDaemons starts one virtiofsd process per filesystem of the VMI and waits for their sockets.
+// The processes exit when QEMU closes the connection.
+func StartDaemons(vmi *v1.VirtualMachineInstance) error {
+	if len(vmi.Spec.Domain.Devices.Filesystems) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(SocketDir, 0755); err != nil {
+		return err
+	}
+
+	startedDaemonsMux.Lock()
+	defer startedDaemonsMux.Unlock()
+
+	for _, filesystem := range vmi.Spec.Domain.Devices.Filesystems {
+		if filesystem.Virtiofs == nil {
+			continue
+		}
+		socket := SocketPath(filesystem.Name)
+		if startedDaemons[socket] {
+			continue
+		}
+
+		volume := getVolume(vmi, filesystem.Name)
+		if volume == nil {
+			return fmt.Errorf("no matching volume with name %s found for filesystem", filesystem.Name)
+		}
+		args, err := daemonArgs(volume)
+		if err != nil {
+			return err
+		}
+
+		os.Remove(socket)
+		cmd := exec.Command(VirtiofsdBinary, args...)
+		// connect the virtiofsd logs to the container logs
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start virtiofsd for filesystem %s: %v", filesystem.Name, err)
+		}
+		startedDaemons[socket] = true
+		go func(name string, socket string) {
+			err := cmd.Wait()
+			log.Log.Object(vmi).Reason(err).Infof("virtiofsd for filesystem %s exited", name)
+			startedDaemonsMux.Lock()
+			delete(startedDaemons, socket)
+			startedDaemonsMux.Unlock()
+		}(filesystem.Name, socket)
+
+		err = utilwait.PollImmediate(socketInterval, socketTimeout, func() (done bool, err error) {
+			_, err = os.Stat(socket)
+			return err == nil, nil
+		})
+		if err != nil {
+			return fmt.Errorf("virtiofsd for filesystem %s did not create its socket: %v", filesystem.Name, err)
+		}
+		log.Log.Object(vmi).Infof("Started virtiofsd for filesystem %s", filesystem.Name)
+	}
+	return nil
+}
+
+func getVolume(vmi *v1.VirtualMachineInstance, name string) *v1.Volume {
+	for i := range vmi.Spec.Volumes {
+		if vmi.Spec.Volumes[i].Name == name {
+			return &vmi.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package virtiofs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Virtiofs", func() {
+
+	table.DescribeTable("should share the pod mount of the volume", func(source v1.VolumeSource, expectedDir string) {
+		dir, err := SourceDir(&v1.Volume{Name: "myvolume", VolumeSource: source})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dir).To(Equal(expectedDir))
+	},
+		table.Entry("for a PVC", v1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+		}, "/var/run/kubevirt-private/vmi-disks/myvolume"),
+		table.Entry("for a DataVolume", v1.VolumeSource{
+			DataVolume: &v1.DataVolumeSource{Name: "dv"},
+		}, "/var/run/kubevirt-private/vmi-disks/myvolume"),
+		table.Entry("for a ConfigMap", v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{},
+		}, "/var/run/kubevirt-private/config-map/myvolume"),
+		table.Entry("for a Secret", v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{SecretName: "secret"},
+		}, "/var/run/kubevirt-private/secret/myvolume"),
+		table.Entry("for a ServiceAccount", v1.VolumeSource{
+			ServiceAccount: &v1.ServiceAccountVolumeSource{ServiceAccountName: "account"},
+		}, "/var/run/secrets/kubernetes.io/serviceaccount/"),
+	)
+
+	It("should not share other volumes", func() {
+		_, err := SourceDir(&v1.Volume{Name: "myvolume", VolumeSource: v1.VolumeSource{EmptyDisk: &v1.EmptyDiskSource{}}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("starting virtiofsd", func() {
+		var tmpDir string
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "virtiofs")
+			Expect(err).ToNot(HaveOccurred())
+			SocketDir = filepath.Join(tmpDir, "sockets")
+
+			// the fake virtiofsd records its arguments and creates the socket
+			VirtiofsdBinary = filepath.Join(tmpDir, "virtiofsd")
+			script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(tmpDir, "args") + "\ntouch \"${1#--socket-path=}\"\n"
+			Expect(ioutil.WriteFile(VirtiofsdBinary, []byte(script), 0755)).To(Succeed())
+
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "config", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should start one virtiofsd per filesystem and wait for its socket", func() {
+			Expect(StartDaemons(vmi)).To(Succeed())
+
+			_, err := os.Stat(SocketPath("config"))
+			Expect(err).ToNot(HaveOccurred())
+			args, err := ioutil.ReadFile(filepath.Join(tmpDir, "args"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(args)).To(Equal("--socket-path=" + SocketPath("config") +
+				" -o source=/var/run/kubevirt-private/config-map/config -o cache=none -o no_posix_lock\n"))
+		})
+
+		It("should fail if the filesystem has no matching volume", func() {
+			vmi.Spec.Volumes = nil
+			Expect(StartDaemons(vmi)).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+
+// Package v1alpha1 contains the subset of the snapshot.storage.k8s.io/v1alpha1
+// API which KubeVirt needs to create and track CSI VolumeSnapshots.
+package v1alpha1
@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+//go:generate deepcopy-gen -i . --go-header-file ../../../../../../../hack/boilerplate/boilerplate.go.txt
+
+/*
+ ATTENTION: Keep these types in sync with the snapshot.storage.k8s.io/v1alpha1 API of the external-snapshotter.
+*/
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+// GroupName is the group name of the CSI snapshot API
+const GroupName = "snapshot.storage.k8s.io"
+
+// GroupVersion is the group version of the CSI snapshot API
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+var VolumeSnapshotGroupVersionKind = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VolumeSnapshot"}
+
+// Adds the list of known types to api.Scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&VolumeSnapshot{},
+		&VolumeSnapshotList{},
+		&metav1.ListOptions{},
+		&metav1.DeleteOptions{},
+		&metav1.GetOptions{},
+	)
+	scheme.AddKnownTypes(metav1.Unversioned,
+		&metav1.Status{},
+	)
+	return nil
+}
+
+var (
+	Scheme        = runtime.NewScheme()
+	Codecs        = serializer.NewCodecFactory(Scheme)
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+func init() {
+	AddToScheme(Scheme)
+}
+
+// VolumeSnapshot is a user's request for taking a snapshot of a PersistentVolumeClaim
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec defines the desired characteristics of a snapshot requested by a user.
+	Spec VolumeSnapshotSpec `json:"spec"`
+	// Status represents the latest observed state of the snapshot
+	Status VolumeSnapshotStatus `json:"status"`
+}
+
+// VolumeSnapshotList is a list of VolumeSnapshots
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []VolumeSnapshot `json:"items"`
+}
+
+// VolumeSnapshotSpec describes the common attributes of a volume snapshot
+type VolumeSnapshotSpec struct {
+	// Source has the information about where the snapshot is created from.
+	// Only a PersistentVolumeClaim is supported as a source.
+	Source *k8sv1.TypedLocalObjectReference `json:"source"`
+	// SnapshotContentName binds the VolumeSnapshot object with the VolumeSnapshotContent
+	SnapshotContentName string `json:"snapshotContentName"`
+	// Name of the VolumeSnapshotClass used by the VolumeSnapshot. If not specified, the default
+	// snapshot class will be used.
+	VolumeSnapshotClassName *string `json:"snapshotClassName"`
+}
+
+// VolumeSnapshotStatus is the status of the VolumeSnapshot
+type VolumeSnapshotStatus struct {
+	// CreationTime is the time the snapshot was successfully created. If it is set,
+	// it means the snapshot was created.
+	CreationTime *metav1.Time `json:"creationTime"`
+	// When restoring volume from the snapshot, the size of the volume MUST NOT
+	// be smaller than the RestoreSize if it is specified.
+	RestoreSize *resource.Quantity `json:"restoreSize"`
+	// ReadyToUse is set to true only if the snapshot is ready to use (e.g., finish uploading if
+	// there is an uploading phase) and also VolumeSnapshot and its VolumeSnapshotContent
+	// bind correctly with each other.
+	ReadyToUse bool `json:"readyToUse"`
+	// The last error encountered during create snapshot operation, if any.
+	Error *storagev1beta1.VolumeError `json:"error,omitempty"`
+}
@@ -238,6 +238,11 @@ type CPU struct {
 	// Sockets specifies the number of sockets inside the vmi.
 	// Must be a value greater or equal 1.
 	Sockets uint32 `json:"sockets,omitempty"`
+	// MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running.
+	// Sockets can be increased on a running vmi up to this value.
+	// Must be a value greater or equal to Sockets.
+	// +optional
+	MaxSockets uint32 `json:"maxSockets,omitempty"`
 	// Threads specifies the number of threads inside the vmi.
 	// Must be a value greater or equal 1.
 	Threads uint32 `json:"threads,omitempty"`
@@ -255,6 +260,27 @@ type CPU struct {
 	// with enough dedicated pCPUs and pin the vCPUs to it.
 	// +optional
 	DedicatedCPUPlacement bool `json:"dedicatedCpuPlacement,omitempty"`
+	// NUMA allows specifying settings for the guest NUMA topology
+	// +optional
+	NUMA *NUMA `json:"numa,omitempty"`
+}
+
+// NUMA specifies the guest NUMA topology.
+// ---
+// +k8s:openapi-gen=true
+type NUMA struct {
+	// GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes
+	// of the CPUs dedicated to the VirtualMachineInstance. The guest memory of each cell is bound
+	// to the matching host NUMA node and backed by hugepages.
+	// Requires dedicatedCpuPlacement and hugepages.
+	// +optional
+	GuestMappingPassthrough *NUMAGuestMappingPassthrough `json:"guestMappingPassthrough,omitempty"`
+}
+
+// NUMAGuestMappingPassthrough mirrors the host NUMA topology of the dedicated CPUs in the guest.
+// ---
+// +k8s:openapi-gen=true
+type NUMAGuestMappingPassthrough struct {
 }
 
 // CPUFeature allows specifying a CPU feature.
@@ -286,6 +312,11 @@ type Memory struct {
 	// Defaults to the requested memory in the resources section if not specified.
 	// + optional
 	Guest *resource.Quantity `json:"guest,omitempty"`
+	// MaxGuest specifies the maximum amount of memory the Guest can be scaled up to while it is running.
+	// Guest memory can be increased on a running VirtualMachineInstance up to this value.
+	// Must be equal to or larger than the Guest memory.
+	// +optional
+	MaxGuest *resource.Quantity `json:"maxGuest,omitempty"`
 }
 
 // Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
@@ -316,11 +347,28 @@ type Firmware struct {
 	Serial string `json:"serial,omitempty"`
 }
 
+// ---
+// +k8s:openapi-gen=true
+type Filesystem struct {
+	// Name is the filesystem name, it must match the name of a PersistentVolumeClaim, DataVolume,
+	// ConfigMap, Secret or ServiceAccount volume. It is used as mount tag in the guest.
+	Name string `json:"name"`
+	// Virtiofs shares the volume through a virtiofsd process running in the virt-launcher pod.
+	Virtiofs *FilesystemVirtiofs `json:"virtiofs"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type FilesystemVirtiofs struct{}
+
 // ---
 // +k8s:openapi-gen=true
 type Devices struct {
 	// Disks describes disks, cdroms, floppy and luns which are connected to the vmi.
 	Disks []Disk `json:"disks,omitempty"`
+	// Filesystems describes filesystems which are shared with the vmi through virtio-fs.
+	// +optional
+	Filesystems []Filesystem `json:"filesystems,omitempty"`
 	// Watchdog describes a watchdog device which can be added to the vmi.
 	Watchdog *Watchdog `json:"watchdog,omitempty"`
 	// Interfaces describe network interfaces which are added to the vmi.
@@ -341,6 +389,10 @@ type Devices struct {
 	// If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature
 	// +optional
 	NetworkInterfaceMultiQueue *bool `json:"networkInterfaceMultiqueue,omitempty"`
+	// Ballooning configures the memory balloon device of the vmi.
+	// No balloon device is attached if it is not set.
+	// +optional
+	Ballooning *Ballooning `json:"ballooning,omitempty"`
 }
 
 // ---
@@ -381,6 +433,74 @@ type Disk struct {
 	// Cache specifies which kvm disk cache mode should be used.
 	// +optional
 	Cache DriverCache `json:"cache,omitempty"`
+	// IO specifies how the I/O of the disk is submitted on the host.
+	// supported values: threads, native.
+	// +optional
+	IO DriverIO `json:"io,omitempty"`
+	// ErrorPolicy specifies how read and write errors of the disk are handled.
+	// supported values: stop, report, ignore, enospace.
+	// +optional
+	ErrorPolicy DiskErrorPolicy `json:"errorPolicy,omitempty"`
+	// Discard specifies whether discard requests of the guest are passed to the storage.
+	// supported values: unmap, ignore.
+	// +optional
+	Discard DiskDiscard `json:"discard,omitempty"`
+	// DetectZeroes specifies whether writes of zeroes are optimized.
+	// supported values: off, on, unmap.
+	// +optional
+	DetectZeroes DiskDetectZeroes `json:"detectZeroes,omitempty"`
+	// IOTune limits the IOPS and the bandwidth of the disk.
+	// Defaults to the cluster wide default-disk-iotune, if set, except for cdrom and floppy disks.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+	// Medium is the name of the volume whose medium is in a CD-ROM or floppy of a running
+	// VirtualMachineInstance. It can only be set by the insertmedia and ejectmedia subresources,
+	// an empty name ejects the medium. Defaults to the volume of the disk.
+	// +optional
+	Medium *string `json:"medium,omitempty"`
+}
+
+// DiskIOTune throttles the I/O of a disk. All values are optional and zero means unlimited.
+// A total limit can't be combined with a read or a write limit of the same kind.
+// ---
+// +k8s:openapi-gen=true
+type DiskIOTune struct {
+	// TotalBytesSec limits the throughput of reads and writes in bytes per second.
+	// +optional
+	TotalBytesSec int64 `json:"totalBytesSec,omitempty"`
+	// ReadBytesSec limits the read throughput in bytes per second.
+	// +optional
+	ReadBytesSec int64 `json:"readBytesSec,omitempty"`
+	// WriteBytesSec limits the write throughput in bytes per second.
+	// +optional
+	WriteBytesSec int64 `json:"writeBytesSec,omitempty"`
+	// TotalIOPSSec limits the read and write operations per second.
+	// +optional
+	TotalIOPSSec int64 `json:"totalIOPSSec,omitempty"`
+	// ReadIOPSSec limits the read operations per second.
+	// +optional
+	ReadIOPSSec int64 `json:"readIOPSSec,omitempty"`
+	// WriteIOPSSec limits the write operations per second.
+	// +optional
+	WriteIOPSSec int64 `json:"writeIOPSSec,omitempty"`
+	// TotalBytesSecMax is the throughput of reads and writes in bytes per second which may be reached in bursts.
+	// +optional
+	TotalBytesSecMax int64 `json:"totalBytesSecMax,omitempty"`
+	// ReadBytesSecMax is the read throughput in bytes per second which may be reached in bursts.
+	// +optional
+	ReadBytesSecMax int64 `json:"readBytesSecMax,omitempty"`
+	// WriteBytesSecMax is the write throughput in bytes per second which may be reached in bursts.
+	// +optional
+	WriteBytesSecMax int64 `json:"writeBytesSecMax,omitempty"`
+	// TotalIOPSSecMax is the number of read and write operations per second which may be reached in bursts.
+	// +optional
+	TotalIOPSSecMax int64 `json:"totalIOPSSecMax,omitempty"`
+	// ReadIOPSSecMax is the number of read operations per second which may be reached in bursts.
+	// +optional
+	ReadIOPSSecMax int64 `json:"readIOPSSecMax,omitempty"`
+	// WriteIOPSSecMax is the number of write operations per second which may be reached in bursts.
+	// +optional
+	WriteIOPSSecMax int64 `json:"writeIOPSSecMax,omitempty"`
 }
 
 // Represents the target of a volume to mount.
@@ -1051,6 +1171,11 @@ type PodNetwork struct {
 	// CIDR for vm network.
 	// Default 10.0.2.0/24 if not specified.
 	VMNetworkCIDR string `json:"vmNetworkCIDR,omitempty"`
+
+	// IPv6 CIDR for the vm network.
+	// Default fd10:0:2::/120 if not specified.
+	// It is only used if the pod network has IPv6 addresses.
+	VMIPv6NetworkCIDR string `json:"vmIPv6NetworkCIDR,omitempty"`
 }
 
 // Rng represents the random device passed from host
@@ -1059,6 +1184,26 @@ type PodNetwork struct {
 type Rng struct {
 }
 
+// Ballooning configures the memory balloon device, which also reports guest memory statistics
+// ---
+// +k8s:openapi-gen=true
+type Ballooning struct {
+	// Enabled attaches the memory balloon device to the vmi.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Model of the memory balloon device.
+	// Supported values: virtio.
+	// Defaults to virtio.
+	// +optional
+	Model string `json:"model,omitempty"`
+	// StatsPeriod is the interval in seconds at which the guest memory statistics are collected.
+	// Zero disables the collection.
+	// Defaults to 10.
+	// +optional
+	StatsPeriod *uint32 `json:"statsPeriod,omitempty"`
+}
+
 // Represents the genie cni network.
 // ---
 // +k8s:openapi-gen=true
@@ -112,10 +112,25 @@ func (CPU) SwaggerDoc() map[string]string {
 		"":                      "CPU allows specifying the CPU topology.",
 		"cores":                 "Cores specifies the number of cores inside the vmi.\nMust be a value greater or equal 1.",
 		"sockets":               "Sockets specifies the number of sockets inside the vmi.\nMust be a value greater or equal 1.",
+		"maxSockets":            "MaxSockets specifies the maximum number of sockets the vmi can be scaled up to while it is running.\nSockets can be increased on a running vmi up to this value.\nMust be a value greater or equal to Sockets.\n+optional",
 		"threads":               "Threads specifies the number of threads inside the vmi.\nMust be a value greater or equal 1.",
 		"model":                 "Model specifies the CPU model inside the VMI.\nList of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.\nIt is possible to specify special cases like \"host-passthrough\" to get the same CPU as the node\nand \"host-model\" to get CPU closest to the node one.\nDefaults to host-model.\n+optional",
 		"features":              "Features specifies the CPU features list inside the VMI.\n+optional",
 		"dedicatedCpuPlacement": "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
+		"numa":                  "NUMA allows specifying settings for the guest NUMA topology\n+optional",
+	}
+}
+
+func (NUMA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "NUMA specifies the guest NUMA topology.",
+		"guestMappingPassthrough": "GuestMappingPassthrough creates a guest NUMA topology which mirrors the host NUMA nodes\nof the CPUs dedicated to the VirtualMachineInstance. The guest memory of each cell is bound\nto the matching host NUMA node and backed by hugepages.\nRequires dedicatedCpuPlacement and hugepages.\n+optional",
+	}
+}
+
+func (NUMAGuestMappingPassthrough) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "NUMAGuestMappingPassthrough mirrors the host NUMA topology of the dedicated CPUs in the guest.",
 	}
 }
 
@@ -132,6 +147,7 @@ func (Memory) SwaggerDoc() map[string]string {
 		"":          "Memory allows specifying the VirtualMachineInstance memory features.",
 		"hugepages": "Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.\n+optional",
 		"guest":     "Guest allows to specifying the amount of memory which is visible inside the Guest OS.\nThe Guest must lie between Requests and Limits from the resources section.\nDefaults to the requested memory in the resources section if not specified.\n+ optional",
+		"maxGuest":  "MaxGuest specifies the maximum amount of memory the Guest can be scaled up to while it is running.\nGuest memory can be increased on a running VirtualMachineInstance up to this value.\nMust be equal to or larger than the Guest memory.\n+optional",
 	}
 }
 
@@ -156,9 +172,21 @@ func (Firmware) SwaggerDoc() map[string]string {
 	}
 }
 
+func (Filesystem) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"name":     "Name is the filesystem name, it must match the name of a PersistentVolumeClaim, DataVolume,\nConfigMap, Secret or ServiceAccount volume. It is used as mount tag in the guest.",
+		"virtiofs": "Virtiofs shares the volume through a virtiofsd process running in the virt-launcher pod.",
+	}
+}
+
+func (FilesystemVirtiofs) SwaggerDoc() map[string]string {
+	return map[string]string{}
+}
+
 func (Devices) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"disks":                      "Disks describes disks, cdroms, floppy and luns which are connected to the vmi.",
+		"filesystems":                "Filesystems describes filesystems which are shared with the vmi through virtio-fs.\n+optional",
 		"watchdog":                   "Watchdog describes a watchdog device which can be added to the vmi.",
 		"interfaces":                 "Interfaces describe network interfaces which are added to the vmi.",
 		"inputs":                     "Inputs describe input devices",
@@ -167,6 +195,7 @@ func (Devices) SwaggerDoc() map[string]string {
 		"rng":                        "Whether to have random number generator from host\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature\n+optional",
+		"ballooning":                 "Ballooning configures the memory balloon device of the vmi.\nNo balloon device is attached if it is not set.\n+optional",
 	}
 }
 
@@ -185,6 +214,30 @@ func (Disk) SwaggerDoc() map[string]string {
 		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\n+optional",
+		"io":                "IO specifies how the I/O of the disk is submitted on the host.\nsupported values: threads, native.\n+optional",
+		"errorPolicy":       "ErrorPolicy specifies how read and write errors of the disk are handled.\nsupported values: stop, report, ignore, enospace.\n+optional",
+		"discard":           "Discard specifies whether discard requests of the guest are passed to the storage.\nsupported values: unmap, ignore.\n+optional",
+		"detectZeroes":      "DetectZeroes specifies whether writes of zeroes are optimized.\nsupported values: off, on, unmap.\n+optional",
+		"ioTune":            "IOTune limits the IOPS and the bandwidth of the disk.\nDefaults to the cluster wide default-disk-iotune, if set, except for cdrom and floppy disks.\n+optional",
+		"medium":            "Medium is the name of the volume whose medium is in a CD-ROM or floppy of a running\nVirtualMachineInstance. It can only be set by the insertmedia and ejectmedia subresources,\nan empty name ejects the medium. Defaults to the volume of the disk.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DiskIOTune throttles the I/O of a disk. All values are optional and zero means unlimited.\nA total limit can't be combined with a read or a write limit of the same kind.",
+		"totalBytesSec":    "TotalBytesSec limits the throughput of reads and writes in bytes per second.\n+optional",
+		"readBytesSec":     "ReadBytesSec limits the read throughput in bytes per second.\n+optional",
+		"writeBytesSec":    "WriteBytesSec limits the write throughput in bytes per second.\n+optional",
+		"totalIOPSSec":     "TotalIOPSSec limits the read and write operations per second.\n+optional",
+		"readIOPSSec":      "ReadIOPSSec limits the read operations per second.\n+optional",
+		"writeIOPSSec":     "WriteIOPSSec limits the write operations per second.\n+optional",
+		"totalBytesSecMax": "TotalBytesSecMax is the throughput of reads and writes in bytes per second which may be reached in bursts.\n+optional",
+		"readBytesSecMax":  "ReadBytesSecMax is the read throughput in bytes per second which may be reached in bursts.\n+optional",
+		"writeBytesSecMax": "WriteBytesSecMax is the write throughput in bytes per second which may be reached in bursts.\n+optional",
+		"totalIOPSSecMax":  "TotalIOPSSecMax is the number of read and write operations per second which may be reached in bursts.\n+optional",
+		"readIOPSSecMax":   "ReadIOPSSecMax is the number of read operations per second which may be reached in bursts.\n+optional",
+		"writeIOPSSecMax":  "WriteIOPSSecMax is the number of write operations per second which may be reached in bursts.\n+optional",
 	}
 }
 
@@ -502,8 +555,9 @@ func (NetworkSource) SwaggerDoc() map[string]string {
 
 func (PodNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "Represents the stock pod network interface.",
-		"vmNetworkCIDR": "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"":                  "Represents the stock pod network interface.",
+		"vmNetworkCIDR":     "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"vmIPv6NetworkCIDR": "IPv6 CIDR for the vm network.\nDefault fd10:0:2::/120 if not specified.\nIt is only used if the pod network has IPv6 addresses.",
 	}
 }
 
@@ -513,6 +567,15 @@ func (Rng) SwaggerDoc() map[string]string {
 	}
 }
 
+func (Ballooning) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "Ballooning configures the memory balloon device, which also reports guest memory statistics",
+		"enabled":     "Enabled attaches the memory balloon device to the vmi.\nDefaults to true.\n+optional",
+		"model":       "Model of the memory balloon device.\nSupported values: virtio.\nDefaults to virtio.\n+optional",
+		"statsPeriod": "StatsPeriod is the interval in seconds at which the guest memory statistics are collected.\nZero disables the collection.\nDefaults to 10.\n+optional",
+	}
+}
+
 func (GenieNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "Represents the genie cni network.",
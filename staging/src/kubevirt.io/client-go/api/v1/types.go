@@ -36,6 +36,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -93,6 +94,12 @@ var VirtualMachineInstanceMigrationGroupVersionKind = schema.GroupVersionKind{Gr
 
 var KubeVirtGroupVersionKind = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "KubeVirt"}
 
+var VirtualMachineSnapshotGroupVersionKind = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineSnapshot"}
+
+var VirtualMachineRestoreGroupVersionKind = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineRestore"}
+
+var MigrationPolicyGroupVersionKind = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "MigrationPolicy"}
+
 // Adds the list of known types to api.Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 
@@ -113,6 +120,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 			&VirtualMachineList{},
 			&KubeVirt{},
 			&KubeVirtList{},
+			&VirtualMachineSnapshot{},
+			&VirtualMachineSnapshotList{},
+			&VirtualMachineRestore{},
+			&VirtualMachineRestoreList{},
+			&MigrationPolicy{},
+			&MigrationPolicyList{},
 		)
 	}
 	scheme.AddKnownTypes(metav1.Unversioned,
@@ -260,6 +273,17 @@ type VirtualMachineInstanceStatus struct {
 	// More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md
 	// +optional
 	QOSClass *k8sv1.PodQOSClass `json:"qosClass,omitempty"`
+	// VolumeStatus contains the statuses of the volumes which were hotplugged into the running VirtualMachineInstance
+	// +optional
+	VolumeStatus []VolumeStatus `json:"volumeStatus,omitempty"`
+	// HostModelCPU is the CPU model and features which libvirt expanded the host-model CPU to when the VirtualMachineInstance started.
+	// Migration targets have to support them.
+	// +optional
+	HostModelCPU *HostModelCPU `json:"hostModelCPU,omitempty"`
+	// Media contains the media in the CD-ROMs and floppies of the running VirtualMachineInstance and
+	// the state of their trays
+	// +optional
+	Media []MediaStatus `json:"media,omitempty"`
 }
 
 // Required to satisfy Object interface
@@ -301,6 +325,26 @@ func (v *VirtualMachineInstance) IsCPUDedicated() bool {
 	return v.Spec.Domain.CPU != nil && v.Spec.Domain.CPU.DedicatedCPUPlacement
 }
 
+// Checks if the guest NUMA topology should mirror the host NUMA nodes of the dedicated CPUs
+func (v *VirtualMachineInstance) IsNUMAPassthrough() bool {
+	return v.Spec.Domain.CPU != nil && v.Spec.Domain.CPU.NUMA != nil && v.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil
+}
+
+// Checks if the VirtualMachineInstance gets the host-model CPU, which is the default
+func (v *VirtualMachineInstance) IsCPUHostModel() bool {
+	return v.Spec.Domain.CPU == nil || v.Spec.Domain.CPU.Model == "" || v.Spec.Domain.CPU.Model == CPUModeHostModel
+}
+
+// Checks if the volume is shared with the guest as filesystem instead of being attached as disk
+func (v *VirtualMachineInstance) IsFilesystemVolume(volumeName string) bool {
+	for _, filesystem := range v.Spec.Domain.Devices.Filesystems {
+		if filesystem.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
 // WantsToHaveQOSGuaranteed checks if cpu and memoyr limits and requests are identical on the VMI.
 // This is the indicator that people want a VMI with QOS of guaranteed
 func (v *VirtualMachineInstance) WantsToHaveQOSGuaranteed() bool {
@@ -328,6 +372,8 @@ const (
 	// VMIReady means the pod is able to service requests and should be added to the
 	// load balancing pools of all matching services.
 	VirtualMachineInstanceReady VirtualMachineInstanceConditionType = "Ready"
+	// Reason means that the readiness probe, which virt-handler runs through the guest agent, did not succeed
+	VirtualMachineInstanceReasonGuestNotReady = "GuestNotReady"
 
 	// If there happens any error while trying to synchronize the VirtualMachineInstance with the Domain,
 	// this is reported as false.
@@ -342,6 +388,18 @@ const (
 	VirtualMachineInstanceReasonDisksNotMigratable = "DisksNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
+	// Reason means that VMI is not live migratioable because of it's hotplugged volumes
+	VirtualMachineInstanceReasonHotplugNotMigratable = "HotplugNotLiveMigratable"
+
+	// Reflects whether the VMI was paused
+	VirtualMachineInstancePaused VirtualMachineInstanceConditionType = "Paused"
+	// Reason means that the VMI was paused by the user through the pause subresource
+	VirtualMachineInstanceReasonPausedByUser = "PausedByUser"
+
+	// Reflects whether the guest was paused because of an I/O error on one of its disks
+	VirtualMachineInstanceIOError VirtualMachineInstanceConditionType = "IOError"
+	// Reason means that the storage of a disk failed or ran out of space
+	VirtualMachineInstanceReasonDiskIOError = "DiskIOError"
 )
 
 // +k8s:openapi-gen=true
@@ -445,6 +503,35 @@ type VirtualMachineInstanceMigrationState struct {
 	AbortStatus MigrationAbortStatus `json:"abortStatus,omitempty"`
 	// The VirtualMachineInstanceMigration object associated with this migration
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
+	// The data transfer progress of the running migration, as reported by the source node
+	Progress *VirtualMachineInstanceMigrationProgress `json:"progress,omitempty"`
+	// The name of the MigrationPolicy which overrides the cluster wide migration configuration
+	MigrationPolicyName string `json:"migrationPolicyName,omitempty"`
+}
+
+// VirtualMachineInstanceMigrationProgress reports how far a live migration has come
+// and whether it converges.
+//
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceMigrationProgress struct {
+	// The time the progress was reported
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+	// The time since the migration started, in milliseconds
+	TimeElapsedMilliseconds int64 `json:"timeElapsedMilliseconds,omitempty"`
+	// The total amount of data to transfer, in bytes
+	DataTotalBytes int64 `json:"dataTotalBytes,omitempty"`
+	// The amount of data which was already transferred, in bytes
+	DataProcessedBytes int64 `json:"dataProcessedBytes,omitempty"`
+	// The amount of data which is left to transfer, in bytes
+	DataRemainingBytes int64 `json:"dataRemainingBytes,omitempty"`
+	// The rate at which the guest dirties its memory, in bytes per second
+	MemoryDirtyRateBytes int64 `json:"memoryDirtyRateBytes,omitempty"`
+	// The rate at which memory is transferred, in bytes per second
+	MemoryTransferRateBytes int64 `json:"memoryTransferRateBytes,omitempty"`
+	// The number of iterations over the guest memory. An iteration count which keeps
+	// growing while the remaining data does not shrink means the migration does not converge.
+	MemoryIteration int64 `json:"memoryIteration,omitempty"`
 }
 
 // ---
@@ -517,6 +604,17 @@ const (
 	CreatedByLabel string = "kubevirt.io/created-by"
 	// This label is used to indicate that this pod is the target of a migration job.
 	MigrationJobLabel string = "kubevirt.io/migrationJobUID"
+	// This label is used to match hotplug attachment pods with the volume they attach to the node.
+	// Used on Pod.
+	HotplugVolumeLabel string = "kubevirt.io/hotplug-volume"
+	// This annotation lists the comma separated names of all volumes which were hotplugged
+	// into the virtual machine instance. It is set by virt-api and can't be changed by users.
+	// Used on VirtualMachineInstance.
+	HotplugVolumesAnnotation string = "kubevirt.io/hotplug-volumes"
+	// This annotation holds the json encoded spec of the VirtualMachine template the
+	// virtual machine instance was started from. It is set by virt-controller.
+	// Used on VirtualMachineInstance.
+	VirtualMachineTemplateSpecAnnotation string = "kubevirt.io/vm-template-spec"
 	// This label describes which cluster node runs the virtual machine
 	// instance. Needed because with CRDs we can't use field selectors. Used on
 	// VirtualMachineInstance.
@@ -532,6 +630,10 @@ const (
 	// if a particular node is alive and hence should be available for new
 	// virtual machine instance scheduling. Used on Node.
 	VirtHandlerHeartbeat string = "kubevirt.io/heartbeat"
+	// This annotation lists the CPU model and feature labels which the node
+	// labeller in virt-handler added, so that labels set by others are left
+	// alone. Used on Node.
+	NodeLabellerLabelsAnnotation string = "kubevirt.io/node-labeller-labels"
 	// This label will be set on all resources created by the operator
 	ManagedByLabel              = "app.kubernetes.io/managed-by"
 	ManagedByLabelOperatorValue = "kubevirt-operator"
@@ -549,6 +651,7 @@ const (
 
 	VirtualMachineInstanceFinalizer          string = "foregroundDeleteVirtualMachine"
 	VirtualMachineInstanceMigrationFinalizer string = "kubevirt.io/migrationJobFinalize"
+	VirtualMachineSnapshotFinalizer          string = "kubevirt.io/snapshotUnfreeze"
 	CPUManager                               string = "cpumanager"
 	// This annotation is used to inject ignition data
 	// Used on VirtualMachineInstance.
@@ -576,18 +679,22 @@ func NewVMI(name string, uid types.UID) *VirtualMachineInstance {
 type SyncEvent string
 
 const (
-	Created         SyncEvent = "Created"
-	Deleted         SyncEvent = "Deleted"
-	PresetFailed    SyncEvent = "PresetFailed"
-	Override        SyncEvent = "Override"
-	Started         SyncEvent = "Started"
-	ShuttingDown    SyncEvent = "ShuttingDown"
-	Stopped         SyncEvent = "Stopped"
-	PreparingTarget SyncEvent = "PreparingTarget"
-	Migrating       SyncEvent = "Migrating"
-	Migrated        SyncEvent = "Migrated"
-	SyncFailed      SyncEvent = "SyncFailed"
-	Resumed         SyncEvent = "Resumed"
+	Created               SyncEvent = "Created"
+	Deleted               SyncEvent = "Deleted"
+	PresetFailed          SyncEvent = "PresetFailed"
+	Override              SyncEvent = "Override"
+	Started               SyncEvent = "Started"
+	ShuttingDown          SyncEvent = "ShuttingDown"
+	Stopped               SyncEvent = "Stopped"
+	PreparingTarget       SyncEvent = "PreparingTarget"
+	Migrating             SyncEvent = "Migrating"
+	Migrated              SyncEvent = "Migrated"
+	SyncFailed            SyncEvent = "SyncFailed"
+	Resumed               SyncEvent = "Resumed"
+	Unhealthy             SyncEvent = "Unhealthy"
+	IOError               SyncEvent = "IOError"
+	VolumeExpanded        SyncEvent = "VolumeExpanded"
+	VolumeExpansionFailed SyncEvent = "VolumeExpansionFailed"
 )
 
 func (s SyncEvent) String() string {
@@ -837,6 +944,10 @@ type VirtualMachineInstanceMigrationSpec struct {
 type VirtualMachineInstanceMigrationStatus struct {
 	Phase      VirtualMachineInstanceMigrationPhase       `json:"phase,omitempty"`
 	Conditions []VirtualMachineInstanceMigrationCondition `json:"conditions,omitempty"`
+	// The data transfer progress of the running migration
+	Progress *VirtualMachineInstanceMigrationProgress `json:"progress,omitempty"`
+	// The name of the MigrationPolicy applied to the migration
+	MigrationPolicyName string `json:"migrationPolicyName,omitempty"`
 }
 
 // VirtualMachineInstanceMigrationPhase is a label for the condition of a VirtualMachineInstanceMigration at the current time.
@@ -865,6 +976,79 @@ const (
 	MigrationFailed VirtualMachineInstanceMigrationPhase = "Failed"
 )
 
+// MigrationPolicy overrides the cluster wide migration configuration for the
+// VirtualMachineInstances it selects
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type MigrationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MigrationPolicySpec `json:"spec" valid:"required"`
+}
+
+// Required to satisfy Object interface
+func (p *MigrationPolicy) GetObjectKind() schema.ObjectKind {
+	return &p.TypeMeta
+}
+
+// Required to satisfy ObjectMetaAccessor interface
+func (p *MigrationPolicy) GetObjectMeta() metav1.Object {
+	return &p.ObjectMeta
+}
+
+// MigrationPolicyList is a list of MigrationPolicies
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type MigrationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        metav1.ListMeta   `json:"metadata,omitempty"`
+	Items           []MigrationPolicy `json:"items"`
+}
+
+// Required to satisfy Object interface
+func (pl *MigrationPolicyList) GetObjectKind() schema.ObjectKind {
+	return &pl.TypeMeta
+}
+
+// Required to satisfy ListMetaAccessor interface
+func (pl *MigrationPolicyList) GetListMeta() meta.List {
+	return &pl.ListMeta
+}
+
+// MigrationPolicySpec selects VirtualMachineInstances and holds the migration settings
+// which apply to them. Unset settings fall back to the cluster wide configuration.
+// ---
+// +k8s:openapi-gen=true
+type MigrationPolicySpec struct {
+	// Selectors choose the VirtualMachineInstances the policy applies to
+	Selectors *MigrationPolicySelectors `json:"selectors" valid:"required"`
+	// The bandwidth limit of each migration
+	BandwidthPerMigration *resource.Quantity `json:"bandwidthPerMigration,omitempty"`
+	// The time in seconds per GiB of memory after which a migration is canceled
+	CompletionTimeoutPerGiB *int64 `json:"completionTimeoutPerGiB,omitempty"`
+	// The time in seconds without progress after which a migration is canceled
+	ProgressTimeout *int64 `json:"progressTimeout,omitempty"`
+	// Allows the guest to be throttled when a migration does not converge
+	AllowAutoConverge *bool `json:"allowAutoConverge,omitempty"`
+	// Allows a migration which does not converge to switch to post-copy
+	AllowPostCopy *bool `json:"allowPostCopy,omitempty"`
+	// The number of memory iterations after which a migration switches to post-copy
+	PostCopyAfterIterations *int64 `json:"postCopyAfterIterations,omitempty"`
+}
+
+// MigrationPolicySelectors select VirtualMachineInstances by the labels of their namespace
+// and by their own labels. A VirtualMachineInstance has to match all given selectors.
+// ---
+// +k8s:openapi-gen=true
+type MigrationPolicySelectors struct {
+	// Selects the namespaces by their labels
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Selects the VirtualMachineInstances by their labels
+	VirtualMachineInstanceSelector *metav1.LabelSelector `json:"virtualMachineInstanceSelector,omitempty"`
+}
+
 // ---
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:openapi-gen=true
@@ -1000,8 +1184,24 @@ const (
 	// VMI will initially be running--and restarted if a failure occurs.
 	// It will not be restarted upon successful completion.
 	RunStrategyRerunOnFailure VirtualMachineRunStrategy = "RerunOnFailure"
+	// VMI will run once and not be restarted upon completion, regardless
+	// if the completion is of phase Failure or Success. Once the VMI completed,
+	// the RunStrategy is switched to Halted.
+	RunStrategyOnce VirtualMachineRunStrategy = "Once"
 )
 
+// VirtualMachineRunSchedule starts and stops the VirtualMachineInstance at the times given as cron expressions
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineRunSchedule struct {
+	// Start is a cron expression like "0 8 * * 1-5", in UTC, at which the VirtualMachineInstance gets started
+	// +optional
+	Start string `json:"start,omitempty"`
+	// Stop is a cron expression like "0 18 * * 1-5", in UTC, at which the VirtualMachineInstance gets stopped
+	// +optional
+	Stop string `json:"stop,omitempty"`
+}
+
 // VirtualMachineSpec describes how the proper VirtualMachine
 // should look like
 // ---
@@ -1015,6 +1215,11 @@ type VirtualMachineSpec struct {
 	// mutually exclusive with Running
 	RunStrategy *VirtualMachineRunStrategy `json:"runStrategy,omitempty" optional:"true"`
 
+	// RunSchedule starts and stops the VirtualMachineInstance at the scheduled times,
+	// like a user calling the start and stop subresources. Requires the Manual RunStrategy.
+	// +optional
+	RunSchedule *VirtualMachineRunSchedule `json:"runSchedule,omitempty"`
+
 	// Template is the direct specification of VirtualMachineInstance
 	Template *VirtualMachineInstanceTemplateSpec `json:"template"`
 
@@ -1043,13 +1248,55 @@ type VirtualMachineStatus struct {
 	Created bool `json:"created,omitempty"`
 	// Ready indicates if the virtual machine is running and ready
 	Ready bool `json:"ready,omitempty"`
+	// PrintableStatus is a human readable, high-level representation of the status of the virtual machine
+	PrintableStatus VirtualMachinePrintableStatus `json:"printableStatus,omitempty"`
 	// Hold the state information of the VirtualMachine and its VirtualMachineInstance
 	Conditions []VirtualMachineCondition `json:"conditions,omitempty" optional:"true"`
+	// LastScheduleTime is the last time at which the RunSchedule started or stopped the VirtualMachineInstance
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
 	// StateChangeRequests indicates a list of actions that should be taken on a VMI
 	// e.g. stop a specific VMI then start a new one.
 	StateChangeRequests []VirtualMachineStateChangeRequest `json:"stateChangeRequests,omitempty" optional:"true"`
 }
 
+// VirtualMachinePrintableStatus is a human readable, high-level representation of the status of the virtual machine.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachinePrintableStatus string
+
+// A list of statuses which can be displayed for a virtual machine
+const (
+	// VirtualMachineStatusStopped indicates that the virtual machine is currently stopped and isn't expected to start.
+	VirtualMachineStatusStopped VirtualMachinePrintableStatus = "Stopped"
+	// VirtualMachineStatusProvisioning indicates that cluster resources associated with the virtual machine
+	// (e.g., DataVolumes) are being provisioned and prepared.
+	VirtualMachineStatusProvisioning VirtualMachinePrintableStatus = "Provisioning"
+	// VirtualMachineStatusStarting indicates that the virtual machine is being prepared for running.
+	VirtualMachineStatusStarting VirtualMachinePrintableStatus = "Starting"
+	// VirtualMachineStatusRunning indicates that the virtual machine is running.
+	VirtualMachineStatusRunning VirtualMachinePrintableStatus = "Running"
+	// VirtualMachineStatusPaused indicates that the virtual machine is paused.
+	VirtualMachineStatusPaused VirtualMachinePrintableStatus = "Paused"
+	// VirtualMachineStatusStopping indicates that the virtual machine is in the process of being stopped.
+	VirtualMachineStatusStopping VirtualMachinePrintableStatus = "Stopping"
+	// VirtualMachineStatusTerminating indicates that the virtual machine is in the process of deletion,
+	// as well as its associated resources (VirtualMachineInstance, DataVolumes, etc.).
+	VirtualMachineStatusTerminating VirtualMachinePrintableStatus = "Terminating"
+	// VirtualMachineStatusMigrating indicates that the virtual machine is in the process of being migrated
+	// to another host.
+	VirtualMachineStatusMigrating VirtualMachinePrintableStatus = "Migrating"
+	// VirtualMachineStatusUnknown indicates that the state of the virtual machine could not be obtained,
+	// typically due to an error in communicating with the host on which it's running.
+	VirtualMachineStatusUnknown VirtualMachinePrintableStatus = "Unknown"
+	// VirtualMachineStatusUnschedulable indicates that an error has occurred while scheduling the virtual machine,
+	// e.g. due to unsatisfiable resource requests or unsatisfiable scheduling constraints.
+	VirtualMachineStatusUnschedulable VirtualMachinePrintableStatus = "ErrorUnschedulable"
+	// VirtualMachineStatusDataVolumeError indicates that an error has been reported by one of the DataVolumes
+	// referenced by the virtual machines.
+	VirtualMachineStatusDataVolumeError VirtualMachinePrintableStatus = "DataVolumeError"
+)
+
 type VirtualMachineStateChangeRequest struct {
 	// Indicates the type of action that is requested. e.g. Start or Stop
 	Action StateChangeRequestAction `json:"action"`
@@ -1088,6 +1335,10 @@ const (
 	// fails to be created due to insufficient quota, limit ranges, pod security policy, node selectors,
 	// etc. or deleted due to kubelet being down or finalizers are failing.
 	VirtualMachineFailure VirtualMachineConditionType = "Failure"
+
+	// VirtualMachineRestartRequired is added in a virtual machine when changes of its template
+	// can not be applied to the running vmi and only take effect after a restart.
+	VirtualMachineRestartRequired VirtualMachineConditionType = "RestartRequired"
 )
 
 // ---
@@ -1126,6 +1377,56 @@ const (
 	CacheWriteThrough DriverCache = "writethrough"
 )
 
+// ---
+// +k8s:openapi-gen=true
+type DriverIO string
+
+const (
+	// IOThreads - I/O is submitted by a pool of user space threads.
+	IOThreads DriverIO = "threads"
+	// IONative - I/O is submitted through the kernel AIO interface, this requires the cache mode none.
+	IONative DriverIO = "native"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type DiskErrorPolicy string
+
+const (
+	// DiskErrorPolicyStop - the guest is paused on I/O errors until the storage is available again.
+	DiskErrorPolicyStop DiskErrorPolicy = "stop"
+	// DiskErrorPolicyReport - I/O errors are reported to the guest.
+	DiskErrorPolicyReport DiskErrorPolicy = "report"
+	// DiskErrorPolicyIgnore - I/O errors are ignored.
+	DiskErrorPolicyIgnore DiskErrorPolicy = "ignore"
+	// DiskErrorPolicyENOSPC - the guest is paused if the storage runs out of space, other errors are reported to the guest.
+	DiskErrorPolicyENOSPC DiskErrorPolicy = "enospace"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type DiskDiscard string
+
+const (
+	// DiscardUnmap - discard requests of the guest are passed to the storage.
+	DiscardUnmap DiskDiscard = "unmap"
+	// DiscardIgnore - discard requests of the guest are ignored.
+	DiscardIgnore DiskDiscard = "ignore"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type DiskDetectZeroes string
+
+const (
+	// DetectZeroesOff - writes of zeroes are not detected.
+	DetectZeroesOff DiskDetectZeroes = "off"
+	// DetectZeroesOn - writes of zeroes are turned into write zeroes requests.
+	DetectZeroesOn DiskDetectZeroes = "on"
+	// DetectZeroesUnmap - writes of zeroes are turned into discard requests, this requires the discard mode unmap.
+	DetectZeroesUnmap DiskDetectZeroes = "unmap"
+)
+
 // Handler defines a specific action that should be taken
 // TODO: pass structured data to these actions, and document that data here.
 type Handler struct {
@@ -1137,8 +1438,22 @@ type Handler struct {
 	// TODO: implement a realistic TCP lifecycle hook
 	// +optional
 	TCPSocket *k8sv1.TCPSocketAction `json:"tcpSocket,omitempty"`
+	// Exec specifies a command to run in the guest through the qemu guest agent.
+	// The command is not run in a shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+	// If the guest agent is not connected, the probe fails.
+	// +optional
+	Exec *k8sv1.ExecAction `json:"exec,omitempty"`
+	// GuestAgentPing contacts the qemu guest agent in the guest.
+	// The probe succeeds as long as the guest agent answers.
+	// +optional
+	GuestAgentPing *GuestAgentPing `json:"guestAgentPing,omitempty"`
 }
 
+// GuestAgentPing configures the guest agent based ping probe
+// ---
+// +k8s:openapi-gen=true
+type GuestAgentPing struct{}
+
 // Probe describes a health check to be performed against a VirtualMachineInstance to determine whether it is
 // alive or ready to receive traffic.
 type Probe struct {
@@ -1167,6 +1482,216 @@ type Probe struct {
 	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
+// IsGuestAgentProbe returns true if the probe has to be run by virt-handler through the guest agent,
+// since the pod can't reach into the guest
+func (p *Probe) IsGuestAgentProbe() bool {
+	return p != nil && (p.Exec != nil || p.GuestAgentPing != nil)
+}
+
+// VirtualMachineSnapshot defines the operation of snapshotting a VirtualMachine.
+// It captures the VirtualMachine spec and the contents of its persistent volumes at a point in time.
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineSnapshotSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineSnapshotStatus `json:"status,omitempty"`
+}
+
+// Required to satisfy Object interface
+func (s *VirtualMachineSnapshot) GetObjectKind() schema.ObjectKind {
+	return &s.TypeMeta
+}
+
+// Required to satisfy ObjectMetaAccessor interface
+func (s *VirtualMachineSnapshot) GetObjectMeta() metav1.Object {
+	return &s.ObjectMeta
+}
+
+// The snapshot phase indicates that the snapshot has either been taken or failed
+func (s *VirtualMachineSnapshot) IsFinal() bool {
+	return s.Status.Phase == VirtualMachineSnapshotSucceeded || s.Status.Phase == VirtualMachineSnapshotFailed
+}
+
+// VirtualMachineSnapshotList is a list of VirtualMachineSnapshots
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        metav1.ListMeta          `json:"metadata,omitempty"`
+	Items           []VirtualMachineSnapshot `json:"items"`
+}
+
+// Required to satisfy Object interface
+func (sl *VirtualMachineSnapshotList) GetObjectKind() schema.ObjectKind {
+	return &sl.TypeMeta
+}
+
+// Required to satisfy ListMetaAccessor interface
+func (sl *VirtualMachineSnapshotList) GetListMeta() meta.List {
+	return &sl.ListMeta
+}
+
+// VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineSnapshotSpec struct {
+	// The object to snapshot. Only a VirtualMachine in the namespace of the snapshot is supported
+	Source k8sv1.TypedLocalObjectReference `json:"source" valid:"required"`
+}
+
+// VirtualMachineSnapshotStatus represents information pertaining to a VirtualMachineSnapshot.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineSnapshotStatus struct {
+	Phase VirtualMachineSnapshotPhase `json:"phase,omitempty"`
+	// The UID of the VirtualMachine the snapshot was taken from
+	SourceUID *types.UID `json:"sourceUID,omitempty"`
+	// The time the volume snapshots were requested
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// Indicates that all volume snapshots are ready and the snapshot can be restored
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+	// Indicates that the guest filesystems were frozen for the volume snapshots and still have to be thawed
+	Frozen bool `json:"frozen,omitempty"`
+	// A human readable message indicating why the snapshot failed
+	Message string `json:"message,omitempty"`
+	// The VirtualMachine spec at the time the snapshot was taken
+	VirtualMachineSpec *VirtualMachineSpec `json:"virtualMachineSpec,omitempty"`
+	// The snapshots taken of the persistent volumes of the VirtualMachine
+	VolumeBackups []VolumeBackup `json:"volumeBackups,omitempty"`
+}
+
+// VolumeBackup describes the snapshot of a single VirtualMachine volume
+// ---
+// +k8s:openapi-gen=true
+type VolumeBackup struct {
+	// The name of the volume in the VirtualMachine template
+	VolumeName string `json:"volumeName"`
+	// The PersistentVolumeClaim backing the volume
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// The spec of the PersistentVolumeClaim at the time the snapshot was taken
+	PersistentVolumeClaimSpec k8sv1.PersistentVolumeClaimSpec `json:"persistentVolumeClaimSpec"`
+	// The CSI VolumeSnapshot holding the volume contents
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+}
+
+// VirtualMachineSnapshotPhase is a label for the phase of a VirtualMachineSnapshot at the current time.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineSnapshotPhase string
+
+// These are the valid snapshot phases
+const (
+	VirtualMachineSnapshotPhaseUnset VirtualMachineSnapshotPhase = ""
+	// The VirtualMachine spec was recorded and the volume snapshots are being taken
+	VirtualMachineSnapshotInProgress VirtualMachineSnapshotPhase = "InProgress"
+	// All volume snapshots are ready to use
+	VirtualMachineSnapshotSucceeded VirtualMachineSnapshotPhase = "Succeeded"
+	// The snapshot could not be taken
+	VirtualMachineSnapshotFailed VirtualMachineSnapshotPhase = "Failed"
+)
+
+// VirtualMachineRestore defines the operation of restoring a VirtualMachine from a VirtualMachineSnapshot.
+// The persistent volumes are recreated from the volume snapshots and the VirtualMachine spec is rewritten.
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineRestoreSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineRestoreStatus `json:"status,omitempty"`
+}
+
+// Required to satisfy Object interface
+func (r *VirtualMachineRestore) GetObjectKind() schema.ObjectKind {
+	return &r.TypeMeta
+}
+
+// Required to satisfy ObjectMetaAccessor interface
+func (r *VirtualMachineRestore) GetObjectMeta() metav1.Object {
+	return &r.ObjectMeta
+}
+
+// The restore phase indicates that the restore has either completed or failed
+func (r *VirtualMachineRestore) IsFinal() bool {
+	return r.Status.Phase == VirtualMachineRestoreSucceeded || r.Status.Phase == VirtualMachineRestoreFailed
+}
+
+// VirtualMachineRestoreList is a list of VirtualMachineRestores
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	ListMeta        metav1.ListMeta         `json:"metadata,omitempty"`
+	Items           []VirtualMachineRestore `json:"items"`
+}
+
+// Required to satisfy Object interface
+func (rl *VirtualMachineRestoreList) GetObjectKind() schema.ObjectKind {
+	return &rl.TypeMeta
+}
+
+// Required to satisfy ListMetaAccessor interface
+func (rl *VirtualMachineRestoreList) GetListMeta() meta.List {
+	return &rl.ListMeta
+}
+
+// VirtualMachineRestoreSpec is the spec for a VirtualMachineRestore
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineRestoreSpec struct {
+	// The object to restore. Only the VirtualMachine the snapshot was taken from is supported
+	Target k8sv1.TypedLocalObjectReference `json:"target" valid:"required"`
+	// The name of the VirtualMachineSnapshot to restore from
+	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName" valid:"required"`
+}
+
+// VirtualMachineRestoreStatus represents information pertaining to a VirtualMachineRestore.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineRestoreStatus struct {
+	Phase VirtualMachineRestorePhase `json:"phase,omitempty"`
+	// The time the VirtualMachine was rewritten
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+	// A human readable message indicating why the restore failed
+	Message string `json:"message,omitempty"`
+	// The PersistentVolumeClaims recreated from the volume snapshots
+	Restores []VolumeRestore `json:"restores,omitempty"`
+}
+
+// VolumeRestore describes the PersistentVolumeClaim recreated for a single VirtualMachine volume
+// ---
+// +k8s:openapi-gen=true
+type VolumeRestore struct {
+	// The name of the volume in the VirtualMachine template
+	VolumeName string `json:"volumeName"`
+	// The PersistentVolumeClaim created from the volume snapshot
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// The CSI VolumeSnapshot the PersistentVolumeClaim is populated from
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+}
+
+// VirtualMachineRestorePhase is a label for the phase of a VirtualMachineRestore at the current time.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineRestorePhase string
+
+// These are the valid restore phases
+const (
+	VirtualMachineRestorePhaseUnset VirtualMachineRestorePhase = ""
+	// The PersistentVolumeClaims are being recreated
+	VirtualMachineRestoreInProgress VirtualMachineRestorePhase = "InProgress"
+	// The VirtualMachine was rewritten to use the restored volumes
+	VirtualMachineRestoreSucceeded VirtualMachineRestorePhase = "Succeeded"
+	// The VirtualMachine could not be restored
+	VirtualMachineRestoreFailed VirtualMachineRestorePhase = "Failed"
+)
+
 // KubeVirt represents the object deploying all KubeVirt resources
 // ---
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1291,3 +1816,213 @@ const (
 const (
 	EvictionStrategyLiveMigrate EvictionStrategy = "LiveMigrate"
 )
+
+// VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestAgentInfo struct {
+	metav1.TypeMeta `json:",inline"`
+	// Hostname represents the FQDN of the guest
+	Hostname string `json:"hostname,omitempty"`
+	// OS contains the guest operating system information
+	OS VirtualMachineInstanceGuestOSInfo `json:"os,omitempty"`
+	// Timezone is the current timezone of the guest, in the form "<zone>, <offset in seconds>"
+	Timezone string `json:"timezone,omitempty"`
+	// UserList is a list of users logged into the guest
+	UserList []VirtualMachineInstanceGuestOSUser `json:"userList,omitempty"`
+	// FSInfo contains the filesystems mounted in the guest and their usage
+	FSInfo VirtualMachineInstanceFileSystemInfo `json:"fsInfo,omitempty"`
+}
+
+// VirtualMachineInstanceGuestOSInfo represents the operating system of the guest, as reported by the guest agent
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestOSInfo struct {
+	// Name of the guest operating system
+	Name string `json:"name,omitempty"`
+	// Guest OS kernel release
+	KernelRelease string `json:"kernelRelease,omitempty"`
+	// Guest OS version
+	Version string `json:"version,omitempty"`
+	// Guest OS pretty name
+	PrettyName string `json:"prettyName,omitempty"`
+	// Version ID of the guest OS
+	VersionID string `json:"versionId,omitempty"`
+	// Kernel version of the guest OS
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// Machine type of the guest OS
+	Machine string `json:"machine,omitempty"`
+	// Guest OS ID
+	ID string `json:"id,omitempty"`
+}
+
+// VirtualMachineInstanceGuestOSUser is a user logged into the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestOSUser struct {
+	UserName string `json:"userName"`
+	Domain   string `json:"domain,omitempty"`
+	// LoginTime is the time of the login in seconds since the epoch
+	LoginTime float64 `json:"loginTime,omitempty"`
+}
+
+// VirtualMachineInstanceFileSystemInfo contains the filesystems mounted in the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystemInfo struct {
+	Filesystems []VirtualMachineInstanceFileSystem `json:"disks"`
+}
+
+// VirtualMachineInstanceFileSystem is a filesystem mounted in the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystem struct {
+	DiskName       string `json:"diskName"`
+	MountPoint     string `json:"mountPoint"`
+	FileSystemType string `json:"fileSystemType"`
+	UsedBytes      int64  `json:"usedBytes"`
+	TotalBytes     int64  `json:"totalBytes"`
+}
+
+// VolumeStatus represents the status of a volume which was hotplugged into or expanded in a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type VolumeStatus struct {
+	// Name is the name of the volume
+	Name string `json:"name"`
+	// Target is the device name of the disk inside the domain, e.g. sdb
+	// +optional
+	Target string `json:"target,omitempty"`
+	// Phase is the phase of the volume
+	// +optional
+	Phase VolumePhase `json:"phase,omitempty"`
+	// Reason is a brief CamelCase string that describes why the volume is in its current phase
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// HotplugVolume contains the details of how the volume gets attached to the node
+	// +optional
+	HotplugVolume *HotplugVolumeStatus `json:"hotplugVolume,omitempty"`
+	// Size is the size of the disk in bytes as seen by the guest. It grows with the
+	// PersistentVolumeClaim while the VirtualMachineInstance is running.
+	// +optional
+	Size int64 `json:"size,omitempty"`
+	// Capacity is the capacity of the PersistentVolumeClaim in bytes when the volume was last checked.
+	// Disk images are only grown once the capacity changes.
+	// +optional
+	Capacity int64 `json:"capacity,omitempty"`
+}
+
+// HotplugVolumeStatus represents the attachment of a hotplugged volume to the node of the VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type HotplugVolumeStatus struct {
+	// AttachPodName is the name of the pod which attaches the volume to the node
+	// +optional
+	AttachPodName string `json:"attachPodName,omitempty"`
+	// AttachPodUID is the UID of the pod which attaches the volume to the node
+	// +optional
+	AttachPodUID types.UID `json:"attachPodUID,omitempty"`
+}
+
+// HostModelCPU represents the CPU which libvirt expanded the host-model CPU of a VirtualMachineInstance to
+// ---
+// +k8s:openapi-gen=true
+type HostModelCPU struct {
+	// Model is the name of the CPU model, e.g. Skylake-Client-IBRS
+	Model string `json:"model"`
+	// Features are the names of the CPU features which are required on top of the model
+	// +optional
+	Features []string `json:"features,omitempty"`
+}
+
+// VolumePhase is a label for the phase of a hotplugged volume at the current time
+// ---
+// +k8s:openapi-gen=true
+type VolumePhase string
+
+// These are the valid hotplug volume phases
+const (
+	// VolumePending means the volume was added to the VirtualMachineInstance but is not attached to the node yet
+	VolumePending VolumePhase = "Pending"
+	// HotplugVolumeAttachedToNode means the attachment pod of the volume is running on the node of the VirtualMachineInstance
+	HotplugVolumeAttachedToNode VolumePhase = "AttachedToNode"
+	// HotplugVolumeMounted means the volume is mounted into the virt-launcher pod
+	HotplugVolumeMounted VolumePhase = "MountedToPod"
+	// VolumeReady means the disk of the volume is attached to the domain
+	VolumeReady VolumePhase = "Ready"
+)
+
+// AddVolumeOptions is provided when hotplugging a volume and its disk into a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type AddVolumeOptions struct {
+	// Name represents the name that will be used to map the
+	// disk to the corresponding volume. This overrides any name
+	// set inside the Disk struct itself.
+	Name string `json:"name"`
+	// Disk represents the hotplug disk that will be plugged into the running VirtualMachineInstance
+	Disk *Disk `json:"disk"`
+	// VolumeSource represents the source of the volume to map to the disk
+	VolumeSource *HotplugVolumeSource `json:"volumeSource"`
+}
+
+// RemoveVolumeOptions is provided when unplugging a volume and its disk from a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type RemoveVolumeOptions struct {
+	// Name represents the name of the volume to unplug
+	Name string `json:"name"`
+}
+
+// InsertMediaOptions is provided when inserting a medium into a CD-ROM or floppy of a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type InsertMediaOptions struct {
+	// Name is the name of the CD-ROM or floppy disk
+	Name string `json:"name"`
+	// VolumeName is the name of the volume with the medium. It is either the volume of the disk itself,
+	// a volume which was inserted before, or a new volume which is hotplugged from the VolumeSource.
+	VolumeName string `json:"volumeName"`
+	// VolumeSource represents the source of a new volume with the medium
+	// +optional
+	VolumeSource *HotplugVolumeSource `json:"volumeSource,omitempty"`
+}
+
+// EjectMediaOptions is provided when ejecting the medium from a CD-ROM or floppy of a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type EjectMediaOptions struct {
+	// Name is the name of the CD-ROM or floppy disk
+	Name string `json:"name"`
+}
+
+// MediaStatus represents the medium in a CD-ROM or floppy of a running VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type MediaStatus struct {
+	// Name is the name of the CD-ROM or floppy disk
+	Name string `json:"name"`
+	// Source is the name of the volume whose medium is in the drive, empty if the drive is empty
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Tray indicates if the tray of the drive is open or closed
+	// +optional
+	Tray TrayState `json:"tray,omitempty"`
+}
+
+// HotplugVolumeSource represents the source of a volume which can be hotplugged
+// ---
+// +k8s:openapi-gen=true
+type HotplugVolumeSource struct {
+	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
+	// More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+	// +optional
+	PersistentVolumeClaim *k8sv1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// DataVolume represents the dynamic creation a PVC for this volume as well as
+	// the process of populating that PVC with a disk image.
+	// +optional
+	DataVolume *DataVolumeSource `json:"dataVolume,omitempty"`
+}
@@ -47,6 +47,9 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"migrationState":  "Represents the status of a live migration",
 		"migrationMethod": "Represents the method using which the vmi can be migrated: live migration or block migration",
 		"qosClass":        "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
+		"volumeStatus":    "VolumeStatus contains the statuses of the volumes which were hotplugged into the running VirtualMachineInstance\n+optional",
+		"hostModelCPU":    "HostModelCPU is the CPU model and features which libvirt expanded the host-model CPU to when the VirtualMachineInstance started.\nMigration targets have to support them.\n+optional",
+		"media":           "Media contains the media in the CD-ROMs and floppies of the running VirtualMachineInstance and\nthe state of their trays\n+optional",
 	}
 }
 
@@ -83,6 +86,22 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortRequested":                 "Indicates that the migration has been requested to abort",
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
+		"progress":                       "The data transfer progress of the running migration, as reported by the source node",
+		"migrationPolicyName":            "The name of the MigrationPolicy which overrides the cluster wide migration configuration",
+	}
+}
+
+func (VirtualMachineInstanceMigrationProgress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "VirtualMachineInstanceMigrationProgress reports how far a live migration has come\nand whether it converges.",
+		"timestamp":               "The time the progress was reported",
+		"timeElapsedMilliseconds": "The time since the migration started, in milliseconds",
+		"dataTotalBytes":          "The total amount of data to transfer, in bytes",
+		"dataProcessedBytes":      "The amount of data which was already transferred, in bytes",
+		"dataRemainingBytes":      "The amount of data which is left to transfer, in bytes",
+		"memoryDirtyRateBytes":    "The rate at which the guest dirties its memory, in bytes per second",
+		"memoryTransferRateBytes": "The rate at which memory is transferred, in bytes per second",
+		"memoryIteration":         "The number of iterations over the guest memory. An iteration count which keeps\ngrowing while the remaining data does not shrink means the migration does not converge.",
 	}
 }
 
@@ -153,7 +172,42 @@ func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
+		"":                    "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
+		"progress":            "The data transfer progress of the running migration",
+		"migrationPolicyName": "The name of the MigrationPolicy applied to the migration",
+	}
+}
+
+func (MigrationPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MigrationPolicy overrides the cluster wide migration configuration for the\nVirtualMachineInstances it selects",
+	}
+}
+
+func (MigrationPolicyList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MigrationPolicyList is a list of MigrationPolicies",
+	}
+}
+
+func (MigrationPolicySpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "MigrationPolicySpec selects VirtualMachineInstances and holds the migration settings\nwhich apply to them. Unset settings fall back to the cluster wide configuration.",
+		"selectors":               "Selectors choose the VirtualMachineInstances the policy applies to",
+		"bandwidthPerMigration":   "The bandwidth limit of each migration",
+		"completionTimeoutPerGiB": "The time in seconds per GiB of memory after which a migration is canceled",
+		"progressTimeout":         "The time in seconds without progress after which a migration is canceled",
+		"allowAutoConverge":       "Allows the guest to be throttled when a migration does not converge",
+		"allowPostCopy":           "Allows a migration which does not converge to switch to post-copy",
+		"postCopyAfterIterations": "The number of memory iterations after which a migration switches to post-copy",
+	}
+}
+
+func (MigrationPolicySelectors) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                               "MigrationPolicySelectors select VirtualMachineInstances by the labels of their namespace\nand by their own labels. A VirtualMachineInstance has to match all given selectors.",
+		"namespaceSelector":              "Selects the namespaces by their labels",
+		"virtualMachineInstanceSelector": "Selects the VirtualMachineInstances by their labels",
 	}
 }
 
@@ -191,11 +245,20 @@ func (VirtualMachineList) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineRunSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "VirtualMachineRunSchedule starts and stops the VirtualMachineInstance at the times given as cron expressions",
+		"start": "Start is a cron expression like \"0 8 * * 1-5\", in UTC, at which the VirtualMachineInstance gets started\n+optional",
+		"stop":  "Stop is a cron expression like \"0 18 * * 1-5\", in UTC, at which the VirtualMachineInstance gets stopped\n+optional",
+	}
+}
+
 func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                    "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like",
 		"running":             "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
 		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
+		"runSchedule":         "RunSchedule starts and stops the VirtualMachineInstance at the scheduled times,\nlike a user calling the start and stop subresources. Requires the Manual RunStrategy.\n+optional",
 		"template":            "Template is the direct specification of VirtualMachineInstance",
 		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
 	}
@@ -206,7 +269,9 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"":                    "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing",
 		"created":             "Created indicates if the virtual machine is created in the cluster",
 		"ready":               "Ready indicates if the virtual machine is running and ready",
+		"printableStatus":     "PrintableStatus is a human readable, high-level representation of the status of the virtual machine",
 		"conditions":          "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
+		"lastScheduleTime":    "LastScheduleTime is the last time at which the RunSchedule started or stopped the VirtualMachineInstance\n+optional",
 		"stateChangeRequests": "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
 	}
 }
@@ -226,9 +291,17 @@ func (VirtualMachineCondition) SwaggerDoc() map[string]string {
 
 func (Handler) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":          "Handler defines a specific action that should be taken",
-		"httpGet":   "HTTPGet specifies the http request to perform.\n+optional",
-		"tcpSocket": "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"":               "Handler defines a specific action that should be taken",
+		"httpGet":        "HTTPGet specifies the http request to perform.\n+optional",
+		"tcpSocket":      "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"exec":           "Exec specifies a command to run in the guest through the qemu guest agent.\nThe command is not run in a shell. Exit status of 0 is treated as live/healthy and non-zero is unhealthy.\nIf the guest agent is not connected, the probe fails.\n+optional",
+		"guestAgentPing": "GuestAgentPing contacts the qemu guest agent in the guest.\nThe probe succeeds as long as the guest agent answers.\n+optional",
+	}
+}
+
+func (GuestAgentPing) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "GuestAgentPing configures the guest agent based ping probe",
 	}
 }
 
@@ -243,6 +316,86 @@ func (Probe) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineSnapshot) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshot defines the operation of snapshotting a VirtualMachine.\nIt captures the VirtualMachine spec and the contents of its persistent volumes at a point in time.",
+	}
+}
+
+func (VirtualMachineSnapshotList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotList is a list of VirtualMachineSnapshots",
+	}
+}
+
+func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot",
+		"source": "The object to snapshot. Only a VirtualMachine in the namespace of the snapshot is supported",
+	}
+}
+
+func (VirtualMachineSnapshotStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "VirtualMachineSnapshotStatus represents information pertaining to a VirtualMachineSnapshot.",
+		"sourceUID":          "The UID of the VirtualMachine the snapshot was taken from",
+		"creationTime":       "The time the volume snapshots were requested",
+		"readyToUse":         "Indicates that all volume snapshots are ready and the snapshot can be restored",
+		"frozen":             "Indicates that the guest filesystems were frozen for the volume snapshots and still have to be thawed",
+		"message":            "A human readable message indicating why the snapshot failed",
+		"virtualMachineSpec": "The VirtualMachine spec at the time the snapshot was taken",
+		"volumeBackups":      "The snapshots taken of the persistent volumes of the VirtualMachine",
+	}
+}
+
+func (VolumeBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "VolumeBackup describes the snapshot of a single VirtualMachine volume",
+		"volumeName":                "The name of the volume in the VirtualMachine template",
+		"persistentVolumeClaimName": "The PersistentVolumeClaim backing the volume",
+		"persistentVolumeClaimSpec": "The spec of the PersistentVolumeClaim at the time the snapshot was taken",
+		"volumeSnapshotName":        "The CSI VolumeSnapshot holding the volume contents",
+	}
+}
+
+func (VirtualMachineRestore) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineRestore defines the operation of restoring a VirtualMachine from a VirtualMachineSnapshot.\nThe persistent volumes are recreated from the volume snapshots and the VirtualMachine spec is rewritten.",
+	}
+}
+
+func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineRestoreList is a list of VirtualMachineRestores",
+	}
+}
+
+func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                           "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestore",
+		"target":                     "The object to restore. Only the VirtualMachine the snapshot was taken from is supported",
+		"virtualMachineSnapshotName": "The name of the VirtualMachineSnapshot to restore from",
+	}
+}
+
+func (VirtualMachineRestoreStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineRestoreStatus represents information pertaining to a VirtualMachineRestore.",
+		"restoreTime": "The time the VirtualMachine was rewritten",
+		"message":     "A human readable message indicating why the restore failed",
+		"restores":    "The PersistentVolumeClaims recreated from the volume snapshots",
+	}
+}
+
+func (VolumeRestore) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "VolumeRestore describes the PersistentVolumeClaim recreated for a single VirtualMachine volume",
+		"volumeName":                "The name of the volume in the VirtualMachine template",
+		"persistentVolumeClaimName": "The PersistentVolumeClaim created from the volume snapshot",
+		"volumeSnapshotName":        "The CSI VolumeSnapshot the PersistentVolumeClaim is populated from",
+	}
+}
+
 func (KubeVirt) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "KubeVirt represents the object deploying all KubeVirt resources",
@@ -274,3 +427,126 @@ func (KubeVirtCondition) SwaggerDoc() map[string]string {
 		"": "KubeVirtCondition represents a condition of a KubeVirt deployment",
 	}
 }
+
+func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent",
+		"hostname": "Hostname represents the FQDN of the guest",
+		"os":       "OS contains the guest operating system information",
+		"timezone": "Timezone is the current timezone of the guest, in the form \"<zone>, <offset in seconds>\"",
+		"userList": "UserList is a list of users logged into the guest",
+		"fsInfo":   "FSInfo contains the filesystems mounted in the guest and their usage",
+	}
+}
+
+func (VirtualMachineInstanceGuestOSInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VirtualMachineInstanceGuestOSInfo represents the operating system of the guest, as reported by the guest agent",
+		"name":          "Name of the guest operating system",
+		"kernelRelease": "Guest OS kernel release",
+		"version":       "Guest OS version",
+		"prettyName":    "Guest OS pretty name",
+		"versionId":     "Version ID of the guest OS",
+		"kernelVersion": "Kernel version of the guest OS",
+		"machine":       "Machine type of the guest OS",
+		"id":            "Guest OS ID",
+	}
+}
+
+func (VirtualMachineInstanceGuestOSUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineInstanceGuestOSUser is a user logged into the guest",
+		"loginTime": "LoginTime is the time of the login in seconds since the epoch",
+	}
+}
+
+func (VirtualMachineInstanceFileSystemInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceFileSystemInfo contains the filesystems mounted in the guest",
+	}
+}
+
+func (VirtualMachineInstanceFileSystem) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceFileSystem is a filesystem mounted in the guest",
+	}
+}
+
+func (VolumeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VolumeStatus represents the status of a volume which was hotplugged into or expanded in a running VirtualMachineInstance",
+		"name":          "Name is the name of the volume",
+		"target":        "Target is the device name of the disk inside the domain, e.g. sdb\n+optional",
+		"phase":         "Phase is the phase of the volume\n+optional",
+		"reason":        "Reason is a brief CamelCase string that describes why the volume is in its current phase\n+optional",
+		"message":       "Message is a human readable message indicating details about the current phase\n+optional",
+		"hotplugVolume": "HotplugVolume contains the details of how the volume gets attached to the node\n+optional",
+		"size":          "Size is the size of the disk in bytes as seen by the guest. It grows with the\nPersistentVolumeClaim while the VirtualMachineInstance is running.\n+optional",
+		"capacity":      "Capacity is the capacity of the PersistentVolumeClaim in bytes when the volume was last checked.\nDisk images are only grown once the capacity changes.\n+optional",
+	}
+}
+
+func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "HotplugVolumeStatus represents the attachment of a hotplugged volume to the node of the VirtualMachineInstance",
+		"attachPodName": "AttachPodName is the name of the pod which attaches the volume to the node\n+optional",
+		"attachPodUID":  "AttachPodUID is the UID of the pod which attaches the volume to the node\n+optional",
+	}
+}
+
+func (HostModelCPU) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "HostModelCPU represents the CPU which libvirt expanded the host-model CPU of a VirtualMachineInstance to",
+		"model":    "Model is the name of the CPU model, e.g. Skylake-Client-IBRS",
+		"features": "Features are the names of the CPU features which are required on top of the model\n+optional",
+	}
+}
+
+func (AddVolumeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "AddVolumeOptions is provided when hotplugging a volume and its disk into a running VirtualMachineInstance",
+		"name":         "Name represents the name that will be used to map the\ndisk to the corresponding volume. This overrides any name\nset inside the Disk struct itself.",
+		"disk":         "Disk represents the hotplug disk that will be plugged into the running VirtualMachineInstance",
+		"volumeSource": "VolumeSource represents the source of the volume to map to the disk",
+	}
+}
+
+func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "RemoveVolumeOptions is provided when unplugging a volume and its disk from a running VirtualMachineInstance",
+		"name": "Name represents the name of the volume to unplug",
+	}
+}
+
+func (InsertMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InsertMediaOptions is provided when inserting a medium into a CD-ROM or floppy of a running VirtualMachineInstance",
+		"name":         "Name is the name of the CD-ROM or floppy disk",
+		"volumeName":   "VolumeName is the name of the volume with the medium. It is either the volume of the disk itself,\na volume which was inserted before, or a new volume which is hotplugged from the VolumeSource.",
+		"volumeSource": "VolumeSource represents the source of a new volume with the medium\n+optional",
+	}
+}
+
+func (EjectMediaOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "EjectMediaOptions is provided when ejecting the medium from a CD-ROM or floppy of a running VirtualMachineInstance",
+		"name": "Name is the name of the CD-ROM or floppy disk",
+	}
+}
+
+func (MediaStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "MediaStatus represents the medium in a CD-ROM or floppy of a running VirtualMachineInstance",
+		"name":   "Name is the name of the CD-ROM or floppy disk",
+		"source": "Source is the name of the volume whose medium is in the drive, empty if the drive is empty\n+optional",
+		"tray":   "Tray indicates if the tray of the drive is open or closed\n+optional",
+	}
+}
+
+func (HotplugVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "HotplugVolumeSource represents the source of a volume which can be hotplugged",
+		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
+		"dataVolume":            "DataVolume represents the dynamic creation a PVC for this volume as well as\nthe process of populating that PVC with a disk image.\n+optional",
+	}
+}
@@ -48,6 +48,7 @@ import (
 	v1beta111 "k8s.io/client-go/kubernetes/typed/storage/v1beta1"
 	rest "k8s.io/client-go/rest"
 
+	v1alpha15 "kubevirt.io/client-go/api/snapshot/v1alpha1"
 	v111 "kubevirt.io/client-go/api/v1"
 	versioned0 "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 )
@@ -133,6 +134,46 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineInstancePreset(arg0 interf
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineInstancePreset", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshot(namespace string) VirtualMachineSnapshotInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshot", namespace)
+	ret0, _ := ret[0].(VirtualMachineSnapshotInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshot", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineRestore(namespace string) VirtualMachineRestoreInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineRestore", namespace)
+	ret0, _ := ret[0].(VirtualMachineRestoreInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
+}
+
+func (_m *MockKubevirtClient) MigrationPolicy() MigrationPolicyInterface {
+	ret := _m.ctrl.Call(_m, "MigrationPolicy")
+	ret0, _ := ret[0].(MigrationPolicyInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) MigrationPolicy() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MigrationPolicy")
+}
+
+func (_m *MockKubevirtClient) VolumeSnapshot(namespace string) VolumeSnapshotInterface {
+	ret := _m.ctrl.Call(_m, "VolumeSnapshot", namespace)
+	ret0, _ := ret[0].(VolumeSnapshotInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VolumeSnapshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VolumeSnapshot", arg0)
+}
+
 func (_m *MockKubevirtClient) ServerVersion() *ServerVersion {
 	ret := _m.ctrl.Call(_m, "ServerVersion")
 	ret0, _ := ret[0].(*ServerVersion)
@@ -867,6 +908,97 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNC(arg0 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNC", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) Pause(name string) error {
+	ret := _m.ctrl.Call(_m, "Pause", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Pause(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Pause", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Unpause(name string) error {
+	ret := _m.ctrl.Call(_m, "Unpause", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unpause(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unpause", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Freeze(name string) error {
+	ret := _m.ctrl.Call(_m, "Freeze", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Freeze(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Freeze", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Unfreeze(name string) error {
+	ret := _m.ctrl.Call(_m, "Unfreeze", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unfreeze(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unfreeze", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestOsInfo(name string) (v111.VirtualMachineInstanceGuestAgentInfo, error) {
+	ret := _m.ctrl.Call(_m, "GuestOsInfo", name)
+	ret0, _ := ret[0].(v111.VirtualMachineInstanceGuestAgentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestOsInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestOsInfo", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) AddVolume(name string, addVolumeOptions *v111.AddVolumeOptions) error {
+	ret := _m.ctrl.Call(_m, "AddVolume", name, addVolumeOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) AddVolume(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddVolume", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) RemoveVolume(name string, removeVolumeOptions *v111.RemoveVolumeOptions) error {
+	ret := _m.ctrl.Call(_m, "RemoveVolume", name, removeVolumeOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) InsertMedia(name string, insertMediaOptions *v111.InsertMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "InsertMedia", name, insertMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) InsertMedia(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InsertMedia", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) EjectMedia(name string, ejectMediaOptions *v111.EjectMediaOptions) error {
+	ret := _m.ctrl.Call(_m, "EjectMedia", name, ejectMediaOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) EjectMedia(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EjectMedia", arg0, arg1)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1192,6 +1324,17 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Stop(arg0 interface{}) *gomock.
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Stop", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) Migrate(name string) (*v111.VirtualMachineInstanceMigration, error) {
+	ret := _m.ctrl.Call(_m, "Migrate", name)
+	ret0, _ := ret[0].(*v111.VirtualMachineInstanceMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
@@ -1283,6 +1426,343 @@ func (_mr *_MockVirtualMachineInstanceMigrationInterfaceRecorder) Patch(arg0, ar
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
 }
 
+// Mock of VirtualMachineSnapshotInterface interface
+type MockVirtualMachineSnapshotInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineSnapshotInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineSnapshotInterface (not exported)
+type _MockVirtualMachineSnapshotInterfaceRecorder struct {
+	mock *MockVirtualMachineSnapshotInterface
+}
+
+func NewMockVirtualMachineSnapshotInterface(ctrl *gomock.Controller) *MockVirtualMachineSnapshotInterface {
+	mock := &MockVirtualMachineSnapshotInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineSnapshotInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) EXPECT() *_MockVirtualMachineSnapshotInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) Get(name string, options *v11.GetOptions) (*v111.VirtualMachineSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.VirtualMachineSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) List(opts *v11.ListOptions) (*v111.VirtualMachineSnapshotList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.VirtualMachineSnapshotList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) Create(_param0 *v111.VirtualMachineSnapshot) (*v111.VirtualMachineSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) Update(_param0 *v111.VirtualMachineSnapshot) (*v111.VirtualMachineSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineSnapshotInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.VirtualMachineSnapshot, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.VirtualMachineSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineSnapshotInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+// Mock of VirtualMachineRestoreInterface interface
+type MockVirtualMachineRestoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineRestoreInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineRestoreInterface (not exported)
+type _MockVirtualMachineRestoreInterfaceRecorder struct {
+	mock *MockVirtualMachineRestoreInterface
+}
+
+func NewMockVirtualMachineRestoreInterface(ctrl *gomock.Controller) *MockVirtualMachineRestoreInterface {
+	mock := &MockVirtualMachineRestoreInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineRestoreInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineRestoreInterface) EXPECT() *_MockVirtualMachineRestoreInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineRestoreInterface) Get(name string, options *v11.GetOptions) (*v111.VirtualMachineRestore, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.VirtualMachineRestore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineRestoreInterface) List(opts *v11.ListOptions) (*v111.VirtualMachineRestoreList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.VirtualMachineRestoreList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineRestoreInterface) Create(_param0 *v111.VirtualMachineRestore) (*v111.VirtualMachineRestore, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineRestore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineRestoreInterface) Update(_param0 *v111.VirtualMachineRestore) (*v111.VirtualMachineRestore, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineRestore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineRestoreInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineRestoreInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.VirtualMachineRestore, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.VirtualMachineRestore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineRestoreInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+// Mock of MigrationPolicyInterface interface
+type MockMigrationPolicyInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockMigrationPolicyInterfaceRecorder
+}
+
+// Recorder for MockMigrationPolicyInterface (not exported)
+type _MockMigrationPolicyInterfaceRecorder struct {
+	mock *MockMigrationPolicyInterface
+}
+
+func NewMockMigrationPolicyInterface(ctrl *gomock.Controller) *MockMigrationPolicyInterface {
+	mock := &MockMigrationPolicyInterface{ctrl: ctrl}
+	mock.recorder = &_MockMigrationPolicyInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockMigrationPolicyInterface) EXPECT() *_MockMigrationPolicyInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockMigrationPolicyInterface) Get(name string, options *v11.GetOptions) (*v111.MigrationPolicy, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.MigrationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockMigrationPolicyInterface) List(opts *v11.ListOptions) (*v111.MigrationPolicyList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.MigrationPolicyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockMigrationPolicyInterface) Create(_param0 *v111.MigrationPolicy) (*v111.MigrationPolicy, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v111.MigrationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockMigrationPolicyInterface) Update(_param0 *v111.MigrationPolicy) (*v111.MigrationPolicy, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.MigrationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockMigrationPolicyInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockMigrationPolicyInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.MigrationPolicy, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.MigrationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockMigrationPolicyInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+// Mock of VolumeSnapshotInterface interface
+type MockVolumeSnapshotInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVolumeSnapshotInterfaceRecorder
+}
+
+// Recorder for MockVolumeSnapshotInterface (not exported)
+type _MockVolumeSnapshotInterfaceRecorder struct {
+	mock *MockVolumeSnapshotInterface
+}
+
+func NewMockVolumeSnapshotInterface(ctrl *gomock.Controller) *MockVolumeSnapshotInterface {
+	mock := &MockVolumeSnapshotInterface{ctrl: ctrl}
+	mock.recorder = &_MockVolumeSnapshotInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVolumeSnapshotInterface) EXPECT() *_MockVolumeSnapshotInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVolumeSnapshotInterface) Get(name string, options *v11.GetOptions) (*v1alpha15.VolumeSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v1alpha15.VolumeSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVolumeSnapshotInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVolumeSnapshotInterface) List(opts *v11.ListOptions) (*v1alpha15.VolumeSnapshotList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v1alpha15.VolumeSnapshotList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVolumeSnapshotInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVolumeSnapshotInterface) Create(_param0 *v1alpha15.VolumeSnapshot) (*v1alpha15.VolumeSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v1alpha15.VolumeSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVolumeSnapshotInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVolumeSnapshotInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVolumeSnapshotInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
 // Mock of KubeVirtInterface interface
 type MockKubeVirtInterface struct {
 	ctrl     *gomock.Controller
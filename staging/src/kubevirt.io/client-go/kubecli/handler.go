@@ -2,6 +2,8 @@ package kubecli
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"strconv"
 
 	v1 "k8s.io/api/core/v1"
@@ -14,8 +16,14 @@ import (
 )
 
 const (
-	consoleTemplateURI = "wss://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/console"
-	vncTemplateURI     = "wss://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/vnc"
+	consoleTemplateURI     = "wss://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/console"
+	vncTemplateURI         = "wss://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/vnc"
+	pauseTemplateURI       = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/pause"
+	unpauseTemplateURI     = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/unpause"
+	freezeTemplateURI      = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/freeze"
+	unfreezeTemplateURI    = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/unfreeze"
+	domainStatsTemplateURI = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/domainstats"
+	guestInfoTemplateURI   = "https://%s:%s/v1/namespaces/%s/virtualmachineinstances/%s/guestosinfo"
 )
 
 func NewVirtHandlerClient(client KubevirtClient) VirtHandlerClient {
@@ -30,7 +38,15 @@ type VirtHandlerConn interface {
 	ConnectionDetails() (ip string, port string, err error)
 	ConsoleURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	VNCURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	DomainStatsURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	GuestInfoURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	Pod() (pod *v1.Pod, err error)
+	Put(url string, client *http.Client) error
+	Get(url string, client *http.Client) (string, error)
 	SetPort(port int) VirtHandlerConn
 }
 
@@ -102,7 +118,7 @@ func (v *virtHandlerConn) ConnectionDetails() (ip string, port string, err error
 	return
 }
 
-//TODO move the actual ws handling in here, and work with channels
+// TODO move the actual ws handling in here, and work with channels
 func (v *virtHandlerConn) ConsoleURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	ip, port, err := v.ConnectionDetails()
 	if err != nil {
@@ -124,6 +140,95 @@ func (v *virtHandlerConn) VNCURI(vmi *virtv1.VirtualMachineInstance) (string, er
 	return fmt.Sprintf(vncTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
 
+func (v *virtHandlerConn) PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(pauseTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(unpauseTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(freezeTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(unfreezeTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) DomainStatsURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(domainStatsTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) GuestInfoURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(guestInfoTemplateURI, ip, port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) Put(url string, client *http.Client) error {
+	req, err := http.NewRequest(http.MethodPut, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected return code %d (%s), message: %s", resp.StatusCode, resp.Status, string(body))
+	}
+	return nil
+}
+
+func (v *virtHandlerConn) Get(url string, client *http.Client) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected return code %d (%s), message: %s", resp.StatusCode, resp.Status, string(body))
+	}
+	return string(body), nil
+}
+
 func (v *virtHandlerConn) Pod() (pod *v1.Pod, err error) {
 	if v.err != nil {
 		err = v.err
@@ -108,6 +108,11 @@ func GetKubevirtSubresourceClientFromFlags(master string, kubeconfig string) (Ku
 		return nil, err
 	}
 
+	snapshotClient, err := snapshotRESTClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &kubevirt{
 		master,
 		kubeconfig,
@@ -118,6 +123,7 @@ func GetKubevirtSubresourceClientFromFlags(master string, kubeconfig string) (Ku
 		extensionsClient,
 		secClient,
 		discoveryClient,
+		snapshotClient,
 		coreClient,
 	}, nil
 }
@@ -236,6 +242,11 @@ func GetKubevirtClientFromRESTConfig(config *rest.Config) (KubevirtClient, error
 		return nil, err
 	}
 
+	snapshotClient, err := snapshotRESTClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &kubevirt{
 		master,
 		kubeconfig,
@@ -246,6 +257,7 @@ func GetKubevirtClientFromRESTConfig(config *rest.Config) (KubevirtClient, error
 		extensionsClient,
 		secClient,
 		discoveryClient,
+		snapshotClient,
 		coreClient,
 	}, nil
 }
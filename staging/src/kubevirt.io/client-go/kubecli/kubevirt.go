@@ -40,6 +40,7 @@ import (
 
 	networkclient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
 	v1 "kubevirt.io/client-go/api/v1"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 )
@@ -51,6 +52,10 @@ type KubevirtClient interface {
 	VirtualMachine(namespace string) VirtualMachineInterface
 	KubeVirt(namespace string) KubeVirtInterface
 	VirtualMachineInstancePreset(namespace string) VirtualMachineInstancePresetInterface
+	VirtualMachineSnapshot(namespace string) VirtualMachineSnapshotInterface
+	VirtualMachineRestore(namespace string) VirtualMachineRestoreInterface
+	MigrationPolicy() MigrationPolicyInterface
+	VolumeSnapshot(namespace string) VolumeSnapshotInterface
 	ServerVersion() *ServerVersion
 	RestClient() *rest.RESTClient
 	CdiClient() cdiclient.Interface
@@ -72,6 +77,7 @@ type kubevirt struct {
 	extensionsClient *extclient.Clientset
 	secClient        *secv1.SecurityV1Client
 	discoveryClient  *discovery.DiscoveryClient
+	snapshotClient   *rest.RESTClient
 	*kubernetes.Clientset
 }
 
@@ -121,6 +127,15 @@ type VirtualMachineInstanceInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	SerialConsole(name string, timeout time.Duration) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	Pause(name string) error
+	Unpause(name string) error
+	Freeze(name string) error
+	Unfreeze(name string) error
+	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
+	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
+	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	InsertMedia(name string, insertMediaOptions *v1.InsertMediaOptions) error
+	EjectMedia(name string, ejectMediaOptions *v1.EjectMediaOptions) error
 }
 
 type ReplicaSetInterface interface {
@@ -155,6 +170,7 @@ type VirtualMachineInterface interface {
 	Restart(name string) error
 	Start(name string) error
 	Stop(name string) error
+	Migrate(name string) (*v1.VirtualMachineInstanceMigration, error)
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -166,6 +182,42 @@ type VirtualMachineInstanceMigrationInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstanceMigration, err error)
 }
 
+type VirtualMachineSnapshotInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineSnapshot, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineSnapshotList, error)
+	Create(*v1.VirtualMachineSnapshot) (*v1.VirtualMachineSnapshot, error)
+	Update(*v1.VirtualMachineSnapshot) (*v1.VirtualMachineSnapshot, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineSnapshot, err error)
+}
+
+type VirtualMachineRestoreInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineRestore, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineRestoreList, error)
+	Create(*v1.VirtualMachineRestore) (*v1.VirtualMachineRestore, error)
+	Update(*v1.VirtualMachineRestore) (*v1.VirtualMachineRestore, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineRestore, err error)
+}
+
+// MigrationPolicyInterface gives access to the cluster scoped MigrationPolicies
+type MigrationPolicyInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.MigrationPolicy, error)
+	List(opts *k8smetav1.ListOptions) (*v1.MigrationPolicyList, error)
+	Create(*v1.MigrationPolicy) (*v1.MigrationPolicy, error)
+	Update(*v1.MigrationPolicy) (*v1.MigrationPolicy, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.MigrationPolicy, err error)
+}
+
+// VolumeSnapshotInterface gives access to the CSI VolumeSnapshots of the snapshot.storage.k8s.io api group
+type VolumeSnapshotInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*snapshotv1.VolumeSnapshot, error)
+	List(opts *k8smetav1.ListOptions) (*snapshotv1.VolumeSnapshotList, error)
+	Create(*snapshotv1.VolumeSnapshot) (*snapshotv1.VolumeSnapshot, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+}
+
 type KubeVirtInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.KubeVirt, error)
 	List(opts *k8smetav1.ListOptions) (*v1.KubeVirtList, error)
@@ -71,3 +71,27 @@ func NewVirtualMachineInstancePresetList(rss ...v1.VirtualMachineInstancePreset)
 func NewMinimalVirtualMachineInstancePreset(name string) *v1.VirtualMachineInstancePreset {
 	return &v1.VirtualMachineInstancePreset{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineInstancePreset"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
 }
+
+func NewMinimalVirtualMachineSnapshot(name string) *v1.VirtualMachineSnapshot {
+	return &v1.VirtualMachineSnapshot{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineSnapshot"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
+}
+
+func NewVirtualMachineSnapshotList(snapshots ...v1.VirtualMachineSnapshot) *v1.VirtualMachineSnapshotList {
+	return &v1.VirtualMachineSnapshotList{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineSnapshotList"}, Items: snapshots}
+}
+
+func NewMinimalVirtualMachineRestore(name string) *v1.VirtualMachineRestore {
+	return &v1.VirtualMachineRestore{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineRestore"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
+}
+
+func NewVirtualMachineRestoreList(restores ...v1.VirtualMachineRestore) *v1.VirtualMachineRestoreList {
+	return &v1.VirtualMachineRestoreList{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineRestoreList"}, Items: restores}
+}
+
+func NewMinimalMigrationPolicy(name string) *v1.MigrationPolicy {
+	return &v1.MigrationPolicy{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "MigrationPolicy"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
+}
+
+func NewMigrationPolicyList(policies ...v1.MigrationPolicy) *v1.MigrationPolicyList {
+	return &v1.MigrationPolicyList{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "MigrationPolicyList"}, Items: policies}
+}
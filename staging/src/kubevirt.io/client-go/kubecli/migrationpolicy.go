@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) MigrationPolicy() MigrationPolicyInterface {
+	return &migrationPolicy{
+		restClient: k.restClient,
+		resource:   "migrationpolicies",
+	}
+}
+
+type migrationPolicy struct {
+	restClient *rest.RESTClient
+	resource   string
+}
+
+// Create new MigrationPolicy in the cluster
+func (o *migrationPolicy) Create(newPolicy *v1.MigrationPolicy) (*v1.MigrationPolicy, error) {
+	newPolicyResult := &v1.MigrationPolicy{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Body(newPolicy).
+		Do().
+		Into(newPolicyResult)
+
+	newPolicyResult.SetGroupVersionKind(v1.MigrationPolicyGroupVersionKind)
+
+	return newPolicyResult, err
+}
+
+// Get the MigrationPolicy from the cluster by its name
+func (o *migrationPolicy) Get(name string, options *k8smetav1.GetOptions) (*v1.MigrationPolicy, error) {
+	newPolicy := &v1.MigrationPolicy{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newPolicy)
+
+	newPolicy.SetGroupVersionKind(v1.MigrationPolicyGroupVersionKind)
+
+	return newPolicy, err
+}
+
+// Update the MigrationPolicy in the cluster
+func (o *migrationPolicy) Update(policy *v1.MigrationPolicy) (*v1.MigrationPolicy, error) {
+	updatedPolicy := &v1.MigrationPolicy{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Name(policy.Name).
+		Body(policy).
+		Do().
+		Into(updatedPolicy)
+
+	updatedPolicy.SetGroupVersionKind(v1.MigrationPolicyGroupVersionKind)
+
+	return updatedPolicy, err
+}
+
+// Delete the defined MigrationPolicy in the cluster
+func (o *migrationPolicy) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	err := o.restClient.Delete().
+		Resource(o.resource).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+
+	return err
+}
+
+// List all MigrationPolicies in the cluster
+func (o *migrationPolicy) List(options *k8smetav1.ListOptions) (*v1.MigrationPolicyList, error) {
+	newPolicyList := &v1.MigrationPolicyList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newPolicyList)
+
+	for _, policy := range newPolicyList.Items {
+		policy.SetGroupVersionKind(v1.MigrationPolicyGroupVersionKind)
+	}
+
+	return newPolicyList, err
+}
+
+func (o *migrationPolicy) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.MigrationPolicy, err error) {
+	result = &v1.MigrationPolicy{}
+	err = o.restClient.Patch(pt).
+		Resource(o.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
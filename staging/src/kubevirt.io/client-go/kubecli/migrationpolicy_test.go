@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Kubevirt MigrationPolicy Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/migrationpolicies"
+	policyPath := basePath + "/testpolicy"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a MigrationPolicy", func() {
+		policy := NewMinimalMigrationPolicy("testpolicy")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", policyPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, policy),
+		))
+		fetchedPolicy, err := client.MigrationPolicy().Get("testpolicy", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedPolicy).To(Equal(policy))
+	})
+
+	It("should detect non existent MigrationPolicies", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", policyPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testpolicy")),
+		))
+		_, err := client.MigrationPolicy().Get("testpolicy", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a MigrationPolicy list", func() {
+		policy := NewMinimalMigrationPolicy("testpolicy")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, NewMigrationPolicyList(*policy)),
+		))
+		fetchedPolicyList, err := client.MigrationPolicy().List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedPolicyList.Items).To(HaveLen(1))
+		Expect(fetchedPolicyList.Items[0]).To(Equal(*policy))
+	})
+
+	It("should create a MigrationPolicy", func() {
+		policy := NewMinimalMigrationPolicy("testpolicy")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, policy),
+		))
+		createdPolicy, err := client.MigrationPolicy().Create(policy)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdPolicy).To(Equal(policy))
+	})
+
+	It("should update a MigrationPolicy", func() {
+		policy := NewMinimalMigrationPolicy("testpolicy")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", policyPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, policy),
+		))
+		updatedPolicy, err := client.MigrationPolicy().Update(policy)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPolicy).To(Equal(policy))
+	})
+
+	It("should patch a MigrationPolicy", func() {
+		policy := NewMinimalMigrationPolicy("testpolicy")
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", policyPath),
+			ghttp.VerifyBody([]byte("{\"spec\":{\"allowPostCopy\":true}}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, policy),
+		))
+
+		_, err := client.MigrationPolicy().Patch(policy.Name, types.MergePatchType,
+			[]byte("{\"spec\":{\"allowPostCopy\":true}}"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete a MigrationPolicy", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", policyPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.MigrationPolicy().Delete("testpolicy", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
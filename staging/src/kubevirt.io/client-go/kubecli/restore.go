@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineRestore(namespace string) VirtualMachineRestoreInterface {
+	return &restore{
+		restClient: k.restClient,
+		namespace:  namespace,
+		resource:   "virtualmachinerestores",
+	}
+}
+
+type restore struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VirtualMachineRestore in the cluster to specified namespace
+func (o *restore) Create(newRestore *v1.VirtualMachineRestore) (*v1.VirtualMachineRestore, error) {
+	newRestoreResult := &v1.VirtualMachineRestore{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newRestore).
+		Do().
+		Into(newRestoreResult)
+
+	newRestoreResult.SetGroupVersionKind(v1.VirtualMachineRestoreGroupVersionKind)
+
+	return newRestoreResult, err
+}
+
+// Get the VirtualMachineRestore from the cluster by its name and namespace
+func (o *restore) Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineRestore, error) {
+	newRestore := &v1.VirtualMachineRestore{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newRestore)
+
+	newRestore.SetGroupVersionKind(v1.VirtualMachineRestoreGroupVersionKind)
+
+	return newRestore, err
+}
+
+// Update the VirtualMachineRestore instance in the cluster in given namespace
+func (o *restore) Update(restore *v1.VirtualMachineRestore) (*v1.VirtualMachineRestore, error) {
+	updatedRestore := &v1.VirtualMachineRestore{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(restore.Name).
+		Body(restore).
+		Do().
+		Into(updatedRestore)
+
+	updatedRestore.SetGroupVersionKind(v1.VirtualMachineRestoreGroupVersionKind)
+
+	return updatedRestore, err
+}
+
+// Delete the defined VirtualMachineRestore in the cluster in defined namespace
+func (o *restore) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	err := o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+
+	return err
+}
+
+// List all VirtualMachineRestores in given namespace
+func (o *restore) List(options *k8smetav1.ListOptions) (*v1.VirtualMachineRestoreList, error) {
+	newRestoreList := &v1.VirtualMachineRestoreList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newRestoreList)
+
+	for _, restore := range newRestoreList.Items {
+		restore.SetGroupVersionKind(v1.VirtualMachineRestoreGroupVersionKind)
+	}
+
+	return newRestoreList, err
+}
+
+func (v *restore) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineRestore, err error) {
+	result = &v1.VirtualMachineRestore{}
+	err = v.restClient.Patch(pt).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Kubevirt VirtualMachineRestore Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinerestores"
+	restorePath := basePath + "/testrestore"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachineRestore", func() {
+		restore := NewMinimalVirtualMachineRestore("testrestore")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", restorePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, restore),
+		))
+		fetchedRestore, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Get("testrestore", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedRestore).To(Equal(restore))
+	})
+
+	It("should detect non existent VirtualMachineRestores", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", restorePath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testrestore")),
+		))
+		_, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Get("testrestore", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a VirtualMachineRestore list", func() {
+		restore := NewMinimalVirtualMachineRestore("testrestore")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, NewVirtualMachineRestoreList(*restore)),
+		))
+		fetchedRestoreList, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedRestoreList.Items).To(HaveLen(1))
+		Expect(fetchedRestoreList.Items[0]).To(Equal(*restore))
+	})
+
+	It("should create a VirtualMachineRestore", func() {
+		restore := NewMinimalVirtualMachineRestore("testrestore")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, restore),
+		))
+		createdRestore, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Create(restore)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdRestore).To(Equal(restore))
+	})
+
+	It("should update a VirtualMachineRestore", func() {
+		restore := NewMinimalVirtualMachineRestore("testrestore")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", restorePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, restore),
+		))
+		updatedRestore, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Update(restore)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedRestore).To(Equal(restore))
+	})
+
+	It("should patch a VirtualMachineRestore", func() {
+		restore := NewMinimalVirtualMachineRestore("testrestore")
+		restore.Spec.VirtualMachineSnapshotName = "somethingelse"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", restorePath),
+			ghttp.VerifyBody([]byte("{\"spec\":{\"virtualMachineSnapshotName\":something}}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, restore),
+		))
+
+		_, err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Patch(restore.Name, types.MergePatchType,
+			[]byte("{\"spec\":{\"virtualMachineSnapshotName\":something}}"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete a VirtualMachineRestore", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", restorePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineRestore(k8sv1.NamespaceDefault).Delete("testrestore", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineSnapshot(namespace string) VirtualMachineSnapshotInterface {
+	return &snapshot{
+		restClient: k.restClient,
+		namespace:  namespace,
+		resource:   "virtualmachinesnapshots",
+	}
+}
+
+type snapshot struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VirtualMachineSnapshot in the cluster to specified namespace
+func (o *snapshot) Create(newSnapshot *v1.VirtualMachineSnapshot) (*v1.VirtualMachineSnapshot, error) {
+	newSnapshotResult := &v1.VirtualMachineSnapshot{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newSnapshot).
+		Do().
+		Into(newSnapshotResult)
+
+	newSnapshotResult.SetGroupVersionKind(v1.VirtualMachineSnapshotGroupVersionKind)
+
+	return newSnapshotResult, err
+}
+
+// Get the VirtualMachineSnapshot from the cluster by its name and namespace
+func (o *snapshot) Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineSnapshot, error) {
+	newSnapshot := &v1.VirtualMachineSnapshot{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newSnapshot)
+
+	newSnapshot.SetGroupVersionKind(v1.VirtualMachineSnapshotGroupVersionKind)
+
+	return newSnapshot, err
+}
+
+// Update the VirtualMachineSnapshot instance in the cluster in given namespace
+func (o *snapshot) Update(snapshot *v1.VirtualMachineSnapshot) (*v1.VirtualMachineSnapshot, error) {
+	updatedSnapshot := &v1.VirtualMachineSnapshot{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(snapshot.Name).
+		Body(snapshot).
+		Do().
+		Into(updatedSnapshot)
+
+	updatedSnapshot.SetGroupVersionKind(v1.VirtualMachineSnapshotGroupVersionKind)
+
+	return updatedSnapshot, err
+}
+
+// Delete the defined VirtualMachineSnapshot in the cluster in defined namespace
+func (o *snapshot) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	err := o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+
+	return err
+}
+
+// List all VirtualMachineSnapshots in given namespace
+func (o *snapshot) List(options *k8smetav1.ListOptions) (*v1.VirtualMachineSnapshotList, error) {
+	newSnapshotList := &v1.VirtualMachineSnapshotList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newSnapshotList)
+
+	for _, snapshot := range newSnapshotList.Items {
+		snapshot.SetGroupVersionKind(v1.VirtualMachineSnapshotGroupVersionKind)
+	}
+
+	return newSnapshotList, err
+}
+
+func (v *snapshot) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineSnapshot, err error) {
+	result = &v1.VirtualMachineSnapshot{}
+	err = v.restClient.Patch(pt).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
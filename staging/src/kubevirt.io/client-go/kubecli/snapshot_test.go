@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Kubevirt VirtualMachineSnapshot Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinesnapshots"
+	snapshotPath := basePath + "/testsnapshot"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachineSnapshot", func() {
+		snapshot := NewMinimalVirtualMachineSnapshot("testsnapshot")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", snapshotPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, snapshot),
+		))
+		fetchedSnapshot, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Get("testsnapshot", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedSnapshot).To(Equal(snapshot))
+	})
+
+	It("should detect non existent VirtualMachineSnapshots", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", snapshotPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testsnapshot")),
+		))
+		_, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Get("testsnapshot", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a VirtualMachineSnapshot list", func() {
+		snapshot := NewMinimalVirtualMachineSnapshot("testsnapshot")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, NewVirtualMachineSnapshotList(*snapshot)),
+		))
+		fetchedSnapshotList, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedSnapshotList.Items).To(HaveLen(1))
+		Expect(fetchedSnapshotList.Items[0]).To(Equal(*snapshot))
+	})
+
+	It("should create a VirtualMachineSnapshot", func() {
+		snapshot := NewMinimalVirtualMachineSnapshot("testsnapshot")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, snapshot),
+		))
+		createdSnapshot, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Create(snapshot)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdSnapshot).To(Equal(snapshot))
+	})
+
+	It("should update a VirtualMachineSnapshot", func() {
+		snapshot := NewMinimalVirtualMachineSnapshot("testsnapshot")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", snapshotPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, snapshot),
+		))
+		updatedSnapshot, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Update(snapshot)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedSnapshot).To(Equal(snapshot))
+	})
+
+	It("should patch a VirtualMachineSnapshot", func() {
+		snapshot := NewMinimalVirtualMachineSnapshot("testsnapshot")
+		snapshot.Spec.Source.Name = "somethingelse"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", snapshotPath),
+			ghttp.VerifyBody([]byte("{\"spec\":{\"source\":{\"name\":something}}}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, snapshot),
+		))
+
+		_, err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Patch(snapshot.Name, types.MergePatchType,
+			[]byte("{\"spec\":{\"source\":{\"name\":something}}}"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete a VirtualMachineSnapshot", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", snapshotPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineSnapshot(k8sv1.NamespaceDefault).Delete("testsnapshot", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -20,6 +20,7 @@
 package kubecli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -150,3 +151,17 @@ func (v *vm) Stop(name string) error {
 	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "stop")
 	return v.restClient.Put().RequestURI(uri).Do().Error()
 }
+
+// Migrate creates a migration of the running VirtualMachine and returns it
+func (v *vm) Migrate(name string) (*v1.VirtualMachineInstanceMigration, error) {
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "migrate")
+	res, err := v.restClient.Put().RequestURI(uri).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	migration := &v1.VirtualMachineInstanceMigration{}
+	if err := json.Unmarshal(res, migration); err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
@@ -176,6 +176,18 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should migrate a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/migrate"),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, NewMinimalMigration("testmigration")),
+		))
+		migration, err := client.VirtualMachine(k8sv1.NamespaceDefault).Migrate("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration.Name).To(Equal("testmigration"))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -21,6 +21,7 @@ package kubecli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -37,6 +38,8 @@ import (
 	"kubevirt.io/client-go/subresources"
 )
 
+const vmiSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachineinstances/%s/%s"
+
 func (k *kubevirt) VirtualMachineInstance(namespace string) VirtualMachineInstanceInterface {
 	return &vmis{
 		restClient: k.restClient,
@@ -295,6 +298,81 @@ func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInter
 	}
 }
 
+func (v *vmis) Pause(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "pause")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
+func (v *vmis) Unpause(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "unpause")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
+func (v *vmis) Freeze(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "freeze")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
+func (v *vmis) Unfreeze(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "unfreeze")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
+func (v *vmis) GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "guestosinfo")
+	res, err := v.restClient.Get().RequestURI(uri).DoRaw()
+	if err != nil {
+		return guestInfo, err
+	}
+	err = json.Unmarshal(res, &guestInfo)
+	return guestInfo, err
+}
+
+func (v *vmis) AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addvolume")
+
+	JSON, err := json.Marshal(addVolumeOptions)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
+}
+
+func (v *vmis) RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "removevolume")
+
+	JSON, err := json.Marshal(removeVolumeOptions)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
+}
+
+func (v *vmis) InsertMedia(name string, insertMediaOptions *v1.InsertMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "insertmedia")
+
+	JSON, err := json.Marshal(insertMediaOptions)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
+}
+
+func (v *vmis) EjectMedia(name string, ejectMediaOptions *v1.EjectMediaOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "ejectmedia")
+
+	JSON, err := json.Marshal(ejectMediaOptions)
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
+}
+
 func (v *vmis) Get(name string, options *k8smetav1.GetOptions) (vmi *v1.VirtualMachineInstance, err error) {
 	vmi = &v1.VirtualMachineInstance{}
 	err = v.restClient.Get().
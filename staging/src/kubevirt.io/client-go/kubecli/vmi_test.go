@@ -43,6 +43,8 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 	var client KubevirtClient
 	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances"
 	vmiPath := basePath + "/testvm"
+	subBasePath := "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances"
+	subVMIPath := subBasePath + "/testvm"
 
 	BeforeEach(func() {
 		var err error
@@ -218,6 +220,132 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(bufOut).To(Equal(bufIn))
 	})
 
+	It("should pause a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/pause"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pause("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should unpause a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/unpause"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unpause("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should freeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/freeze"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Freeze("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should unfreeze a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/unfreeze"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unfreeze("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch the guest OS info of a VirtualMachineInstance", func() {
+		guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{
+			Hostname: "testvm",
+			OS:       v1.VirtualMachineInstanceGuestOSInfo{Name: "Fedora"},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMIPath+"/guestosinfo"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, guestInfo),
+		))
+		fetchedInfo, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).GuestOsInfo("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedInfo).To(Equal(guestInfo))
+	})
+
+	It("should hotplug a volume into a VirtualMachineInstance", func() {
+		addVolumeOptions := &v1.AddVolumeOptions{
+			Name: "testvolume",
+			Disk: &v1.Disk{
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}},
+			},
+			VolumeSource: &v1.HotplugVolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+			},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/addvolume"),
+			ghttp.VerifyJSONRepresenting(addVolumeOptions),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).AddVolume("testvm", addVolumeOptions)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should unplug a volume from a VirtualMachineInstance", func() {
+		removeVolumeOptions := &v1.RemoveVolumeOptions{Name: "testvolume"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/removevolume"),
+			ghttp.VerifyJSONRepresenting(removeVolumeOptions),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).RemoveVolume("testvm", removeVolumeOptions)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should insert a medium into a VirtualMachineInstance", func() {
+		insertMediaOptions := &v1.InsertMediaOptions{
+			Name:       "cdrom",
+			VolumeName: "testvolume",
+			VolumeSource: &v1.HotplugVolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+			},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/insertmedia"),
+			ghttp.VerifyJSONRepresenting(insertMediaOptions),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).InsertMedia("testvm", insertMediaOptions)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should eject a medium from a VirtualMachineInstance", func() {
+		ejectMediaOptions := &v1.EjectMediaOptions{Name: "cdrom"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/ejectmedia"),
+			ghttp.VerifyJSONRepresenting(ejectMediaOptions),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).EjectMedia("testvm", ejectMediaOptions)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -0,0 +1,113 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	snapshotv1 "kubevirt.io/client-go/api/snapshot/v1alpha1"
+)
+
+// snapshotRESTClientFor creates a REST client for the snapshot.storage.k8s.io api group,
+// based on a copy of the given config
+func snapshotRESTClientFor(config *rest.Config) (*rest.RESTClient, error) {
+	snapshotConfig := rest.CopyConfig(config)
+	snapshotConfig.GroupVersion = &snapshotv1.GroupVersion
+	snapshotConfig.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: snapshotv1.Codecs}
+	snapshotConfig.APIPath = "/apis"
+	snapshotConfig.ContentType = runtime.ContentTypeJSON
+	return rest.RESTClientFor(snapshotConfig)
+}
+
+func (k *kubevirt) VolumeSnapshot(namespace string) VolumeSnapshotInterface {
+	return &volumeSnapshot{
+		restClient: k.snapshotClient,
+		namespace:  namespace,
+		resource:   "volumesnapshots",
+	}
+}
+
+type volumeSnapshot struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VolumeSnapshot in the cluster to specified namespace
+func (o *volumeSnapshot) Create(newSnapshot *snapshotv1.VolumeSnapshot) (*snapshotv1.VolumeSnapshot, error) {
+	result := &snapshotv1.VolumeSnapshot{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newSnapshot).
+		Do().
+		Into(result)
+
+	result.SetGroupVersionKind(snapshotv1.VolumeSnapshotGroupVersionKind)
+
+	return result, err
+}
+
+// Get the VolumeSnapshot from the cluster by its name and namespace
+func (o *volumeSnapshot) Get(name string, options *k8smetav1.GetOptions) (*snapshotv1.VolumeSnapshot, error) {
+	result := &snapshotv1.VolumeSnapshot{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+
+	result.SetGroupVersionKind(snapshotv1.VolumeSnapshotGroupVersionKind)
+
+	return result, err
+}
+
+// Delete the defined VolumeSnapshot in the cluster in defined namespace
+func (o *volumeSnapshot) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// List all VolumeSnapshots in given namespace
+func (o *volumeSnapshot) List(options *k8smetav1.ListOptions) (*snapshotv1.VolumeSnapshotList, error) {
+	result := &snapshotv1.VolumeSnapshotList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	for i := range result.Items {
+		result.Items[i].SetGroupVersionKind(snapshotv1.VolumeSnapshotGroupVersionKind)
+	}
+
+	return result, err
+}
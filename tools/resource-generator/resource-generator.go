@@ -32,7 +32,7 @@ import (
 )
 
 func main() {
-	resourceType := flag.String("type", "", "Type of resource to generate. vmi | vmipreset | vmirs | vm | vmim | kv | rbac")
+	resourceType := flag.String("type", "", "Type of resource to generate. vmi | vmipreset | vmirs | vm | vmim | vmsnapshot | vmrestore | migrationpolicy | kv | rbac")
 	namespace := flag.String("namespace", "kube-system", "Namespace to use.")
 	repository := flag.String("repository", "kubevirt", "Image Repository to use.")
 	version := flag.String("version", "latest", "Version to use.")
@@ -55,6 +55,12 @@ func main() {
 		util.MarshallObject(components.NewVirtualMachineCrd(), os.Stdout)
 	case "vmim":
 		util.MarshallObject(components.NewVirtualMachineInstanceMigrationCrd(), os.Stdout)
+	case "vmsnapshot":
+		util.MarshallObject(components.NewVirtualMachineSnapshotCrd(), os.Stdout)
+	case "vmrestore":
+		util.MarshallObject(components.NewVirtualMachineRestoreCrd(), os.Stdout)
+	case "migrationpolicy":
+		util.MarshallObject(components.NewMigrationPolicyCrd(), os.Stdout)
 	case "kv":
 		util.MarshallObject(components.NewKubeVirtCrd(), os.Stdout)
 	case "kv-cr":
@@ -98,7 +104,7 @@ func main() {
 		}
 		util.MarshallObject(controller, os.Stdout)
 	case "virt-handler":
-		handler, err := components.NewHandlerDaemonSet(*namespace, *repository, *version, imagePullPolicy, *verbosity)
+		handler, err := components.NewHandlerDaemonSet(*namespace, *repository, *version, *launcherVersion, imagePullPolicy, *verbosity, false)
 		if err != nil {
 			panic(fmt.Errorf("error generating virt-handler deployment %v", err))
 		}